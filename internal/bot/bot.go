@@ -25,32 +25,57 @@ import (
 
 // HandlerDependencies contains all dependencies needed by handlers
 type HandlerDependencies struct {
-	OpenAiClient                      *clients.OpenAiClient
-	AppConfig                         *config.Config
-	ProfileService                    *services.ProfileService
-	SummarizationService              *services.SummarizationService
-	RandomCoffeeService               *services.RandomCoffeeService
-	MessageSenderService              *services.MessageSenderService
-	PermissionsService                *services.PermissionsService
-	EventRepository                   *repositories.EventRepository
-	TopicRepository                   *repositories.TopicRepository
-	GroupTopicRepository              *repositories.GroupTopicRepository
-	PromptingTemplateRepository       *repositories.PromptingTemplateRepository
-	UserRepository                    *repositories.UserRepository
-	ProfileRepository                 *repositories.ProfileRepository
-	RandomCoffeePollRepository        *repositories.RandomCoffeePollRepository
-	RandomCoffeeParticipantRepository *repositories.RandomCoffeeParticipantRepository
-	RandomCoffeePairRepository        *repositories.RandomCoffeePairRepository
-	GroupMessageRepository            *repositories.GroupMessageRepository
-	RandomCoffeePollAnswersService    *grouphandlersservices.RandomCoffeePollAnswersService
-	JoinLeftService                   *grouphandlersservices.JoinLeftService
-	CleanClosedThreadsService         *grouphandlersservices.CleanClosedThreadsService
-	RepliesFromClosedThreadsService   *grouphandlersservices.RepliesFromClosedThreadsService
-	DeleteJoinLeftMessagesService     *grouphandlersservices.DeleteJoinLeftMessagesService
-	SaveTopicService                  *grouphandlersservices.SaveTopicService
-	AdminSaveMessageService           *grouphandlersservices.AdminSaveMessageService
-	SaveMessageService                *grouphandlersservices.SaveMessageService
-	SaveUpdateMessageService          *grouphandlersservices.SaveUpdateMessageService
+	OpenAiClient                       *clients.OpenAiClient
+	AppConfig                          *config.Config
+	ProfileService                     *services.ProfileService
+	SummarizationService               *services.SummarizationService
+	RandomCoffeeService                *services.RandomCoffeeService
+	MatchingProgramService             *services.MatchingProgramService
+	EventRSVPService                   *services.EventRSVPService
+	EventReminderService               *services.EventReminderService
+	EventCalendarService               *services.EventCalendarService
+	EventSeriesService                 *services.EventSeriesService
+	EventFeedbackService               *services.EventFeedbackService
+	EventMaterialService               *services.EventMaterialService
+	EventTopicService                  *services.EventTopicService
+	EventProposalService               *services.EventProposalService
+	TopicService                       *services.TopicService
+	OnboardingService                  *services.OnboardingService
+	MessageSenderService               *services.MessageSenderService
+	PermissionsService                 *services.PermissionsService
+	EventRepository                    *repositories.EventRepository
+	EventRSVPRepository                *repositories.EventRSVPRepository
+	EventFeedbackRepository            *repositories.EventFeedbackRepository
+	EventMaterialRepository            *repositories.EventMaterialRepository
+	EventProposalRepository            *repositories.EventProposalRepository
+	EventTypeRepository                *repositories.EventTypeRepository
+	KarmaRepository                    *repositories.KarmaRepository
+	BadgeRepository                    *repositories.BadgeRepository
+	ClubMembershipEventRepository      *repositories.ClubMembershipEventRepository
+	ReengagementRepository             *repositories.ReengagementRepository
+	OnboardingRepository               *repositories.OnboardingRepository
+	TopicRepository                    *repositories.TopicRepository
+	GroupTopicRepository               *repositories.GroupTopicRepository
+	PromptingTemplateRepository        *repositories.PromptingTemplateRepository
+	UserRepository                     *repositories.UserRepository
+	ProfileRepository                  *repositories.ProfileRepository
+	RandomCoffeePollRepository         *repositories.RandomCoffeePollRepository
+	RandomCoffeeParticipantRepository  *repositories.RandomCoffeeParticipantRepository
+	RandomCoffeePairRepository         *repositories.RandomCoffeePairRepository
+	RandomCoffeeSubscriptionRepository *repositories.RandomCoffeeSubscriptionRepository
+	RandomCoffeeReminderMuteRepository *repositories.RandomCoffeeReminderMuteRepository
+	MatchingProgramRepository          *repositories.MatchingProgramRepository
+	GroupMessageRepository             *repositories.GroupMessageRepository
+	RandomCoffeePollAnswersService     *grouphandlersservices.RandomCoffeePollAnswersService
+	JoinLeftService                    *grouphandlersservices.JoinLeftService
+	CleanClosedThreadsService          *grouphandlersservices.CleanClosedThreadsService
+	RepliesFromClosedThreadsService    *grouphandlersservices.RepliesFromClosedThreadsService
+	DeleteJoinLeftMessagesService      *grouphandlersservices.DeleteJoinLeftMessagesService
+	SaveTopicService                   *grouphandlersservices.SaveTopicService
+	AdminSaveMessageService            *grouphandlersservices.AdminSaveMessageService
+	SaveMessageService                 *grouphandlersservices.SaveMessageService
+	SaveUpdateMessageService           *grouphandlersservices.SaveUpdateMessageService
+	KarmaService                       *grouphandlersservices.KarmaService
 }
 
 // TgBotClient represents a Telegram bot client with all required dependencies
@@ -91,6 +116,19 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 
 	// Initialize repositories
 	eventRepository := repositories.NewEventRepository(db.DB)
+	eventRSVPRepository := repositories.NewEventRSVPRepository(db.DB)
+	eventReminderRepository := repositories.NewEventReminderRepository(db.DB)
+	eventFeedbackRepository := repositories.NewEventFeedbackRepository(db.DB)
+	eventMaterialRepository := repositories.NewEventMaterialRepository(db.DB)
+	eventProposalRepository := repositories.NewEventProposalRepository(db.DB)
+	eventTypeRepository := repositories.NewEventTypeRepository(db.DB)
+	karmaRepository := repositories.NewKarmaRepository(db.DB)
+	badgeRepository := repositories.NewBadgeRepository(db.DB)
+	clubMembershipEventRepository := repositories.NewClubMembershipEventRepository(db.DB)
+	reengagementRepository := repositories.NewReengagementRepository(db.DB)
+	onboardingRepository := repositories.NewOnboardingRepository(db.DB)
+	calendarFeedTokenRepository := repositories.NewCalendarFeedTokenRepository(db.DB)
+	eventSeriesRepository := repositories.NewEventSeriesRepository(db.DB)
 	topicRepository := repositories.NewTopicRepository(db.DB)
 	groupTopicRepository := repositories.NewGroupTopicRepository(db.DB)
 	promptingTemplateRepository := repositories.NewPromptingTemplateRepository(db.DB)
@@ -99,6 +137,10 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 	randomCoffeePollRepository := repositories.NewRandomCoffeePollRepository(db.DB)
 	randomCoffeeParticipantRepository := repositories.NewRandomCoffeeParticipantRepository(db.DB)
 	randomCoffeePairRepository := repositories.NewRandomCoffeePairRepository(db.DB)
+	randomCoffeePairDraftRepository := repositories.NewRandomCoffeePairDraftRepository(db.DB)
+	randomCoffeeSubscriptionRepository := repositories.NewRandomCoffeeSubscriptionRepository(db.DB)
+	randomCoffeeReminderMuteRepository := repositories.NewRandomCoffeeReminderMuteRepository(db.DB)
+	matchingProgramRepository := repositories.NewMatchingProgramRepository(db.DB)
 	groupMessageRepository := repositories.NewGroupMessageRepository(db.DB)
 
 	// Initialize services
@@ -127,7 +169,98 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 		randomCoffeeParticipantRepository,
 		profileRepository,
 		randomCoffeePairRepository,
+		randomCoffeePairDraftRepository,
 		userRepository,
+		matchingProgramRepository,
+	)
+	matchingProgramService := services.NewMatchingProgramService(
+		appConfig,
+		pollSenderService,
+		messageSenderService,
+		matchingProgramRepository,
+		randomCoffeePollRepository,
+		randomCoffeeParticipantRepository,
+		randomCoffeeService,
+	)
+	eventRSVPService := services.NewEventRSVPService(
+		appConfig,
+		messageSenderService,
+		eventRepository,
+		eventRSVPRepository,
+		userRepository,
+	)
+	topicService := services.NewTopicService(
+		appConfig,
+		messageSenderService,
+		topicRepository,
+		eventRepository,
+		userRepository,
+	)
+	eventReminderService := services.NewEventReminderService(
+		appConfig,
+		messageSenderService,
+		eventRepository,
+		eventRSVPRepository,
+		eventReminderRepository,
+		topicService,
+	)
+	eventFeedbackService := services.NewEventFeedbackService(
+		appConfig,
+		messageSenderService,
+		eventRepository,
+		eventRSVPRepository,
+		eventFeedbackRepository,
+	)
+	eventMaterialService := services.NewEventMaterialService(
+		appConfig,
+		messageSenderService,
+		eventMaterialRepository,
+		groupMessageRepository,
+	)
+	eventTopicService := services.NewEventTopicService(
+		appConfig,
+		messageSenderService,
+		eventRepository,
+		eventRSVPRepository,
+		groupTopicRepository,
+	)
+	badgeService := services.NewBadgeService(
+		bot,
+		appConfig,
+		messageSenderService,
+		badgeRepository,
+		userRepository,
+		profileRepository,
+	)
+	reengagementService := services.NewReengagementService(
+		appConfig,
+		messageSenderService,
+		reengagementRepository,
+	)
+	onboardingService := services.NewOnboardingService(
+		bot,
+		appConfig,
+		messageSenderService,
+		onboardingRepository,
+	)
+	eventProposalService := services.NewEventProposalService(
+		appConfig,
+		messageSenderService,
+		eventProposalRepository,
+		eventRepository,
+		userRepository,
+		eventTopicService,
+	)
+	eventCalendarService := services.NewEventCalendarService(
+		appConfig,
+		eventRepository,
+		calendarFeedTokenRepository,
+	)
+	eventSeriesService := services.NewEventSeriesService(
+		appConfig,
+		eventRepository,
+		eventSeriesRepository,
+		eventTopicService,
 	)
 	randomCoffeePollAnswersService := grouphandlersservices.NewRandomCoffeePollAnswersService(
 		messageSenderService,
@@ -136,11 +269,24 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 		randomCoffeeParticipantRepository,
 		userRepository,
 	)
-	joinLeftService := grouphandlersservices.NewJoinLeftService(userRepository)
+	introArchiveService := services.NewIntroArchiveService(
+		bot,
+		appConfig,
+		messageSenderService,
+		profileRepository,
+		badgeRepository,
+	)
+	joinLeftService := grouphandlersservices.NewJoinLeftService(
+		userRepository,
+		clubMembershipEventRepository,
+		onboardingService,
+		introArchiveService,
+	)
 	cleanClosedThreadsService := grouphandlersservices.NewCleanClosedThreadsService(
 		appConfig,
 		messageSenderService,
 		groupTopicRepository,
+		eventTopicService,
 	)
 	saveUpdateMessageService := grouphandlersservices.NewSaveUpdateMessageService(
 		groupMessageRepository,
@@ -152,10 +298,11 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 		appConfig,
 		messageSenderService,
 		groupTopicRepository,
+		eventTopicService,
 		saveUpdateMessageService,
 	)
 	deleteJoinLeftMessagesService := grouphandlersservices.NewDeleteJoinLeftMessagesService()
-	saveTopicService := grouphandlersservices.NewSaveTopicService(groupTopicRepository)
+	saveTopicService := grouphandlersservices.NewSaveTopicService(groupTopicRepository, eventTopicService)
 	adminSaveMessageService := grouphandlersservices.NewAdminSaveMessageService(
 		groupMessageRepository,
 		bot,
@@ -168,12 +315,28 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 		saveUpdateMessageService,
 		appConfig,
 	)
+	karmaService := grouphandlersservices.NewKarmaService(
+		appConfig,
+		karmaRepository,
+		userRepository,
+		groupMessageRepository,
+	)
 
 	// Initialize scheduled tasks
 	scheduledTasks := []tasks.Task{
 		tasks.NewDailySummarizationTask(appConfig, summarizationService),
 		tasks.NewRandomCoffeePollTask(appConfig, randomCoffeeService),
 		tasks.NewRandomCoffeePairsTask(appConfig, randomCoffeeService),
+		tasks.NewRandomCoffeeReminderTask(appConfig, randomCoffeeService),
+		tasks.NewMatchingProgramsTask(appConfig, matchingProgramService),
+		tasks.NewEventRemindersTask(appConfig, eventReminderService),
+		tasks.NewEventFeedbackTask(appConfig, eventFeedbackService),
+		tasks.NewEventSeriesTask(appConfig, eventSeriesService),
+		tasks.NewEventTopicsTask(appConfig, eventTopicService),
+		tasks.NewBadgesTask(appConfig, badgeService),
+		tasks.NewReengagementTask(appConfig, reengagementService),
+		tasks.NewOnboardingTask(appConfig, onboardingService),
+		tasks.NewCalendarFeedServerTask(appConfig, bot, eventCalendarService, userRepository),
 	}
 
 	// Create bot client
@@ -187,32 +350,57 @@ func NewTgBotClient(openaiClient *clients.OpenAiClient, appConfig *config.Config
 
 	// Create dependencies container
 	deps := &HandlerDependencies{
-		OpenAiClient:                      openaiClient,
-		AppConfig:                         appConfig,
-		ProfileService:                    profileService,
-		SummarizationService:              summarizationService,
-		RandomCoffeeService:               randomCoffeeService,
-		MessageSenderService:              messageSenderService,
-		PermissionsService:                permissionsService,
-		EventRepository:                   eventRepository,
-		TopicRepository:                   topicRepository,
-		GroupTopicRepository:              groupTopicRepository,
-		PromptingTemplateRepository:       promptingTemplateRepository,
-		UserRepository:                    userRepository,
-		ProfileRepository:                 profileRepository,
-		RandomCoffeePollRepository:        randomCoffeePollRepository,
-		RandomCoffeeParticipantRepository: randomCoffeeParticipantRepository,
-		RandomCoffeePairRepository:        randomCoffeePairRepository,
-		GroupMessageRepository:            groupMessageRepository,
-		RandomCoffeePollAnswersService:    randomCoffeePollAnswersService,
-		JoinLeftService:                   joinLeftService,
-		CleanClosedThreadsService:         cleanClosedThreadsService,
-		RepliesFromClosedThreadsService:   repliesFromClosedThreadsService,
-		DeleteJoinLeftMessagesService:     deleteJoinLeftMessagesService,
-		SaveTopicService:                  saveTopicService,
-		AdminSaveMessageService:           adminSaveMessageService,
-		SaveMessageService:                saveMessageService,
-		SaveUpdateMessageService:          saveUpdateMessageService,
+		OpenAiClient:                       openaiClient,
+		AppConfig:                          appConfig,
+		ProfileService:                     profileService,
+		SummarizationService:               summarizationService,
+		RandomCoffeeService:                randomCoffeeService,
+		MatchingProgramService:             matchingProgramService,
+		EventRSVPService:                   eventRSVPService,
+		EventReminderService:               eventReminderService,
+		EventCalendarService:               eventCalendarService,
+		EventSeriesService:                 eventSeriesService,
+		EventFeedbackService:               eventFeedbackService,
+		EventMaterialService:               eventMaterialService,
+		EventTopicService:                  eventTopicService,
+		EventProposalService:               eventProposalService,
+		TopicService:                       topicService,
+		OnboardingService:                  onboardingService,
+		MessageSenderService:               messageSenderService,
+		PermissionsService:                 permissionsService,
+		EventRepository:                    eventRepository,
+		EventRSVPRepository:                eventRSVPRepository,
+		EventFeedbackRepository:            eventFeedbackRepository,
+		EventMaterialRepository:            eventMaterialRepository,
+		EventProposalRepository:            eventProposalRepository,
+		EventTypeRepository:                eventTypeRepository,
+		KarmaRepository:                    karmaRepository,
+		BadgeRepository:                    badgeRepository,
+		ClubMembershipEventRepository:      clubMembershipEventRepository,
+		ReengagementRepository:             reengagementRepository,
+		OnboardingRepository:               onboardingRepository,
+		TopicRepository:                    topicRepository,
+		GroupTopicRepository:               groupTopicRepository,
+		PromptingTemplateRepository:        promptingTemplateRepository,
+		UserRepository:                     userRepository,
+		ProfileRepository:                  profileRepository,
+		RandomCoffeePollRepository:         randomCoffeePollRepository,
+		RandomCoffeeParticipantRepository:  randomCoffeeParticipantRepository,
+		RandomCoffeePairRepository:         randomCoffeePairRepository,
+		RandomCoffeeSubscriptionRepository: randomCoffeeSubscriptionRepository,
+		RandomCoffeeReminderMuteRepository: randomCoffeeReminderMuteRepository,
+		MatchingProgramRepository:          matchingProgramRepository,
+		GroupMessageRepository:             groupMessageRepository,
+		RandomCoffeePollAnswersService:     randomCoffeePollAnswersService,
+		JoinLeftService:                    joinLeftService,
+		CleanClosedThreadsService:          cleanClosedThreadsService,
+		RepliesFromClosedThreadsService:    repliesFromClosedThreadsService,
+		DeleteJoinLeftMessagesService:      deleteJoinLeftMessagesService,
+		SaveTopicService:                   saveTopicService,
+		AdminSaveMessageService:            adminSaveMessageService,
+		SaveMessageService:                 saveMessageService,
+		SaveUpdateMessageService:           saveUpdateMessageService,
+		KarmaService:                       karmaService,
 	}
 
 	// Register all handlers
@@ -238,31 +426,69 @@ func setupDatabase(connectionString string) (*database.DB, error) {
 // registerHandlers registers all bot handlers
 func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 	// Register start handler, that avaliable for all users
-	b.dispatcher.AddHandler(handlers.NewStartHandler(deps.AppConfig, deps.MessageSenderService, deps.PermissionsService))
+	b.dispatcher.AddHandler(handlers.NewStartHandler(
+		deps.AppConfig,
+		deps.MessageSenderService,
+		deps.PermissionsService,
+		deps.OnboardingService,
+		deps.UserRepository,
+	))
 
 	// Register admin chat handlers
 	adminHandlers := []ext.Handler{
 		eventhandlers.NewEventDeleteHandler(
 			deps.AppConfig,
 			deps.EventRepository,
+			deps.EventSeriesService,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
 		eventhandlers.NewEventEditHandler(
 			deps.AppConfig,
 			deps.EventRepository,
+			deps.EventTypeRepository,
+			deps.UserRepository,
+			deps.EventRSVPService,
+			deps.EventMaterialService,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
 		eventhandlers.NewEventSetupHandler(
 			deps.AppConfig,
 			deps.EventRepository,
+			deps.EventTypeRepository,
+			deps.UserRepository,
+			deps.EventSeriesService,
+			deps.EventTopicService,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
+		eventhandlers.NewEventAnnounceHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.EventRSVPRepository,
+			deps.MessageSenderService,
+		),
 		eventhandlers.NewEventStartHandler(
 			deps.AppConfig,
 			deps.EventRepository,
+			deps.EventReminderService,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		eventhandlers.NewEventReportHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.EventTypeRepository,
+			deps.EventRSVPRepository,
+			deps.EventFeedbackRepository,
+			deps.TopicRepository,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		eventhandlers.NewEventTypesHandler(
+			deps.AppConfig,
+			deps.EventTypeRepository,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
@@ -300,6 +526,33 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.ProfileService,
 			deps.UserRepository,
 			deps.ProfileRepository,
+			deps.BadgeRepository,
+			deps.ClubMembershipEventRepository,
+		),
+		adminhandlers.NewGroupStatsHandler(
+			deps.AppConfig,
+			deps.GroupMessageRepository,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		adminhandlers.NewMatchingProgramsHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+			deps.MatchingProgramService,
+			deps.MatchingProgramRepository,
+			deps.RandomCoffeePollRepository,
+		),
+		adminhandlers.NewRandomCoffeeDraftHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.RandomCoffeeService,
+		),
+		adminhandlers.NewRandomCoffeeStatsHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+			deps.RandomCoffeePairRepository,
 		),
 		adminhandlers.NewShowTopicsHandler(
 			deps.AppConfig,
@@ -308,6 +561,21 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
+		adminhandlers.NewTopicsQueueHandler(
+			deps.AppConfig,
+			deps.TopicRepository,
+			deps.EventRepository,
+			deps.TopicService,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		adminhandlers.NewEventProposalsQueueHandler(
+			deps.AppConfig,
+			deps.EventProposalRepository,
+			deps.EventProposalService,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
 	}
 
 	// Register group chat handlers
@@ -324,7 +592,9 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.SaveTopicService,
 			deps.AdminSaveMessageService,
 			deps.SaveMessageService,
+			deps.KarmaService,
 		),
+		grouphandlers.NewReactionHandler(deps.KarmaService),
 	}
 
 	// Register private chat handlers
@@ -333,6 +603,8 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.AppConfig,
 			deps.TopicRepository,
 			deps.EventRepository,
+			deps.UserRepository,
+			deps.TopicService,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
@@ -340,6 +612,22 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.AppConfig,
 			deps.TopicRepository,
 			deps.EventRepository,
+			deps.UserRepository,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		topicshandlers.NewTopicVoteHandler(
+			deps.AppConfig,
+			deps.TopicRepository,
+			deps.EventRepository,
+			deps.UserRepository,
+		),
+		topicshandlers.NewMyTopicsHandler(
+			deps.AppConfig,
+			deps.TopicRepository,
+			deps.EventRepository,
+			deps.UserRepository,
+			deps.TopicService,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
@@ -354,6 +642,70 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 		privatehandlers.NewEventsHandler(
 			deps.AppConfig,
 			deps.EventRepository,
+			deps.EventRSVPRepository,
+			deps.EventCalendarService,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		privatehandlers.NewEventRSVPHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.EventRSVPRepository,
+			deps.UserRepository,
+			deps.EventRSVPService,
+			deps.MessageSenderService,
+		),
+		privatehandlers.NewEventsArchiveHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.EventMaterialRepository,
+			deps.MessageSenderService,
+		),
+		privatehandlers.NewEventProposeHandler(
+			deps.AppConfig,
+			deps.EventProposalRepository,
+			deps.EventTypeRepository,
+			deps.UserRepository,
+			deps.EventProposalService,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		privatehandlers.NewEventFeedbackHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.EventFeedbackRepository,
+			deps.UserRepository,
+			deps.MessageSenderService,
+		),
+		privatehandlers.NewEventCalendarHandler(
+			deps.AppConfig,
+			deps.EventRepository,
+			deps.UserRepository,
+			deps.EventCalendarService,
+			deps.MessageSenderService,
+		),
+		privatehandlers.NewMyEventsHandler(
+			deps.AppConfig,
+			deps.EventRSVPRepository,
+			deps.UserRepository,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+		),
+		privatehandlers.NewReengagementHandler(
+			deps.UserRepository,
+			deps.ReengagementRepository,
+		),
+		privatehandlers.NewOnboardingHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.OnboardingService,
+			deps.UserRepository,
+			deps.OnboardingRepository,
+		),
+		privatehandlers.NewKarmaLeaderboardHandler(
+			deps.AppConfig,
+			deps.KarmaRepository,
+			deps.UserRepository,
 			deps.MessageSenderService,
 			deps.PermissionsService,
 		),
@@ -377,9 +729,25 @@ func (b *TgBotClient) registerHandlers(deps *HandlerDependencies) {
 			deps.ProfileService,
 			deps.UserRepository,
 			deps.ProfileRepository,
+			deps.BadgeRepository,
 			deps.PromptingTemplateRepository,
 			deps.OpenAiClient,
 		),
+		privatehandlers.NewRandomCoffeeHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+			deps.UserRepository,
+			deps.RandomCoffeeSubscriptionRepository,
+			deps.RandomCoffeeReminderMuteRepository,
+		),
+		privatehandlers.NewRandomCoffeeHistoryHandler(
+			deps.AppConfig,
+			deps.MessageSenderService,
+			deps.PermissionsService,
+			deps.UserRepository,
+			deps.RandomCoffeePairRepository,
+		),
 		privatehandlers.NewToolsHandler(
 			deps.AppConfig,
 			deps.OpenAiClient,
@@ -420,6 +788,7 @@ func (b *TgBotClient) Start() {
 				"callback_query",
 				"poll_answer",
 				"my_chat_member",
+				"message_reaction",
 			},
 		},
 	}
@@ -48,31 +48,50 @@ var expectedConstructors = []string{
 	"NewEventDeleteHandler",
 	"NewEventEditHandler",
 	"NewEventSetupHandler",
+	"NewEventAnnounceHandler",
 	"NewEventStartHandler",
+	"NewEventReportHandler",
+	"NewEventTypesHandler",
 	"NewTryCreateCoffeePoolHandler",
 	"NewTryGenerateCoffeePairsHandler",
 	"NewTrySummarizeHandler",
 	"NewAdminProfilesHandler",
+	"NewMatchingProgramsHandler",
+	"NewRandomCoffeeDraftHandler",
+	"NewRandomCoffeeStatsHandler",
+	"NewGroupStatsHandler",
 	"NewShowTopicsHandler",
+	"NewTopicsQueueHandler",
+	"NewEventProposalsQueueHandler",
+	"NewTryLinkToLearnHandler",
 
 	// Group
-	"NewSaveTopicsHandler",
-	"NewAdminMessageControlHandler",
-	"NewSaveMessagesHandler",
-	"NewCleanClosedThreadsHandler",
-	"NewDeleteJoinLeftMessagesHandler",
-	"NewJoinLeftHandler",
-	"NewRandomCoffeePollAnswerHandler",
-	"NewRepliesFromClosedThreadsHandler",
+	"NewChatMemberHandler",
+	"NewPollAnswerHandler",
+	"NewMessageHandler",
+	"NewReactionHandler",
 
 	// Private
 	"NewTopicAddHandler",
 	"NewTopicsHandler",
+	"NewTopicVoteHandler",
+	"NewMyTopicsHandler",
 	"NewContentHandler",
 	"NewEventsHandler",
+	"NewEventRSVPHandler",
+	"NewEventFeedbackHandler",
+	"NewEventsArchiveHandler",
+	"NewEventProposeHandler",
+	"NewEventCalendarHandler",
+	"NewMyEventsHandler",
+	"NewKarmaLeaderboardHandler",
+	"NewReengagementHandler",
+	"NewOnboardingHandler",
 	"NewHelpHandler",
 	"NewIntroHandler",
 	"NewProfileHandler",
+	"NewRandomCoffeeHandler",
+	"NewRandomCoffeeHistoryHandler",
 	"NewToolsHandler",
 }
 
@@ -0,0 +1,36 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventFeedbackRatingButtons returns the 1 to 5 star rating buttons of the feedback form sent after an event
+func EventFeedbackRatingButtons(eventID int) gotgbot.InlineKeyboardMarkup {
+	row := make([]gotgbot.InlineKeyboardButton, 0, 5)
+	for rating := 1; rating <= 5; rating++ {
+		row = append(row, gotgbot.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%d \u2b50", rating),
+			CallbackData: fmt.Sprintf("%s%d_%d", constants.EventFeedbackRatePrefix, eventID, rating),
+		})
+	}
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{row},
+	}
+}
+
+// EventFeedbackSkipCommentButton returns the button that finishes the feedback without a comment
+func EventFeedbackSkipCommentButton(callbackData string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u23ed Skip",
+					CallbackData: callbackData,
+				},
+			},
+		},
+	}
+}
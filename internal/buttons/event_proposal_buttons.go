@@ -0,0 +1,46 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventProposalReviewButtons returns the Approve / Request changes / Reject buttons for a pending event proposal
+func EventProposalReviewButtons(proposalID int) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u2705 Approve",
+					CallbackData: fmt.Sprintf("%s%d", constants.EventProposalReviewApprovePrefix, proposalID),
+				},
+				{
+					Text:         "\u274c Reject",
+					CallbackData: fmt.Sprintf("%s%d", constants.EventProposalReviewRejectPrefix, proposalID),
+				},
+			},
+			{
+				{
+					Text:         "\u270f\ufe0f Request changes",
+					CallbackData: fmt.Sprintf("%s%d", constants.EventProposalReviewChangesPrefix, proposalID),
+				},
+			},
+		},
+	}
+}
+
+// EventProposalReviseButton returns the button that lets the member revise the proposal the administrators asked to change
+func EventProposalReviseButton(proposalID int) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u270f\ufe0f Revise proposal",
+					CallbackData: fmt.Sprintf("%s%d", constants.EventProposalRevisePrefix, proposalID),
+				},
+			},
+		},
+	}
+}
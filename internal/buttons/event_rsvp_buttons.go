@@ -0,0 +1,146 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventRSVPButtons returns the Going / Maybe / Can't go buttons for an event
+func EventRSVPButtons(eventID int) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			eventRSVPRow(eventID),
+		},
+	}
+}
+
+// EventCardButtons returns the RSVP buttons for an event, followed by the .ics file button for events
+// with a planned start and the attendee list button for hosts and admins
+func EventCardButtons(eventID int, showCalendarFile bool, showAttendees bool) gotgbot.InlineKeyboardMarkup {
+	keyboard := [][]gotgbot.InlineKeyboardButton{
+		eventRSVPRow(eventID),
+	}
+	if showCalendarFile {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f4e5 Add to calendar",
+				CallbackData: fmt.Sprintf("%s%d", constants.EventCalendarFilePrefix, eventID),
+			},
+		})
+	}
+	if showAttendees {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f465 Attendees",
+				CallbackData: fmt.Sprintf("%s%d", constants.EventRSVPAttendeesPrefix, eventID),
+			},
+		})
+	}
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// EventsListButtons returns one button per event, opening the event card with the RSVP buttons,
+// the calendar subscription button if showCalendarFeed is set and the past events button if showArchive is set
+func EventsListButtons(events []repositories.Event, showCalendarFeed bool, showArchive bool) gotgbot.InlineKeyboardMarkup {
+	keyboard := make([][]gotgbot.InlineKeyboardButton, 0, len(events))
+	for _, event := range events {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f4c5 " + event.Name,
+				CallbackData: fmt.Sprintf("%s%d", constants.EventRSVPViewPrefix, event.ID),
+			},
+		})
+	}
+	if showCalendarFeed {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f5d3 Subscribe to calendar",
+				CallbackData: constants.EventCalendarFeedCallback,
+			},
+		})
+	}
+	if showArchive {
+		keyboard = append(keyboard, eventsArchiveRow())
+	}
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// EventsArchiveButton returns the button that shows the past events with their materials
+func EventsArchiveButton() gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			eventsArchiveRow(),
+		},
+	}
+}
+
+// EventAnnounceButton returns the button that posts the event announcement with RSVP buttons to the group
+func EventAnnounceButton(eventID int) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\U0001f4e3 Announce in the group",
+					CallbackData: fmt.Sprintf("%s%d", constants.EventAnnouncePrefix, eventID),
+				},
+			},
+		},
+	}
+}
+
+// EventJoinLinkButton returns the button that opens the event's join link
+func EventJoinLinkButton(link string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text: "\U0001f517 Zoom Link",
+					Url:  link,
+				},
+			},
+		},
+	}
+}
+
+// EventCalendarFeedButtons returns the button that replaces the member's calendar feed link with a new one
+func EventCalendarFeedButtons() gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\U0001f504 Reset link",
+					CallbackData: constants.EventCalendarFeedResetCallback,
+				},
+			},
+		},
+	}
+}
+
+func eventRSVPRow(eventID int) []gotgbot.InlineKeyboardButton {
+	return []gotgbot.InlineKeyboardButton{
+		{
+			Text:         "\u2705 Going",
+			CallbackData: fmt.Sprintf("%s%d", constants.EventRSVPGoingPrefix, eventID),
+		},
+		{
+			Text:         "\U0001f914 Maybe",
+			CallbackData: fmt.Sprintf("%s%d", constants.EventRSVPMaybePrefix, eventID),
+		},
+		{
+			Text:         "\u274c Can't go",
+			CallbackData: fmt.Sprintf("%s%d", constants.EventRSVPNotGoingPrefix, eventID),
+		},
+	}
+}
+
+func eventsArchiveRow() []gotgbot.InlineKeyboardButton {
+	return []gotgbot.InlineKeyboardButton{
+		{
+			Text:         "\U0001f5c2 Past events and recordings",
+			CallbackData: constants.EventsArchiveCallback,
+		},
+	}
+}
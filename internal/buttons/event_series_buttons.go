@@ -0,0 +1,30 @@
+package buttons
+
+import "github.com/PaulSonOfLars/gotgbot/v2"
+
+// EventDeleteOccurrenceButtons returns the buttons to delete a single occurrence of a recurring event,
+// to stop the whole series, or to cancel
+func EventDeleteOccurrenceButtons(callbackDataYes string, callbackDataStopSeries string, callbackDataNo string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\U0001f5d1 Only this occurrence",
+					CallbackData: callbackDataYes,
+				},
+			},
+			{
+				{
+					Text:         "\U0001f6d1 Stop the series",
+					CallbackData: callbackDataStopSeries,
+				},
+			},
+			{
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: callbackDataNo,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// GroupStatsButtons returns the range buttons of "/stats", the current range is marked,
+// and the button that sends the chart of the current range
+func GroupStatsButtons(days int) gotgbot.InlineKeyboardMarkup {
+	ranges := make([]gotgbot.InlineKeyboardButton, 0, len(constants.GroupStatsRanges))
+	for _, rangeDays := range constants.GroupStatsRanges {
+		text := fmt.Sprintf("%d days", rangeDays)
+		if rangeDays == days {
+			text = "\u2022 " + text
+		}
+		ranges = append(ranges, gotgbot.InlineKeyboardButton{
+			Text:         text,
+			CallbackData: fmt.Sprintf("%s%d", constants.GroupStatsRangePrefix, rangeDays),
+		})
+	}
+
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			ranges,
+			{
+				{
+					Text:         "\U0001f4c8 Chart",
+					CallbackData: fmt.Sprintf("%s%d", constants.GroupStatsChartPrefix, days),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// MatchingProgramsListButtons returns one button per program plus buttons to create a program or cancel
+func MatchingProgramsListButtons(programs []repositories.MatchingProgram) gotgbot.InlineKeyboardMarkup {
+	var keyboard [][]gotgbot.InlineKeyboardButton
+	for _, program := range programs {
+		status := "\u25b6\ufe0f"
+		if !program.IsActive {
+			status = "\u23f8\ufe0f"
+		}
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s %s", status, program.Name),
+				CallbackData: fmt.Sprintf("%s%d", constants.MatchingProgramsViewPrefix, program.ID),
+			},
+		})
+	}
+
+	keyboard = append(keyboard,
+		[]gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\u2795 New program",
+				CallbackData: constants.MatchingProgramsCreateCallback,
+			},
+		},
+		[]gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\u274c Cancel",
+				CallbackData: constants.MatchingProgramsCancelCallback,
+			},
+		},
+	)
+
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// MatchingProgramViewButtons returns the actions available for a single program
+func MatchingProgramViewButtons(isActive bool) gotgbot.InlineKeyboardMarkup {
+	toggleButtonText := "\u25b6\ufe0f Resume"
+	if isActive {
+		toggleButtonText = "\u23f8\ufe0f Pause"
+	}
+
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         toggleButtonText,
+					CallbackData: constants.MatchingProgramsToggleActiveCallback,
+				},
+			},
+			{
+				{
+					Text:         "\U0001f4e3 Send poll now",
+					CallbackData: constants.MatchingProgramsSendPollCallback,
+				},
+				{
+					Text:         "\U0001f91d Match now",
+					CallbackData: constants.MatchingProgramsMatchCallback,
+				},
+			},
+			{
+				{
+					Text:         "\U0001f5d1 Delete",
+					CallbackData: constants.MatchingProgramsDeleteCallback,
+				},
+			},
+			{
+				{
+					Text:         "\u25c0\ufe0f Back",
+					CallbackData: constants.MatchingProgramsStartCallback,
+				},
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: constants.MatchingProgramsCancelCallback,
+				},
+			},
+		},
+	}
+}
+
+func MatchingProgramsBackCancelButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u25c0\ufe0f Back",
+					CallbackData: backCallbackData,
+				},
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: constants.MatchingProgramsCancelCallback,
+				},
+			},
+		},
+	}
+}
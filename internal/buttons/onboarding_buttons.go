@@ -0,0 +1,46 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// OnboardingButtons returns the buttons of the onboarding checklist, the rules button only if the rules are required.
+// The rules and the course are opened through callbacks, so the steps can be ticked.
+func OnboardingButtons(rulesRequired bool) gotgbot.InlineKeyboardMarkup {
+	var rows [][]gotgbot.InlineKeyboardButton
+	if rulesRequired {
+		rows = append(rows, []gotgbot.InlineKeyboardButton{
+			{Text: "\U0001f4dc Read the rules", CallbackData: constants.OnboardingRulesCallback},
+		})
+	}
+	rows = append(rows,
+		[]gotgbot.InlineKeyboardButton{
+			{Text: "\U0001f4d6 Open the AI course", CallbackData: constants.OnboardingCourseCallback},
+		},
+		[]gotgbot.InlineKeyboardButton{
+			{Text: "\U0001f504 Check my progress", CallbackData: constants.OnboardingRefreshCallback},
+		},
+	)
+
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// OnboardingMentionButtons returns the button of the group welcome that opens a chat with the bot
+func OnboardingMentionButtons(botUsername string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{
+			{Text: "\U0001f916 Start the bot", Url: "https://t.me/" + botUsername + "?start=onboarding"},
+		}},
+	}
+}
+
+// OnboardingCourseButtons returns the button that opens the course Mini App
+func OnboardingCourseButtons() gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{
+			{Text: "\U0001f4d6 Open AI Course", WebApp: &gotgbot.WebAppInfo{Url: constants.CourseMiniAppURL}},
+		}},
+	}
+}
@@ -2,6 +2,7 @@ package buttons
 
 import (
 	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/formatters"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 )
@@ -69,6 +70,58 @@ func ProfileBackCancelButtons(backCallbackData string) gotgbot.InlineKeyboardMar
 	}
 }
 
+// ProfileBioEditButtons offers the AI-drafted bio next to the usual navigation
+func ProfileBioEditButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u2728 Help me write my bio",
+					CallbackData: constants.ProfileBioAssistCallback,
+				},
+			},
+			{
+				{
+					Text:         "\u25c0\ufe0f Back",
+					CallbackData: backCallbackData,
+				},
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: constants.ProfileFullCancel,
+				},
+			},
+		},
+	}
+}
+
+// ProfileBioDraftButtons lets the member save or regenerate the drafted bio, editing is done by sending the text back
+func ProfileBioDraftButtons() gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u2705 Save",
+					CallbackData: constants.ProfileBioDraftSaveCallback,
+				},
+				{
+					Text:         "\U0001f504 Regenerate",
+					CallbackData: constants.ProfileBioDraftRegenerateCallback,
+				},
+			},
+			{
+				{
+					Text:         "\u25c0\ufe0f Back",
+					CallbackData: constants.ProfileEditBioCallback,
+				},
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: constants.ProfileFullCancel,
+				},
+			},
+		},
+	}
+}
+
 func ProfileEditButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
 	buttons := [][]gotgbot.InlineKeyboardButton{
 		{
@@ -85,19 +138,36 @@ func ProfileEditButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
 				CallbackData: constants.ProfileEditBioCallback,
 			},
 		},
+	}
+	buttons = append(buttons, ProfileFieldButtonRows(constants.ProfileEditFieldPrefix)...)
+	buttons = append(buttons, []gotgbot.InlineKeyboardButton{
 		{
-			{
-				Text:         "\u25c0\ufe0f Back",
-				CallbackData: backCallbackData,
-			},
-			{
-				Text:         "\u274c Cancel",
-				CallbackData: constants.ProfileFullCancel,
-			},
+			Text:         "\u25c0\ufe0f Back",
+			CallbackData: backCallbackData,
 		},
-	}
+		{
+			Text:         "\u274c Cancel",
+			CallbackData: constants.ProfileFullCancel,
+		},
+	})
 
 	return gotgbot.InlineKeyboardMarkup{
 		InlineKeyboard: buttons,
 	}
 }
+
+// ProfileFieldButtonRows returns the buttons editing the structured profile fields, three per row.
+// The callback data is the prefix followed by the field.
+func ProfileFieldButtonRows(callbackPrefix string) [][]gotgbot.InlineKeyboardButton {
+	var rows [][]gotgbot.InlineKeyboardButton
+	for i, field := range constants.AllProfileFields {
+		if i%3 == 0 {
+			rows = append(rows, []gotgbot.InlineKeyboardButton{})
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], gotgbot.InlineKeyboardButton{
+			Text:         formatters.GetProfileFieldEmoji(field) + " " + formatters.GetProfileFieldName(field),
+			CallbackData: callbackPrefix + string(field),
+		})
+	}
+	return rows
+}
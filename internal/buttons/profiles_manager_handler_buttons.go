@@ -76,53 +76,58 @@ func ProfilesCoffeeBanButtons(backCallbackData string, hasCoffeeBan bool) gotgbo
 }
 
 func ProfilesEditMenuButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
-	return gotgbot.InlineKeyboardMarkup{
-		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+	buttons := [][]gotgbot.InlineKeyboardButton{
+		{
 			{
-				{
-					Text:         "\U0001f464 First Name",
-					CallbackData: constants.AdminProfilesEditFirstnameCallback,
-				},
-				{
-					Text:         "\U0001f464 Last Name",
-					CallbackData: constants.AdminProfilesEditLastnameCallback,
-				},
-				{
-					Text:         "\U0001f464 Username",
-					CallbackData: constants.AdminProfilesEditUsernameCallback,
-				},
+				Text:         "\U0001f464 First Name",
+				CallbackData: constants.AdminProfilesEditFirstnameCallback,
 			},
 			{
-				{
-					Text:         "\U0001f4dd Bio",
-					CallbackData: constants.AdminProfilesEditBioCallback,
-				},
-				{
-					Text:         "\u2615\ufe0f Coffee?",
-					CallbackData: constants.AdminProfilesEditCoffeeBanCallback,
-				},
+				Text:         "\U0001f464 Last Name",
+				CallbackData: constants.AdminProfilesEditLastnameCallback,
 			},
 			{
-				{
-					Text:         "\U0001f4e2 Publish (+ preview)",
-					CallbackData: constants.AdminProfilesPublishCallback,
-				},
-				{
-					Text:         "\U0001f4e2 Publish (- preview)",
-					CallbackData: constants.AdminProfilesPublishNoPreviewCallback,
-				},
+				Text:         "\U0001f464 Username",
+				CallbackData: constants.AdminProfilesEditUsernameCallback,
 			},
+		},
+		{
 			{
-				{
-					Text:         "\u25c0\ufe0f Back",
-					CallbackData: backCallbackData,
-				},
-				{
-					Text:         "\u274c Cancel",
-					CallbackData: constants.AdminProfilesCancelCallback,
-				},
+				Text:         "\U0001f4dd Bio",
+				CallbackData: constants.AdminProfilesEditBioCallback,
+			},
+			{
+				Text:         "\u2615\ufe0f Coffee?",
+				CallbackData: constants.AdminProfilesEditCoffeeBanCallback,
+			},
+		},
+	}
+	buttons = append(buttons, ProfileFieldButtonRows(constants.AdminProfilesEditFieldPrefix)...)
+	buttons = append(buttons,
+		[]gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f4e2 Publish (+ preview)",
+				CallbackData: constants.AdminProfilesPublishCallback,
+			},
+			{
+				Text:         "\U0001f4e2 Publish (- preview)",
+				CallbackData: constants.AdminProfilesPublishNoPreviewCallback,
+			},
+		},
+		[]gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\u25c0\ufe0f Back",
+				CallbackData: backCallbackData,
+			},
+			{
+				Text:         "\u274c Cancel",
+				CallbackData: constants.AdminProfilesCancelCallback,
 			},
 		},
+	)
+
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: buttons,
 	}
 }
 
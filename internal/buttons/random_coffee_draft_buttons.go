@@ -0,0 +1,77 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// RandomCoffeeDraftButtons returns the review buttons for the pairs draft of a round
+func RandomCoffeeDraftButtons(pollID int) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u2705 Publish",
+					CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftPublishPrefix, pollID),
+				},
+			},
+			{
+				{
+					Text:         "\U0001f3b2 Re-roll",
+					CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftRerollPrefix, pollID),
+				},
+				{
+					Text:         "\U0001f500 Swap",
+					CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftSwapPrefix, pollID),
+				},
+				{
+					Text:         "\u2796 Remove",
+					CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftRemovePrefix, pollID),
+				},
+			},
+			{
+				{
+					Text:         "\U0001f5d1 Discard",
+					CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftDiscardPrefix, pollID),
+				},
+			},
+		},
+	}
+}
+
+// RandomCoffeeDraftPickMemberButtons returns one button per member (two per row) followed by a Back button
+// to the draft of the round. The callback data of each member button is callbackPrefix followed by the member's user ID.
+func RandomCoffeeDraftPickMemberButtons(pollID int, members []repositories.User, callbackPrefix string) gotgbot.InlineKeyboardMarkup {
+	var keyboard [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+
+	for _, member := range members {
+		label := member.Firstname
+		if member.TgUsername != "" {
+			label = "@" + member.TgUsername
+		}
+		row = append(row, gotgbot.InlineKeyboardButton{
+			Text:         label,
+			CallbackData: fmt.Sprintf("%s%d", callbackPrefix, member.ID),
+		})
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+		{
+			Text:         "\u25c0\ufe0f Back",
+			CallbackData: fmt.Sprintf("%s%d", constants.RandomCoffeeDraftBackPrefix, pollID),
+		},
+	})
+
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
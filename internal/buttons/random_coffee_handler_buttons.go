@@ -0,0 +1,82 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func RandomCoffeeMainButtons(isSubscribed bool, isPaused bool, isRemindersMuted bool) gotgbot.InlineKeyboardMarkup {
+	var buttons [][]gotgbot.InlineKeyboardButton
+
+	if !isSubscribed {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\u2705 Subscribe to every round",
+				CallbackData: constants.RandomCoffeeSubscribeCallback,
+			},
+		})
+	} else {
+		if isPaused {
+			buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+				{
+					Text:         "\u25b6\ufe0f Resume now",
+					CallbackData: constants.RandomCoffeeSubscribeCallback,
+				},
+			})
+		}
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\u23f8\ufe0f Pause until date",
+				CallbackData: constants.RandomCoffeePauseCallback,
+			},
+			{
+				Text:         "\U0001f6ab Unsubscribe",
+				CallbackData: constants.RandomCoffeeUnsubscribeCallback,
+			},
+		})
+	}
+
+	// Reminders are only sent to members without a subscription
+	if !isSubscribed && isRemindersMuted {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f514 Unmute reminders",
+				CallbackData: constants.RandomCoffeeUnmuteRemindersCallback,
+			},
+		})
+	} else if !isSubscribed {
+		buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f515 Mute reminders",
+				CallbackData: constants.RandomCoffeeMuteRemindersCallback,
+			},
+		})
+	}
+
+	buttons = append(buttons, []gotgbot.InlineKeyboardButton{
+		{
+			Text:         "\u274c Cancel",
+			CallbackData: constants.RandomCoffeeFullCancel,
+		},
+	})
+
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
+
+func RandomCoffeeBackCancelButtons(backCallbackData string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\u25c0\ufe0f Back",
+					CallbackData: backCallbackData,
+				},
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: constants.RandomCoffeeFullCancel,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// ReengagementButtons returns the button of the re-engagement message that opts out of it, or back in
+func ReengagementButtons(isOptedOut bool) gotgbot.InlineKeyboardMarkup {
+	button := gotgbot.InlineKeyboardButton{
+		Text:         "\U0001f515 Don't send me these",
+		CallbackData: constants.ReengagementOptOutCallback,
+	}
+	if isOptedOut {
+		button = gotgbot.InlineKeyboardButton{
+			Text:         "\U0001f514 Send me these again",
+			CallbackData: constants.ReengagementOptInCallback,
+		}
+	}
+
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{button}},
+	}
+}
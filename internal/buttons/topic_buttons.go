@@ -0,0 +1,117 @@
+package buttons
+
+import (
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"fmt"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// topicVoteButtonsPerRow keeps the vote buttons readable on phones
+const topicVoteButtonsPerRow = 4
+
+// TopicVoteButtons returns one upvote button per topic, numbered in the order of the ranked list.
+// Topics the member has already upvoted are marked, pressing the button again takes the vote back.
+func TopicVoteButtons(topics []repositories.Topic, votedTopicIDs map[int]bool) gotgbot.InlineKeyboardMarkup {
+	var keyboard [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+	for i, topic := range topics {
+		icon := "\U0001f44d"
+		if votedTopicIDs[topic.ID] {
+			icon = "\u2705"
+		}
+		row = append(row, gotgbot.InlineKeyboardButton{
+			Text:         fmt.Sprintf("%s %d · %d", icon, i+1, topic.Votes),
+			CallbackData: fmt.Sprintf("%s%d", constants.TopicVotePrefix, topic.ID),
+		})
+		if len(row) == topicVoteButtonsPerRow {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// TopicModerationButtons returns the Approve / Reject buttons for a pending topic,
+// and for an anonymous topic the button that reveals its author
+func TopicModerationButtons(topicID int, isAnonymous bool) gotgbot.InlineKeyboardMarkup {
+	keyboard := [][]gotgbot.InlineKeyboardButton{
+		{
+			{
+				Text:         "\u2705 Approve",
+				CallbackData: fmt.Sprintf("%s%d", constants.TopicModerationApprovePrefix, topicID),
+			},
+			{
+				Text:         "\u274c Reject",
+				CallbackData: fmt.Sprintf("%s%d", constants.TopicModerationRejectPrefix, topicID),
+			},
+		},
+	}
+	if isAnonymous {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         "\U0001f575 Reveal author",
+				CallbackData: fmt.Sprintf("%s%d", constants.TopicModerationRevealPrefix, topicID),
+			},
+		})
+	}
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// TopicAuthorshipButtons returns the buttons to send a topic with the author's name or anonymously, or to cancel
+func TopicAuthorshipButtons(callbackDataWithName string, callbackDataAnonymous string, callbackDataCancel string) gotgbot.InlineKeyboardMarkup {
+	return gotgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
+			{
+				{
+					Text:         "\U0001f464 With my name",
+					CallbackData: callbackDataWithName,
+				},
+				{
+					Text:         "\U0001f576 Anonymously",
+					CallbackData: callbackDataAnonymous,
+				},
+			},
+			{
+				{
+					Text:         "\u274c Cancel",
+					CallbackData: callbackDataCancel,
+				},
+			},
+		},
+	}
+}
+
+// MyTopicsButtons returns the Edit / Withdraw buttons for each of the member's topics, numbered
+// in the order of the list, followed by the cancel button. Callback data is the prefix followed by "<topicID>".
+func MyTopicsButtons(
+	topics []repositories.Topic,
+	callbackDataEditPrefix string,
+	callbackDataWithdrawPrefix string,
+	callbackDataCancel string,
+) gotgbot.InlineKeyboardMarkup {
+	keyboard := make([][]gotgbot.InlineKeyboardButton, 0, len(topics)+1)
+	for i, topic := range topics {
+		keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("\u270f\ufe0f Edit #%d", i+1),
+				CallbackData: fmt.Sprintf("%s%d", callbackDataEditPrefix, topic.ID),
+			},
+			{
+				Text:         fmt.Sprintf("\U0001f5d1 Withdraw #%d", i+1),
+				CallbackData: fmt.Sprintf("%s%d", callbackDataWithdrawPrefix, topic.ID),
+			},
+		})
+	}
+	keyboard = append(keyboard, []gotgbot.InlineKeyboardButton{
+		{
+			Text:         "\u274c Cancel",
+			CallbackData: callbackDataCancel,
+		},
+	})
+	return gotgbot.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
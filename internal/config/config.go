@@ -1,8 +1,10 @@
 package config
 
 import (
+	"evo-bot-go/internal/constants"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -38,9 +40,72 @@ type Config struct {
 	RandomCoffeePollTime        time.Time
 	RandomCoffeePollDay         time.Weekday
 
-	RandomCoffeePairsTaskEnabled bool
-	RandomCoffeePairsTime        time.Time
-	RandomCoffeePairsDay         time.Weekday
+	RandomCoffeePairsTaskEnabled  bool
+	RandomCoffeePairsTime         time.Time
+	RandomCoffeePairsDay          time.Weekday
+	RandomCoffeePairsDraftTimeout time.Duration
+
+	RandomCoffeeReminderTaskEnabled bool
+	RandomCoffeeReminderTime        time.Time
+	RandomCoffeeReminderDay         time.Weekday
+
+	// Matching Programs Feature (programs themselves are configured by admins via /programs)
+	MatchingProgramsTaskEnabled bool
+
+	// Events: times are entered by admins and shown to members in this timezone
+	EventsTimezone *time.Location
+
+	// Event Reminders Feature: reminders are sent at each offset before the planned start, longest first
+	EventRemindersTaskEnabled bool
+	EventReminderOffsets      []time.Duration
+
+	// Event Feedback Feature: the rating form is sent at the planned end of the event,
+	// or this long after its start for events without a duration
+	EventFeedbackTaskEnabled bool
+	EventFeedbackDelay       time.Duration
+
+	// Recurring Events Feature: occurrences are created this far ahead of their start
+	EventSeriesHorizonDays int
+
+	// Event Topics Feature: a forum topic is created for every new event and closed this long after its end
+	EventTopicsEnabled   bool
+	EventTopicCloseDelay time.Duration
+
+	// Karma Feature: members grant a point by replying "+1" or "thanks" to a message or by reacting to it
+	// with one of the reactions. A member grants at most the daily limit of points, and a point to the same
+	// member once per cooldown.
+	KarmaEnabled      bool
+	KarmaReactions    []string
+	KarmaDailyLimit   int
+	KarmaPairCooldown time.Duration
+
+	// Badges Feature: badges are evaluated hourly from the existing data, the tool scout badge
+	// is earned for this many posts in the tools topic
+	BadgesEnabled   bool
+	BadgeToolsCount int
+
+	// Re-engagement Feature: members who haven't posted for the given number of weeks get a friendly DM
+	// once a day at the given time (UTC), until they are active again or opt out
+	ReengagementEnabled     bool
+	ReengagementSilentWeeks int
+	ReengagementTime        time.Time
+
+	// Onboarding Feature: new members get a checklist in a DM, or a short group mention deleted after the cleanup
+	// delay if they haven't started the bot. Up to the given number of reminders are sent at the interval until
+	// the checklist is done, the rules step is skipped if the rules link is empty.
+	OnboardingEnabled             bool
+	OnboardingRulesLink           string
+	OnboardingMentionCleanupDelay time.Duration
+	OnboardingReminderInterval    time.Duration
+	OnboardingReminderCount       int
+
+	// Left Members Feature: the published intro of a member who leaves or is banned is archived, deleted or kept,
+	// archived and deleted intros are published again when the member rejoins
+	LeftMemberIntroPolicy string
+
+	// Calendar Feed Feature: disabled if the address is empty
+	CalendarFeedAddr    string // Address the HTTP server listens on, e.g. ":8080"
+	CalendarFeedBaseURL string // Public URL of the server, used in the links sent to members
 }
 
 // LoadConfig loads the configuration from environment variables
@@ -314,5 +379,368 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Pairs draft timeout: how long admins can review the draft before it's published automatically
+	randomCoffeePairsDraftTimeoutStr := os.Getenv("TG_EVO_BOT_RANDOM_COFFEE_PAIRS_DRAFT_TIMEOUT_MINUTES")
+	if randomCoffeePairsDraftTimeoutStr == "" {
+		// Default to 60 minutes if not specified
+		config.RandomCoffeePairsDraftTimeout = 60 * time.Minute
+	} else {
+		randomCoffeePairsDraftTimeoutMinutes, err := strconv.Atoi(randomCoffeePairsDraftTimeoutStr)
+		if err != nil || randomCoffeePairsDraftTimeoutMinutes < 0 {
+			return nil, fmt.Errorf("invalid random coffee pairs draft timeout: %s", randomCoffeePairsDraftTimeoutStr)
+		}
+		config.RandomCoffeePairsDraftTimeout = time.Duration(randomCoffeePairsDraftTimeoutMinutes) * time.Minute
+	}
+
+	// Random Coffee Reminder Feature
+	randomCoffeeReminderTaskEnabledStr := os.Getenv("TG_EVO_BOT_RANDOM_COFFEE_REMINDER_TASK_ENABLED")
+	if randomCoffeeReminderTaskEnabledStr == "" {
+		// Default to enabled if not specified
+		config.RandomCoffeeReminderTaskEnabled = true
+	} else {
+		randomCoffeeReminderTaskEnabled, err := strconv.ParseBool(randomCoffeeReminderTaskEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid random coffee reminder task enabled value: %s", randomCoffeeReminderTaskEnabledStr)
+		}
+		config.RandomCoffeeReminderTaskEnabled = randomCoffeeReminderTaskEnabled
+	}
+
+	// Reminder time
+	randomCoffeeReminderTimeStr := os.Getenv("TG_EVO_BOT_RANDOM_COFFEE_REMINDER_TIME")
+	if randomCoffeeReminderTimeStr == "" {
+		// Default to 12:00 PM if not specified
+		randomCoffeeReminderTimeStr = "12:00"
+	}
+
+	// Parse the time in 24-hour format
+	randomCoffeeReminderTime, err := time.Parse("15:04", randomCoffeeReminderTimeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid random coffee reminder time format: %s", randomCoffeeReminderTimeStr)
+	}
+	config.RandomCoffeeReminderTime = randomCoffeeReminderTime
+
+	// Reminder day
+	randomCoffeeReminderDayStr := os.Getenv("TG_EVO_BOT_RANDOM_COFFEE_REMINDER_DAY")
+	if randomCoffeeReminderDayStr == "" {
+		// Default to Sunday if not specified
+		config.RandomCoffeeReminderDay = time.Sunday
+	} else {
+		switch strings.ToLower(randomCoffeeReminderDayStr) {
+		case "sunday":
+			config.RandomCoffeeReminderDay = time.Sunday
+		case "monday":
+			config.RandomCoffeeReminderDay = time.Monday
+		case "tuesday":
+			config.RandomCoffeeReminderDay = time.Tuesday
+		case "wednesday":
+			config.RandomCoffeeReminderDay = time.Wednesday
+		case "thursday":
+			config.RandomCoffeeReminderDay = time.Thursday
+		case "friday":
+			config.RandomCoffeeReminderDay = time.Friday
+		case "saturday":
+			config.RandomCoffeeReminderDay = time.Saturday
+		default:
+			return nil, fmt.Errorf("invalid random coffee reminder day: %s (valid values: sunday, monday, tuesday, wednesday, thursday, friday, saturday)", randomCoffeeReminderDayStr)
+		}
+	}
+
+	// Matching Programs Feature
+	matchingProgramsTaskEnabledStr := os.Getenv("TG_EVO_BOT_MATCHING_PROGRAMS_TASK_ENABLED")
+	if matchingProgramsTaskEnabledStr == "" {
+		// Default to enabled if not specified
+		config.MatchingProgramsTaskEnabled = true
+	} else {
+		matchingProgramsTaskEnabled, err := strconv.ParseBool(matchingProgramsTaskEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matching programs task enabled value: %s", matchingProgramsTaskEnabledStr)
+		}
+		config.MatchingProgramsTaskEnabled = matchingProgramsTaskEnabled
+	}
+
+	// Events timezone
+	eventsTimezoneStr := os.Getenv("TG_EVO_BOT_EVENTS_TIMEZONE")
+	if eventsTimezoneStr == "" {
+		// Default to Kyiv time if not specified
+		eventsTimezoneStr = "Europe/Kyiv"
+	}
+	eventsTimezone, err := time.LoadLocation(eventsTimezoneStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid events timezone: %s (use an IANA name, e.g. Europe/Kyiv)", eventsTimezoneStr)
+	}
+	config.EventsTimezone = eventsTimezone
+
+	// Event Reminders Feature
+	eventRemindersTaskEnabledStr := os.Getenv("TG_EVO_BOT_EVENT_REMINDERS_TASK_ENABLED")
+	if eventRemindersTaskEnabledStr == "" {
+		// Default to enabled if not specified
+		config.EventRemindersTaskEnabled = true
+	} else {
+		eventRemindersTaskEnabled, err := strconv.ParseBool(eventRemindersTaskEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event reminders task enabled value: %s", eventRemindersTaskEnabledStr)
+		}
+		config.EventRemindersTaskEnabled = eventRemindersTaskEnabled
+	}
+
+	// Reminder offsets
+	eventReminderOffsetsStr := os.Getenv("TG_EVO_BOT_EVENT_REMINDER_OFFSETS")
+	if eventReminderOffsetsStr == "" {
+		// Default to a day and 15 minutes before the start if not specified
+		eventReminderOffsetsStr = "24h,15m"
+	}
+	for _, offsetStr := range strings.Split(eventReminderOffsetsStr, ",") {
+		offset, err := time.ParseDuration(strings.TrimSpace(offsetStr))
+		if err != nil || offset < time.Minute {
+			return nil, fmt.Errorf("invalid event reminder offset in TG_EVO_BOT_EVENT_REMINDER_OFFSETS: %s (use durations of at least a minute, e.g. 24h,15m)", offsetStr)
+		}
+		config.EventReminderOffsets = append(config.EventReminderOffsets, offset.Truncate(time.Minute))
+	}
+	sort.Slice(config.EventReminderOffsets, func(i, j int) bool {
+		return config.EventReminderOffsets[i] > config.EventReminderOffsets[j]
+	})
+
+	// Event Feedback Feature
+	eventFeedbackTaskEnabledStr := os.Getenv("TG_EVO_BOT_EVENT_FEEDBACK_TASK_ENABLED")
+	if eventFeedbackTaskEnabledStr == "" {
+		// Default to enabled if not specified
+		config.EventFeedbackTaskEnabled = true
+	} else {
+		eventFeedbackTaskEnabled, err := strconv.ParseBool(eventFeedbackTaskEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event feedback task enabled value: %s", eventFeedbackTaskEnabledStr)
+		}
+		config.EventFeedbackTaskEnabled = eventFeedbackTaskEnabled
+	}
+
+	eventFeedbackDelayStr := os.Getenv("TG_EVO_BOT_EVENT_FEEDBACK_DELAY")
+	if eventFeedbackDelayStr == "" {
+		// Default to two hours after the start if not specified
+		config.EventFeedbackDelay = 2 * time.Hour
+	} else {
+		eventFeedbackDelay, err := time.ParseDuration(eventFeedbackDelayStr)
+		if err != nil || eventFeedbackDelay < time.Minute {
+			return nil, fmt.Errorf("invalid event feedback delay value: %s (use a duration of at least a minute, e.g. 2h)", eventFeedbackDelayStr)
+		}
+		config.EventFeedbackDelay = eventFeedbackDelay.Truncate(time.Minute)
+	}
+
+	// Recurring Events Feature
+	eventSeriesHorizonDaysStr := os.Getenv("TG_EVO_BOT_EVENT_SERIES_HORIZON_DAYS")
+	if eventSeriesHorizonDaysStr == "" {
+		// Default to four weeks ahead if not specified
+		config.EventSeriesHorizonDays = 28
+	} else {
+		eventSeriesHorizonDays, err := strconv.Atoi(eventSeriesHorizonDaysStr)
+		if err != nil || eventSeriesHorizonDays < 1 || eventSeriesHorizonDays > 365 {
+			return nil, fmt.Errorf("invalid event series horizon days value: %s (use 1 to 365)", eventSeriesHorizonDaysStr)
+		}
+		config.EventSeriesHorizonDays = eventSeriesHorizonDays
+	}
+
+	// Event Topics Feature
+	eventTopicsEnabledStr := os.Getenv("TG_EVO_BOT_EVENT_TOPICS_ENABLED")
+	if eventTopicsEnabledStr == "" {
+		// Default to disabled if not specified, the bot needs the right to manage topics
+		config.EventTopicsEnabled = false
+	} else {
+		eventTopicsEnabled, err := strconv.ParseBool(eventTopicsEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event topics enabled value: %s", eventTopicsEnabledStr)
+		}
+		config.EventTopicsEnabled = eventTopicsEnabled
+	}
+
+	eventTopicCloseDelayStr := os.Getenv("TG_EVO_BOT_EVENT_TOPIC_CLOSE_DELAY")
+	if eventTopicCloseDelayStr == "" {
+		// Default to a day after the event if not specified
+		config.EventTopicCloseDelay = 24 * time.Hour
+	} else {
+		eventTopicCloseDelay, err := time.ParseDuration(eventTopicCloseDelayStr)
+		if err != nil || eventTopicCloseDelay < 0 {
+			return nil, fmt.Errorf("invalid event topic close delay value: %s (use a duration, e.g. 24h)", eventTopicCloseDelayStr)
+		}
+		config.EventTopicCloseDelay = eventTopicCloseDelay.Truncate(time.Minute)
+	}
+
+	// Karma Feature
+	karmaEnabledStr := os.Getenv("TG_EVO_BOT_KARMA_ENABLED")
+	if karmaEnabledStr == "" {
+		// Default to enabled if not specified
+		config.KarmaEnabled = true
+	} else {
+		karmaEnabled, err := strconv.ParseBool(karmaEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid karma enabled value: %s", karmaEnabledStr)
+		}
+		config.KarmaEnabled = karmaEnabled
+	}
+
+	karmaReactionsStr := os.Getenv("TG_EVO_BOT_KARMA_REACTIONS")
+	if karmaReactionsStr == "" {
+		// Default to the thumbs up if not specified
+		config.KarmaReactions = []string{"\U0001f44d"}
+	} else {
+		for _, reaction := range strings.Split(karmaReactionsStr, ",") {
+			if reaction = strings.TrimSpace(reaction); reaction != "" {
+				config.KarmaReactions = append(config.KarmaReactions, reaction)
+			}
+		}
+	}
+
+	karmaDailyLimitStr := os.Getenv("TG_EVO_BOT_KARMA_DAILY_LIMIT")
+	if karmaDailyLimitStr == "" {
+		// Default to 10 points a day if not specified
+		config.KarmaDailyLimit = 10
+	} else {
+		karmaDailyLimit, err := strconv.Atoi(karmaDailyLimitStr)
+		if err != nil || karmaDailyLimit < 1 {
+			return nil, fmt.Errorf("invalid karma daily limit value: %s (use a positive number)", karmaDailyLimitStr)
+		}
+		config.KarmaDailyLimit = karmaDailyLimit
+	}
+
+	karmaPairCooldownStr := os.Getenv("TG_EVO_BOT_KARMA_PAIR_COOLDOWN")
+	if karmaPairCooldownStr == "" {
+		// Default to a point to the same member once an hour if not specified
+		config.KarmaPairCooldown = time.Hour
+	} else {
+		karmaPairCooldown, err := time.ParseDuration(karmaPairCooldownStr)
+		if err != nil || karmaPairCooldown < 0 {
+			return nil, fmt.Errorf("invalid karma pair cooldown value: %s (use a duration, e.g. 1h)", karmaPairCooldownStr)
+		}
+		config.KarmaPairCooldown = karmaPairCooldown
+	}
+
+	// Badges Feature
+	badgesEnabledStr := os.Getenv("TG_EVO_BOT_BADGES_ENABLED")
+	if badgesEnabledStr == "" {
+		// Default to enabled if not specified
+		config.BadgesEnabled = true
+	} else {
+		badgesEnabled, err := strconv.ParseBool(badgesEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid badges enabled value: %s", badgesEnabledStr)
+		}
+		config.BadgesEnabled = badgesEnabled
+	}
+
+	badgeToolsCountStr := os.Getenv("TG_EVO_BOT_BADGE_TOOLS_COUNT")
+	if badgeToolsCountStr == "" {
+		// Default to five tools if not specified
+		config.BadgeToolsCount = 5
+	} else {
+		badgeToolsCount, err := strconv.Atoi(badgeToolsCountStr)
+		if err != nil || badgeToolsCount < 1 {
+			return nil, fmt.Errorf("invalid badge tools count value: %s (use a positive number)", badgeToolsCountStr)
+		}
+		config.BadgeToolsCount = badgeToolsCount
+	}
+
+	// Re-engagement Feature
+	reengagementEnabledStr := os.Getenv("TG_EVO_BOT_REENGAGEMENT_ENABLED")
+	if reengagementEnabledStr == "" {
+		// Default to disabled if not specified
+		config.ReengagementEnabled = false
+	} else {
+		reengagementEnabled, err := strconv.ParseBool(reengagementEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid re-engagement enabled value: %s", reengagementEnabledStr)
+		}
+		config.ReengagementEnabled = reengagementEnabled
+	}
+
+	reengagementSilentWeeksStr := os.Getenv("TG_EVO_BOT_REENGAGEMENT_SILENT_WEEKS")
+	if reengagementSilentWeeksStr == "" {
+		// Default to four weeks if not specified
+		config.ReengagementSilentWeeks = 4
+	} else {
+		reengagementSilentWeeks, err := strconv.Atoi(reengagementSilentWeeksStr)
+		if err != nil || reengagementSilentWeeks < 1 || reengagementSilentWeeks > 52 {
+			return nil, fmt.Errorf("invalid re-engagement silent weeks value: %s (use 1 to 52)", reengagementSilentWeeksStr)
+		}
+		config.ReengagementSilentWeeks = reengagementSilentWeeks
+	}
+
+	reengagementTimeStr := os.Getenv("TG_EVO_BOT_REENGAGEMENT_TIME")
+	if reengagementTimeStr == "" {
+		// Default to noon UTC if not specified
+		reengagementTimeStr = "12:00"
+	}
+	reengagementTime, err := time.Parse("15:04", reengagementTimeStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid re-engagement time format: %s", reengagementTimeStr)
+	}
+	config.ReengagementTime = reengagementTime
+
+	// Onboarding Feature
+	onboardingEnabledStr := os.Getenv("TG_EVO_BOT_ONBOARDING_ENABLED")
+	if onboardingEnabledStr == "" {
+		// Default to enabled if not specified
+		config.OnboardingEnabled = true
+	} else {
+		onboardingEnabled, err := strconv.ParseBool(onboardingEnabledStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid onboarding enabled value: %s", onboardingEnabledStr)
+		}
+		config.OnboardingEnabled = onboardingEnabled
+	}
+
+	config.OnboardingRulesLink = os.Getenv("TG_EVO_BOT_ONBOARDING_RULES_LINK")
+
+	onboardingMentionCleanupDelayStr := os.Getenv("TG_EVO_BOT_ONBOARDING_MENTION_CLEANUP_DELAY")
+	if onboardingMentionCleanupDelayStr == "" {
+		// Default to five minutes if not specified
+		config.OnboardingMentionCleanupDelay = 5 * time.Minute
+	} else {
+		onboardingMentionCleanupDelay, err := time.ParseDuration(onboardingMentionCleanupDelayStr)
+		if err != nil || onboardingMentionCleanupDelay < 10*time.Second {
+			return nil, fmt.Errorf("invalid onboarding mention cleanup delay value: %s (use a duration of at least 10 seconds, e.g. 5m)", onboardingMentionCleanupDelayStr)
+		}
+		config.OnboardingMentionCleanupDelay = onboardingMentionCleanupDelay
+	}
+
+	onboardingReminderIntervalStr := os.Getenv("TG_EVO_BOT_ONBOARDING_REMINDER_INTERVAL")
+	if onboardingReminderIntervalStr == "" {
+		// Default to three days if not specified
+		config.OnboardingReminderInterval = 72 * time.Hour
+	} else {
+		onboardingReminderInterval, err := time.ParseDuration(onboardingReminderIntervalStr)
+		if err != nil || onboardingReminderInterval < time.Hour {
+			return nil, fmt.Errorf("invalid onboarding reminder interval value: %s (use a duration of at least an hour, e.g. 72h)", onboardingReminderIntervalStr)
+		}
+		config.OnboardingReminderInterval = onboardingReminderInterval
+	}
+
+	onboardingReminderCountStr := os.Getenv("TG_EVO_BOT_ONBOARDING_REMINDER_COUNT")
+	if onboardingReminderCountStr == "" {
+		// Default to two reminders if not specified
+		config.OnboardingReminderCount = 2
+	} else {
+		onboardingReminderCount, err := strconv.Atoi(onboardingReminderCountStr)
+		if err != nil || onboardingReminderCount < 0 || onboardingReminderCount > 10 {
+			return nil, fmt.Errorf("invalid onboarding reminder count value: %s (use 0 to 10)", onboardingReminderCountStr)
+		}
+		config.OnboardingReminderCount = onboardingReminderCount
+	}
+
+	// Left Members Feature
+	config.LeftMemberIntroPolicy = strings.ToLower(os.Getenv("TG_EVO_BOT_LEFT_MEMBER_INTRO_POLICY"))
+	switch config.LeftMemberIntroPolicy {
+	case "":
+		// Default to archive if not specified
+		config.LeftMemberIntroPolicy = constants.LeftMemberIntroPolicyArchive
+	case constants.LeftMemberIntroPolicyArchive, constants.LeftMemberIntroPolicyDelete, constants.LeftMemberIntroPolicyKeep:
+	default:
+		return nil, fmt.Errorf("invalid left member intro policy: %s (valid values: archive, delete, keep)", config.LeftMemberIntroPolicy)
+	}
+
+	// Calendar Feed Feature
+	config.CalendarFeedAddr = os.Getenv("TG_EVO_BOT_CALENDAR_FEED_ADDR")
+	config.CalendarFeedBaseURL = strings.TrimSuffix(os.Getenv("TG_EVO_BOT_CALENDAR_FEED_BASE_URL"), "/")
+	if config.CalendarFeedAddr != "" && config.CalendarFeedBaseURL == "" {
+		return nil, fmt.Errorf("TG_EVO_BOT_CALENDAR_FEED_BASE_URL environment variable is required when TG_EVO_BOT_CALENDAR_FEED_ADDR is set")
+	}
+
 	return config, nil
 }
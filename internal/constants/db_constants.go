@@ -1,25 +1,8 @@
 package constants
 
-// EventType represents the type of event
+// EventType is the slug of an event type, the types are stored in the event_types table
 type EventType string
 
-const (
-	EventTypeClubCall    EventType = "club-call"
-	EventTypeMeetup      EventType = "meetup"
-	EventTypeWorkshop    EventType = "workshop"
-	EventTypeReadingClub EventType = "reading-club"
-	EventTypeConference  EventType = "conference"
-)
-
-// AllEventTypes is a slice containing all possible EventType values
-var AllEventTypes = []EventType{
-	EventTypeClubCall,
-	EventTypeMeetup,
-	EventTypeWorkshop,
-	EventTypeReadingClub,
-	EventTypeConference,
-}
-
 // EventStatus represents the status of event
 type EventStatus string
 
@@ -33,3 +16,119 @@ var AllEventStatuses = []EventStatus{
 	EventStatusFinished,
 	EventStatusActual,
 }
+
+// EventRSVPStatus represents a member's response to an event
+type EventRSVPStatus string
+
+const (
+	EventRSVPStatusGoing    EventRSVPStatus = "going"
+	EventRSVPStatusMaybe    EventRSVPStatus = "maybe"
+	EventRSVPStatusNotGoing EventRSVPStatus = "not_going"
+	// EventRSVPStatusWaitlist is set instead of "going" when the event is full
+	EventRSVPStatusWaitlist EventRSVPStatus = "waitlist"
+)
+
+// KarmaVoteSource represents how a member granted a karma point
+type KarmaVoteSource string
+
+const (
+	KarmaVoteSourceReply    KarmaVoteSource = "reply"
+	KarmaVoteSourceReaction KarmaVoteSource = "reaction"
+)
+
+// ClubMembershipEvent represents a member joining or leaving the club group
+type ClubMembershipEvent string
+
+const (
+	ClubMembershipEventJoined ClubMembershipEvent = "joined"
+	ClubMembershipEventLeft   ClubMembershipEvent = "left"
+	ClubMembershipEventBanned ClubMembershipEvent = "banned"
+)
+
+// Badge is the slug of a badge a member earns for their contributions, stored in user_badges
+type Badge string
+
+const (
+	BadgeFirstCoffee      Badge = "first_coffee"
+	BadgeCoffeeRegular    Badge = "coffee_regular"
+	BadgeFirstTopic       Badge = "first_topic"
+	BadgeEventHost        Badge = "event_host"
+	BadgePublishedProfile Badge = "published_profile"
+	BadgeToolScout        Badge = "tool_scout"
+
+	// BadgeCoffeeRegularRounds is how many Random Coffee rounds earn the coffee regular badge
+	BadgeCoffeeRegularRounds = 10
+)
+
+// AllBadges is a slice containing all badges, in the order they are listed on profiles
+var AllBadges = []Badge{
+	BadgePublishedProfile,
+	BadgeFirstCoffee,
+	BadgeCoffeeRegular,
+	BadgeFirstTopic,
+	BadgeEventHost,
+	BadgeToolScout,
+}
+
+// ProfileField is an optional structured field of a profile, the value is its column in the profiles table
+type ProfileField string
+
+const (
+	ProfileFieldRole        ProfileField = "role"
+	ProfileFieldCompany     ProfileField = "company"
+	ProfileFieldSkills      ProfileField = "skills"
+	ProfileFieldCity        ProfileField = "city"
+	ProfileFieldTimezone    ProfileField = "timezone"
+	ProfileFieldGitHub      ProfileField = "github_url"
+	ProfileFieldLinkedIn    ProfileField = "linkedin_url"
+	ProfileFieldLookingFor  ProfileField = "looking_for"
+	ProfileFieldCanHelpWith ProfileField = "can_help_with"
+)
+
+// AllProfileFields is a slice containing all structured profile fields, in the order they are shown on profiles
+var AllProfileFields = []ProfileField{
+	ProfileFieldRole,
+	ProfileFieldCompany,
+	ProfileFieldSkills,
+	ProfileFieldCity,
+	ProfileFieldTimezone,
+	ProfileFieldGitHub,
+	ProfileFieldLinkedIn,
+	ProfileFieldLookingFor,
+	ProfileFieldCanHelpWith,
+}
+
+// TopicStatus represents the moderation status of a topic
+type TopicStatus string
+
+const (
+	TopicStatusPending  TopicStatus = "pending"
+	TopicStatusApproved TopicStatus = "approved"
+	TopicStatusRejected TopicStatus = "rejected"
+)
+
+// EventMaterialKind represents the kind of material attached to a finished event
+type EventMaterialKind string
+
+const (
+	EventMaterialKindRecording EventMaterialKind = "recording"
+	EventMaterialKindSlides    EventMaterialKind = "slides"
+	EventMaterialKindLink      EventMaterialKind = "link"
+)
+
+// AllEventMaterialKinds is a slice containing all possible EventMaterialKind values
+var AllEventMaterialKinds = []EventMaterialKind{
+	EventMaterialKindRecording,
+	EventMaterialKindSlides,
+	EventMaterialKindLink,
+}
+
+// EventProposalStatus represents the review status of an event proposed by a member
+type EventProposalStatus string
+
+const (
+	EventProposalStatusPending          EventProposalStatus = "pending"
+	EventProposalStatusApproved         EventProposalStatus = "approved"
+	EventProposalStatusRejected         EventProposalStatus = "rejected"
+	EventProposalStatusChangesRequested EventProposalStatus = "changes_requested"
+)
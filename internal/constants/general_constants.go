@@ -6,7 +6,8 @@ const CancelCommand = "cancel"
 
 // Profile fields
 const (
-	ProfileBioLengthLimit = 3900 //max Telegram message length
+	ProfileBioLengthLimit   = 3900 //max Telegram message length
+	ProfileFieldLengthLimit = 300  // structured fields, e.g. role or skills
 )
 
 // Search types
@@ -14,3 +15,13 @@ const (
 	SearchTypeFast = "fast"
 	SearchTypeDeep = "deep"
 )
+
+// CourseMiniAppURL is the AI course Telegram Mini App, opened from /start and the onboarding checklist
+const CourseMiniAppURL = "https://antikriza.github.io/BBD-evolution-code-clone/telegram-archive/course/twa/index.html"
+
+// What happens to the published intro of a member who leaves or is banned from the group
+const (
+	LeftMemberIntroPolicyArchive = "archive" // The post is replaced with a short note
+	LeftMemberIntroPolicyDelete  = "delete"
+	LeftMemberIntroPolicyKeep    = "keep"
+)
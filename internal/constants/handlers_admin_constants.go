@@ -11,9 +11,31 @@ const EventEditGetLastLimit = 10
 const EventSetupCommand = "eventSetup"
 const EventDeleteCommand = "eventDelete"
 const EventStartCommand = "eventStart"
+const EventReportCommand = "eventReport"
+const EventReportListLimit = 10
+const EventReportTrendLimit = 10
+const EventProposalsQueueCommand = "proposalsQueue"
+const EventProposalsQueueLimit = 20
+const EventTypesCommand = "eventTypes"
 
 // Topics Handlers
 const ShowTopicsCommand = "showTopics"
+const TopicsQueueCommand = "topicsQueue"
+const TopicsQueueLimit = 20
+
+// Random Coffee Handlers
+const RandomCoffeeStatsCommand = "coffeeStats"
+
+// Group Stats Handler
+const GroupStatsCommand = "stats"
+const GroupStatsDefaultDays = 30
+const GroupStatsMaxDays = 180
+
+// GroupStatsRanges are the ranges in days offered by the "/stats" buttons
+var GroupStatsRanges = []int{7, 30, 90}
+
+// Matching Programs Handler
+const MatchingProgramsCommand = "programs"
 
 // Profiles Handler
 const AdminProfilesCommand = "profilesManager"
@@ -37,6 +59,9 @@ const (
 	AdminProfilesPublishCallback          = AdminProfilesPrefix + "publish"
 	AdminProfilesPublishNoPreviewCallback = AdminProfilesPrefix + "publish_without_preview"
 
+	// AdminProfilesEditFieldPrefix is followed by the constants.ProfileField to edit
+	AdminProfilesEditFieldPrefix = AdminProfilesPrefix + "edit_field_"
+
 	AdminProfilesStartCallback  = AdminProfilesPrefix + "start"
 	AdminProfilesCancelCallback = AdminProfilesPrefix + "cancel"
 )
@@ -57,3 +82,59 @@ const (
 	TryGenerateCoffeePairsBackCallback    = TryGenerateCoffeePairsPrefix + "back"
 	TryGenerateCoffeePairsCancelCallback  = TryGenerateCoffeePairsPrefix + "cancel"
 )
+
+// Random Coffee pairs draft callback constants, each action is followed by the poll ID of the draft's round
+const (
+	RandomCoffeeDraftPrefix           = "coffee_pairs_draft_"
+	RandomCoffeeDraftPublishPrefix    = RandomCoffeeDraftPrefix + "publish_"     // + "<pollID>"
+	RandomCoffeeDraftRerollPrefix     = RandomCoffeeDraftPrefix + "reroll_"      // + "<pollID>"
+	RandomCoffeeDraftSwapPrefix       = RandomCoffeeDraftPrefix + "swap_"        // + "<pollID>"
+	RandomCoffeeDraftRemovePrefix     = RandomCoffeeDraftPrefix + "remove_"      // + "<pollID>"
+	RandomCoffeeDraftDiscardPrefix    = RandomCoffeeDraftPrefix + "discard_"     // + "<pollID>"
+	RandomCoffeeDraftBackPrefix       = RandomCoffeeDraftPrefix + "back_"        // + "<pollID>"
+	RandomCoffeeDraftSwapPickPrefix   = RandomCoffeeDraftPrefix + "pick_swap_"   // + "<pollID>_<userID>" or "<pollID>_<userID>_<userID>"
+	RandomCoffeeDraftRemovePickPrefix = RandomCoffeeDraftPrefix + "pick_remove_" // + "<pollID>_<userID>"
+)
+
+// Callback data constants for admin "/programs" handler
+const (
+	MatchingProgramsPrefix                = "matching_programs_"
+	MatchingProgramsCreateCallback        = MatchingProgramsPrefix + "create"
+	MatchingProgramsViewPrefix            = MatchingProgramsPrefix + "view_" // + "<programID>"
+	MatchingProgramsToggleActiveCallback  = MatchingProgramsPrefix + "toggle_active"
+	MatchingProgramsSendPollCallback      = MatchingProgramsPrefix + "send_poll"
+	MatchingProgramsMatchCallback         = MatchingProgramsPrefix + "match"
+	MatchingProgramsDeleteCallback        = MatchingProgramsPrefix + "delete"
+	MatchingProgramsDeleteConfirmCallback = MatchingProgramsPrefix + "delete_confirm"
+	MatchingProgramsBackToProgramCallback = MatchingProgramsPrefix + "back_to_program"
+	MatchingProgramsStartCallback         = MatchingProgramsPrefix + "start"
+	MatchingProgramsCancelCallback        = MatchingProgramsPrefix + "cancel"
+)
+
+// Callback data constants for event announcements
+const (
+	EventAnnouncePrefix = "event_announce_" // + "<eventID>"
+)
+
+// Callback data constants for topic moderation buttons, each followed by "<topicID>"
+const (
+	TopicModerationPrefix        = "topic_moderation_"
+	TopicModerationApprovePrefix = TopicModerationPrefix + "approve_"
+	TopicModerationRejectPrefix  = TopicModerationPrefix + "reject_"
+	TopicModerationRevealPrefix  = TopicModerationPrefix + "reveal_"
+)
+
+// Callback data constants for admin "/stats" buttons, each followed by "<days>"
+const (
+	GroupStatsPrefix      = "group_stats_"
+	GroupStatsRangePrefix = GroupStatsPrefix + "range_"
+	GroupStatsChartPrefix = GroupStatsPrefix + "chart_"
+)
+
+// Callback data constants for event proposal review buttons, each followed by "<proposalID>"
+const (
+	EventProposalReviewPrefix        = "event_proposal_review_"
+	EventProposalReviewApprovePrefix = EventProposalReviewPrefix + "approve_"
+	EventProposalReviewRejectPrefix  = EventProposalReviewPrefix + "reject_"
+	EventProposalReviewChangesPrefix = EventProposalReviewPrefix + "changes_"
+)
@@ -3,12 +3,19 @@ package constants
 const ToolsCommand = "tools"
 const ContentCommand = "content"
 const EventsCommand = "events"
+const MyEventsCommand = "myEvents"
 const TopicsCommand = "topics"
 const TopicAddCommand = "topicAdd"
+const MyTopicsCommand = "myTopics"
+const EventProposeCommand = "proposeEvent"
 const HelpCommand = "help"
 const StartCommand = "start"
 const IntroCommand = "intro"
 const ProfileCommand = "profile"
+const RandomCoffeeCommand = "coffee"
+const RandomCoffeeHistoryCommand = "coffeeHistory"
+const KarmaLeaderboardCommand = "leaderboard"
+const KarmaLeaderboardLimit = 10
 const CopyrightString = ""
 
 // Callback data constants for profile handler
@@ -21,6 +28,81 @@ const (
 	ProfileEditFirstnameCallback = ProfilePrefix + "edit_firstname"
 	ProfileEditLastnameCallback  = ProfilePrefix + "edit_lastname"
 
+	ProfileBioAssistCallback          = ProfilePrefix + "bio_assist"
+	ProfileBioDraftSaveCallback       = ProfilePrefix + "bio_draft_save"
+	ProfileBioDraftRegenerateCallback = ProfilePrefix + "bio_draft_regenerate"
+	// ProfileEditFieldPrefix is followed by the constants.ProfileField to edit
+	ProfileEditFieldPrefix = ProfilePrefix + "edit_field_"
+
 	ProfileStartCallback = ProfilePrefix + "start"
 	ProfileFullCancel    = "full_cancel" + ProfilePrefix
 )
+
+// Callback data constants for random coffee handler
+const (
+	RandomCoffeePrefix              = "random_coffee_"
+	RandomCoffeeSubscribeCallback   = RandomCoffeePrefix + "subscribe"
+	RandomCoffeePauseCallback       = RandomCoffeePrefix + "pause"
+	RandomCoffeeUnsubscribeCallback = RandomCoffeePrefix + "unsubscribe"
+
+	RandomCoffeeMuteRemindersCallback   = RandomCoffeePrefix + "mute_reminders"
+	RandomCoffeeUnmuteRemindersCallback = RandomCoffeePrefix + "unmute_reminders"
+
+	RandomCoffeeStartCallback = RandomCoffeePrefix + "start"
+	RandomCoffeeFullCancel    = "full_cancel" + RandomCoffeePrefix
+)
+
+// Callback data constants for the re-engagement message sent to silent members
+const (
+	ReengagementPrefix         = "reengagement_"
+	ReengagementOptOutCallback = ReengagementPrefix + "opt_out"
+	ReengagementOptInCallback  = ReengagementPrefix + "opt_in"
+)
+
+// Callback data constants for the onboarding checklist sent to new members
+const (
+	OnboardingPrefix          = "onboarding_"
+	OnboardingRulesCallback   = OnboardingPrefix + "rules"
+	OnboardingCourseCallback  = OnboardingPrefix + "course"
+	OnboardingRefreshCallback = OnboardingPrefix + "refresh"
+)
+
+// Callback data constants for event RSVP buttons, each followed by "<eventID>"
+const (
+	EventRSVPPrefix          = "event_rsvp_"
+	EventRSVPGoingPrefix     = EventRSVPPrefix + "going_"
+	EventRSVPMaybePrefix     = EventRSVPPrefix + "maybe_"
+	EventRSVPNotGoingPrefix  = EventRSVPPrefix + "not_going_"
+	EventRSVPViewPrefix      = EventRSVPPrefix + "view_"
+	EventRSVPAttendeesPrefix = EventRSVPPrefix + "attendees_"
+)
+
+// Callback data constants for event calendar buttons
+const (
+	EventCalendarPrefix            = "event_calendar_"
+	EventCalendarFilePrefix        = EventCalendarPrefix + "file_" // followed by "<eventID>"
+	EventCalendarFeedCallback      = EventCalendarPrefix + "feed"
+	EventCalendarFeedResetCallback = EventCalendarPrefix + "feed_reset"
+)
+
+// Callback data constants for the archive of past events in /events
+const (
+	EventsArchiveCallback = "events_archive"
+	EventsArchiveLimit    = 10
+)
+
+// Callback data constants for topic vote buttons in /topics
+const (
+	TopicVotePrefix = "topic_vote_" // + "<topicID>"
+)
+
+// Callback data constants for the rating buttons of the feedback form sent after events
+const (
+	EventFeedbackPrefix     = "event_feedback_"
+	EventFeedbackRatePrefix = EventFeedbackPrefix + "rate_" // + "<eventID>_<rating>"
+)
+
+// Callback data constants for the button that lets members revise their event proposal
+const (
+	EventProposalRevisePrefix = "event_proposal_revise_" // + "<proposalID>"
+)
@@ -0,0 +1,43 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddRandomCoffeeSubscriptionsTable struct {
+	BaseMigration
+}
+
+func NewAddRandomCoffeeSubscriptionsTable() *AddRandomCoffeeSubscriptionsTable {
+	return &AddRandomCoffeeSubscriptionsTable{
+		BaseMigration: BaseMigration{
+			name:      "add_random_coffee_subscriptions_table",
+			timestamp: "20251001",
+		},
+	}
+}
+
+func (m *AddRandomCoffeeSubscriptionsTable) Apply(db *sql.DB) error {
+	sql := `
+	CREATE TABLE IF NOT EXISTS random_coffee_subscriptions (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+		paused_until DATE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TRIGGER update_random_coffee_subscriptions_updated_at
+		BEFORE UPDATE ON random_coffee_subscriptions
+		FOR EACH ROW
+		EXECUTE FUNCTION update_updated_at_column();
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddRandomCoffeeSubscriptionsTable) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS random_coffee_subscriptions;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,35 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddRandomCoffeeReminderMutesTable struct {
+	BaseMigration
+}
+
+func NewAddRandomCoffeeReminderMutesTable() *AddRandomCoffeeReminderMutesTable {
+	return &AddRandomCoffeeReminderMutesTable{
+		BaseMigration: BaseMigration{
+			name:      "add_random_coffee_reminder_mutes_table",
+			timestamp: "20251002",
+		},
+	}
+}
+
+func (m *AddRandomCoffeeReminderMutesTable) Apply(db *sql.DB) error {
+	sql := `
+	CREATE TABLE IF NOT EXISTS random_coffee_reminder_mutes (
+		user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddRandomCoffeeReminderMutesTable) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS random_coffee_reminder_mutes;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,70 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddMatchingPrograms struct {
+	BaseMigration
+}
+
+func NewAddMatchingPrograms() *AddMatchingPrograms {
+	return &AddMatchingPrograms{
+		BaseMigration: BaseMigration{
+			name:      "add_matching_programs",
+			timestamp: "20251003",
+		},
+	}
+}
+
+func (m *AddMatchingPrograms) Apply(db *sql.DB) error {
+	sql := `
+	CREATE TABLE IF NOT EXISTS matching_programs (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		topic_id INTEGER NOT NULL,
+		poll_question TEXT NOT NULL,
+		frequency TEXT NOT NULL DEFAULT 'weekly' CHECK (frequency IN ('weekly', 'monthly')),
+		poll_weekday INTEGER NOT NULL CHECK (poll_weekday BETWEEN 0 AND 6),
+		poll_time TEXT NOT NULL,
+		pairs_weekday INTEGER NOT NULL CHECK (pairs_weekday BETWEEN 0 AND 6),
+		pairs_time TEXT NOT NULL,
+		first_role TEXT,
+		second_role TEXT,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		CHECK ((first_role IS NULL) = (second_role IS NULL))
+	);
+
+	CREATE TRIGGER update_matching_programs_updated_at
+	BEFORE UPDATE ON matching_programs
+	FOR EACH ROW
+	EXECUTE FUNCTION update_updated_at_column();
+
+	-- Polls without a program belong to the weekly Random Coffee configured via environment
+	ALTER TABLE random_coffee_polls
+		ADD COLUMN IF NOT EXISTS program_id INTEGER REFERENCES matching_programs(id) ON DELETE CASCADE,
+		ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+	CREATE INDEX IF NOT EXISTS idx_random_coffee_polls_program_id ON random_coffee_polls(program_id);
+
+	ALTER TABLE random_coffee_participants ADD COLUMN IF NOT EXISTS role TEXT;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddMatchingPrograms) Rollback(db *sql.DB) error {
+	sql := `
+	ALTER TABLE random_coffee_participants DROP COLUMN IF EXISTS role;
+	DELETE FROM random_coffee_polls WHERE program_id IS NOT NULL;
+	DROP INDEX IF EXISTS idx_random_coffee_polls_program_id;
+	ALTER TABLE random_coffee_polls
+		DROP COLUMN IF EXISTS closed_at,
+		DROP COLUMN IF EXISTS program_id;
+	DROP TRIGGER IF EXISTS update_matching_programs_updated_at ON matching_programs;
+	DROP TABLE IF EXISTS matching_programs;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,46 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventDetails struct {
+	BaseMigration
+}
+
+func NewAddEventDetails() *AddEventDetails {
+	return &AddEventDetails{
+		BaseMigration: BaseMigration{
+			name:      "add_event_details",
+			timestamp: "20251004",
+		},
+	}
+}
+
+func (m *AddEventDetails) Apply(db *sql.DB) error {
+	sql := `
+	ALTER TABLE events
+		ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS link TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS duration_minutes INTEGER CHECK (duration_minutes > 0),
+		ADD COLUMN IF NOT EXISTS host_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity > 0);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventDetails) Rollback(db *sql.DB) error {
+	sql := `
+	ALTER TABLE events
+		DROP COLUMN IF EXISTS capacity,
+		DROP COLUMN IF EXISTS host_user_id,
+		DROP COLUMN IF EXISTS duration_minutes,
+		DROP COLUMN IF EXISTS link,
+		DROP COLUMN IF EXISTS location,
+		DROP COLUMN IF EXISTS description;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,43 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventRSVPsTable struct {
+	BaseMigration
+}
+
+func NewAddEventRSVPsTable() *AddEventRSVPsTable {
+	return &AddEventRSVPsTable{
+		BaseMigration: BaseMigration{
+			name:      "add_event_rsvps_table",
+			timestamp: "20251005",
+		},
+	}
+}
+
+func (m *AddEventRSVPsTable) Apply(db *sql.DB) error {
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_rsvps (
+		id SERIAL PRIMARY KEY,
+		event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		status TEXT NOT NULL CHECK (status IN ('going', 'maybe', 'not_going', 'waitlist')),
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		UNIQUE (event_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_rsvps_event_id_status ON event_rsvps(event_id, status);
+	CREATE INDEX IF NOT EXISTS idx_event_rsvps_user_id ON event_rsvps(user_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventRSVPsTable) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS event_rsvps;`
+	_, err := db.Exec(sql)
+	return err
+}
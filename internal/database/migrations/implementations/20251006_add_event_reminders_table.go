@@ -0,0 +1,41 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventRemindersTable struct {
+	BaseMigration
+}
+
+func NewAddEventRemindersTable() *AddEventRemindersTable {
+	return &AddEventRemindersTable{
+		BaseMigration: BaseMigration{
+			name:      "add_event_reminders_table",
+			timestamp: "20251006",
+		},
+	}
+}
+
+func (m *AddEventRemindersTable) Apply(db *sql.DB) error {
+	// started_at is the planned start the reminder was sent for, so rescheduling an event re-arms its reminders.
+	// offset_minutes = 0 marks the start announcement with the join link.
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_reminders (
+		id SERIAL PRIMARY KEY,
+		event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+		started_at TIMESTAMPTZ NOT NULL,
+		offset_minutes INTEGER NOT NULL CHECK (offset_minutes >= 0),
+		sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		UNIQUE (event_id, started_at, offset_minutes)
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventRemindersTable) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS event_reminders;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,49 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddCalendarFeedTables struct {
+	BaseMigration
+}
+
+func NewAddCalendarFeedTables() *AddCalendarFeedTables {
+	return &AddCalendarFeedTables{
+		BaseMigration: BaseMigration{
+			name:      "add_calendar_feed_tables",
+			timestamp: "20251007",
+		},
+	}
+}
+
+func (m *AddCalendarFeedTables) Apply(db *sql.DB) error {
+	// cancelled_events keeps deleted events, so the calendar feed can tell subscribed calendars to remove them.
+	// It has no foreign key to events on purpose, since the event row is gone.
+	sql := `
+	CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+		user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+		token TEXT NOT NULL UNIQUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS cancelled_events (
+		event_id INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		duration_minutes INTEGER,
+		cancelled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddCalendarFeedTables) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS cancelled_events;
+	DROP TABLE IF EXISTS calendar_feed_tokens;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,62 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventSeries struct {
+	BaseMigration
+}
+
+func NewAddEventSeries() *AddEventSeries {
+	return &AddEventSeries{
+		BaseMigration: BaseMigration{
+			name:      "add_event_series",
+			timestamp: "20251008",
+		},
+	}
+}
+
+func (m *AddEventSeries) Apply(db *sql.DB) error {
+	// A series keeps the details its occurrences are created with. materialized_until is the start of the last
+	// created occurrence, so occurrences are never created twice, and deleted ones don't come back.
+	// series_occurrence_at is the start the rule gave the occurrence, it doesn't change when the occurrence is rescheduled.
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_series (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL,
+		type TEXT NOT NULL,
+		rule TEXT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		duration_minutes INTEGER CHECK (duration_minutes > 0),
+		description TEXT NOT NULL DEFAULT '',
+		location TEXT NOT NULL DEFAULT '',
+		link TEXT NOT NULL DEFAULT '',
+		host_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		capacity INTEGER CHECK (capacity > 0),
+		materialized_until TIMESTAMPTZ NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	ALTER TABLE events
+		ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES event_series(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS series_occurrence_at TIMESTAMPTZ;
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_events_series_occurrence ON events(series_id, series_occurrence_at);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventSeries) Rollback(db *sql.DB) error {
+	sql := `
+	DROP INDEX IF EXISTS idx_events_series_occurrence;
+	ALTER TABLE events
+		DROP COLUMN IF EXISTS series_occurrence_at,
+		DROP COLUMN IF EXISTS series_id;
+	DROP TABLE IF EXISTS event_series;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,64 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddTopicVotesAndModeration struct {
+	BaseMigration
+}
+
+func NewAddTopicVotesAndModeration() *AddTopicVotesAndModeration {
+	return &AddTopicVotesAndModeration{
+		BaseMigration: BaseMigration{
+			name:      "add_topic_votes_and_moderation",
+			timestamp: "20251009",
+		},
+	}
+}
+
+func (m *AddTopicVotesAndModeration) Apply(db *sql.DB) error {
+	// Topics added before moderation stay visible, new ones wait for an administrator.
+	// Authors of existing topics are found by their nickname, so they can edit or withdraw them too.
+	sql := `
+	ALTER TABLE topics
+		ADD COLUMN IF NOT EXISTS author_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'approved'
+			CHECK (status IN ('pending', 'approved', 'rejected')),
+		ADD COLUMN IF NOT EXISTS rejection_reason TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+	ALTER TABLE topics ALTER COLUMN status SET DEFAULT 'pending';
+
+	UPDATE topics t SET author_user_id = u.id
+	FROM users u
+	WHERE t.author_user_id IS NULL AND t.user_nickname IS NOT NULL AND u.tg_username = t.user_nickname;
+
+	CREATE INDEX IF NOT EXISTS idx_topics_status ON topics(status);
+	CREATE INDEX IF NOT EXISTS idx_topics_author_user_id ON topics(author_user_id);
+
+	CREATE TABLE IF NOT EXISTS topic_votes (
+		topic_id INTEGER NOT NULL REFERENCES topics(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (topic_id, user_id)
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddTopicVotesAndModeration) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS topic_votes;
+	DROP INDEX IF EXISTS idx_topics_author_user_id;
+	DROP INDEX IF EXISTS idx_topics_status;
+	ALTER TABLE topics
+		DROP COLUMN IF EXISTS updated_at,
+		DROP COLUMN IF EXISTS rejection_reason,
+		DROP COLUMN IF EXISTS status,
+		DROP COLUMN IF EXISTS author_user_id;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
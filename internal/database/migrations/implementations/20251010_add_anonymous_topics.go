@@ -0,0 +1,44 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddAnonymousTopics struct {
+	BaseMigration
+}
+
+func NewAddAnonymousTopics() *AddAnonymousTopics {
+	return &AddAnonymousTopics{
+		BaseMigration: BaseMigration{
+			name:      "add_anonymous_topics",
+			timestamp: "20251010",
+		},
+	}
+}
+
+func (m *AddAnonymousTopics) Apply(db *sql.DB) error {
+	// Anonymous topics have no nickname or author in the topics table. The author is kept in a separate table,
+	// so it can be looked up only on purpose, e.g. when an administrator has to deal with abuse.
+	sql := `
+	ALTER TABLE topics ADD COLUMN IF NOT EXISTS is_anonymous BOOLEAN NOT NULL DEFAULT false;
+
+	CREATE TABLE IF NOT EXISTS topic_anonymous_authors (
+		topic_id INTEGER PRIMARY KEY REFERENCES topics(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_topic_anonymous_authors_user_id ON topic_anonymous_authors(user_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddAnonymousTopics) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS topic_anonymous_authors;
+	ALTER TABLE topics DROP COLUMN IF EXISTS is_anonymous;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,52 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventFeedback struct {
+	BaseMigration
+}
+
+func NewAddEventFeedback() *AddEventFeedback {
+	return &AddEventFeedback{
+		BaseMigration: BaseMigration{
+			name:      "add_event_feedback",
+			timestamp: "20251011",
+		},
+	}
+}
+
+func (m *AddEventFeedback) Apply(db *sql.DB) error {
+	// event_feedback_requests records the events the feedback form was sent for, so it's sent at most once.
+	// event_feedback keeps one rating per member and event, the comment is optional.
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_feedback_requests (
+		event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+		sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS event_feedback (
+		event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		comment TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (event_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_feedback_user_id ON event_feedback(user_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventFeedback) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS event_feedback;
+	DROP TABLE IF EXISTS event_feedback_requests;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,44 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventMaterials struct {
+	BaseMigration
+}
+
+func NewAddEventMaterials() *AddEventMaterials {
+	return &AddEventMaterials{
+		BaseMigration: BaseMigration{
+			name:      "add_event_materials",
+			timestamp: "20251012",
+		},
+	}
+}
+
+func (m *AddEventMaterials) Apply(db *sql.DB) error {
+	// Materials are posted to the Content topic, message_id is the post there.
+	// url is empty for files sent to the bot, they are available from the post only.
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_materials (
+		id SERIAL PRIMARY KEY,
+		event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+		kind TEXT NOT NULL CHECK (kind IN ('recording', 'slides', 'link')),
+		description TEXT NOT NULL DEFAULT '',
+		url TEXT NOT NULL DEFAULT '',
+		message_id BIGINT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_materials_event_id ON event_materials(event_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventMaterials) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS event_materials;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,44 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventTopics struct {
+	BaseMigration
+}
+
+func NewAddEventTopics() *AddEventTopics {
+	return &AddEventTopics{
+		BaseMigration: BaseMigration{
+			name:      "add_event_topics",
+			timestamp: "20251013",
+		},
+	}
+}
+
+func (m *AddEventTopics) Apply(db *sql.DB) error {
+	// event_id is set for the topics created by the bot for an event,
+	// closed_at is set once such a topic is closed after the event.
+	sql := `
+	ALTER TABLE group_topics
+		ADD COLUMN IF NOT EXISTS event_id INTEGER REFERENCES events(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_group_topics_event_id ON group_topics(event_id) WHERE event_id IS NOT NULL;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventTopics) Rollback(db *sql.DB) error {
+	sql := `
+	DROP INDEX IF EXISTS idx_group_topics_event_id;
+
+	ALTER TABLE group_topics
+		DROP COLUMN IF EXISTS closed_at,
+		DROP COLUMN IF EXISTS event_id;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
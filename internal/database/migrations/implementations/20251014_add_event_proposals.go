@@ -0,0 +1,49 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventProposals struct {
+	BaseMigration
+}
+
+func NewAddEventProposals() *AddEventProposals {
+	return &AddEventProposals{
+		BaseMigration: BaseMigration{
+			name:      "add_event_proposals",
+			timestamp: "20251014",
+		},
+	}
+}
+
+func (m *AddEventProposals) Apply(db *sql.DB) error {
+	// preferred_dates is free text, the exact start is set by admins once the proposal becomes an event.
+	// event_id is set for approved proposals.
+	sql := `
+	CREATE TABLE IF NOT EXISTS event_proposals (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		title TEXT NOT NULL,
+		type TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		preferred_dates TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'changes_requested')),
+		admin_comment TEXT NOT NULL DEFAULT '',
+		event_id INTEGER REFERENCES events(id) ON DELETE SET NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_event_proposals_status ON event_proposals(status);
+	CREATE INDEX IF NOT EXISTS idx_event_proposals_user_id ON event_proposals(user_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventProposals) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS event_proposals;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,107 @@
+package implementations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// eventTypeSeeds are the types that used to be hardcoded, with the same slugs, names and emoji
+var eventTypeSeeds = []struct {
+	slug      string
+	name      string
+	emoji     string
+	rulesLink string
+}{
+	{"club-call", "club call", "\U0001f4ac", "https://t.me/c/2069889012/127/33823"},
+	{"meetup", "meetup", "\U0001f399", ""},
+	{"workshop", "workshop", "\u2699\ufe0f", ""},
+	{"reading-club", "reading club", "\U0001f4da", ""},
+	{"conference", "conference", "\U0001f465", ""},
+}
+
+type AddEventTypesTable struct {
+	BaseMigration
+}
+
+func NewAddEventTypesTable() *AddEventTypesTable {
+	return &AddEventTypesTable{
+		BaseMigration: BaseMigration{
+			name:      "add_event_types_table",
+			timestamp: "20251015",
+		},
+	}
+}
+
+// Apply moves the event types from the check constraint on events to the event_types table,
+// events, series and proposals reference the types by slug
+func (m *AddEventTypesTable) Apply(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// announcement_template replaces the default header of the start announcement when set,
+	// rules_link is linked from the start announcement when set
+	_, err = tx.Exec(`
+	CREATE TABLE IF NOT EXISTS event_types (
+		slug TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		emoji TEXT NOT NULL DEFAULT '',
+		announcement_template TEXT NOT NULL DEFAULT '',
+		rules_link TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create event_types table: %w", err)
+	}
+
+	for _, seed := range eventTypeSeeds {
+		_, err = tx.Exec(
+			`INSERT INTO event_types (slug, name, emoji, rules_link) VALUES ($1, $2, $3, $4) ON CONFLICT (slug) DO NOTHING`,
+			seed.slug, seed.name, seed.emoji, seed.rulesLink,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert event type %s: %w", seed.slug, err)
+		}
+	}
+
+	_, err = tx.Exec(`
+	ALTER TABLE events DROP CONSTRAINT IF EXISTS events_type_check;
+
+	ALTER TABLE events
+		ADD CONSTRAINT events_type_fkey FOREIGN KEY (type) REFERENCES event_types(slug);
+
+	ALTER TABLE event_series
+		ADD CONSTRAINT event_series_type_fkey FOREIGN KEY (type) REFERENCES event_types(slug);
+
+	ALTER TABLE event_proposals
+		ADD CONSTRAINT event_proposals_type_fkey FOREIGN KEY (type) REFERENCES event_types(slug);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to reference event types: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Rollback restores the check constraint, the events must have one of the seeded types
+func (m *AddEventTypesTable) Rollback(db *sql.DB) error {
+	sql := `
+	ALTER TABLE event_proposals DROP CONSTRAINT IF EXISTS event_proposals_type_fkey;
+	ALTER TABLE event_series DROP CONSTRAINT IF EXISTS event_series_type_fkey;
+	ALTER TABLE events DROP CONSTRAINT IF EXISTS events_type_fkey;
+
+	DROP TABLE IF EXISTS event_types;
+
+	ALTER TABLE events ADD CONSTRAINT events_type_check
+		CHECK (type IN ('club-call', 'meetup', 'workshop', 'reading-club', 'conference'));
+	`
+	_, err := db.Exec(sql)
+	return err
+}
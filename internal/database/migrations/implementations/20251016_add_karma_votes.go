@@ -0,0 +1,45 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddKarmaVotes struct {
+	BaseMigration
+}
+
+func NewAddKarmaVotes() *AddKarmaVotes {
+	return &AddKarmaVotes{
+		BaseMigration: BaseMigration{
+			name:      "add_karma_votes",
+			timestamp: "20251016",
+		},
+	}
+}
+
+func (m *AddKarmaVotes) Apply(db *sql.DB) error {
+	// Each row is a point granted to the author of the message, users.score holds the total.
+	// A member grants a point for a message once, whether by a reply or by a reaction.
+	sql := `
+	CREATE TABLE IF NOT EXISTS karma_votes (
+		id SERIAL PRIMARY KEY,
+		giver_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		receiver_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		message_id BIGINT NOT NULL,
+		source TEXT NOT NULL CHECK (source IN ('reply', 'reaction')),
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		UNIQUE (giver_user_id, message_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_karma_votes_giver_created_at ON karma_votes(giver_user_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_karma_votes_receiver ON karma_votes(receiver_user_id);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddKarmaVotes) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS karma_votes;`
+	_, err := db.Exec(sql)
+	return err
+}
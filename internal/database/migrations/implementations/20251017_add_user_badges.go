@@ -0,0 +1,49 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddUserBadges struct {
+	BaseMigration
+}
+
+func NewAddUserBadges() *AddUserBadges {
+	return &AddUserBadges{
+		BaseMigration: BaseMigration{
+			name:      "add_user_badges",
+			timestamp: "20251017",
+		},
+	}
+}
+
+func (m *AddUserBadges) Apply(db *sql.DB) error {
+	// The badges are evaluated from the existing data, a row is kept once a member earned a badge,
+	// so the badge isn't lost and the member is congratulated only once.
+	// badge_backfills records the badges first awarded for the contributions made before the badge existed,
+	// these are awarded without congratulating
+	sql := `
+	CREATE TABLE IF NOT EXISTS user_badges (
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		badge TEXT NOT NULL,
+		awarded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, badge)
+	);
+
+	CREATE TABLE IF NOT EXISTS badge_backfills (
+		badge TEXT PRIMARY KEY,
+		backfilled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddUserBadges) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS badge_backfills;
+	DROP TABLE IF EXISTS user_badges;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,54 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddMemberLifecycle struct {
+	BaseMigration
+}
+
+func NewAddMemberLifecycle() *AddMemberLifecycle {
+	return &AddMemberLifecycle{
+		BaseMigration: BaseMigration{
+			name:      "add_member_lifecycle",
+			timestamp: "20251018",
+		},
+	}
+}
+
+func (m *AddMemberLifecycle) Apply(db *sql.DB) error {
+	// club_membership_events keeps every join and leave with the invite link used to join.
+	// reengagement_messages keeps when a silent member was last sent the friendly reminder
+	// and whether they opted out of it.
+	sql := `
+	CREATE TABLE IF NOT EXISTS club_membership_events (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		event TEXT NOT NULL CHECK (event IN ('joined', 'left', 'banned')),
+		invite_link TEXT NOT NULL DEFAULT '',
+		invite_link_name TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_club_membership_events_user_created_at ON club_membership_events(user_id, created_at);
+
+	CREATE TABLE IF NOT EXISTS reengagement_messages (
+		user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+		last_sent_at TIMESTAMPTZ,
+		opted_out BOOLEAN NOT NULL DEFAULT FALSE,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddMemberLifecycle) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS reengagement_messages;
+	DROP TABLE IF EXISTS club_membership_events;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,45 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddMemberOnboarding struct {
+	BaseMigration
+}
+
+func NewAddMemberOnboarding() *AddMemberOnboarding {
+	return &AddMemberOnboarding{
+		BaseMigration: BaseMigration{
+			name:      "add_member_onboarding",
+			timestamp: "20251019",
+		},
+	}
+}
+
+func (m *AddMemberOnboarding) Apply(db *sql.DB) error {
+	// member_onboarding keeps the checklist progress of a new member. The profile and Random Coffee
+	// steps are derived from the existing tables, only the steps without their own data are stored.
+	sql := `
+	CREATE TABLE IF NOT EXISTS member_onboarding (
+		user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+		rules_read_at TIMESTAMPTZ,
+		course_opened_at TIMESTAMPTZ,
+		reminders_sent INTEGER NOT NULL DEFAULT 0,
+		last_reminder_at TIMESTAMPTZ,
+		completed_at TIMESTAMPTZ,
+		started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_member_onboarding_completed_at ON member_onboarding(completed_at);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddMemberOnboarding) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS member_onboarding;`
+	_, err := db.Exec(sql)
+	return err
+}
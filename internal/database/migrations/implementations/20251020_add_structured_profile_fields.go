@@ -0,0 +1,50 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddStructuredProfileFields struct {
+	BaseMigration
+}
+
+func NewAddStructuredProfileFields() *AddStructuredProfileFields {
+	return &AddStructuredProfileFields{
+		BaseMigration: BaseMigration{
+			name:      "add_structured_profile_fields",
+			timestamp: "20251020",
+		},
+	}
+}
+
+// Apply adds the optional fields shown next to the bio, the links are stored as full URLs.
+// The names differ from the social link columns removed earlier, so that rollback still works.
+func (m *AddStructuredProfileFields) Apply(db *sql.DB) error {
+	sql := `ALTER TABLE profiles
+			ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS company TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS skills TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS city TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS github_url TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS linkedin_url TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS looking_for TEXT NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS can_help_with TEXT NOT NULL DEFAULT ''`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddStructuredProfileFields) Rollback(db *sql.DB) error {
+	sql := `ALTER TABLE profiles
+			DROP COLUMN IF EXISTS role,
+			DROP COLUMN IF EXISTS company,
+			DROP COLUMN IF EXISTS skills,
+			DROP COLUMN IF EXISTS city,
+			DROP COLUMN IF EXISTS timezone,
+			DROP COLUMN IF EXISTS github_url,
+			DROP COLUMN IF EXISTS linkedin_url,
+			DROP COLUMN IF EXISTS looking_for,
+			DROP COLUMN IF EXISTS can_help_with`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,32 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddProfileArchivedAt struct {
+	BaseMigration
+}
+
+func NewAddProfileArchivedAt() *AddProfileArchivedAt {
+	return &AddProfileArchivedAt{
+		BaseMigration: BaseMigration{
+			name:      "add_profile_archived_at",
+			timestamp: "20251021",
+		},
+	}
+}
+
+// Apply adds the time the intro of a member was archived or deleted after they left the group,
+// so that it is published again only for these members when they rejoin
+func (m *AddProfileArchivedAt) Apply(db *sql.DB) error {
+	sql := `ALTER TABLE profiles ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddProfileArchivedAt) Rollback(db *sql.DB) error {
+	sql := `ALTER TABLE profiles DROP COLUMN IF EXISTS archived_at`
+	_, err := db.Exec(sql)
+	return err
+}
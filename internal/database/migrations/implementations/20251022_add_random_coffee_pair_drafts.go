@@ -0,0 +1,60 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddRandomCoffeePairDrafts struct {
+	BaseMigration
+}
+
+func NewAddRandomCoffeePairDrafts() *AddRandomCoffeePairDrafts {
+	return &AddRandomCoffeePairDrafts{
+		BaseMigration: BaseMigration{
+			name:      "add_random_coffee_pair_drafts",
+			timestamp: "20251022",
+		},
+	}
+}
+
+func (m *AddRandomCoffeePairDrafts) Apply(db *sql.DB) error {
+	// Pairs drafts awaiting admin review survive restarts, one per round, their polls are already closed by then.
+	// Pairs and members left without a partner are stored in draft order.
+	sql := `
+	CREATE TABLE IF NOT EXISTS random_coffee_pair_drafts (
+		poll_id INTEGER PRIMARY KEY REFERENCES random_coffee_polls(id) ON DELETE CASCADE,
+		week_start_date DATE NOT NULL,
+		auto_publish_at TIMESTAMPTZ,
+		admin_chat_id BIGINT NOT NULL DEFAULT 0,
+		admin_message_id BIGINT NOT NULL DEFAULT 0,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS random_coffee_pair_draft_pairs (
+		poll_id INTEGER NOT NULL REFERENCES random_coffee_pair_drafts(poll_id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		user1_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		user2_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		PRIMARY KEY (poll_id, position)
+	);
+
+	CREATE TABLE IF NOT EXISTS random_coffee_pair_draft_unpaired (
+		poll_id INTEGER NOT NULL REFERENCES random_coffee_pair_drafts(poll_id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		PRIMARY KEY (poll_id, position)
+	);
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddRandomCoffeePairDrafts) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TABLE IF EXISTS random_coffee_pair_draft_unpaired;
+	DROP TABLE IF EXISTS random_coffee_pair_draft_pairs;
+	DROP TABLE IF EXISTS random_coffee_pair_drafts;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
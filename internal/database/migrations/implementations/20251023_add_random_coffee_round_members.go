@@ -0,0 +1,52 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddRandomCoffeeRoundMembers struct {
+	BaseMigration
+}
+
+func NewAddRandomCoffeeRoundMembers() *AddRandomCoffeeRoundMembers {
+	return &AddRandomCoffeeRoundMembers{
+		BaseMigration: BaseMigration{
+			name:      "add_random_coffee_round_members",
+			timestamp: "20251023",
+		},
+	}
+}
+
+func (m *AddRandomCoffeeRoundMembers) Apply(db *sql.DB) error {
+	// Members of a round are recorded when its pairs are published, including the one left without a partner.
+	// Past rounds are filled from their pairs and "Yes" voters, which is everything known about them.
+	sql := `
+	CREATE TABLE IF NOT EXISTS random_coffee_round_members (
+		poll_id INTEGER NOT NULL REFERENCES random_coffee_polls(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (poll_id, user_id)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_random_coffee_round_members_user_id ON random_coffee_round_members(user_id);
+
+	INSERT INTO random_coffee_round_members (poll_id, user_id)
+	SELECT poll_id, user1_id FROM random_coffee_pairs
+	UNION
+	SELECT poll_id, user2_id FROM random_coffee_pairs
+	UNION
+	SELECT rpc.poll_id, rpc.user_id
+	FROM random_coffee_participants rpc
+	WHERE rpc.is_participating = TRUE
+		AND EXISTS (SELECT 1 FROM random_coffee_pairs rp WHERE rp.poll_id = rpc.poll_id)
+	ON CONFLICT (poll_id, user_id) DO NOTHING;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddRandomCoffeeRoundMembers) Rollback(db *sql.DB) error {
+	sql := `DROP TABLE IF EXISTS random_coffee_round_members;`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -0,0 +1,45 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddRandomCoffeePollRoles struct {
+	BaseMigration
+}
+
+func NewAddRandomCoffeePollRoles() *AddRandomCoffeePollRoles {
+	return &AddRandomCoffeePollRoles{
+		BaseMigration: BaseMigration{
+			name:      "add_random_coffee_poll_roles",
+			timestamp: "20251024",
+		},
+	}
+}
+
+func (m *AddRandomCoffeePollRoles) Apply(db *sql.DB) error {
+	// Roles of a program are copied to its poll when the poll is sent, so votes and matching
+	// of an open round keep working if admins edit the program's roles in the meantime
+	sql := `
+	ALTER TABLE random_coffee_polls
+		ADD COLUMN IF NOT EXISTS first_role TEXT,
+		ADD COLUMN IF NOT EXISTS second_role TEXT;
+
+	UPDATE random_coffee_polls poll
+	SET first_role = program.first_role, second_role = program.second_role
+	FROM matching_programs program
+	WHERE program.id = poll.program_id;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddRandomCoffeePollRoles) Rollback(db *sql.DB) error {
+	sql := `
+	ALTER TABLE random_coffee_polls
+		DROP COLUMN IF EXISTS second_role,
+		DROP COLUMN IF EXISTS first_role;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
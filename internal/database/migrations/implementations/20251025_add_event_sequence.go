@@ -0,0 +1,54 @@
+package implementations
+
+import (
+	"database/sql"
+)
+
+type AddEventSequence struct {
+	BaseMigration
+}
+
+func NewAddEventSequence() *AddEventSequence {
+	return &AddEventSequence{
+		BaseMigration: BaseMigration{
+			name:      "add_event_sequence",
+			timestamp: "20251025",
+		},
+	}
+}
+
+func (m *AddEventSequence) Apply(db *sql.DB) error {
+	// sequence is the iCalendar SEQUENCE of an event: calendar apps apply an update only if it's higher
+	// than the one they have, so it grows with every change of the event and once more on cancellation
+	sql := `
+	ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE cancelled_events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
+
+	CREATE OR REPLACE FUNCTION increment_event_sequence()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		NEW.sequence = OLD.sequence + 1;
+		RETURN NEW;
+	END;
+	$$ language 'plpgsql';
+
+	DROP TRIGGER IF EXISTS increment_events_sequence ON events;
+	CREATE TRIGGER increment_events_sequence
+	BEFORE UPDATE ON events
+	FOR EACH ROW
+	EXECUTE FUNCTION increment_event_sequence();
+	`
+	_, err := db.Exec(sql)
+	return err
+}
+
+func (m *AddEventSequence) Rollback(db *sql.DB) error {
+	sql := `
+	DROP TRIGGER IF EXISTS increment_events_sequence ON events;
+	DROP FUNCTION IF EXISTS increment_event_sequence();
+	ALTER TABLE cancelled_events DROP COLUMN IF EXISTS sequence;
+	ALTER TABLE events DROP COLUMN IF EXISTS sequence;
+	`
+	_, err := db.Exec(sql)
+	return err
+}
@@ -47,6 +47,31 @@ func Registry() []implementations.Migration {
 		implementations.NewAddGroupTopicsTable(),
 		implementations.NewAddGroupMessagesTable(),
 		implementations.NewRemoveTgSessionsTable(),
+		implementations.NewAddRandomCoffeeSubscriptionsTable(),
+		implementations.NewAddRandomCoffeeReminderMutesTable(),
+		implementations.NewAddMatchingPrograms(),
+		implementations.NewAddEventDetails(),
+		implementations.NewAddEventRSVPsTable(),
+		implementations.NewAddEventRemindersTable(),
+		implementations.NewAddCalendarFeedTables(),
+		implementations.NewAddEventSeries(),
+		implementations.NewAddTopicVotesAndModeration(),
+		implementations.NewAddAnonymousTopics(),
+		implementations.NewAddEventFeedback(),
+		implementations.NewAddEventMaterials(),
+		implementations.NewAddEventTopics(),
+		implementations.NewAddEventProposals(),
+		implementations.NewAddEventTypesTable(),
+		implementations.NewAddKarmaVotes(),
+		implementations.NewAddUserBadges(),
+		implementations.NewAddMemberLifecycle(),
+		implementations.NewAddMemberOnboarding(),
+		implementations.NewAddStructuredProfileFields(),
+		implementations.NewAddProfileArchivedAt(),
+		implementations.NewAddRandomCoffeePairDrafts(),
+		implementations.NewAddRandomCoffeeRoundMembers(),
+		implementations.NewAddRandomCoffeePollRoles(),
+		implementations.NewAddEventSequence(),
 		// Add new migrations here
 	}
 }
@@ -8,6 +8,7 @@ const GetIntroPromptDefaultValue = `You are an AI assistant for searching inform
     <li>Member information is stored in JSON format in the database inside the <database> tag below.</li>
     <li>The search query is inside the <request> tag below.</li>
     <li>Find members matching the query and provide brief information about them.</li>
+    <li>Besides the bio, members may have structured fields: role, company, skills, city, timezone, links, looking_for and can_help_with. Prefer them when the query is about skills, location, or what members need or offer.</li>
     <li>If multiple members are found, list them all. But no more than 10 unless a different number is specified in the search query.</li>
     <li>If multiple members are found, list them using '🔸' at the beginning of each description, with a blank line between them.</li>
     <li>Maximum 20 members per response. If more than 20 found, show the first 20 and ask the user to refine their search.</li>
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+)
+
+// BadgeRepository handles database operations for the badges of members and the counts they are awarded for
+type BadgeRepository struct {
+	db *sql.DB
+}
+
+// NewBadgeRepository creates a new BadgeRepository
+func NewBadgeRepository(db *sql.DB) *BadgeRepository {
+	return &BadgeRepository{db: db}
+}
+
+// GetByUserID retrieves the badges of a user, in the order of constants.AllBadges
+func (r *BadgeRepository) GetByUserID(userID int) ([]constants.Badge, error) {
+	rows, err := r.db.Query(`SELECT badge FROM user_badges WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query badges for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	defer rows.Close()
+
+	earned := make(map[constants.Badge]bool)
+	for rows.Next() {
+		var badge constants.Badge
+		if err := rows.Scan(&badge); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan badge row: %w", utils.GetCurrentTypeName(), err)
+		}
+		earned[badge] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for badges: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	var badges []constants.Badge
+	for _, badge := range constants.AllBadges {
+		if earned[badge] {
+			badges = append(badges, badge)
+		}
+	}
+	return badges, nil
+}
+
+// GetAwardedUserIDs retrieves the IDs of the users who have a badge
+func (r *BadgeRepository) GetAwardedUserIDs(badge constants.Badge) (map[int]bool, error) {
+	rows, err := r.db.Query(`SELECT user_id FROM user_badges WHERE badge = $1`, badge)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query users with badge %s: %w", utils.GetCurrentTypeName(), badge, err)
+	}
+	defer rows.Close()
+
+	userIDs := make(map[int]bool)
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan user ID row: %w", utils.GetCurrentTypeName(), err)
+		}
+		userIDs[userID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for users with badge %s: %w", utils.GetCurrentTypeName(), badge, err)
+	}
+
+	return userIDs, nil
+}
+
+// Award records a badge earned by a user.
+// Returns false if the user already has the badge.
+func (r *BadgeRepository) Award(userID int, badge constants.Badge) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO user_badges (user_id, badge)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, badge) DO NOTHING`,
+		userID, badge)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to award badge %s to user %d: %w", utils.GetCurrentTypeName(), badge, userID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: could not get rows affected after insert: %w", utils.GetCurrentTypeName(), err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// IsBackfilled reports whether the badge was already awarded for the contributions made before it existed
+func (r *BadgeRepository) IsBackfilled(badge constants.Badge) (bool, error) {
+	var backfilled bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM badge_backfills WHERE badge = $1)`, badge).Scan(&backfilled)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to check backfill of badge %s: %w", utils.GetCurrentTypeName(), badge, err)
+	}
+	return backfilled, nil
+}
+
+// MarkBackfilled records that the badge was awarded for the contributions made before it existed
+func (r *BadgeRepository) MarkBackfilled(badge constants.Badge) error {
+	_, err := r.db.Exec(`INSERT INTO badge_backfills (badge) VALUES ($1) ON CONFLICT (badge) DO NOTHING`, badge)
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark badge %s as backfilled: %w", utils.GetCurrentTypeName(), badge, err)
+	}
+	return nil
+}
+
+// CountCoffeeRounds counts the Random Coffee rounds each user was paired in
+func (r *BadgeRepository) CountCoffeeRounds() (map[int]int, error) {
+	return r.queryCounts(`
+		SELECT user_id, COUNT(DISTINCT poll_id)
+		FROM (
+			SELECT user1_id AS user_id, poll_id FROM random_coffee_pairs
+			UNION ALL
+			SELECT user2_id AS user_id, poll_id FROM random_coffee_pairs
+		) AS pairs
+		GROUP BY user_id`)
+}
+
+// CountApprovedTopics counts the approved topics each user proposed under their name,
+// anonymous topics don't count, so a badge doesn't hint at their authors
+func (r *BadgeRepository) CountApprovedTopics() (map[int]int, error) {
+	return r.queryCounts(`
+		SELECT author_user_id, COUNT(*)
+		FROM topics
+		WHERE author_user_id IS NOT NULL AND status = $1
+		GROUP BY author_user_id`,
+		constants.TopicStatusApproved)
+}
+
+// CountHostedEvents counts the finished events each user hosted
+func (r *BadgeRepository) CountHostedEvents() (map[int]int, error) {
+	return r.queryCounts(`
+		SELECT host_user_id, COUNT(*)
+		FROM events
+		WHERE host_user_id IS NOT NULL AND status = $1
+		GROUP BY host_user_id`,
+		constants.EventStatusFinished)
+}
+
+// CountPublishedProfiles returns 1 for each user whose profile is published in the intro topic
+func (r *BadgeRepository) CountPublishedProfiles() (map[int]int, error) {
+	return r.queryCounts(`
+		SELECT user_id, COUNT(*)
+		FROM profiles
+		WHERE published_message_id IS NOT NULL
+		GROUP BY user_id`)
+}
+
+// CountSavedTools counts the posts each user made in the tools topic, replies to the posts don't count
+func (r *BadgeRepository) CountSavedTools(toolTopicID int) (map[int]int, error) {
+	return r.queryCounts(`
+		SELECT u.id, COUNT(*)
+		FROM group_messages gm
+		JOIN users u ON u.tg_id = gm.user_tg_id
+		WHERE gm.group_topic_id = $1 AND gm.reply_to_message_id IS NULL
+		GROUP BY u.id`,
+		toolTopicID)
+}
+
+// queryCounts runs a query selecting a user ID and a count per row
+func (r *BadgeRepository) queryCounts(query string, args ...any) (map[int]int, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query counts: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var userID, count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan count row: %w", utils.GetCurrentTypeName(), err)
+		}
+		counts[userID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for counts: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return counts, nil
+}
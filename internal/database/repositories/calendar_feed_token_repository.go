@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+)
+
+// CalendarFeedTokenRepository stores the secret tokens of members' personal calendar feed links
+type CalendarFeedTokenRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarFeedTokenRepository(db *sql.DB) *CalendarFeedTokenRepository {
+	return &CalendarFeedTokenRepository{db: db}
+}
+
+// GetByUserID returns the user's token, or an empty string if the user has none yet
+func (r *CalendarFeedTokenRepository) GetByUserID(userID int) (string, error) {
+	var token string
+	err := r.db.QueryRow(`SELECT token FROM calendar_feed_tokens WHERE user_id = $1`, userID).Scan(&token)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get calendar feed token for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return token, nil
+}
+
+// GetUserIDByToken returns the ID of the token's owner, sql.ErrNoRows if the token is unknown
+func (r *CalendarFeedTokenRepository) GetUserIDByToken(token string) (int, error) {
+	var userID int
+	err := r.db.QueryRow(`SELECT user_id FROM calendar_feed_tokens WHERE token = $1`, token).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, err
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get user by calendar feed token: %w", utils.GetCurrentTypeName(), err)
+	}
+	return userID, nil
+}
+
+// Set stores the user's token, replacing the previous one so the old link stops working
+func (r *CalendarFeedTokenRepository) Set(userID int, token string) error {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = NOW()`
+	if _, err := r.db.Exec(query, userID, token); err != nil {
+		return fmt.Errorf("%s: failed to set calendar feed token for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// ClubMembershipEvent represents a row in the club_membership_events table
+type ClubMembershipEvent struct {
+	ID             int
+	UserID         int
+	Event          constants.ClubMembershipEvent
+	InviteLink     string // Only set for joins by an invite link
+	InviteLinkName string
+	CreatedAt      time.Time
+}
+
+// ClubMembershipEventRepository handles database operations for the joins and leaves of members
+type ClubMembershipEventRepository struct {
+	db *sql.DB
+}
+
+// NewClubMembershipEventRepository creates a new ClubMembershipEventRepository
+func NewClubMembershipEventRepository(db *sql.DB) *ClubMembershipEventRepository {
+	return &ClubMembershipEventRepository{db: db}
+}
+
+// Create records a join or a leave of a member at the given time
+func (r *ClubMembershipEventRepository) Create(
+	userID int,
+	event constants.ClubMembershipEvent,
+	inviteLink string,
+	inviteLinkName string,
+	createdAt time.Time,
+) error {
+	query := `
+		INSERT INTO club_membership_events (user_id, event, invite_link, invite_link_name, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	if _, err := r.db.Exec(query, userID, event, inviteLink, inviteLinkName, createdAt); err != nil {
+		return fmt.Errorf("%s: failed to insert %s event for user %d: %w", utils.GetCurrentTypeName(), event, userID, err)
+	}
+	return nil
+}
+
+// GetByUserID retrieves the joins and leaves of a user, newest first
+func (r *ClubMembershipEventRepository) GetByUserID(userID int) ([]ClubMembershipEvent, error) {
+	query := `
+		SELECT id, user_id, event, invite_link, invite_link_name, created_at
+		FROM club_membership_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query membership events for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	defer rows.Close()
+
+	var events []ClubMembershipEvent
+	for rows.Next() {
+		var e ClubMembershipEvent
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Event, &e.InviteLink, &e.InviteLinkName, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan membership event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for membership events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventFeedback represents a row in the event_feedback table: a member's rating of a finished event
+type EventFeedback struct {
+	EventID   int
+	UserID    int
+	Rating    int // 1 to 5
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// EventFeedbackReport holds the ratings of an event, comments are kept without their authors
+type EventFeedbackReport struct {
+	Ratings       int
+	AverageRating float64
+	RatingCounts  [5]int // Number of ratings of 1 to 5 stars
+	Comments      []EventFeedback
+}
+
+// EventFeedbackSummary holds the key numbers of a finished event, used to compare events with each other
+type EventFeedbackSummary struct {
+	EventID       int
+	Name          string
+	Type          string
+	TypeInfo      EventType // Display fields of the type, populated from the event_types table
+	StartedAt     time.Time // Planned start, or the moment the event was marked finished
+	Ratings       int
+	AverageRating float64
+	Going         int
+	Topics        int // Approved topics, the ones discussed at the event
+}
+
+// EventFeedbackRepository handles database operations for the feedback on finished events
+type EventFeedbackRepository struct {
+	db *sql.DB
+}
+
+// NewEventFeedbackRepository creates a new EventFeedbackRepository
+func NewEventFeedbackRepository(db *sql.DB) *EventFeedbackRepository {
+	return &EventFeedbackRepository{db: db}
+}
+
+// MarkRequested records the feedback form of the event as sent. Returns false if it had already been recorded,
+// so the caller can claim the form before sending it and never send it twice.
+func (r *EventFeedbackRepository) MarkRequested(eventID int) (bool, error) {
+	query := `INSERT INTO event_feedback_requests (event_id) VALUES ($1) ON CONFLICT (event_id) DO NOTHING`
+	result, err := r.db.Exec(query, eventID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to mark feedback request for event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to get affected rows: %w", utils.GetCurrentTypeName(), err)
+	}
+	return inserted > 0, nil
+}
+
+// SaveRating stores the member's rating of the event, replacing the previous one and keeping the comment
+func (r *EventFeedbackRepository) SaveRating(eventID int, userID int, rating int) error {
+	query := `
+		INSERT INTO event_feedback (event_id, user_id, rating)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET rating = EXCLUDED.rating, updated_at = NOW()`
+	if _, err := r.db.Exec(query, eventID, userID, rating); err != nil {
+		return fmt.Errorf("%s: failed to save rating of user %d for event %d: %w", utils.GetCurrentTypeName(), userID, eventID, err)
+	}
+	return nil
+}
+
+// SaveComment stores the member's comment on the event. The member has to rate the event first.
+func (r *EventFeedbackRepository) SaveComment(eventID int, userID int, comment string) error {
+	query := `UPDATE event_feedback SET comment = $1, updated_at = NOW() WHERE event_id = $2 AND user_id = $3`
+	result, err := r.db.Exec(query, comment, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save comment of user %d for event %d: %w", utils.GetCurrentTypeName(), userID, eventID, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get affected rows: %w", utils.GetCurrentTypeName(), err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%s: user %d hasn't rated event %d", utils.GetCurrentTypeName(), userID, eventID)
+	}
+	return nil
+}
+
+// GetReport returns the ratings of the event and its comments, newest first
+func (r *EventFeedbackRepository) GetReport(eventID int) (*EventFeedbackReport, error) {
+	query := `
+		SELECT event_id, user_id, rating, comment, created_at, updated_at
+		FROM event_feedback
+		WHERE event_id = $1
+		ORDER BY updated_at DESC`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get feedback of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	defer rows.Close()
+
+	var report EventFeedbackReport
+	total := 0
+	for rows.Next() {
+		var f EventFeedback
+		if err := rows.Scan(&f.EventID, &f.UserID, &f.Rating, &f.Comment, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan feedback: %w", utils.GetCurrentTypeName(), err)
+		}
+		report.Ratings++
+		report.RatingCounts[f.Rating-1]++
+		total += f.Rating
+		if f.Comment != "" {
+			report.Comments = append(report.Comments, f)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for feedback: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if report.Ratings > 0 {
+		report.AverageRating = float64(total) / float64(report.Ratings)
+	}
+	return &report, nil
+}
+
+// GetSummaries returns the summaries of the last finished events, newest first.
+// If eventType is not empty, only the events of that type are returned.
+func (r *EventFeedbackRepository) GetSummaries(eventType constants.EventType, limit int) ([]EventFeedbackSummary, error) {
+	query := `
+		SELECT e.id, e.name, e.type, COALESCE(et.name, ''), COALESCE(et.emoji, ''), COALESCE(e.started_at, e.updated_at),
+			COUNT(f.user_id), COALESCE(AVG(f.rating), 0),
+			(SELECT COUNT(*) FROM event_rsvps r WHERE r.event_id = e.id AND r.status = $1),
+			(SELECT COUNT(*) FROM topics t WHERE t.event_id = e.id AND t.status = $2)
+		FROM events e
+		LEFT JOIN event_feedback f ON f.event_id = e.id
+		LEFT JOIN event_types et ON et.slug = e.type
+		WHERE e.status = $3
+			AND ($4::text = '' OR e.type = $4::text)
+		GROUP BY e.id, et.slug
+		ORDER BY COALESCE(e.started_at, e.updated_at) DESC
+		LIMIT $5`
+
+	rows, err := r.db.Query(query,
+		constants.EventRSVPStatusGoing, constants.TopicStatusApproved, constants.EventStatusFinished, eventType, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get event summaries: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var summaries []EventFeedbackSummary
+	for rows.Next() {
+		var s EventFeedbackSummary
+		if err := rows.Scan(&s.EventID, &s.Name, &s.Type, &s.TypeInfo.Name, &s.TypeInfo.Emoji, &s.StartedAt,
+			&s.Ratings, &s.AverageRating, &s.Going, &s.Topics); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event summary: %w", utils.GetCurrentTypeName(), err)
+		}
+		s.TypeInfo.Slug = constants.EventType(s.Type)
+		summaries = append(summaries, s)
+	}
+
+	return summaries, rows.Err()
+}
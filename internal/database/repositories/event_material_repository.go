@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventMaterial represents a row in the event_materials table: a recording, slides or a link of a finished event
+type EventMaterial struct {
+	ID          int
+	EventID     int
+	Kind        constants.EventMaterialKind
+	Description string
+	URL         string // Empty for files sent to the bot
+	MessageID   int64  // Post of the material in the Content topic
+	CreatedAt   time.Time
+}
+
+// EventMaterialRepository handles database operations for event materials
+type EventMaterialRepository struct {
+	db *sql.DB
+}
+
+// NewEventMaterialRepository creates a new EventMaterialRepository
+func NewEventMaterialRepository(db *sql.DB) *EventMaterialRepository {
+	return &EventMaterialRepository{db: db}
+}
+
+// Create inserts a new material of the event
+func (r *EventMaterialRepository) Create(
+	eventID int,
+	kind constants.EventMaterialKind,
+	description string,
+	url string,
+	messageID int64,
+) (int, error) {
+	var id int
+	query := `
+		INSERT INTO event_materials (event_id, kind, description, url, message_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+	err := r.db.QueryRow(query, eventID, kind, description, url, messageID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to insert material of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	return id, nil
+}
+
+// GetByEventID returns the materials of the event in the order they were added
+func (r *EventMaterialRepository) GetByEventID(eventID int) ([]EventMaterial, error) {
+	query := `
+		SELECT id, event_id, kind, description, url, message_id, created_at
+		FROM event_materials
+		WHERE event_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get materials of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	defer rows.Close()
+
+	var materials []EventMaterial
+	for rows.Next() {
+		var m EventMaterial
+		if err := rows.Scan(&m.ID, &m.EventID, &m.Kind, &m.Description, &m.URL, &m.MessageID, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan material: %w", utils.GetCurrentTypeName(), err)
+		}
+		materials = append(materials, m)
+	}
+
+	return materials, rows.Err()
+}
@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventProposal represents a row in the event_proposals table: an event suggested by a member
+type EventProposal struct {
+	ID             int
+	UserID         int
+	Title          string
+	Type           constants.EventType
+	TypeInfo       EventType // Display fields of the type, populated from the event_types table
+	Description    string
+	PreferredDates string // Free text, e.g. "any Thursday evening in May"
+	Status         constants.EventProposalStatus
+	AdminComment   string // Reason of the rejection or the changes requested
+	EventID        *int   // Set once the proposal is approved
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+const eventProposalSelectQuery = `
+	SELECT p.id, p.user_id, p.title, p.type, p.description, p.preferred_dates, p.status, p.admin_comment, p.event_id,
+		p.created_at, p.updated_at, COALESCE(et.name, ''), COALESCE(et.emoji, '')
+	FROM event_proposals p
+	LEFT JOIN event_types et ON et.slug = p.type`
+
+// EventProposalRepository handles database operations for event proposals
+type EventProposalRepository struct {
+	db *sql.DB
+}
+
+// NewEventProposalRepository creates a new EventProposalRepository
+func NewEventProposalRepository(db *sql.DB) *EventProposalRepository {
+	return &EventProposalRepository{db: db}
+}
+
+// Create inserts a new proposal, it stays pending until an administrator reviews it
+func (r *EventProposalRepository) Create(
+	userID int,
+	title string,
+	eventType constants.EventType,
+	description string,
+	preferredDates string,
+) (int, error) {
+	var id int
+	query := `
+		INSERT INTO event_proposals (user_id, title, type, description, preferred_dates, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	err := r.db.QueryRow(query, userID, title, eventType, description, preferredDates, constants.EventProposalStatusPending).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to insert event proposal: %w", utils.GetCurrentTypeName(), err)
+	}
+	return id, nil
+}
+
+// Revise replaces the details of a proposal the administrators asked to change and sends it back for review
+func (r *EventProposalRepository) Revise(
+	id int,
+	title string,
+	eventType constants.EventType,
+	description string,
+	preferredDates string,
+) error {
+	query := `
+		UPDATE event_proposals
+		SET title = $1, type = $2, description = $3, preferred_dates = $4, status = $5, updated_at = NOW()
+		WHERE id = $6 AND status = $7`
+	return r.execProposalUpdate(query, id, title, eventType, description, preferredDates,
+		constants.EventProposalStatusPending, id, constants.EventProposalStatusChangesRequested)
+}
+
+// UpdateReviewStatus sets the result of the review of a pending proposal.
+// Fails if the proposal has already been reviewed, so two administrators can't review it twice.
+func (r *EventProposalRepository) UpdateReviewStatus(
+	id int,
+	status constants.EventProposalStatus,
+	adminComment string,
+	eventID *int,
+) error {
+	query := `
+		UPDATE event_proposals
+		SET status = $1, admin_comment = $2, event_id = $3, updated_at = NOW()
+		WHERE id = $4 AND status = $5`
+	return r.execProposalUpdate(query, id, status, adminComment, eventID, id, constants.EventProposalStatusPending)
+}
+
+// GetByID retrieves a proposal by its ID
+func (r *EventProposalRepository) GetByID(id int) (*EventProposal, error) {
+	proposal, err := scanEventProposal(r.db.QueryRow(eventProposalSelectQuery+` WHERE p.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no event proposal found with ID %d", utils.GetCurrentTypeName(), id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get event proposal %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+	return proposal, nil
+}
+
+// GetPending retrieves the proposals waiting for review, the oldest first
+func (r *EventProposalRepository) GetPending(limit int) ([]EventProposal, error) {
+	query := eventProposalSelectQuery + `
+		WHERE p.status = $1
+		ORDER BY p.updated_at ASC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, constants.EventProposalStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query pending event proposals: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var proposals []EventProposal
+	for rows.Next() {
+		proposal, err := scanEventProposal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event proposal row: %w", utils.GetCurrentTypeName(), err)
+		}
+		proposals = append(proposals, *proposal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for event proposals: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return proposals, nil
+}
+
+func (r *EventProposalRepository) execProposalUpdate(query string, id int, args ...any) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update event proposal %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: could not get rows affected after update: %w", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: event proposal %d not found or already reviewed", utils.GetCurrentTypeName(), id)
+	}
+
+	return nil
+}
+
+func scanEventProposal(scanner interface{ Scan(dest ...any) error }) (*EventProposal, error) {
+	var proposal EventProposal
+	err := scanner.Scan(
+		&proposal.ID,
+		&proposal.UserID,
+		&proposal.Title,
+		&proposal.Type,
+		&proposal.Description,
+		&proposal.PreferredDates,
+		&proposal.Status,
+		&proposal.AdminComment,
+		&proposal.EventID,
+		&proposal.CreatedAt,
+		&proposal.UpdatedAt,
+		&proposal.TypeInfo.Name,
+		&proposal.TypeInfo.Emoji,
+	)
+	if err != nil {
+		return nil, err
+	}
+	proposal.TypeInfo.Slug = proposal.Type
+	return &proposal, nil
+}
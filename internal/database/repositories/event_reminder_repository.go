@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventReminderRepository keeps track of the reminders already sent for events
+type EventReminderRepository struct {
+	db *sql.DB
+}
+
+func NewEventReminderRepository(db *sql.DB) *EventReminderRepository {
+	return &EventReminderRepository{db: db}
+}
+
+// MarkSent records the reminder as sent. Returns false if it had already been recorded,
+// so the caller can claim a reminder before sending it and never send it twice.
+func (r *EventReminderRepository) MarkSent(eventID int, startedAt time.Time, offsetMinutes int) (bool, error) {
+	query := `
+		INSERT INTO event_reminders (event_id, started_at, offset_minutes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, started_at, offset_minutes) DO NOTHING`
+	result, err := r.db.Exec(query, eventID, startedAt, offsetMinutes)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to mark reminder for event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to get affected rows: %w", utils.GetCurrentTypeName(), err)
+	}
+	return inserted > 0, nil
+}
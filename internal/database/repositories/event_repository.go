@@ -11,15 +11,59 @@ import (
 
 // Event represents a row in the events table
 type Event struct {
-	ID        int
-	Name      string
-	Type      string
-	Status    string
-	StartedAt *time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              int
+	Name            string
+	Type            string
+	Status          string
+	StartedAt       *time.Time // Planned start time, set by admins in /eventSetup and /eventEdit
+	DurationMinutes *int
+	Description     string
+	Location        string // Address for offline events
+	Link            string // Join link for online events
+	HostUserID      *int
+	Capacity        *int // nil means unlimited
+	SeriesID        *int // Set for occurrences of a recurring event
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Sequence        int // iCalendar SEQUENCE, incremented by a trigger on every update
+
+	// Host display fields, populated from the users table
+	HostFirstname string
+	HostUsername  string
+
+	// RecurrenceRule is the rule of the event's series, populated from the event_series table
+	RecurrenceRule string
+
+	// TypeInfo holds the display fields of the type, populated from the event_types table
+	TypeInfo EventType
 }
 
+// CancelledEvent represents a row in the cancelled_events table: a deleted event that had a planned start
+type CancelledEvent struct {
+	EventID         int
+	Name            string
+	StartedAt       time.Time
+	DurationMinutes *int
+	CancelledAt     time.Time
+	Sequence        int
+}
+
+// eventSelectColumns and eventSelectFrom select events together with their host's display fields,
+// series rule and type, to be scanned by scanEvent
+const (
+	eventSelectColumns = `
+		e.id, e.name, e.type, e.status, e.started_at, e.duration_minutes, e.description, e.location, e.link,
+		e.host_user_id, e.capacity, e.series_id, e.created_at, e.updated_at, e.sequence,
+		COALESCE(host.firstname, ''), COALESCE(host.tg_username, ''), COALESCE(series.rule, ''),
+		COALESCE(et.name, ''), COALESCE(et.emoji, ''), COALESCE(et.announcement_template, ''), COALESCE(et.rules_link, '')`
+	eventSelectFrom = `
+	FROM events e
+	LEFT JOIN users host ON host.id = e.host_user_id
+	LEFT JOIN event_series series ON series.id = e.series_id
+	LEFT JOIN event_types et ON et.slug = e.type`
+	eventSelectQuery = `SELECT` + eventSelectColumns + eventSelectFrom
+)
+
 // EventRepository handles database operations for events
 type EventRepository struct {
 	db              *sql.DB
@@ -58,11 +102,9 @@ func (r *EventRepository) CreateEventWithStartedAt(name string, eventType consta
 
 // GetLastActualEvents retrieves the last N actual event records
 func (r *EventRepository) GetLastActualEvents(limit int) ([]Event, error) {
-	query := `
-		SELECT id, name, type, status, started_at, created_at, updated_at
-		FROM events
-		WHERE status = $1
-		ORDER BY started_at ASC NULLS LAST
+	query := eventSelectQuery + `
+		WHERE e.status = $1
+		ORDER BY e.started_at ASC NULLS LAST
 		LIMIT $2`
 
 	rows, err := r.db.Query(query, constants.EventStatusActual, limit)
@@ -73,11 +115,195 @@ func (r *EventRepository) GetLastActualEvents(limit int) ([]Event, error) {
 
 	var events []Event
 	for rows.Next() {
-		var e Event
-		if err := rows.Scan(&e.ID, &e.Name, &e.Type, &e.Status, &e.StartedAt, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		e, err := scanEvent(rows)
+		if err != nil {
 			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
 		}
-		events = append(events, e)
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetLastActualEventsWithNextOccurrences retrieves the last N actual event records like GetLastActualEvents,
+// but recurring events are represented only by their next occurrence
+func (r *EventRepository) GetLastActualEventsWithNextOccurrences(limit int) ([]Event, error) {
+	query := eventSelectQuery + `
+		WHERE e.status = $1
+			AND (e.series_id IS NULL OR e.id = (
+				SELECT o.id FROM events o
+				WHERE o.series_id = e.series_id AND o.status = $1
+				ORDER BY o.started_at ASC NULLS LAST, o.id ASC
+				LIMIT 1
+			))
+		ORDER BY e.started_at ASC NULLS LAST
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, constants.EventStatusActual, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query last events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetActualEventsStartingBetween retrieves actual events planned to start within [from, to], soonest first
+func (r *EventRepository) GetActualEventsStartingBetween(from time.Time, to time.Time) ([]Event, error) {
+	query := eventSelectQuery + `
+		WHERE e.status = $1
+			AND e.started_at BETWEEN $2 AND $3
+		ORDER BY e.started_at ASC`
+
+	rows, err := r.db.Query(query, constants.EventStatusActual, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query upcoming events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetFinishedEventsEndedBetween retrieves finished events that ended within [from, to], earliest first.
+// An event ends its duration after the planned start, or after defaultDuration if it has none.
+// Events started without a planned start are counted from the moment they were marked finished.
+func (r *EventRepository) GetFinishedEventsEndedBetween(from time.Time, to time.Time, defaultDuration time.Duration) ([]Event, error) {
+	endedAt := `COALESCE(e.started_at, e.updated_at) + make_interval(mins => COALESCE(e.duration_minutes, $4))`
+	query := eventSelectQuery + `
+		WHERE e.status = $1
+			AND ` + endedAt + ` BETWEEN $2 AND $3
+		ORDER BY ` + endedAt + ` ASC`
+
+	rows, err := r.db.Query(query, constants.EventStatusFinished, from, to, int(defaultDuration.Minutes()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query finished events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetEventsStartingSince retrieves events of any status planned to start at or after since, soonest first
+func (r *EventRepository) GetEventsStartingSince(since time.Time) ([]Event, error) {
+	query := eventSelectQuery + `
+		WHERE e.started_at >= $1
+		ORDER BY e.started_at ASC`
+
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetActualSeriesEventsStartingSince retrieves the actual occurrences of a series planned to start at or after since
+func (r *EventRepository) GetActualSeriesEventsStartingSince(seriesID int, since time.Time) ([]Event, error) {
+	query := eventSelectQuery + `
+		WHERE e.series_id = $1
+			AND e.status = $2
+			AND e.started_at >= $3
+		ORDER BY e.started_at ASC`
+
+	rows, err := r.db.Query(query, seriesID, constants.EventStatusActual, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query events of series %d: %w", utils.GetCurrentTypeName(), seriesID, err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
+// GetLastFinishedEvents retrieves the last N finished events, the most recent first
+func (r *EventRepository) GetLastFinishedEvents(limit int) ([]Event, error) {
+	query := eventSelectQuery + `
+		WHERE e.status = $1
+		ORDER BY COALESCE(e.started_at, e.updated_at) DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, constants.EventStatusFinished, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query finished events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, *e)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -89,10 +315,8 @@ func (r *EventRepository) GetLastActualEvents(limit int) ([]Event, error) {
 
 // GetLastEvents retrieves the last N event records
 func (r *EventRepository) GetLastEvents(limit int) ([]Event, error) {
-	query := `
-		SELECT id, name, type, status, started_at, created_at, updated_at
-		FROM events
-		ORDER BY started_at DESC NULLS LAST
+	query := eventSelectQuery + `
+		ORDER BY e.started_at DESC NULLS LAST
 		LIMIT $1`
 
 	rows, err := r.db.Query(query, limit)
@@ -103,11 +327,11 @@ func (r *EventRepository) GetLastEvents(limit int) ([]Event, error) {
 
 	var events []Event
 	for rows.Next() {
-		var e Event
-		if err := rows.Scan(&e.ID, &e.Name, &e.Type, &e.Status, &e.StartedAt, &e.CreatedAt, &e.UpdatedAt); err != nil {
+		e, err := scanEvent(rows)
+		if err != nil {
 			return nil, fmt.Errorf("%s: failed to scan event row: %w", utils.GetCurrentTypeName(), err)
 		}
-		events = append(events, e)
+		events = append(events, *e)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -205,9 +429,24 @@ func (r *EventRepository) DeleteEvent(id int) error {
 		}
 	}
 
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	// Keep scheduled events as cancelled, so subscribed calendars remove them
+	_, err = tx.Exec(`
+		INSERT INTO cancelled_events (event_id, name, started_at, duration_minutes, sequence)
+		SELECT id, name, started_at, duration_minutes, sequence + 1 FROM events WHERE id = $1 AND started_at IS NOT NULL
+		ON CONFLICT (event_id) DO NOTHING`, id)
+	if err != nil {
+		return fmt.Errorf("%s: failed to record cancellation of event with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
 	// Now delete the event itself
 	query := `DELETE FROM events WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := tx.Exec(query, id)
 	if err != nil {
 		return fmt.Errorf("%s: failed to delete event with ID %d: %w", utils.GetCurrentTypeName(), id, err)
 	}
@@ -219,34 +458,135 @@ func (r *EventRepository) DeleteEvent(id int) error {
 		return fmt.Errorf("%s: no event found with ID %d to delete", utils.GetCurrentTypeName(), id)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit deletion of event with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
 	return nil
 }
 
+// GetCancelledEventsSince retrieves deleted events that were planned to start at or after since
+func (r *EventRepository) GetCancelledEventsSince(since time.Time) ([]CancelledEvent, error) {
+	query := `
+		SELECT event_id, name, started_at, duration_minutes, cancelled_at, sequence
+		FROM cancelled_events
+		WHERE started_at >= $1
+		ORDER BY started_at ASC`
+
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query cancelled events: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var events []CancelledEvent
+	for rows.Next() {
+		var e CancelledEvent
+		if err := rows.Scan(&e.EventID, &e.Name, &e.StartedAt, &e.DurationMinutes, &e.CancelledAt, &e.Sequence); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan cancelled event row: %w", utils.GetCurrentTypeName(), err)
+		}
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for cancelled events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return events, nil
+}
+
 // GetEventByID retrieves a single event record by its ID
 func (r *EventRepository) GetEventByID(id int) (*Event, error) {
-	query := `
-		SELECT id, name, type, status, started_at, created_at, updated_at
-		FROM events
-		WHERE id = $1`
+	query := eventSelectQuery + `
+		WHERE e.id = $1`
+
+	event, err := scanEvent(r.db.QueryRow(query, id))
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no event found with ID %d", utils.GetCurrentTypeName(), id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get event with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
+	return event, nil
+}
+
+// UpdateEventDuration updates the planned duration of an event, nil clears it
+func (r *EventRepository) UpdateEventDuration(id int, durationMinutes *int) error {
+	return r.updateEvent(id, "duration", "duration_minutes = $1", durationMinutes)
+}
+
+// UpdateEventDescription updates the description of an event
+func (r *EventRepository) UpdateEventDescription(id int, description string) error {
+	return r.updateEvent(id, "description", "description = $1", description)
+}
+
+// UpdateEventPlace updates where the event takes place: an address for offline events and/or a join link for online ones
+func (r *EventRepository) UpdateEventPlace(id int, location string, link string) error {
+	return r.updateEvent(id, "place", "location = $1, link = $2", location, link)
+}
+
+// UpdateEventHost updates the host of an event, nil clears it
+func (r *EventRepository) UpdateEventHost(id int, hostUserID *int) error {
+	return r.updateEvent(id, "host", "host_user_id = $1", hostUserID)
+}
+
+// UpdateEventCapacity updates the capacity of an event, nil means unlimited
+func (r *EventRepository) UpdateEventCapacity(id int, capacity *int) error {
+	return r.updateEvent(id, "capacity", "capacity = $1", capacity)
+}
+
+// updateEvent applies the given SET clause to an event, args are bound to the clause placeholders in order
+func (r *EventRepository) updateEvent(id int, fieldName string, setClause string, args ...interface{}) error {
+	query := fmt.Sprintf(`UPDATE events SET %s, updated_at = NOW() WHERE id = $%d`, setClause, len(args)+1)
+	result, err := r.db.Exec(query, append(args, id)...)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update event %s for ID %d: %w", utils.GetCurrentTypeName(), fieldName, id, err)
+	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("%s: Could not get rows affected after update: %v", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no event found with ID %d to update %s", utils.GetCurrentTypeName(), id, fieldName)
+	}
+
+	return nil
+}
+
+// scanEvent scans a row selected with eventSelectColumns, followed by the extra columns if any
+func scanEvent(scanner interface{ Scan(dest ...any) error }, extra ...any) (*Event, error) {
 	var event Event
-	err := r.db.QueryRow(query, id).Scan(
+	dest := []any{
 		&event.ID,
 		&event.Name,
 		&event.Type,
 		&event.Status,
 		&event.StartedAt,
+		&event.DurationMinutes,
+		&event.Description,
+		&event.Location,
+		&event.Link,
+		&event.HostUserID,
+		&event.Capacity,
+		&event.SeriesID,
 		&event.CreatedAt,
 		&event.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("%s: no event found with ID %d", utils.GetCurrentTypeName(), id)
+		&event.Sequence,
+		&event.HostFirstname,
+		&event.HostUsername,
+		&event.RecurrenceRule,
+		&event.TypeInfo.Name,
+		&event.TypeInfo.Emoji,
+		&event.TypeInfo.AnnouncementTemplate,
+		&event.TypeInfo.RulesLink,
 	}
-
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to get event with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	if err := scanner.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
 	}
+	event.TypeInfo.Slug = constants.EventType(event.Type)
 
 	return &event, nil
 }
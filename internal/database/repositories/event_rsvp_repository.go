@@ -0,0 +1,363 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventRSVPCounts holds the number of responses of each kind for an event
+type EventRSVPCounts struct {
+	Going    int
+	Maybe    int
+	NotGoing int
+	Waitlist int
+}
+
+// EventRSVPResult describes the outcome of a member's response
+type EventRSVPResult struct {
+	Status           constants.EventRSVPStatus // Final status, "waitlist" if the member asked to go but the event is full
+	PreviousStatus   constants.EventRSVPStatus // Empty if the member hasn't responded before
+	WaitlistPosition int                       // 1-based position, set only for the waitlist status
+	PromotedUserIDs  []int                     // Waitlisted members who got the freed seats
+}
+
+// EventAttendee is a member who responded to an event
+type EventAttendee struct {
+	User      User
+	Status    constants.EventRSVPStatus
+	UpdatedAt time.Time
+}
+
+// EventRSVP is a member's response to one of the upcoming events
+type EventRSVP struct {
+	Event            Event
+	Status           constants.EventRSVPStatus
+	WaitlistPosition int // 1-based position, set only for the waitlist status
+}
+
+type EventRSVPRepository struct {
+	db *sql.DB
+}
+
+func NewEventRSVPRepository(db *sql.DB) *EventRSVPRepository {
+	return &EventRSVPRepository{db: db}
+}
+
+// Respond stores the member's response. Asking to go to a full event puts the member on the waitlist,
+// and a seat freed by a member who was going is given to the first members on the waitlist.
+// The event row is locked for the duration and the capacity is read under the lock, so neither concurrent
+// responses nor a concurrent capacity change can make the event exceed its capacity.
+func (r *EventRSVPRepository) Respond(eventID int, userID int, status constants.EventRSVPStatus) (*EventRSVPResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	capacity, err := lockEventCapacity(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventRSVPResult{Status: status}
+	err = tx.QueryRow(`SELECT status FROM event_rsvps WHERE event_id = $1 AND user_id = $2`, eventID, userID).
+		Scan(&result.PreviousStatus)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: failed to get current response: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if status == constants.EventRSVPStatusGoing && capacity != nil {
+		switch result.PreviousStatus {
+		case constants.EventRSVPStatusGoing:
+			// Already has a seat
+		case constants.EventRSVPStatusWaitlist:
+			// Keeps the place in the queue
+			result.Status = constants.EventRSVPStatusWaitlist
+		default:
+			going, err := countByStatus(tx, eventID, constants.EventRSVPStatusGoing)
+			if err != nil {
+				return nil, err
+			}
+			if going >= *capacity {
+				result.Status = constants.EventRSVPStatusWaitlist
+			}
+		}
+	}
+
+	// updated_at changes only with the status, so it keeps the order of the waitlist
+	_, err = tx.Exec(`
+		INSERT INTO event_rsvps (event_id, user_id, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, user_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			updated_at = CASE WHEN event_rsvps.status <> EXCLUDED.status THEN NOW() ELSE event_rsvps.updated_at END`,
+		eventID, userID, result.Status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save response: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if result.PreviousStatus == constants.EventRSVPStatusGoing && result.Status != constants.EventRSVPStatusGoing {
+		result.PromotedUserIDs, err = promoteWaitlisted(tx, eventID, capacity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Status == constants.EventRSVPStatusWaitlist {
+		result.WaitlistPosition, err = waitlistPosition(tx, eventID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit response: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return result, nil
+}
+
+// PromoteWaitlisted gives free seats to the members on the waitlist, e.g. after the capacity was increased
+func (r *EventRSVPRepository) PromoteWaitlisted(eventID int) ([]int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	capacity, err := lockEventCapacity(tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	promotedUserIDs, err := promoteWaitlisted(tx, eventID, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit waitlist promotion: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return promotedUserIDs, nil
+}
+
+// GetUserStatus returns the member's response to the event, empty if there is none
+func (r *EventRSVPRepository) GetUserStatus(eventID int, userID int) (constants.EventRSVPStatus, error) {
+	var status constants.EventRSVPStatus
+	err := r.db.QueryRow(`SELECT status FROM event_rsvps WHERE event_id = $1 AND user_id = $2`, eventID, userID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get response: %w", utils.GetCurrentTypeName(), err)
+	}
+	return status, nil
+}
+
+// GetWaitlistPosition returns the member's 1-based position on the waitlist, 0 if they are not on it
+func (r *EventRSVPRepository) GetWaitlistPosition(eventID int, userID int) (int, error) {
+	return waitlistPosition(r.db, eventID, userID)
+}
+
+// GetCounts returns the number of responses of each kind for the event
+func (r *EventRSVPRepository) GetCounts(eventID int) (EventRSVPCounts, error) {
+	var counts EventRSVPCounts
+	rows, err := r.db.Query(`SELECT status, COUNT(*) FROM event_rsvps WHERE event_id = $1 GROUP BY status`, eventID)
+	if err != nil {
+		return counts, fmt.Errorf("%s: failed to count responses: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status constants.EventRSVPStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return counts, fmt.Errorf("%s: failed to scan response count: %w", utils.GetCurrentTypeName(), err)
+		}
+		switch status {
+		case constants.EventRSVPStatusGoing:
+			counts.Going = count
+		case constants.EventRSVPStatusMaybe:
+			counts.Maybe = count
+		case constants.EventRSVPStatusNotGoing:
+			counts.NotGoing = count
+		case constants.EventRSVPStatusWaitlist:
+			counts.Waitlist = count
+		}
+	}
+
+	return counts, rows.Err()
+}
+
+// GetAttendees returns everyone who responded to the event: going first, then the waitlist in order, maybe and not going
+func (r *EventRSVPRepository) GetAttendees(eventID int) ([]EventAttendee, error) {
+	query := `
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username, u.score, u.has_coffee_ban, u.is_club_member,
+			u.created_at, u.updated_at, r.status, r.updated_at
+		FROM event_rsvps r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.event_id = $1
+		ORDER BY
+			CASE r.status WHEN 'going' THEN 0 WHEN 'waitlist' THEN 1 WHEN 'maybe' THEN 2 ELSE 3 END,
+			r.updated_at, r.id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get attendees: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var attendees []EventAttendee
+	for rows.Next() {
+		var a EventAttendee
+		if err := rows.Scan(
+			&a.User.ID,
+			&a.User.TgID,
+			&a.User.Firstname,
+			&a.User.Lastname,
+			&a.User.TgUsername,
+			&a.User.Score,
+			&a.User.HasCoffeeBan,
+			&a.User.IsClubMember,
+			&a.User.CreatedAt,
+			&a.User.UpdatedAt,
+			&a.Status,
+			&a.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan attendee: %w", utils.GetCurrentTypeName(), err)
+		}
+		attendees = append(attendees, a)
+	}
+
+	return attendees, rows.Err()
+}
+
+// GetUpcomingResponsesForUser returns the member's going, maybe and waitlist responses to actual events, soonest first
+func (r *EventRSVPRepository) GetUpcomingResponsesForUser(userID int) ([]EventRSVP, error) {
+	query := `SELECT` + eventSelectColumns + `, r.status,
+			CASE WHEN r.status = 'waitlist' THEN (
+				SELECT COUNT(*) FROM event_rsvps w
+				WHERE w.event_id = r.event_id AND w.status = 'waitlist' AND (w.updated_at, w.id) <= (r.updated_at, r.id)
+			) ELSE 0 END` +
+		eventSelectFrom + `
+		JOIN event_rsvps r ON r.event_id = e.id
+		WHERE r.user_id = $1
+			AND e.status = $2
+			AND r.status IN ('going', 'maybe', 'waitlist')
+		ORDER BY e.started_at ASC NULLS LAST`
+
+	rows, err := r.db.Query(query, userID, constants.EventStatusActual)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get responses for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	defer rows.Close()
+
+	var responses []EventRSVP
+	for rows.Next() {
+		var rsvp EventRSVP
+		event, err := scanEvent(rows, &rsvp.Status, &rsvp.WaitlistPosition)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan response: %w", utils.GetCurrentTypeName(), err)
+		}
+		rsvp.Event = *event
+		responses = append(responses, rsvp)
+	}
+
+	return responses, rows.Err()
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func countByStatus(q queryer, eventID int, status constants.EventRSVPStatus) (int, error) {
+	var count int
+	err := q.QueryRow(`SELECT COUNT(*) FROM event_rsvps WHERE event_id = $1 AND status = $2`, eventID, status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count %s responses for event %d: %w", status, eventID, err)
+	}
+	return count, nil
+}
+
+func waitlistPosition(q queryer, eventID int, userID int) (int, error) {
+	var position int
+	err := q.QueryRow(`
+		SELECT COUNT(*)
+		FROM event_rsvps w
+		JOIN event_rsvps me ON me.event_id = w.event_id AND me.user_id = $2 AND me.status = 'waitlist'
+		WHERE w.event_id = $1
+			AND w.status = 'waitlist'
+			AND (w.updated_at, w.id) <= (me.updated_at, me.id)`,
+		eventID, userID,
+	).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get waitlist position for event %d: %w", eventID, err)
+	}
+	return position, nil
+}
+
+// promoteWaitlisted moves members from the waitlist to going while there are free seats, in the order they joined it
+// lockEventCapacity locks the event row until the end of the transaction and returns its capacity, nil if unlimited
+func lockEventCapacity(tx *sql.Tx, eventID int) (*int, error) {
+	var capacity sql.NullInt64
+	if err := tx.QueryRow(`SELECT capacity FROM events WHERE id = $1 FOR UPDATE`, eventID).Scan(&capacity); err != nil {
+		return nil, fmt.Errorf("%s: failed to lock event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	if !capacity.Valid {
+		return nil, nil
+	}
+	value := int(capacity.Int64)
+	return &value, nil
+}
+
+func promoteWaitlisted(q queryer, eventID int, capacity *int) ([]int, error) {
+	limitClause := ""
+	args := []any{eventID}
+	if capacity != nil {
+		going, err := countByStatus(q, eventID, constants.EventRSVPStatusGoing)
+		if err != nil {
+			return nil, err
+		}
+		freeSeats := *capacity - going
+		if freeSeats <= 0 {
+			return nil, nil
+		}
+		limitClause = "LIMIT $2"
+		args = append(args, freeSeats)
+	}
+
+	rows, err := q.Query(`
+		UPDATE event_rsvps SET status = 'going', updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM event_rsvps
+			WHERE event_id = $1 AND status = 'waitlist'
+			ORDER BY updated_at, id
+			`+limitClause+`
+		)
+		RETURNING user_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote waitlisted members for event %d: %w", eventID, err)
+	}
+	defer rows.Close()
+
+	var promotedUserIDs []int
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan promoted member: %w", err)
+		}
+		promotedUserIDs = append(promotedUserIDs, userID)
+	}
+
+	return promotedUserIDs, rows.Err()
+}
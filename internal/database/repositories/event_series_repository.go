@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventSeries represents a row in the event_series table: a recurring event and the details of its occurrences
+type EventSeries struct {
+	ID                int
+	Name              string
+	Type              string
+	Rule              string    // RRULE-style recurrence rule, e.g. "FREQ=WEEKLY;BYDAY=TH"
+	StartedAt         time.Time // Start of the first occurrence, the rule keeps its time of day
+	DurationMinutes   *int
+	Description       string
+	Location          string
+	Link              string
+	HostUserID        *int
+	Capacity          *int
+	MaterializedUntil time.Time // Start of the last occurrence created from the rule
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+const eventSeriesSelectQuery = `
+	SELECT id, name, type, rule, started_at, duration_minutes, description, location, link,
+		host_user_id, capacity, materialized_until, created_at, updated_at
+	FROM event_series`
+
+// EventSeriesRepository handles database operations for recurring events
+type EventSeriesRepository struct {
+	db *sql.DB
+}
+
+// NewEventSeriesRepository creates a new EventSeriesRepository
+func NewEventSeriesRepository(db *sql.DB) *EventSeriesRepository {
+	return &EventSeriesRepository{db: db}
+}
+
+// CreateFromEvent creates a series with the details of the event and makes the event its first occurrence
+func (r *EventSeriesRepository) CreateFromEvent(eventID int, rule string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	var seriesID int
+	err = tx.QueryRow(`
+		INSERT INTO event_series (
+			name, type, rule, started_at, duration_minutes, description, location, link,
+			host_user_id, capacity, materialized_until
+		)
+		SELECT name, type, $2, started_at, duration_minutes, description, location, link,
+			host_user_id, capacity, started_at
+		FROM events
+		WHERE id = $1 AND started_at IS NOT NULL AND series_id IS NULL
+		RETURNING id`, eventID, rule).Scan(&seriesID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%s: event %d has no planned start or already repeats", utils.GetCurrentTypeName(), eventID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create series from event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE events SET series_id = $1, series_occurrence_at = started_at, updated_at = NOW()
+		WHERE id = $2`, seriesID, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to attach event %d to series %d: %w", utils.GetCurrentTypeName(), eventID, seriesID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit series of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	return seriesID, nil
+}
+
+// GetByID retrieves a series by its ID
+func (r *EventSeriesRepository) GetByID(id int) (*EventSeries, error) {
+	series, err := scanEventSeries(r.db.QueryRow(eventSeriesSelectQuery+` WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no series found with ID %d", utils.GetCurrentTypeName(), id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get series with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+	return series, nil
+}
+
+// GetAll retrieves all series
+func (r *EventSeriesRepository) GetAll() ([]EventSeries, error) {
+	rows, err := r.db.Query(eventSeriesSelectQuery + ` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query series: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var seriesList []EventSeries
+	for rows.Next() {
+		series, err := scanEventSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan series row: %w", utils.GetCurrentTypeName(), err)
+		}
+		seriesList = append(seriesList, *series)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for series: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return seriesList, nil
+}
+
+// AddOccurrences creates events for the occurrences with the details of the series
+// and moves materialized_until to the last of them
+func (r *EventSeriesRepository) AddOccurrences(series EventSeries, occurrences []time.Time) ([]int, error) {
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	var eventIDs []int
+	for _, occurrence := range occurrences {
+		var eventID int
+		err := tx.QueryRow(`
+			INSERT INTO events (
+				name, type, status, started_at, duration_minutes, description, location, link,
+				host_user_id, capacity, series_id, series_occurrence_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $4)
+			ON CONFLICT (series_id, series_occurrence_at) DO NOTHING
+			RETURNING id`,
+			series.Name, series.Type, constants.EventStatusActual, occurrence, series.DurationMinutes,
+			series.Description, series.Location, series.Link, series.HostUserID, series.Capacity, series.ID,
+		).Scan(&eventID)
+		if err == sql.ErrNoRows {
+			// The occurrence already exists
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to create occurrence of series %d: %w", utils.GetCurrentTypeName(), series.ID, err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	_, err = tx.Exec(`UPDATE event_series SET materialized_until = $1, updated_at = NOW() WHERE id = $2`,
+		occurrences[len(occurrences)-1], series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update series %d: %w", utils.GetCurrentTypeName(), series.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit occurrences of series %d: %w", utils.GetCurrentTypeName(), series.ID, err)
+	}
+
+	return eventIDs, nil
+}
+
+// Delete removes a series, its existing occurrences stay as one-time events
+func (r *EventSeriesRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM event_series WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete series with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err == nil && rowsAffected == 0 {
+		return fmt.Errorf("%s: no series found with ID %d to delete", utils.GetCurrentTypeName(), id)
+	}
+
+	return nil
+}
+
+func scanEventSeries(scanner interface{ Scan(dest ...any) error }) (*EventSeries, error) {
+	var series EventSeries
+	err := scanner.Scan(
+		&series.ID,
+		&series.Name,
+		&series.Type,
+		&series.Rule,
+		&series.StartedAt,
+		&series.DurationMinutes,
+		&series.Description,
+		&series.Location,
+		&series.Link,
+		&series.HostUserID,
+		&series.Capacity,
+		&series.MaterializedUntil,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// EventType represents a row in the event_types table, managed by admins with /eventTypes
+type EventType struct {
+	Slug                 constants.EventType // Stored in events.type
+	Name                 string
+	Emoji                string
+	AnnouncementTemplate string // Markdown header of the start announcement, the default one is used if empty
+	RulesLink            string // Linked from the start announcement if set
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+const eventTypeSelectQuery = `
+	SELECT slug, name, emoji, announcement_template, rules_link, created_at, updated_at
+	FROM event_types`
+
+// EventTypeRepository handles database operations for event types
+type EventTypeRepository struct {
+	db *sql.DB
+}
+
+// NewEventTypeRepository creates a new EventTypeRepository
+func NewEventTypeRepository(db *sql.DB) *EventTypeRepository {
+	return &EventTypeRepository{db: db}
+}
+
+// GetAll retrieves all event types, in the order they were added
+func (r *EventTypeRepository) GetAll() ([]EventType, error) {
+	rows, err := r.db.Query(eventTypeSelectQuery + ` ORDER BY created_at ASC, slug ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query event types: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var eventTypes []EventType
+	for rows.Next() {
+		eventType, err := scanEventType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan event type row: %w", utils.GetCurrentTypeName(), err)
+		}
+		eventTypes = append(eventTypes, *eventType)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for event types: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return eventTypes, nil
+}
+
+// GetBySlug retrieves an event type by its slug
+func (r *EventTypeRepository) GetBySlug(slug constants.EventType) (*EventType, error) {
+	eventType, err := scanEventType(r.db.QueryRow(eventTypeSelectQuery+` WHERE slug = $1`, slug))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no event type found with slug %s", utils.GetCurrentTypeName(), slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get event type %s: %w", utils.GetCurrentTypeName(), slug, err)
+	}
+	return eventType, nil
+}
+
+// Create inserts a new event type
+func (r *EventTypeRepository) Create(slug constants.EventType, name string, emoji string) error {
+	query := `INSERT INTO event_types (slug, name, emoji) VALUES ($1, $2, $3)`
+	if _, err := r.db.Exec(query, slug, name, emoji); err != nil {
+		return fmt.Errorf("%s: failed to insert event type %s: %w", utils.GetCurrentTypeName(), slug, err)
+	}
+	return nil
+}
+
+// Update replaces the display fields of an event type, the slug can't be changed
+func (r *EventTypeRepository) Update(eventType EventType) error {
+	query := `
+		UPDATE event_types
+		SET name = $1, emoji = $2, announcement_template = $3, rules_link = $4, updated_at = NOW()
+		WHERE slug = $5`
+	result, err := r.db.Exec(query,
+		eventType.Name, eventType.Emoji, eventType.AnnouncementTemplate, eventType.RulesLink, eventType.Slug)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update event type %s: %w", utils.GetCurrentTypeName(), eventType.Slug, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: could not get rows affected after update: %w", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no event type found with slug %s to update", utils.GetCurrentTypeName(), eventType.Slug)
+	}
+
+	return nil
+}
+
+// IsUsed reports whether any event, series or proposal has the type, such types can't be deleted
+func (r *EventTypeRepository) IsUsed(slug constants.EventType) (bool, error) {
+	var used bool
+	query := `
+		SELECT EXISTS (SELECT 1 FROM events WHERE type = $1)
+			OR EXISTS (SELECT 1 FROM event_series WHERE type = $1)
+			OR EXISTS (SELECT 1 FROM event_proposals WHERE type = $1)`
+	if err := r.db.QueryRow(query, slug).Scan(&used); err != nil {
+		return false, fmt.Errorf("%s: failed to check usage of event type %s: %w", utils.GetCurrentTypeName(), slug, err)
+	}
+	return used, nil
+}
+
+// Delete removes an event type, fails if any event, series or proposal still has it
+func (r *EventTypeRepository) Delete(slug constants.EventType) error {
+	result, err := r.db.Exec(`DELETE FROM event_types WHERE slug = $1`, slug)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete event type %s: %w", utils.GetCurrentTypeName(), slug, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: could not get rows affected after delete: %w", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no event type found with slug %s to delete", utils.GetCurrentTypeName(), slug)
+	}
+
+	return nil
+}
+
+func scanEventType(scanner interface{ Scan(dest ...any) error }) (*EventType, error) {
+	var eventType EventType
+	err := scanner.Scan(
+		&eventType.Slug,
+		&eventType.Name,
+		&eventType.Emoji,
+		&eventType.AnnouncementTemplate,
+		&eventType.RulesLink,
+		&eventType.CreatedAt,
+		&eventType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &eventType, nil
+}
@@ -326,3 +326,185 @@ func (r *GroupMessageRepository) DeleteByMessageID(messageID int64) error {
 
 	return nil
 }
+
+// GroupActivity holds the activity in the group during a day or a week
+type GroupActivity struct {
+	Start       time.Time
+	Messages    int
+	ActiveUsers int
+	NewSpeakers int // Users whose first saved message is in this day or week
+}
+
+// GroupTopicActivity holds the number of messages in a topic on a day
+type GroupTopicActivity struct {
+	TopicID   int64
+	TopicName string
+	Day       time.Time
+	Messages  int
+}
+
+// GroupStats holds the activity in the group since a time, the days and weeks are in the given timezone
+type GroupStats struct {
+	Daily          []GroupActivity
+	Weekly         []GroupActivity
+	Topics         []GroupTopicActivity
+	MessagesByHour [24]int
+	ActiveUsers    int
+	PostedMembers  int // Club members who have ever posted
+	ClubMembers    int
+}
+
+// GetGroupStats returns the activity in the group since the given time, for the /stats command.
+// Days and weeks without messages are included, so the activity has no gaps.
+func (r *GroupMessageRepository) GetGroupStats(since time.Time, timezone *time.Location) (*GroupStats, error) {
+	var stats GroupStats
+	var err error
+
+	if stats.Daily, err = r.getActivity(since, timezone, "day"); err != nil {
+		return nil, err
+	}
+	if stats.Weekly, err = r.getActivity(since, timezone, "week"); err != nil {
+		return nil, err
+	}
+	if stats.Topics, err = r.getTopicActivity(since, timezone); err != nil {
+		return nil, err
+	}
+	if stats.MessagesByHour, err = r.getMessagesByHour(since, timezone); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT COUNT(DISTINCT user_tg_id) FROM group_messages WHERE created_at >= $1`
+	if err := r.db.QueryRow(query, since).Scan(&stats.ActiveUsers); err != nil {
+		return nil, fmt.Errorf("%s: failed to count active users: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	query = `
+		SELECT
+			COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM group_messages gm WHERE gm.user_tg_id = u.tg_id)),
+			COUNT(*)
+		FROM users u
+		WHERE u.is_club_member = TRUE`
+	if err := r.db.QueryRow(query).Scan(&stats.PostedMembers, &stats.ClubMembers); err != nil {
+		return nil, fmt.Errorf("%s: failed to count posting members: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return &stats, nil
+}
+
+// getActivity returns the activity per day or per week ("day" or "week"), oldest first
+func (r *GroupMessageRepository) getActivity(since time.Time, timezone *time.Location, period string) ([]GroupActivity, error) {
+	query := `
+		WITH periods AS (
+			SELECT generate_series(
+				date_trunc($3, $1::timestamptz AT TIME ZONE $2),
+				date_trunc($3, NOW() AT TIME ZONE $2),
+				('1 ' || $3)::interval
+			)::date AS start
+		),
+		first_messages AS (
+			SELECT user_tg_id, date_trunc($3, MIN(created_at) AT TIME ZONE $2)::date AS first_start
+			FROM group_messages
+			GROUP BY user_tg_id
+		),
+		messages AS (
+			SELECT gm.user_tg_id, date_trunc($3, gm.created_at AT TIME ZONE $2)::date AS start, fm.first_start
+			FROM group_messages gm
+			JOIN first_messages fm ON fm.user_tg_id = gm.user_tg_id
+			WHERE gm.created_at >= $1
+		)
+		SELECT
+			p.start,
+			COUNT(m.user_tg_id),
+			COUNT(DISTINCT m.user_tg_id),
+			COUNT(DISTINCT m.user_tg_id) FILTER (WHERE m.first_start = m.start)
+		FROM periods p
+		LEFT JOIN messages m ON m.start = p.start
+		GROUP BY p.start
+		ORDER BY p.start`
+
+	rows, err := r.db.Query(query, since, timezone.String(), period)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query activity per %s: %w", utils.GetCurrentTypeName(), period, err)
+	}
+	defer rows.Close()
+
+	var activity []GroupActivity
+	for rows.Next() {
+		var a GroupActivity
+		if err := rows.Scan(&a.Start, &a.Messages, &a.ActiveUsers, &a.NewSpeakers); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan activity row: %w", utils.GetCurrentTypeName(), err)
+		}
+		activity = append(activity, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for activity: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return activity, nil
+}
+
+// getTopicActivity returns the number of messages per topic per day, oldest first
+func (r *GroupMessageRepository) getTopicActivity(since time.Time, timezone *time.Location) ([]GroupTopicActivity, error) {
+	query := `
+		SELECT gm.group_topic_id, COALESCE(gt.name, ''), (gm.created_at AT TIME ZONE $2)::date AS day, COUNT(*)
+		FROM group_messages gm
+		LEFT JOIN group_topics gt ON gt.topic_id = gm.group_topic_id
+		WHERE gm.created_at >= $1
+		GROUP BY gm.group_topic_id, gt.name, day
+		ORDER BY day, gm.group_topic_id`
+
+	rows, err := r.db.Query(query, since, timezone.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query activity per topic: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var activity []GroupTopicActivity
+	for rows.Next() {
+		var a GroupTopicActivity
+		if err := rows.Scan(&a.TopicID, &a.TopicName, &a.Day, &a.Messages); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan topic activity row: %w", utils.GetCurrentTypeName(), err)
+		}
+		activity = append(activity, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for topic activity: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return activity, nil
+}
+
+// getMessagesByHour returns the number of messages per hour of the day
+func (r *GroupMessageRepository) getMessagesByHour(since time.Time, timezone *time.Location) ([24]int, error) {
+	var byHour [24]int
+
+	query := `
+		SELECT EXTRACT(HOUR FROM created_at AT TIME ZONE $2)::int AS hour, COUNT(*)
+		FROM group_messages
+		WHERE created_at >= $1
+		GROUP BY hour`
+
+	rows, err := r.db.Query(query, since, timezone.String())
+	if err != nil {
+		return byHour, fmt.Errorf("%s: failed to query messages per hour: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour, count int
+		if err := rows.Scan(&hour, &count); err != nil {
+			return byHour, fmt.Errorf("%s: failed to scan messages per hour row: %w", utils.GetCurrentTypeName(), err)
+		}
+		if hour >= 0 && hour < len(byHour) {
+			byHour[hour] = count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return byHour, fmt.Errorf("%s: error during rows iteration for messages per hour: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return byHour, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"time"
@@ -12,10 +13,17 @@ type GroupTopic struct {
 	ID        int
 	TopicID   int64
 	Name      string
+	EventID   *int       // Set for the topics created by the bot for an event
+	ClosedAt  *time.Time // Set once the event topic was closed
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+const (
+	groupTopicColumns          = `id, topic_id, name, event_id, closed_at, created_at, updated_at`
+	groupTopicColumnsWithAlias = `gt.id, gt.topic_id, gt.name, gt.event_id, gt.closed_at, gt.created_at, gt.updated_at`
+)
+
 // GroupTopicRepository handles database operations for group topics
 type GroupTopicRepository struct {
 	db *sql.DB
@@ -32,12 +40,14 @@ func (r *GroupTopicRepository) AddGroupTopic(topicID int64, name string) (*Group
 	query := `
 		INSERT INTO group_topics (topic_id, name) 
 		VALUES ($1, $2) 
-		RETURNING id, topic_id, name, created_at, updated_at`
+		RETURNING ` + groupTopicColumns
 
 	err := r.db.QueryRow(query, topicID, name).Scan(
 		&groupTopic.ID,
 		&groupTopic.TopicID,
 		&groupTopic.Name,
+		&groupTopic.EventID,
+		&groupTopic.ClosedAt,
 		&groupTopic.CreatedAt,
 		&groupTopic.UpdatedAt,
 	)
@@ -54,12 +64,14 @@ func (r *GroupTopicRepository) UpdateGroupTopic(topicID int64, name string) (*Gr
 		UPDATE group_topics 
 		SET name = $1, updated_at = NOW() 
 		WHERE topic_id = $2
-		RETURNING id, topic_id, name, created_at, updated_at`
+		RETURNING ` + groupTopicColumns
 
 	err := r.db.QueryRow(query, name, topicID).Scan(
 		&groupTopic.ID,
 		&groupTopic.TopicID,
 		&groupTopic.Name,
+		&groupTopic.EventID,
+		&groupTopic.ClosedAt,
 		&groupTopic.CreatedAt,
 		&groupTopic.UpdatedAt,
 	)
@@ -75,7 +87,7 @@ func (r *GroupTopicRepository) UpdateGroupTopic(topicID int64, name string) (*Gr
 // GetGroupTopicByTopicID retrieves a group topic by its topic_id
 func (r *GroupTopicRepository) GetGroupTopicByTopicID(topicID int64) (*GroupTopic, error) {
 	query := `
-		SELECT id, topic_id, name, created_at, updated_at
+		SELECT ` + groupTopicColumns + `
 		FROM group_topics
 		WHERE topic_id = $1`
 
@@ -84,6 +96,8 @@ func (r *GroupTopicRepository) GetGroupTopicByTopicID(topicID int64) (*GroupTopi
 		&groupTopic.ID,
 		&groupTopic.TopicID,
 		&groupTopic.Name,
+		&groupTopic.EventID,
+		&groupTopic.ClosedAt,
 		&groupTopic.CreatedAt,
 		&groupTopic.UpdatedAt,
 	)
@@ -102,7 +116,7 @@ func (r *GroupTopicRepository) GetGroupTopicByTopicID(topicID int64) (*GroupTopi
 // GetAllGroupTopics retrieves all group topics
 func (r *GroupTopicRepository) GetAllGroupTopics() ([]GroupTopic, error) {
 	query := `
-		SELECT id, topic_id, name, created_at, updated_at
+		SELECT ` + groupTopicColumns + `
 		FROM group_topics
 		ORDER BY created_at ASC`
 
@@ -115,7 +129,7 @@ func (r *GroupTopicRepository) GetAllGroupTopics() ([]GroupTopic, error) {
 	var groupTopics []GroupTopic
 	for rows.Next() {
 		var gt GroupTopic
-		if err := rows.Scan(&gt.ID, &gt.TopicID, &gt.Name, &gt.CreatedAt, &gt.UpdatedAt); err != nil {
+		if err := rows.Scan(&gt.ID, &gt.TopicID, &gt.Name, &gt.EventID, &gt.ClosedAt, &gt.CreatedAt, &gt.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: failed to scan group topic row: %w", utils.GetCurrentTypeName(), err)
 		}
 		groupTopics = append(groupTopics, gt)
@@ -128,6 +142,139 @@ func (r *GroupTopicRepository) GetAllGroupTopics() ([]GroupTopic, error) {
 	return groupTopics, nil
 }
 
+// AddEventGroupTopic inserts the topic created by the bot for the event
+func (r *GroupTopicRepository) AddEventGroupTopic(topicID int64, name string, eventID int) (*GroupTopic, error) {
+	var groupTopic GroupTopic
+	query := `
+		INSERT INTO group_topics (topic_id, name, event_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (topic_id) DO UPDATE SET name = EXCLUDED.name, event_id = EXCLUDED.event_id, updated_at = NOW()
+		RETURNING ` + groupTopicColumns
+
+	err := r.db.QueryRow(query, topicID, name, eventID).Scan(
+		&groupTopic.ID,
+		&groupTopic.TopicID,
+		&groupTopic.Name,
+		&groupTopic.EventID,
+		&groupTopic.ClosedAt,
+		&groupTopic.CreatedAt,
+		&groupTopic.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert topic of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+	return &groupTopic, nil
+}
+
+// GetGroupTopicByEventID retrieves the topic created for the event, nil if there is none
+func (r *GroupTopicRepository) GetGroupTopicByEventID(eventID int) (*GroupTopic, error) {
+	query := `
+		SELECT ` + groupTopicColumns + `
+		FROM group_topics
+		WHERE event_id = $1`
+
+	var groupTopic GroupTopic
+	err := r.db.QueryRow(query, eventID).Scan(
+		&groupTopic.ID,
+		&groupTopic.TopicID,
+		&groupTopic.Name,
+		&groupTopic.EventID,
+		&groupTopic.ClosedAt,
+		&groupTopic.CreatedAt,
+		&groupTopic.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get topic of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	return &groupTopic, nil
+}
+
+// GetOpenEventTopicsEndedBefore retrieves the open topics of the finished events that ended before the given time.
+// Events without a duration end at their start.
+func (r *GroupTopicRepository) GetOpenEventTopicsEndedBefore(before time.Time) ([]GroupTopic, error) {
+	query := `
+		SELECT ` + groupTopicColumnsWithAlias + `
+		FROM group_topics gt
+		JOIN events e ON e.id = gt.event_id
+		WHERE gt.closed_at IS NULL
+			AND e.status = $1
+			AND COALESCE(e.started_at, e.updated_at) + make_interval(mins => COALESCE(e.duration_minutes, 0)) < $2
+		ORDER BY gt.created_at ASC`
+
+	rows, err := r.db.Query(query, constants.EventStatusFinished, before)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query open event topics: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var groupTopics []GroupTopic
+	for rows.Next() {
+		var gt GroupTopic
+		if err := rows.Scan(&gt.ID, &gt.TopicID, &gt.Name, &gt.EventID, &gt.ClosedAt, &gt.CreatedAt, &gt.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan group topic row: %w", utils.GetCurrentTypeName(), err)
+		}
+		groupTopics = append(groupTopics, gt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for group topics: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return groupTopics, nil
+}
+
+// SetEventTopicClosed records whether the event topic is closed, it's a no-op for the other topics.
+// Returns false if the topic isn't an event topic.
+func (r *GroupTopicRepository) SetEventTopicClosed(topicID int64, closed bool) (bool, error) {
+	query := `
+		UPDATE group_topics
+		SET closed_at = CASE WHEN $2 THEN NOW() ELSE NULL END, updated_at = NOW()
+		WHERE topic_id = $1 AND event_id IS NOT NULL`
+
+	result, err := r.db.Exec(query, topicID, closed)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to update closed state of topic %d: %w", utils.GetCurrentTypeName(), topicID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: could not get rows affected after update: %w", utils.GetCurrentTypeName(), err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetClosedEventTopicIDs retrieves the IDs of the event topics that are closed now
+func (r *GroupTopicRepository) GetClosedEventTopicIDs() ([]int64, error) {
+	query := `
+		SELECT topic_id FROM group_topics
+		WHERE event_id IS NOT NULL AND closed_at IS NOT NULL`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query closed event topics: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var topicIDs []int64
+	for rows.Next() {
+		var topicID int64
+		if err := rows.Scan(&topicID); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan topic ID: %w", utils.GetCurrentTypeName(), err)
+		}
+		topicIDs = append(topicIDs, topicID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for closed event topics: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return topicIDs, nil
+}
+
 // DeleteGroupTopic removes a group topic by its topic_id
 func (r *GroupTopicRepository) DeleteGroupTopic(topicID int64) error {
 	query := `DELETE FROM group_topics WHERE topic_id = $1`
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// KarmaRepository handles database operations for karma votes and the scores of users
+type KarmaRepository struct {
+	db *sql.DB
+}
+
+// NewKarmaRepository creates a new KarmaRepository
+func NewKarmaRepository(db *sql.DB) *KarmaRepository {
+	return &KarmaRepository{db: db}
+}
+
+// AddVote records a point granted for a message and adds it to the score of the receiver.
+// Returns false if the giver has already granted a point for this message, has granted dailyLimit points
+// in the last 24 hours or has granted a point to the receiver within the pair cooldown.
+// The limits are checked by the insert itself while the giver's row is locked,
+// so concurrent votes of the same giver can't exceed them.
+func (r *KarmaRepository) AddVote(
+	giverUserID int,
+	receiverUserID int,
+	messageID int64,
+	source constants.KarmaVoteSource,
+	dailyLimit int,
+	pairCooldown time.Duration,
+) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, giverUserID); err != nil {
+		return false, fmt.Errorf("%s: failed to lock user with ID %d: %w", utils.GetCurrentTypeName(), giverUserID, err)
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO karma_votes (giver_user_id, receiver_user_id, message_id, source)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (
+				SELECT 1 FROM karma_votes
+				WHERE giver_user_id = $1 AND receiver_user_id = $2
+					AND created_at > NOW() - make_interval(secs => $6)
+			)
+			AND (
+				SELECT COUNT(*) FROM karma_votes
+				WHERE giver_user_id = $1 AND created_at > NOW() - INTERVAL '24 hours'
+			) < $5
+		ON CONFLICT (giver_user_id, message_id) DO NOTHING`,
+		giverUserID, receiverUserID, messageID, source, dailyLimit, pairCooldown.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to insert karma vote: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: could not get rows affected after insert: %w", utils.GetCurrentTypeName(), err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(`UPDATE users SET score = score + 1, updated_at = NOW() WHERE id = $1`, receiverUserID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to update score for user with ID %d: %w", utils.GetCurrentTypeName(), receiverUserID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("%s: failed to commit transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return true, nil
+}
+
+// GetLeaderboard retrieves the club members with the highest scores, the ones without points are left out
+func (r *KarmaRepository) GetLeaderboard(limit int) ([]User, error) {
+	query := `
+		SELECT id, tg_id, firstname, lastname, tg_username, score, has_coffee_ban, is_club_member, created_at, updated_at
+		FROM users
+		WHERE is_club_member = TRUE AND score > 0
+		ORDER BY score DESC, id ASC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query karma leaderboard: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var user User
+		err := rows.Scan(
+			&user.ID,
+			&user.TgID,
+			&user.Firstname,
+			&user.Lastname,
+			&user.TgUsername,
+			&user.Score,
+			&user.HasCoffeeBan,
+			&user.IsClubMember,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan user row: %w", utils.GetCurrentTypeName(), err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for karma leaderboard: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return users, nil
+}
+
+// GetRank returns the place of the score among the club members, members with the same score share the place
+func (r *KarmaRepository) GetRank(score int) (int, error) {
+	var higher int
+	query := `SELECT COUNT(*) FROM users WHERE is_club_member = TRUE AND score > $1`
+	if err := r.db.QueryRow(query, score).Scan(&higher); err != nil {
+		return 0, fmt.Errorf("%s: failed to get karma rank: %w", utils.GetCurrentTypeName(), err)
+	}
+	return higher + 1, nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	MatchingProgramFrequencyWeekly = "weekly"
+	// Monthly programs run on the first matching weekday of each month
+	MatchingProgramFrequencyMonthly = "monthly"
+)
+
+// MatchingProgram is a named matching round (e.g. mentor/mentee) with its own topic, schedule and poll copy.
+// The weekly Random Coffee configured via environment is not stored here.
+type MatchingProgram struct {
+	ID           int            `db:"id"`
+	Name         string         `db:"name"`
+	TopicID      int            `db:"topic_id"`
+	PollQuestion string         `db:"poll_question"`
+	Frequency    string         `db:"frequency"`
+	PollWeekday  time.Weekday   `db:"poll_weekday"`
+	PollTime     string         `db:"poll_time"` // "15:04", UTC
+	PairsWeekday time.Weekday   `db:"pairs_weekday"`
+	PairsTime    string         `db:"pairs_time"` // "15:04", UTC
+	FirstRole    sql.NullString `db:"first_role"`
+	SecondRole   sql.NullString `db:"second_role"`
+	IsActive     bool           `db:"is_active"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+// HasRoles reports whether members of the first role are matched only with members of the second role
+func (p *MatchingProgram) HasRoles() bool {
+	return p.FirstRole.Valid && p.SecondRole.Valid
+}
+
+type MatchingProgramRepository struct {
+	db *sql.DB
+}
+
+func NewMatchingProgramRepository(db *sql.DB) *MatchingProgramRepository {
+	return &MatchingProgramRepository{db: db}
+}
+
+const matchingProgramColumns = `id, name, topic_id, poll_question, frequency, poll_weekday, poll_time,
+	pairs_weekday, pairs_time, first_role, second_role, is_active, created_at, updated_at`
+
+func scanMatchingProgram(scanner interface{ Scan(dest ...any) error }) (*MatchingProgram, error) {
+	p := &MatchingProgram{}
+	err := scanner.Scan(
+		&p.ID,
+		&p.Name,
+		&p.TopicID,
+		&p.PollQuestion,
+		&p.Frequency,
+		&p.PollWeekday,
+		&p.PollTime,
+		&p.PairsWeekday,
+		&p.PairsTime,
+		&p.FirstRole,
+		&p.SecondRole,
+		&p.IsActive,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+// Create inserts a new program and returns its ID
+func (r *MatchingProgramRepository) Create(program MatchingProgram) (int, error) {
+	query := `
+		INSERT INTO matching_programs (name, topic_id, poll_question, frequency, poll_weekday, poll_time,
+			pairs_weekday, pairs_time, first_role, second_role, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	var id int
+	err := r.db.QueryRow(
+		query,
+		program.Name,
+		program.TopicID,
+		program.PollQuestion,
+		program.Frequency,
+		int(program.PollWeekday),
+		program.PollTime,
+		int(program.PairsWeekday),
+		program.PairsTime,
+		program.FirstRole,
+		program.SecondRole,
+		program.IsActive,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to create matching program: %w", utils.GetCurrentTypeName(), err)
+	}
+	return id, nil
+}
+
+// GetByID returns a program by ID, or nil if it doesn't exist
+func (r *MatchingProgramRepository) GetByID(id int) (*MatchingProgram, error) {
+	query := fmt.Sprintf(`SELECT %s FROM matching_programs WHERE id = $1`, matchingProgramColumns)
+	program, err := scanMatchingProgram(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to get matching program %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+	return program, nil
+}
+
+// GetAll returns all programs ordered by name
+func (r *MatchingProgramRepository) GetAll() ([]MatchingProgram, error) {
+	return r.getMany(fmt.Sprintf(`SELECT %s FROM matching_programs ORDER BY name`, matchingProgramColumns))
+}
+
+// GetActive returns the programs that are currently running
+func (r *MatchingProgramRepository) GetActive() ([]MatchingProgram, error) {
+	return r.getMany(fmt.Sprintf(`SELECT %s FROM matching_programs WHERE is_active = TRUE ORDER BY id`, matchingProgramColumns))
+}
+
+func (r *MatchingProgramRepository) getMany(query string) ([]MatchingProgram, error) {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get matching programs: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var programs []MatchingProgram
+	for rows.Next() {
+		program, err := scanMatchingProgram(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan matching program: %w", utils.GetCurrentTypeName(), err)
+		}
+		programs = append(programs, *program)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for matching programs: %w", utils.GetCurrentTypeName(), err)
+	}
+	return programs, nil
+}
+
+// SetActive pauses or resumes a program
+func (r *MatchingProgramRepository) SetActive(id int, isActive bool) error {
+	result, err := r.db.Exec(`UPDATE matching_programs SET is_active = $1 WHERE id = $2`, isActive, id)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update matching program %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("%s: Could not get rows affected after update: %v", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no matching program found with ID %d", utils.GetCurrentTypeName(), id)
+	}
+	return nil
+}
+
+// Delete removes a program together with its polls, participants and pairs
+func (r *MatchingProgramRepository) Delete(id int) error {
+	if _, err := r.db.Exec(`DELETE FROM matching_programs WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("%s: failed to delete matching program %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+	return nil
+}
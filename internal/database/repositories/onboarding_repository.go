@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// Onboarding represents a row in the member_onboarding table together with the steps derived from other tables
+type Onboarding struct {
+	UserID         int
+	RulesReadAt    sql.NullTime
+	CourseOpenedAt sql.NullTime
+	RemindersSent  int
+	LastReminderAt sql.NullTime
+	CompletedAt    sql.NullTime
+	StartedAt      time.Time
+
+	ProfileFilled      bool // The profile has a bio
+	RandomCoffeeJoined bool // The member is subscribed to Random Coffee or has taken part in a round
+}
+
+// IsComplete reports whether every step of the checklist is done, the rules step only counts if it's required
+func (o *Onboarding) IsComplete(rulesRequired bool) bool {
+	return o.ProfileFilled && o.RandomCoffeeJoined && o.CourseOpenedAt.Valid && (o.RulesReadAt.Valid || !rulesRequired)
+}
+
+// OnboardingWithUser is an onboarding together with its member
+type OnboardingWithUser struct {
+	Onboarding
+	User User
+}
+
+const onboardingSelectColumns = `
+	o.user_id, o.rules_read_at, o.course_opened_at, o.reminders_sent, o.last_reminder_at, o.completed_at, o.started_at,
+	EXISTS (SELECT 1 FROM profiles p WHERE p.user_id = o.user_id AND p.bio <> '') AS profile_filled,
+	EXISTS (SELECT 1 FROM random_coffee_subscriptions s WHERE s.user_id = o.user_id)
+		OR EXISTS (SELECT 1 FROM random_coffee_participants rp WHERE rp.user_id = o.user_id AND rp.is_participating) AS random_coffee_joined`
+
+// OnboardingRepository handles database operations for the onboarding of new members
+type OnboardingRepository struct {
+	db *sql.DB
+}
+
+// NewOnboardingRepository creates a new OnboardingRepository
+func NewOnboardingRepository(db *sql.DB) *OnboardingRepository {
+	return &OnboardingRepository{db: db}
+}
+
+// Start begins the onboarding of a member, or restarts the reminders of a member who rejoined
+// before finishing it. Returns false if the member has already completed the onboarding.
+func (r *OnboardingRepository) Start(userID int) (bool, error) {
+	query := `
+		INSERT INTO member_onboarding (user_id)
+		VALUES ($1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET reminders_sent = 0, last_reminder_at = NULL, started_at = NOW(), updated_at = NOW()
+		WHERE member_onboarding.completed_at IS NULL`
+	result, err := r.db.Exec(query, userID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to start onboarding of user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: could not get rows affected after starting onboarding: %w", utils.GetCurrentTypeName(), err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetByUserID retrieves the onboarding of a member, or nil if it was never started
+func (r *OnboardingRepository) GetByUserID(userID int) (*Onboarding, error) {
+	query := `SELECT ` + onboardingSelectColumns + ` FROM member_onboarding o WHERE o.user_id = $1`
+	onboarding := &Onboarding{}
+	err := r.db.QueryRow(query, userID).Scan(onboardingScanDest(onboarding)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get onboarding of user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return onboarding, nil
+}
+
+// GetInProgress retrieves the onboardings that aren't completed yet of the current club members
+func (r *OnboardingRepository) GetInProgress() ([]OnboardingWithUser, error) {
+	query := `
+		SELECT ` + onboardingSelectColumns + `,
+			u.id, u.tg_id, u.firstname, u.lastname, u.tg_username, u.score, u.has_coffee_ban, u.is_club_member, u.created_at, u.updated_at
+		FROM member_onboarding o
+		JOIN users u ON u.id = o.user_id
+		WHERE o.completed_at IS NULL AND u.is_club_member = TRUE
+		ORDER BY o.started_at ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query onboardings in progress: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var onboardings []OnboardingWithUser
+	for rows.Next() {
+		var o OnboardingWithUser
+		dest := append(onboardingScanDest(&o.Onboarding),
+			&o.User.ID,
+			&o.User.TgID,
+			&o.User.Firstname,
+			&o.User.Lastname,
+			&o.User.TgUsername,
+			&o.User.Score,
+			&o.User.HasCoffeeBan,
+			&o.User.IsClubMember,
+			&o.User.CreatedAt,
+			&o.User.UpdatedAt,
+		)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan onboarding row: %w", utils.GetCurrentTypeName(), err)
+		}
+		onboardings = append(onboardings, o)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for onboardings: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return onboardings, nil
+}
+
+// MarkRulesRead records that the member opened the rules, the first time is kept
+func (r *OnboardingRepository) MarkRulesRead(userID int) error {
+	return r.markStep(userID, "rules_read_at")
+}
+
+// MarkCourseOpened records that the member opened the course Mini App, the first time is kept
+func (r *OnboardingRepository) MarkCourseOpened(userID int) error {
+	return r.markStep(userID, "course_opened_at")
+}
+
+// MarkReminderSent counts a reminder sent to the member
+func (r *OnboardingRepository) MarkReminderSent(userID int) error {
+	query := `
+		UPDATE member_onboarding
+		SET reminders_sent = reminders_sent + 1, last_reminder_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to mark onboarding reminder as sent for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
+
+// MarkCompleted records that the member went through the whole checklist.
+// Returns false if it was already recorded, so the completion is reported once.
+func (r *OnboardingRepository) MarkCompleted(userID int) (bool, error) {
+	query := `
+		UPDATE member_onboarding
+		SET completed_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1 AND completed_at IS NULL`
+	result, err := r.db.Exec(query, userID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to complete onboarding of user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: could not get rows affected after completing onboarding: %w", utils.GetCurrentTypeName(), err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// markStep sets the time of a step column unless it's already set, the column name is never user input
+func (r *OnboardingRepository) markStep(userID int, column string) error {
+	query := fmt.Sprintf(`
+		UPDATE member_onboarding
+		SET %[1]s = COALESCE(%[1]s, NOW()), updated_at = NOW()
+		WHERE user_id = $1`, column)
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to set %s of user %d: %w", utils.GetCurrentTypeName(), column, userID, err)
+	}
+	return nil
+}
+
+func onboardingScanDest(o *Onboarding) []any {
+	return []any{
+		&o.UserID,
+		&o.RulesReadAt,
+		&o.CourseOpenedAt,
+		&o.RemindersSent,
+		&o.LastReminderAt,
+		&o.CompletedAt,
+		&o.StartedAt,
+		&o.ProfileFilled,
+		&o.RandomCoffeeJoined,
+	}
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"log"
@@ -15,11 +16,60 @@ type Profile struct {
 	ID                 int
 	UserID             int
 	Bio                string
+	Role               string
+	Company            string
+	Skills             string // Comma-separated
+	City               string
+	Timezone           string // IANA name, e.g. Europe/Kyiv
+	GitHubURL          string
+	LinkedInURL        string
+	LookingFor         string
+	CanHelpWith        string
 	PublishedMessageID sql.NullInt64
+	ArchivedAt         sql.NullTime // Set while the intro is archived or deleted because the member left
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
 
+// FieldValue returns the value of a structured field
+func (p *Profile) FieldValue(field constants.ProfileField) string {
+	switch field {
+	case constants.ProfileFieldRole:
+		return p.Role
+	case constants.ProfileFieldCompany:
+		return p.Company
+	case constants.ProfileFieldSkills:
+		return p.Skills
+	case constants.ProfileFieldCity:
+		return p.City
+	case constants.ProfileFieldTimezone:
+		return p.Timezone
+	case constants.ProfileFieldGitHub:
+		return p.GitHubURL
+	case constants.ProfileFieldLinkedIn:
+		return p.LinkedInURL
+	case constants.ProfileFieldLookingFor:
+		return p.LookingFor
+	case constants.ProfileFieldCanHelpWith:
+		return p.CanHelpWith
+	default:
+		return ""
+	}
+}
+
+// HasStructuredFields reports whether any structured field is filled in
+func (p *Profile) HasStructuredFields() bool {
+	for _, field := range constants.AllProfileFields {
+		if p.FieldValue(field) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+const profileColumns = `id, user_id, bio, role, company, skills, city, timezone, github_url, linkedin_url,
+			looking_for, can_help_with, published_message_id, archived_at, created_at, updated_at`
+
 // ProfileRepository handles database operations for profiles
 type ProfileRepository struct {
 	db *sql.DB
@@ -33,19 +83,12 @@ func NewProfileRepository(db *sql.DB) *ProfileRepository {
 // GetByID retrieves a profile by ID
 func (r *ProfileRepository) GetByID(id int) (*Profile, error) {
 	query := `
-		SELECT id, user_id, bio, published_message_id, created_at, updated_at
+		SELECT ` + profileColumns + `
 		FROM profiles
 		WHERE id = $1`
 
 	var profile Profile
-	err := r.db.QueryRow(query, id).Scan(
-		&profile.ID,
-		&profile.UserID,
-		&profile.Bio,
-		&profile.PublishedMessageID,
-		&profile.CreatedAt,
-		&profile.UpdatedAt,
-	)
+	err := r.db.QueryRow(query, id).Scan(profileScanDest(&profile)...)
 
 	if err == sql.ErrNoRows {
 		return nil, sql.ErrNoRows
@@ -141,6 +184,32 @@ func (r *ProfileRepository) UpdatePublishedMessageID(profileID int, messageID in
 	return nil
 }
 
+// MarkArchived records that the intro of a member who left was archived, the published message is forgotten
+// if it was deleted
+func (r *ProfileRepository) MarkArchived(profileID int, messageDeleted bool) error {
+	query := `
+		UPDATE profiles
+		SET archived_at = NOW(),
+			published_message_id = CASE WHEN $1 THEN NULL ELSE published_message_id END,
+			updated_at = NOW()
+		WHERE id = $2`
+	_, err := r.db.Exec(query, messageDeleted, profileID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to mark profile with ID %d as archived: %w", utils.GetCurrentTypeName(), profileID, err)
+	}
+	return nil
+}
+
+// ClearArchived records that the intro of a member who rejoined was restored
+func (r *ProfileRepository) ClearArchived(profileID int) error {
+	query := `UPDATE profiles SET archived_at = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, profileID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to clear archived_at for profile with ID %d: %w", utils.GetCurrentTypeName(), profileID, err)
+	}
+	return nil
+}
+
 func (r *ProfileRepository) GetOrCreateWithBio(userID int, bio string) (*Profile, error) {
 	// Try to get profile
 	profile, err := r.getByUserID(userID)
@@ -196,19 +265,12 @@ func (r *ProfileRepository) GetOrFullCreate(user *gotgbot.User) (*Profile, error
 // GetByUserID retrieves a profile by user ID
 func (r *ProfileRepository) getByUserID(userID int) (*Profile, error) {
 	query := `
-		SELECT id, user_id, bio, published_message_id, created_at, updated_at
+		SELECT ` + profileColumns + `
 		FROM profiles
 		WHERE user_id = $1`
 
 	var profile Profile
-	err := r.db.QueryRow(query, userID).Scan(
-		&profile.ID,
-		&profile.UserID,
-		&profile.Bio,
-		&profile.PublishedMessageID,
-		&profile.CreatedAt,
-		&profile.UpdatedAt,
-	)
+	err := r.db.QueryRow(query, userID).Scan(profileScanDest(&profile)...)
 
 	if err == sql.ErrNoRows {
 		return nil, sql.ErrNoRows
@@ -231,7 +293,8 @@ type ProfileWithUser struct {
 func (r *ProfileRepository) GetAllActiveWithUserInfo() ([]ProfileWithUser, error) {
 	query := `
 		SELECT 
-			p.id, p.user_id, p.bio, p.published_message_id, p.created_at, p.updated_at,
+			p.id, p.user_id, p.bio, p.role, p.company, p.skills, p.city, p.timezone, p.github_url, p.linkedin_url,
+			p.looking_for, p.can_help_with, p.published_message_id, p.archived_at, p.created_at, p.updated_at,
 			u.id, u.tg_id, u.firstname, u.lastname, u.tg_username, u.score, u.has_coffee_ban, u.is_club_member, u.created_at, u.updated_at
 		FROM profiles p
 		INNER JOIN users u ON p.user_id = u.id
@@ -249,13 +312,7 @@ func (r *ProfileRepository) GetAllActiveWithUserInfo() ([]ProfileWithUser, error
 		var profile Profile
 		var user User
 
-		err := rows.Scan(
-			&profile.ID,
-			&profile.UserID,
-			&profile.Bio,
-			&profile.PublishedMessageID,
-			&profile.CreatedAt,
-			&profile.UpdatedAt,
+		err := rows.Scan(append(profileScanDest(&profile),
 			&user.ID,
 			&user.TgID,
 			&user.Firstname,
@@ -266,7 +323,7 @@ func (r *ProfileRepository) GetAllActiveWithUserInfo() ([]ProfileWithUser, error
 			&user.IsClubMember,
 			&user.CreatedAt,
 			&user.UpdatedAt,
-		)
+		)...)
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to scan profile with user: %w", utils.GetCurrentTypeName(), err)
 		}
@@ -283,3 +340,24 @@ func (r *ProfileRepository) GetAllActiveWithUserInfo() ([]ProfileWithUser, error
 
 	return profiles, nil
 }
+
+func profileScanDest(profile *Profile) []any {
+	return []any{
+		&profile.ID,
+		&profile.UserID,
+		&profile.Bio,
+		&profile.Role,
+		&profile.Company,
+		&profile.Skills,
+		&profile.City,
+		&profile.Timezone,
+		&profile.GitHubURL,
+		&profile.LinkedInURL,
+		&profile.LookingFor,
+		&profile.CanHelpWith,
+		&profile.PublishedMessageID,
+		&profile.ArchivedAt,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	}
+}
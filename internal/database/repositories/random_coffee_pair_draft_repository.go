@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// RandomCoffeePairDraftPair is a pair of the draft
+type RandomCoffeePairDraftPair struct {
+	User1ID int
+	User2ID int
+}
+
+// RandomCoffeePairDraft represents a pairs draft awaiting admin review, there is at most one per round
+type RandomCoffeePairDraft struct {
+	PollID          int
+	WeekStartDate   time.Time
+	Pairs           []RandomCoffeePairDraftPair
+	UnpairedUserIDs []int
+	AutoPublishAt   sql.NullTime
+	AdminChatID     int64
+	AdminMessageID  int64
+	CreatedAt       time.Time
+}
+
+// RandomCoffeePairDraftRepository handles database operations for Random Coffee pairs drafts
+type RandomCoffeePairDraftRepository struct {
+	db *sql.DB
+}
+
+// NewRandomCoffeePairDraftRepository creates a new RandomCoffeePairDraftRepository
+func NewRandomCoffeePairDraftRepository(db *sql.DB) *RandomCoffeePairDraftRepository {
+	return &RandomCoffeePairDraftRepository{db: db}
+}
+
+// Save stores the draft, replacing the previous draft of the same round
+func (r *RandomCoffeePairDraftRepository) Save(draft *RandomCoffeePairDraft) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM random_coffee_pair_drafts WHERE poll_id = $1`, draft.PollID); err != nil {
+		return fmt.Errorf("%s: failed to delete previous draft for poll %d: %w", utils.GetCurrentTypeName(), draft.PollID, err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO random_coffee_pair_drafts (poll_id, week_start_date, auto_publish_at, admin_chat_id, admin_message_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		draft.PollID, draft.WeekStartDate, draft.AutoPublishAt, draft.AdminChatID, draft.AdminMessageID, draft.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert draft for poll %d: %w", utils.GetCurrentTypeName(), draft.PollID, err)
+	}
+
+	for position, pair := range draft.Pairs {
+		_, err := tx.Exec(`
+			INSERT INTO random_coffee_pair_draft_pairs (poll_id, position, user1_id, user2_id)
+			VALUES ($1, $2, $3, $4)`,
+			draft.PollID, position, pair.User1ID, pair.User2ID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to insert draft pair %d x %d: %w", utils.GetCurrentTypeName(), pair.User1ID, pair.User2ID, err)
+		}
+	}
+
+	for position, userID := range draft.UnpairedUserIDs {
+		_, err := tx.Exec(`
+			INSERT INTO random_coffee_pair_draft_unpaired (poll_id, position, user_id)
+			VALUES ($1, $2, $3)`,
+			draft.PollID, position, userID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to insert unpaired draft member %d: %w", utils.GetCurrentTypeName(), userID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit draft: %w", utils.GetCurrentTypeName(), err)
+	}
+	return nil
+}
+
+// GetAll retrieves all drafts, oldest first
+func (r *RandomCoffeePairDraftRepository) GetAll() ([]RandomCoffeePairDraft, error) {
+	rows, err := r.db.Query(`
+		SELECT poll_id, week_start_date, auto_publish_at, admin_chat_id, admin_message_id, created_at
+		FROM random_coffee_pair_drafts
+		ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get drafts: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var drafts []RandomCoffeePairDraft
+	for rows.Next() {
+		var draft RandomCoffeePairDraft
+		err := rows.Scan(&draft.PollID, &draft.WeekStartDate, &draft.AutoPublishAt, &draft.AdminChatID, &draft.AdminMessageID, &draft.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan draft: %w", utils.GetCurrentTypeName(), err)
+		}
+		drafts = append(drafts, draft)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error iterating over drafts: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	for i := range drafts {
+		if err := r.loadMembers(&drafts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return drafts, nil
+}
+
+// loadMembers fills in the pairs and unpaired members of the draft
+func (r *RandomCoffeePairDraftRepository) loadMembers(draft *RandomCoffeePairDraft) error {
+	pairRows, err := r.db.Query(`
+		SELECT user1_id, user2_id FROM random_coffee_pair_draft_pairs
+		WHERE poll_id = $1
+		ORDER BY position`,
+		draft.PollID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get draft pairs: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer pairRows.Close()
+
+	for pairRows.Next() {
+		var pair RandomCoffeePairDraftPair
+		if err := pairRows.Scan(&pair.User1ID, &pair.User2ID); err != nil {
+			return fmt.Errorf("%s: failed to scan draft pair: %w", utils.GetCurrentTypeName(), err)
+		}
+		draft.Pairs = append(draft.Pairs, pair)
+	}
+	if err = pairRows.Err(); err != nil {
+		return fmt.Errorf("%s: error iterating over draft pairs: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	unpairedRows, err := r.db.Query(`
+		SELECT user_id FROM random_coffee_pair_draft_unpaired
+		WHERE poll_id = $1
+		ORDER BY position`,
+		draft.PollID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get unpaired draft members: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer unpairedRows.Close()
+
+	for unpairedRows.Next() {
+		var userID int
+		if err := unpairedRows.Scan(&userID); err != nil {
+			return fmt.Errorf("%s: failed to scan unpaired draft member: %w", utils.GetCurrentTypeName(), err)
+		}
+		draft.UnpairedUserIDs = append(draft.UnpairedUserIDs, userID)
+	}
+	if err = unpairedRows.Err(); err != nil {
+		return fmt.Errorf("%s: error iterating over unpaired draft members: %w", utils.GetCurrentTypeName(), err)
+	}
+	return nil
+}
+
+// Delete removes the draft of a round
+func (r *RandomCoffeePairDraftRepository) Delete(pollID int) error {
+	if _, err := r.db.Exec(`DELETE FROM random_coffee_pair_drafts WHERE poll_id = $1`, pollID); err != nil {
+		return fmt.Errorf("%s: failed to delete draft for poll %d: %w", utils.GetCurrentTypeName(), pollID, err)
+	}
+	return nil
+}
@@ -34,34 +34,47 @@ func (r *RandomCoffeePairRepository) CreatePair(pollID int, user1ID, user2ID int
 	return nil
 }
 
-// GetPairsHistoryForUsers returns historical pairs for specified users from last N polls
+// GetPairsHistoryForUsers returns historical weekly Random Coffee pairs for specified users from last N polls
 func (r *RandomCoffeePairRepository) GetPairsHistoryForUsers(userIDs []int, lastNPolls int) (map[string][]int, error) {
+	return r.getPairsHistoryForUsers(userIDs, lastNPolls, sql.NullInt64{})
+}
+
+// GetProgramPairsHistoryForUsers returns historical pairs of a matching program for specified users from its last N polls
+func (r *RandomCoffeePairRepository) GetProgramPairsHistoryForUsers(programID int, userIDs []int, lastNPolls int) (map[string][]int, error) {
+	return r.getPairsHistoryForUsers(userIDs, lastNPolls, sql.NullInt64{Int64: int64(programID), Valid: true})
+}
+
+// getPairsHistoryForUsers returns pairs history within a program, or within the weekly Random Coffee if programID is NULL
+func (r *RandomCoffeePairRepository) getPairsHistoryForUsers(userIDs []int, lastNPolls int, programID sql.NullInt64) (map[string][]int, error) {
 	if len(userIDs) == 0 {
 		return make(map[string][]int), nil
 	}
 
 	// Convert userIDs to a format suitable for SQL IN clause
 	placeholders := ""
-	args := make([]interface{}, len(userIDs)+1)
+	args := make([]interface{}, len(userIDs)+2)
 	for i, userID := range userIDs {
 		if i > 0 {
 			placeholders += ","
 		}
-		placeholders += fmt.Sprintf("$%d", i+2)
-		args[i+1] = userID
+		placeholders += fmt.Sprintf("$%d", i+3)
+		args[i+2] = userID
 	}
 	args[0] = lastNPolls
+	args[1] = programID
 
 	query := fmt.Sprintf(`
 		SELECT p.user1_id, p.user2_id, poll.id as poll_id
 		FROM random_coffee_pairs p
 		JOIN random_coffee_polls poll ON p.poll_id = poll.id
 		WHERE (p.user1_id IN (%s) OR p.user2_id IN (%s))
+		AND poll.program_id IS NOT DISTINCT FROM $2
 		ORDER BY poll.week_start_date DESC
 		LIMIT (SELECT COUNT(*) FROM random_coffee_pairs pairs 
 		       JOIN random_coffee_polls polls ON pairs.poll_id = polls.id 
 		       WHERE polls.id IN (
 		           SELECT id FROM random_coffee_polls 
+		           WHERE program_id IS NOT DISTINCT FROM $2
 		           ORDER BY week_start_date DESC 
 		           LIMIT $1
 		       ))
@@ -105,6 +118,7 @@ func (r *RandomCoffeePairRepository) GetMostRecentPairPoll(user1ID, user2ID int,
 		WHERE ((p.user1_id = $1 AND p.user2_id = $2) OR (p.user1_id = $2 AND p.user2_id = $1))
 		AND poll.id IN (
 			SELECT id FROM random_coffee_polls 
+			WHERE program_id IS NULL
 			ORDER BY week_start_date DESC 
 			LIMIT $3
 		)
@@ -123,3 +137,206 @@ func (r *RandomCoffeePairRepository) GetMostRecentPairPoll(user1ID, user2ID int,
 
 	return pollID, nil
 }
+
+// RandomCoffeeUserPair is a past pairing of a user together with the partner's info
+type RandomCoffeeUserPair struct {
+	PollID                    int
+	WeekStartDate             time.Time
+	Partner                   User
+	PartnerPublishedMessageID sql.NullInt64
+}
+
+// RandomCoffeeRoundStats holds aggregated numbers for a single Random Coffee round
+type RandomCoffeeRoundStats struct {
+	PollID                    int
+	WeekStartDate             time.Time
+	Participants              int
+	Pairs                     int
+	RepeatPairs               int
+	ReturningParticipants     int
+	PreviousRoundParticipants int
+}
+
+// RandomCoffeeConnectedUser holds how many distinct partners a user has met
+type RandomCoffeeConnectedUser struct {
+	User          User
+	PartnersCount int
+	RoundsCount   int
+}
+
+// GetPairsForUser returns all weekly Random Coffee rounds the user was paired in, newest first
+func (r *RandomCoffeePairRepository) GetPairsForUser(userID int) ([]RandomCoffeeUserPair, error) {
+	query := `
+		SELECT poll.id, poll.week_start_date,
+			u.id, u.tg_id, u.firstname, u.lastname, u.tg_username,
+			pr.published_message_id
+		FROM random_coffee_pairs p
+		JOIN random_coffee_polls poll ON p.poll_id = poll.id
+		JOIN users u ON u.id = CASE WHEN p.user1_id = $1 THEN p.user2_id ELSE p.user1_id END
+		LEFT JOIN profiles pr ON pr.user_id = u.id
+		WHERE (p.user1_id = $1 OR p.user2_id = $1) AND poll.program_id IS NULL
+		ORDER BY poll.week_start_date DESC
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pairs for user: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []RandomCoffeeUserPair
+	for rows.Next() {
+		var pair RandomCoffeeUserPair
+		if err := rows.Scan(
+			&pair.PollID,
+			&pair.WeekStartDate,
+			&pair.Partner.ID,
+			&pair.Partner.TgID,
+			&pair.Partner.Firstname,
+			&pair.Partner.Lastname,
+			&pair.Partner.TgUsername,
+			&pair.PartnerPublishedMessageID,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning user pair row: %w", err)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration for user pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// CountRoundsJoined returns how many published weekly Random Coffee rounds the user took part in,
+// whether or not they got a partner
+func (r *RandomCoffeePairRepository) CountRoundsJoined(userID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM (` + weeklyRoundParticipantsQuery + `
+		) participants
+		WHERE participants.user_id = $1
+	`
+	var count int
+	if err := r.db.QueryRow(query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting rounds joined by user: %w", err)
+	}
+	return count, nil
+}
+
+// GetRoundStats returns statistics for the last N finished weekly Random Coffee rounds, oldest first.
+// Participants are the recorded members of the round, including those left without a partner,
+// while pairs come from the pairs table. A pair is a repeat if the same two users were paired in any
+// earlier round. Returning participants are those who also took part in the previous round.
+func (r *RandomCoffeePairRepository) GetRoundStats(lastNRounds int) ([]RandomCoffeeRoundStats, error) {
+	query := `
+		WITH participants AS (` + weeklyRoundParticipantsQuery + `
+		),
+		pair_keys AS (
+			SELECT p.poll_id, LEAST(p.user1_id, p.user2_id) AS a, GREATEST(p.user1_id, p.user2_id) AS b
+			FROM random_coffee_pairs p
+			JOIN random_coffee_polls poll ON poll.id = p.poll_id AND poll.program_id IS NULL
+		),
+		rounds AS (
+			SELECT poll.id, poll.week_start_date,
+				(SELECT MAX(pp.poll_id) FROM participants pp WHERE pp.poll_id < poll.id) AS previous_poll_id
+			FROM random_coffee_polls poll
+			WHERE EXISTS (SELECT 1 FROM participants p WHERE p.poll_id = poll.id)
+			ORDER BY poll.week_start_date DESC
+			LIMIT $1
+		)
+		SELECT r.id, r.week_start_date,
+			(SELECT COUNT(*) FROM participants p WHERE p.poll_id = r.id),
+			(SELECT COUNT(*) FROM pair_keys pk WHERE pk.poll_id = r.id),
+			(SELECT COUNT(*) FROM pair_keys pk WHERE pk.poll_id = r.id AND EXISTS (
+				SELECT 1 FROM pair_keys prev
+				WHERE prev.a = pk.a AND prev.b = pk.b AND prev.poll_id < r.id
+			)),
+			(SELECT COUNT(*) FROM participants p WHERE p.poll_id = r.id AND EXISTS (
+				SELECT 1 FROM participants pp WHERE pp.user_id = p.user_id AND pp.poll_id = r.previous_poll_id
+			)),
+			(SELECT COUNT(*) FROM participants p WHERE p.poll_id = r.previous_poll_id)
+		FROM rounds r
+		ORDER BY r.week_start_date ASC
+	`
+	rows, err := r.db.Query(query, lastNRounds)
+	if err != nil {
+		return nil, fmt.Errorf("error getting round stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []RandomCoffeeRoundStats
+	for rows.Next() {
+		var s RandomCoffeeRoundStats
+		if err := rows.Scan(
+			&s.PollID,
+			&s.WeekStartDate,
+			&s.Participants,
+			&s.Pairs,
+			&s.RepeatPairs,
+			&s.ReturningParticipants,
+			&s.PreviousRoundParticipants,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning round stats row: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration for round stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetMostConnectedUsers returns users ordered by the number of distinct weekly Random Coffee partners they have met
+func (r *RandomCoffeePairRepository) GetMostConnectedUsers(limit int) ([]RandomCoffeeConnectedUser, error) {
+	query := `
+		WITH coffee_pairs AS (
+			SELECT p.poll_id, p.user1_id, p.user2_id
+			FROM random_coffee_pairs p
+			JOIN random_coffee_polls poll ON poll.id = p.poll_id AND poll.program_id IS NULL
+		),
+		partners AS (
+			SELECT user1_id AS user_id, user2_id AS partner_id, poll_id FROM coffee_pairs
+			UNION ALL
+			SELECT user2_id AS user_id, user1_id AS partner_id, poll_id FROM coffee_pairs
+		)
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username,
+			COUNT(DISTINCT p.partner_id) AS partners_count,
+			COUNT(DISTINCT p.poll_id) AS rounds_count
+		FROM partners p
+		JOIN users u ON u.id = p.user_id
+		GROUP BY u.id, u.tg_id, u.firstname, u.lastname, u.tg_username
+		ORDER BY partners_count DESC, rounds_count DESC
+		LIMIT $1
+	`
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting most connected users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []RandomCoffeeConnectedUser
+	for rows.Next() {
+		var cu RandomCoffeeConnectedUser
+		if err := rows.Scan(
+			&cu.User.ID,
+			&cu.User.TgID,
+			&cu.User.Firstname,
+			&cu.User.Lastname,
+			&cu.User.TgUsername,
+			&cu.PartnersCount,
+			&cu.RoundsCount,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning most connected user row: %w", err)
+		}
+		users = append(users, cu)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration for most connected users: %w", err)
+	}
+
+	return users, nil
+}
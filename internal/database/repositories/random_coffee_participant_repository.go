@@ -8,12 +8,20 @@ import (
 )
 
 type RandomCoffeeParticipant struct {
-	ID              int64     `db:"id"`
-	PollID          int64     `db:"poll_id"`
-	UserID          int64     `db:"user_id"`
-	IsParticipating bool      `db:"is_participating"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	ID              int64 `db:"id"`
+	PollID          int64 `db:"poll_id"`
+	UserID          int64 `db:"user_id"`
+	IsParticipating bool  `db:"is_participating"`
+	// Role is set only for polls of matching programs with roles (e.g. "Mentor")
+	Role      sql.NullString `db:"role"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+// RandomCoffeeProgramParticipant is a participant of a matching program round together with their role
+type RandomCoffeeProgramParticipant struct {
+	User User
+	Role sql.NullString
 }
 
 type RandomCoffeeParticipantRepository struct {
@@ -26,13 +34,14 @@ func NewRandomCoffeeParticipantRepository(db *sql.DB) *RandomCoffeeParticipantRe
 
 func (r *RandomCoffeeParticipantRepository) UpsertParticipant(participant RandomCoffeeParticipant) error {
 	query := `
-		INSERT INTO random_coffee_participants (poll_id, user_id, is_participating, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())
+		INSERT INTO random_coffee_participants (poll_id, user_id, is_participating, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
 		ON CONFLICT (poll_id, user_id) DO UPDATE SET
 			is_participating = EXCLUDED.is_participating,
+			role = EXCLUDED.role,
 			updated_at = NOW()
 	`
-	_, err := r.db.Exec(query, participant.PollID, participant.UserID, participant.IsParticipating)
+	_, err := r.db.Exec(query, participant.PollID, participant.UserID, participant.IsParticipating, participant.Role)
 	return err
 }
 
@@ -43,10 +52,10 @@ func (r *RandomCoffeeParticipantRepository) RemoveParticipant(pollID int64, user
 }
 
 func (r *RandomCoffeeParticipantRepository) GetParticipant(pollID int64, userID int64) (*RandomCoffeeParticipant, error) {
-	query := "SELECT id, poll_id, user_id, is_participating, created_at, updated_at FROM random_coffee_participants WHERE poll_id = $1 AND user_id = $2"
+	query := "SELECT id, poll_id, user_id, is_participating, role, created_at, updated_at FROM random_coffee_participants WHERE poll_id = $1 AND user_id = $2"
 	row := r.db.QueryRow(query, pollID, userID)
 	p := &RandomCoffeeParticipant{}
-	err := row.Scan(&p.ID, &p.PollID, &p.UserID, &p.IsParticipating, &p.CreatedAt, &p.UpdatedAt)
+	err := row.Scan(&p.ID, &p.PollID, &p.UserID, &p.IsParticipating, &p.Role, &p.CreatedAt, &p.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Or a specific "not found" error
@@ -56,13 +65,62 @@ func (r *RandomCoffeeParticipantRepository) GetParticipant(pollID int64, userID
 	return p, nil
 }
 
-// GetParticipatingUsers retrieves all users who are participating in a given poll
+// weeklyRoundParticipantsQuery selects poll_id and user_id of everyone who took part in a published weekly
+// Random Coffee round, as recorded by AddRoundMembers, including members who were left without a partner
+const weeklyRoundParticipantsQuery = `
+	SELECT rm.poll_id, rm.user_id
+	FROM random_coffee_round_members rm
+	JOIN random_coffee_polls poll ON poll.id = rm.poll_id
+	WHERE poll.program_id IS NULL`
+
+// AddRoundMembers records who took part in a round when its pairs are published
+func (r *RandomCoffeeParticipantRepository) AddRoundMembers(pollID int64, userIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	for _, userID := range userIDs {
+		_, err := tx.Exec(`
+			INSERT INTO random_coffee_round_members (poll_id, user_id)
+			VALUES ($1, $2)
+			ON CONFLICT (poll_id, user_id) DO NOTHING`,
+			pollID, userID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to add member %d of round %d: %w", utils.GetCurrentTypeName(), userID, pollID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit round members: %w", utils.GetCurrentTypeName(), err)
+	}
+	return nil
+}
+
+// GetParticipatingUsers retrieves all users who are participating in a given poll.
+// Poll voters are merged with standing subscribers, unless a subscriber is paused for
+// the poll's week or explicitly voted "No" in this poll. Subscriptions apply only to the weekly Random Coffee.
+// Users who left the group are skipped, even if they voted before leaving.
 func (r *RandomCoffeeParticipantRepository) GetParticipatingUsers(pollID int64) ([]User, error) {
 	query := `
-		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username 
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username
 		FROM users u
 		JOIN random_coffee_participants rpc ON u.id = rpc.user_id
-		WHERE rpc.poll_id = $1 AND rpc.is_participating = TRUE
+		WHERE rpc.poll_id = $1 AND rpc.is_participating = TRUE AND u.is_club_member = TRUE
+		UNION
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username
+		FROM users u
+		JOIN random_coffee_subscriptions rcs ON u.id = rcs.user_id
+		JOIN random_coffee_polls rcp ON rcp.id = $1
+		WHERE rcp.program_id IS NULL
+			AND (rcs.paused_until IS NULL OR rcs.paused_until < rcp.week_start_date)
+			AND u.has_coffee_ban = FALSE
+			AND u.is_club_member = TRUE
+			AND NOT EXISTS (
+				SELECT 1 FROM random_coffee_participants p
+				WHERE p.poll_id = $1 AND p.user_id = u.id AND p.is_participating = FALSE
+			)
 	`
 	rows, err := r.db.Query(query, pollID)
 	if err != nil {
@@ -84,3 +142,103 @@ func (r *RandomCoffeeParticipantRepository) GetParticipatingUsers(pollID int64)
 	}
 	return users, nil
 }
+
+// GetUsersToRemind retrieves users who took part in the round before the given poll, paired or not,
+// but have not voted in this poll yet. Subscribers and users who muted reminders are skipped.
+func (r *RandomCoffeeParticipantRepository) GetUsersToRemind(pollID int64) ([]User, error) {
+	query := `
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username
+		FROM users u
+		JOIN random_coffee_round_members rm ON u.id = rm.user_id
+		WHERE rm.poll_id = (
+				SELECT id FROM random_coffee_polls WHERE id < $1 AND program_id IS NULL ORDER BY id DESC LIMIT 1
+			)
+			AND u.has_coffee_ban = FALSE
+			AND u.is_club_member = TRUE
+			AND NOT EXISTS (
+				SELECT 1 FROM random_coffee_participants p WHERE p.poll_id = $1 AND p.user_id = u.id
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM random_coffee_subscriptions s WHERE s.user_id = u.id
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM random_coffee_reminder_mutes m WHERE m.user_id = u.id
+			)
+	`
+	rows, err := r.db.Query(query, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get users to remind: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.TgID, &user.Firstname, &user.Lastname, &user.TgUsername); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for users to remind: %w", utils.GetCurrentTypeName(), err)
+	}
+	return users, nil
+}
+
+// GetProgramParticipants retrieves the users who voted to participate in a matching program poll, with their roles.
+// Users who left the group are skipped, even if they voted before leaving.
+func (r *RandomCoffeeParticipantRepository) GetProgramParticipants(pollID int64) ([]RandomCoffeeProgramParticipant, error) {
+	query := `
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username, rpc.role
+		FROM users u
+		JOIN random_coffee_participants rpc ON u.id = rpc.user_id
+		WHERE rpc.poll_id = $1
+			AND rpc.is_participating = TRUE
+			AND u.has_coffee_ban = FALSE
+			AND u.is_club_member = TRUE
+	`
+	rows, err := r.db.Query(query, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get program participants: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var participants []RandomCoffeeProgramParticipant
+	for rows.Next() {
+		var p RandomCoffeeProgramParticipant
+		if err := rows.Scan(&p.User.ID, &p.User.TgID, &p.User.Firstname, &p.User.Lastname, &p.User.TgUsername, &p.Role); err != nil {
+			return nil, err
+		}
+		participants = append(participants, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for program participants: %w", utils.GetCurrentTypeName(), err)
+	}
+	return participants, nil
+}
+
+// GetRoles returns the roles participants picked in a matching program poll, by user ID
+func (r *RandomCoffeeParticipantRepository) GetRoles(pollID int64) (map[int]string, error) {
+	rows, err := r.db.Query(`
+		SELECT user_id, role FROM random_coffee_participants
+		WHERE poll_id = $1 AND is_participating = TRUE AND role IS NOT NULL`,
+		pollID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get roles of poll %d: %w", utils.GetCurrentTypeName(), pollID, err)
+	}
+	defer rows.Close()
+
+	roles := make(map[int]string)
+	for rows.Next() {
+		var userID int
+		var role string
+		if err := rows.Scan(&userID, &role); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan role: %w", utils.GetCurrentTypeName(), err)
+		}
+		roles[userID] = role
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for roles: %w", utils.GetCurrentTypeName(), err)
+	}
+	return roles, nil
+}
@@ -13,6 +13,17 @@ type RandomCoffeePoll struct {
 	WeekStartDate  time.Time `db:"week_start_date"`
 	TelegramPollID string    `db:"telegram_poll_id"`
 	CreatedAt      time.Time `db:"created_at"`
+	// ProgramID is NULL for the weekly Random Coffee
+	ProgramID sql.NullInt64 `db:"program_id"`
+	ClosedAt  sql.NullTime  `db:"closed_at"`
+	// Roles of the program when the poll was sent, so editing the program doesn't affect an open round
+	FirstRole  sql.NullString `db:"first_role"`
+	SecondRole sql.NullString `db:"second_role"`
+}
+
+// HasRoles reports whether the round matches members of the first role only with members of the second role
+func (p *RandomCoffeePoll) HasRoles() bool {
+	return p.FirstRole.Valid && p.SecondRole.Valid
 }
 
 type RandomCoffeePollRepository struct {
@@ -25,8 +36,8 @@ func NewRandomCoffeePollRepository(db *sql.DB) *RandomCoffeePollRepository {
 
 func (r *RandomCoffeePollRepository) CreatePoll(poll RandomCoffeePoll) (int64, error) {
 	query := `
-		INSERT INTO random_coffee_polls (message_id, week_start_date, telegram_poll_id, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO random_coffee_polls (message_id, week_start_date, telegram_poll_id, created_at, program_id, first_role, second_role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id
 	`
 	var id int64
@@ -40,6 +51,9 @@ func (r *RandomCoffeePollRepository) CreatePoll(poll RandomCoffeePoll) (int64, e
 		poll.WeekStartDate,
 		poll.TelegramPollID,
 		poll.CreatedAt,
+		poll.ProgramID,
+		poll.FirstRole,
+		poll.SecondRole,
 	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("%s: failed to create poll: %w", utils.GetCurrentTypeName(), err)
@@ -49,7 +63,7 @@ func (r *RandomCoffeePollRepository) CreatePoll(poll RandomCoffeePoll) (int64, e
 
 func (r *RandomCoffeePollRepository) GetPollByTelegramPollID(telegramPollID string) (*RandomCoffeePoll, error) {
 	query := `
-		SELECT id, message_id, week_start_date, telegram_poll_id, created_at
+		SELECT id, message_id, week_start_date, telegram_poll_id, created_at, program_id, closed_at, first_role, second_role
 		FROM random_coffee_polls
 		WHERE telegram_poll_id = $1
 	`
@@ -60,6 +74,10 @@ func (r *RandomCoffeePollRepository) GetPollByTelegramPollID(telegramPollID stri
 		&poll.WeekStartDate,
 		&poll.TelegramPollID,
 		&poll.CreatedAt,
+		&poll.ProgramID,
+		&poll.ClosedAt,
+		&poll.FirstRole,
+		&poll.SecondRole,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -70,11 +88,40 @@ func (r *RandomCoffeePollRepository) GetPollByTelegramPollID(telegramPollID stri
 	return poll, nil
 }
 
-// GetLatestPoll retrieves the latest poll globally
+// GetPollByID retrieves a poll by its ID, or nil if there is none
+func (r *RandomCoffeePollRepository) GetPollByID(pollID int64) (*RandomCoffeePoll, error) {
+	query := `
+		SELECT id, message_id, week_start_date, telegram_poll_id, created_at, program_id, closed_at, first_role, second_role
+		FROM random_coffee_polls
+		WHERE id = $1
+	`
+	poll := &RandomCoffeePoll{}
+	err := r.db.QueryRow(query, pollID).Scan(
+		&poll.ID,
+		&poll.MessageID,
+		&poll.WeekStartDate,
+		&poll.TelegramPollID,
+		&poll.CreatedAt,
+		&poll.ProgramID,
+		&poll.ClosedAt,
+		&poll.FirstRole,
+		&poll.SecondRole,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to get poll %d: %w", utils.GetCurrentTypeName(), pollID, err)
+	}
+	return poll, nil
+}
+
+// GetLatestPoll retrieves the latest poll of the weekly Random Coffee
 func (r *RandomCoffeePollRepository) GetLatestPoll() (*RandomCoffeePoll, error) {
 	query := `
-		SELECT id, message_id, week_start_date, telegram_poll_id, created_at
+		SELECT id, message_id, week_start_date, telegram_poll_id, created_at, program_id, closed_at, first_role, second_role
 		FROM random_coffee_polls
+		WHERE program_id IS NULL
 		ORDER BY week_start_date DESC, id DESC 
 		LIMIT 1
 	`
@@ -85,6 +132,10 @@ func (r *RandomCoffeePollRepository) GetLatestPoll() (*RandomCoffeePoll, error)
 		&poll.WeekStartDate,
 		&poll.TelegramPollID,
 		&poll.CreatedAt,
+		&poll.ProgramID,
+		&poll.ClosedAt,
+		&poll.FirstRole,
+		&poll.SecondRole,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -94,3 +145,41 @@ func (r *RandomCoffeePollRepository) GetLatestPoll() (*RandomCoffeePoll, error)
 	}
 	return poll, nil
 }
+
+// GetLatestProgramPoll retrieves the latest poll of a matching program, or nil if there is none
+func (r *RandomCoffeePollRepository) GetLatestProgramPoll(programID int) (*RandomCoffeePoll, error) {
+	query := `
+		SELECT id, message_id, week_start_date, telegram_poll_id, created_at, program_id, closed_at, first_role, second_role
+		FROM random_coffee_polls
+		WHERE program_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	poll := &RandomCoffeePoll{}
+	err := r.db.QueryRow(query, programID).Scan(
+		&poll.ID,
+		&poll.MessageID,
+		&poll.WeekStartDate,
+		&poll.TelegramPollID,
+		&poll.CreatedAt,
+		&poll.ProgramID,
+		&poll.ClosedAt,
+		&poll.FirstRole,
+		&poll.SecondRole,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to get latest poll for program %d: %w", utils.GetCurrentTypeName(), programID, err)
+	}
+	return poll, nil
+}
+
+// ClosePoll marks a poll as done, so its round is not matched again
+func (r *RandomCoffeePollRepository) ClosePoll(pollID int64) error {
+	if _, err := r.db.Exec(`UPDATE random_coffee_polls SET closed_at = NOW() WHERE id = $1`, pollID); err != nil {
+		return fmt.Errorf("%s: failed to close poll %d: %w", utils.GetCurrentTypeName(), pollID, err)
+	}
+	return nil
+}
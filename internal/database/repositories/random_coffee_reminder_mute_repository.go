@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+)
+
+// RandomCoffeeReminderMuteRepository stores users who don't want Random Coffee reminders
+type RandomCoffeeReminderMuteRepository struct {
+	db *sql.DB
+}
+
+func NewRandomCoffeeReminderMuteRepository(db *sql.DB) *RandomCoffeeReminderMuteRepository {
+	return &RandomCoffeeReminderMuteRepository{db: db}
+}
+
+// IsMuted reports whether a user has muted Random Coffee reminders
+func (r *RandomCoffeeReminderMuteRepository) IsMuted(userID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM random_coffee_reminder_mutes WHERE user_id = $1)`
+	var muted bool
+	if err := r.db.QueryRow(query, userID).Scan(&muted); err != nil {
+		return false, fmt.Errorf("%s: failed to check reminder mute for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return muted, nil
+}
+
+// Mute stops Random Coffee reminders for a user
+func (r *RandomCoffeeReminderMuteRepository) Mute(userID int64) error {
+	query := `INSERT INTO random_coffee_reminder_mutes (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to mute reminders for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
+
+// Unmute resumes Random Coffee reminders for a user
+func (r *RandomCoffeeReminderMuteRepository) Unmute(userID int64) error {
+	query := `DELETE FROM random_coffee_reminder_mutes WHERE user_id = $1`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to unmute reminders for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
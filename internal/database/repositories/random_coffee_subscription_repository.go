@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"log"
+	"time"
+)
+
+// RandomCoffeeSubscription is a standing opt-in to every Random Coffee round
+type RandomCoffeeSubscription struct {
+	ID          int64        `db:"id"`
+	UserID      int64        `db:"user_id"`
+	PausedUntil sql.NullTime `db:"paused_until"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at"`
+}
+
+// IsPausedFor reports whether the subscription is paused for the week starting at weekStartDate
+func (s *RandomCoffeeSubscription) IsPausedFor(weekStartDate time.Time) bool {
+	return s.PausedUntil.Valid && !s.PausedUntil.Time.Before(weekStartDate)
+}
+
+type RandomCoffeeSubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewRandomCoffeeSubscriptionRepository(db *sql.DB) *RandomCoffeeSubscriptionRepository {
+	return &RandomCoffeeSubscriptionRepository{db: db}
+}
+
+// GetByUserID returns the subscription of a user, or nil if the user is not subscribed
+func (r *RandomCoffeeSubscriptionRepository) GetByUserID(userID int64) (*RandomCoffeeSubscription, error) {
+	query := `
+		SELECT id, user_id, paused_until, created_at, updated_at
+		FROM random_coffee_subscriptions
+		WHERE user_id = $1
+	`
+	s := &RandomCoffeeSubscription{}
+	err := r.db.QueryRow(query, userID).Scan(&s.ID, &s.UserID, &s.PausedUntil, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s: failed to get subscription for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return s, nil
+}
+
+// Subscribe creates an active subscription for a user or resumes a paused one
+func (r *RandomCoffeeSubscriptionRepository) Subscribe(userID int64) error {
+	query := `
+		INSERT INTO random_coffee_subscriptions (user_id, paused_until)
+		VALUES ($1, NULL)
+		ON CONFLICT (user_id) DO UPDATE SET paused_until = NULL
+	`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to subscribe user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
+
+// PauseUntil pauses an existing subscription up to and including the given date
+func (r *RandomCoffeeSubscriptionRepository) PauseUntil(userID int64, until time.Time) error {
+	query := `UPDATE random_coffee_subscriptions SET paused_until = $1 WHERE user_id = $2`
+	result, err := r.db.Exec(query, until, userID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to pause subscription for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("%s: Could not get rows affected after update: %v", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no subscription found for user %d to pause", utils.GetCurrentTypeName(), userID)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the subscription of a user
+func (r *RandomCoffeeSubscriptionRepository) Unsubscribe(userID int64) error {
+	query := `DELETE FROM random_coffee_subscriptions WHERE user_id = $1`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to unsubscribe user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
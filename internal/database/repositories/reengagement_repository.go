@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"time"
+)
+
+// ReengagementRepository handles database operations for the reminders sent to silent members
+type ReengagementRepository struct {
+	db *sql.DB
+}
+
+// NewReengagementRepository creates a new ReengagementRepository
+func NewReengagementRepository(db *sql.DB) *ReengagementRepository {
+	return &ReengagementRepository{db: db}
+}
+
+// GetSilentMembers retrieves the club members whose last activity is before the given time
+// and who haven't been reminded since, except those who opted out.
+// The activity is the last message in the group, the last join or the first time the bot saw the member.
+func (r *ReengagementRepository) GetSilentMembers(silentSince time.Time) ([]User, error) {
+	query := `
+		WITH last_messages AS (
+			SELECT user_tg_id, MAX(created_at) AS created_at
+			FROM group_messages
+			GROUP BY user_tg_id
+		),
+		last_joins AS (
+			SELECT user_id, MAX(created_at) AS created_at
+			FROM club_membership_events
+			WHERE event = $2
+			GROUP BY user_id
+		),
+		activity AS (
+			SELECT u.id AS user_id, GREATEST(lm.created_at, lj.created_at, u.created_at) AS last_active_at
+			FROM users u
+			LEFT JOIN last_messages lm ON lm.user_tg_id = u.tg_id
+			LEFT JOIN last_joins lj ON lj.user_id = u.id
+			WHERE u.is_club_member = TRUE
+		)
+		SELECT u.id, u.tg_id, u.firstname, u.lastname, u.tg_username, u.score, u.has_coffee_ban, u.is_club_member, u.created_at, u.updated_at
+		FROM activity a
+		JOIN users u ON u.id = a.user_id
+		LEFT JOIN reengagement_messages rm ON rm.user_id = a.user_id
+		WHERE a.last_active_at < $1
+			AND (rm.user_id IS NULL OR (NOT rm.opted_out AND (rm.last_sent_at IS NULL OR rm.last_sent_at < a.last_active_at)))
+		ORDER BY a.last_active_at ASC`
+
+	rows, err := r.db.Query(query, silentSince, constants.ClubMembershipEventJoined)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query silent members: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(
+			&user.ID,
+			&user.TgID,
+			&user.Firstname,
+			&user.Lastname,
+			&user.TgUsername,
+			&user.Score,
+			&user.HasCoffeeBan,
+			&user.IsClubMember,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan silent member row: %w", utils.GetCurrentTypeName(), err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for silent members: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return users, nil
+}
+
+// MarkSent records that a member was sent the reminder, so they aren't reminded again until they are active
+func (r *ReengagementRepository) MarkSent(userID int) error {
+	query := `
+		INSERT INTO reengagement_messages (user_id, last_sent_at)
+		VALUES ($1, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET last_sent_at = NOW(), updated_at = NOW()`
+	if _, err := r.db.Exec(query, userID); err != nil {
+		return fmt.Errorf("%s: failed to mark reminder as sent for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
+
+// SetOptedOut stops or resumes the reminders for a member
+func (r *ReengagementRepository) SetOptedOut(userID int, optedOut bool) error {
+	query := `
+		INSERT INTO reengagement_messages (user_id, opted_out)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET opted_out = $2, updated_at = NOW()`
+	if _, err := r.db.Exec(query, userID, optedOut); err != nil {
+		return fmt.Errorf("%s: failed to set reminder opt-out for user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"log"
@@ -10,13 +11,25 @@ import (
 
 // Topic represents a row in the topics table
 type Topic struct {
-	ID           int
-	Topic        string
-	UserNickname *string
-	EventID      int
-	CreatedAt    time.Time
+	ID              int
+	Topic           string
+	UserNickname    *string // Nil for anonymous topics
+	EventID         int
+	AuthorUserID    *int // Nil for anonymous topics, see GetTopicAuthorUserID
+	IsAnonymous     bool
+	Status          constants.TopicStatus
+	RejectionReason string
+	Votes           int // Number of members who upvoted the topic
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
+const topicSelectQuery = `
+	SELECT t.id, t.topic, t.user_nickname, t.event_id, t.author_user_id, t.is_anonymous, t.status, t.rejection_reason,
+		(SELECT COUNT(*) FROM topic_votes v WHERE v.topic_id = t.id) AS votes,
+		t.created_at, t.updated_at
+	FROM topics t`
+
 // TopicRepository handles database operations for topics
 type TopicRepository struct {
 	db *sql.DB
@@ -27,72 +40,190 @@ func NewTopicRepository(db *sql.DB) *TopicRepository {
 	return &TopicRepository{db: db}
 }
 
-// CreateTopic inserts a new topic record into the database
-func (r *TopicRepository) CreateTopic(topic string, userNickname string, eventID int) (int, error) {
+// CreateTopic inserts a new topic record into the database, it stays pending until an administrator approves it.
+// The author of an anonymous topic is kept apart from it, in the topic_anonymous_authors table.
+func (r *TopicRepository) CreateTopic(topic string, userNickname string, eventID int, authorUserID int, isAnonymous bool) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer tx.Rollback()
+
+	var nickname *string
+	var author *int
+	if !isAnonymous {
+		nickname = &userNickname
+		author = &authorUserID
+	}
+
 	var id int
-	query := `INSERT INTO topics (topic, user_nickname, event_id) VALUES ($1, $2, $3) RETURNING id`
-	err := r.db.QueryRow(query, topic, userNickname, eventID).Scan(&id)
+	query := `
+		INSERT INTO topics (topic, user_nickname, event_id, author_user_id, is_anonymous, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	err = tx.QueryRow(query, topic, nickname, eventID, author, isAnonymous, constants.TopicStatusPending).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("%s: failed to insert topic: %w", utils.GetCurrentTypeName(), err)
 	}
+
+	if isAnonymous {
+		_, err = tx.Exec(`INSERT INTO topic_anonymous_authors (topic_id, user_id) VALUES ($1, $2)`, id, authorUserID)
+		if err != nil {
+			return 0, fmt.Errorf("%s: failed to insert author of anonymous topic: %w", utils.GetCurrentTypeName(), err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit topic: %w", utils.GetCurrentTypeName(), err)
+	}
+
 	return id, nil
 }
 
-// GetTopicsByEventID retrieves all topics for a specific event
+// GetTopicsByEventID retrieves all topics for a specific event, whatever their status
 func (r *TopicRepository) GetTopicsByEventID(eventID int) ([]Topic, error) {
-	query := `
-		SELECT id, topic, user_nickname, event_id, created_at
-		FROM topics
-		WHERE event_id = $1
-		ORDER BY created_at ASC`
+	return r.queryTopics(topicSelectQuery+`
+		WHERE t.event_id = $1
+		ORDER BY t.created_at ASC`, eventID)
+}
 
-	rows, err := r.db.Query(query, eventID)
+// GetRankedTopicsByEventID retrieves the approved topics for a specific event, the most upvoted first
+func (r *TopicRepository) GetRankedTopicsByEventID(eventID int) ([]Topic, error) {
+	return r.queryTopics(topicSelectQuery+`
+		WHERE t.event_id = $1 AND t.status = $2
+		ORDER BY votes DESC, t.created_at ASC`, eventID, constants.TopicStatusApproved)
+}
+
+// GetPendingTopics retrieves the topics waiting for moderation, the oldest first
+func (r *TopicRepository) GetPendingTopics(limit int) ([]Topic, error) {
+	return r.queryTopics(topicSelectQuery+`
+		WHERE t.status = $1
+		ORDER BY t.created_at ASC
+		LIMIT $2`, constants.TopicStatusPending, limit)
+}
+
+// GetTopicsByAuthor retrieves the topics the member added to events that haven't taken place yet, anonymous ones included
+func (r *TopicRepository) GetTopicsByAuthor(authorUserID int) ([]Topic, error) {
+	return r.queryTopics(topicSelectQuery+`
+		JOIN events e ON e.id = t.event_id
+		LEFT JOIN topic_anonymous_authors a ON a.topic_id = t.id
+		WHERE (t.author_user_id = $1 OR a.user_id = $1) AND e.status = $2
+		ORDER BY t.created_at ASC`, authorUserID, constants.EventStatusActual)
+}
+
+// GetTopicAuthorUserID returns the author of a topic, anonymous or not. Nil if the author is unknown,
+// e.g. for topics added before authors were recorded.
+func (r *TopicRepository) GetTopicAuthorUserID(topicID int) (*int, error) {
+	var authorUserID *int
+	err := r.db.QueryRow(`
+		SELECT COALESCE(t.author_user_id, a.user_id)
+		FROM topics t
+		LEFT JOIN topic_anonymous_authors a ON a.topic_id = t.id
+		WHERE t.id = $1`, topicID).Scan(&authorUserID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no topic found with ID %d", utils.GetCurrentTypeName(), topicID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to query topics for event ID %d: %w", utils.GetCurrentTypeName(), eventID, err)
+		return nil, fmt.Errorf("%s: failed to get author of topic %d: %w", utils.GetCurrentTypeName(), topicID, err)
 	}
-	defer rows.Close()
+	return authorUserID, nil
+}
 
-	var topics []Topic
-	for rows.Next() {
-		var t Topic
-		if err := rows.Scan(&t.ID, &t.Topic, &t.UserNickname, &t.EventID, &t.CreatedAt); err != nil {
-			return nil, fmt.Errorf("%s: failed to scan topic row: %w", utils.GetCurrentTypeName(), err)
-		}
-		topics = append(topics, t)
+// GetTopicByID retrieves a single topic record by its ID
+func (r *TopicRepository) GetTopicByID(id int) (*Topic, error) {
+	topic, err := scanTopic(r.db.QueryRow(topicSelectQuery+` WHERE t.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%s: no topic found with ID %d", utils.GetCurrentTypeName(), id)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%s: error during rows iteration for topics: %w", utils.GetCurrentTypeName(), err)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get topic with ID %d: %w", utils.GetCurrentTypeName(), id, err)
 	}
 
-	return topics, nil
+	return topic, nil
 }
 
-// GetTopicByID retrieves a single topic record by its ID
-func (r *TopicRepository) GetTopicByID(id int) (*Topic, error) {
-	query := `
-		SELECT id, topic, user_nickname, event_id, created_at
-		FROM topics
-		WHERE id = $1`
+// UpdateTopicText changes the text of a topic and sends it back to moderation, the votes are kept
+func (r *TopicRepository) UpdateTopicText(id int, topic string) error {
+	query := `UPDATE topics SET topic = $1, status = $2, rejection_reason = '', updated_at = NOW() WHERE id = $3`
+	return r.execTopicUpdate(query, id, topic, constants.TopicStatusPending, id)
+}
 
-	var topic Topic
-	err := r.db.QueryRow(query, id).Scan(
-		&topic.ID,
-		&topic.Topic,
-		&topic.UserNickname,
-		&topic.EventID,
-		&topic.CreatedAt,
-	)
+// UpdateTopicStatus sets the moderation status of a pending topic, the reason is kept only for rejected topics.
+// Only one of concurrent moderations succeeds, the others get an error.
+func (r *TopicRepository) UpdateTopicStatus(id int, status constants.TopicStatus, rejectionReason string) error {
+	if status != constants.TopicStatusRejected {
+		rejectionReason = ""
+	}
+	result, err := r.db.Exec(`
+		UPDATE topics SET status = $1, rejection_reason = $2, updated_at = NOW()
+		WHERE id = $3 AND status = $4`,
+		status, rejectionReason, id, constants.TopicStatusPending)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update topic with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
 
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("%s: no topic found with ID %d", utils.GetCurrentTypeName(), id)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: could not get rows affected after update: %w", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: topic %d not found or already moderated", utils.GetCurrentTypeName(), id)
 	}
 
+	return nil
+}
+
+// ToggleVote upvotes the topic for the member or takes the vote back if the member has already voted.
+// Returns true if the topic is upvoted by the member afterwards.
+func (r *TopicRepository) ToggleVote(topicID int, userID int) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO topic_votes (topic_id, user_id) VALUES ($1, $2)
+		ON CONFLICT (topic_id, user_id) DO NOTHING`, topicID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to get topic with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+		return false, fmt.Errorf("%s: failed to vote for topic %d: %w", utils.GetCurrentTypeName(), topicID, err)
 	}
 
-	return &topic, nil
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to get affected rows: %w", utils.GetCurrentTypeName(), err)
+	}
+	if inserted > 0 {
+		return true, nil
+	}
+
+	_, err = r.db.Exec(`DELETE FROM topic_votes WHERE topic_id = $1 AND user_id = $2`, topicID, userID)
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to remove vote for topic %d: %w", utils.GetCurrentTypeName(), topicID, err)
+	}
+	return false, nil
+}
+
+// GetUserVotedTopicIDs returns the IDs of the event's topics the member has upvoted
+func (r *TopicRepository) GetUserVotedTopicIDs(eventID int, userID int) (map[int]bool, error) {
+	rows, err := r.db.Query(`
+		SELECT v.topic_id
+		FROM topic_votes v
+		JOIN topics t ON t.id = v.topic_id
+		WHERE t.event_id = $1 AND v.user_id = $2`, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query votes of user %d: %w", utils.GetCurrentTypeName(), userID, err)
+	}
+	defer rows.Close()
+
+	voted := make(map[int]bool)
+	for rows.Next() {
+		var topicID int
+		if err := rows.Scan(&topicID); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan vote row: %w", utils.GetCurrentTypeName(), err)
+		}
+		voted[topicID] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for votes: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return voted, nil
 }
 
 // DeleteTopic removes a topic record from the database by its ID
@@ -112,3 +243,63 @@ func (r *TopicRepository) DeleteTopic(id int) error {
 
 	return nil
 }
+
+func (r *TopicRepository) queryTopics(query string, args ...any) ([]Topic, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query topics: %w", utils.GetCurrentTypeName(), err)
+	}
+	defer rows.Close()
+
+	var topics []Topic
+	for rows.Next() {
+		topic, err := scanTopic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan topic row: %w", utils.GetCurrentTypeName(), err)
+		}
+		topics = append(topics, *topic)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: error during rows iteration for topics: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return topics, nil
+}
+
+func (r *TopicRepository) execTopicUpdate(query string, id int, args ...any) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update topic with ID %d: %w", utils.GetCurrentTypeName(), id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("%s: Could not get rows affected after update: %v", utils.GetCurrentTypeName(), err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("%s: no topic found with ID %d to update", utils.GetCurrentTypeName(), id)
+	}
+
+	return nil
+}
+
+func scanTopic(scanner interface{ Scan(dest ...any) error }) (*Topic, error) {
+	var topic Topic
+	err := scanner.Scan(
+		&topic.ID,
+		&topic.Topic,
+		&topic.UserNickname,
+		&topic.EventID,
+		&topic.AuthorUserID,
+		&topic.IsAnonymous,
+		&topic.Status,
+		&topic.RejectionReason,
+		&topic.Votes,
+		&topic.CreatedAt,
+		&topic.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
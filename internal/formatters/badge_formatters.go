@@ -0,0 +1,83 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"evo-bot-go/internal/constants"
+)
+
+// GetBadgeEmoji returns the emoji of a badge
+func GetBadgeEmoji(badge constants.Badge) string {
+	switch badge {
+	case constants.BadgePublishedProfile:
+		return "\U0001faaa"
+	case constants.BadgeFirstCoffee:
+		return "\u2615"
+	case constants.BadgeCoffeeRegular:
+		return "\U0001fad6"
+	case constants.BadgeFirstTopic:
+		return "\U0001f4a1"
+	case constants.BadgeEventHost:
+		return "\U0001f399"
+	case constants.BadgeToolScout:
+		return "\U0001f6e0"
+	default:
+		return "\U0001f3c5"
+	}
+}
+
+// GetBadgeName returns the display name of a badge
+func GetBadgeName(badge constants.Badge) string {
+	switch badge {
+	case constants.BadgePublishedProfile:
+		return "Introduced"
+	case constants.BadgeFirstCoffee:
+		return "First coffee"
+	case constants.BadgeCoffeeRegular:
+		return "Coffee regular"
+	case constants.BadgeFirstTopic:
+		return "First topic"
+	case constants.BadgeEventHost:
+		return "Event host"
+	case constants.BadgeToolScout:
+		return "Tool scout"
+	default:
+		return string(badge)
+	}
+}
+
+// getBadgeDescription returns what a badge is awarded for
+func getBadgeDescription(badge constants.Badge, toolsCount int) string {
+	switch badge {
+	case constants.BadgePublishedProfile:
+		return "You published your profile in the Intro topic."
+	case constants.BadgeFirstCoffee:
+		return "You met your first member in Random Coffee."
+	case constants.BadgeCoffeeRegular:
+		return fmt.Sprintf("You took part in %d Random Coffee rounds.", constants.BadgeCoffeeRegularRounds)
+	case constants.BadgeFirstTopic:
+		return "Your first topic for an event was approved."
+	case constants.BadgeEventHost:
+		return "You hosted an event for the club."
+	case constants.BadgeToolScout:
+		return fmt.Sprintf("You shared %d tools in the Tools topic.", toolsCount)
+	default:
+		return "Thank you for your contribution to the club."
+	}
+}
+
+// FormatBadges renders the badges of a member in one line, empty if there are none
+func FormatBadges(badges []constants.Badge) string {
+	names := make([]string, 0, len(badges))
+	for _, badge := range badges {
+		names = append(names, GetBadgeEmoji(badge)+" "+GetBadgeName(badge))
+	}
+	return strings.Join(names, " · ")
+}
+
+// FormatBadgeAwardedMessage renders the congratulatory DM sent when a member earns a badge
+func FormatBadgeAwardedMessage(badge constants.Badge, toolsCount int) string {
+	return fmt.Sprintf("\U0001f389 <b>New badge: %s %s</b>\n\n%s\nThe badge is shown on your profile (/%s).",
+		GetBadgeEmoji(badge), GetBadgeName(badge), getBadgeDescription(badge, toolsCount), constants.ProfileCommand)
+}
@@ -0,0 +1,119 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/database/repositories"
+)
+
+// eventFeedbackCommentPreviewLimit and eventFeedbackCommentsLimit cap the comments in the report
+// to keep the message within Telegram limits
+const (
+	eventFeedbackCommentPreviewLimit = 300
+	eventFeedbackCommentsLimit       = 10
+)
+
+// FormatHtmlEventFeedbackRequest renders the feedback form sent to the attendees after an event.
+// The form posted in the group has no comment step, comments are collected in the DM only.
+func FormatHtmlEventFeedbackRequest(event repositories.Event, inGroup bool) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\U0001f64f Thanks for joining <b>%s</b>! How was it?\n\n", html.EscapeString(event.Name)))
+	if inGroup {
+		response.WriteString("Rate the event from 1 to 5 with the buttons below.")
+	} else {
+		response.WriteString("Rate the event from 1 to 5 with the buttons below, you can add a comment afterwards.")
+	}
+	response.WriteString("\n<i>Your feedback is shared with the organizers without your name.</i>")
+	return response.String()
+}
+
+// FormatHtmlEventFeedbackCommentPrompt renders the message asking for a comment after the member rated the event
+func FormatHtmlEventFeedbackCommentPrompt(event repositories.Event, rating int) string {
+	return fmt.Sprintf("%s Thanks for rating <b>%s</b>!\n\n"+
+		"Anything the organizers should keep or change? Send a comment, or press Skip.",
+		FormatRatingStars(rating), html.EscapeString(event.Name))
+}
+
+// FormatRatingStars renders a 1 to 5 rating as stars
+func FormatRatingStars(rating int) string {
+	return strings.Repeat("\u2b50", rating)
+}
+
+// FormatHtmlEventReport renders the feedback report of a finished event for the administrators
+func FormatHtmlEventReport(
+	event repositories.Event,
+	report repositories.EventFeedbackReport,
+	counts repositories.EventRSVPCounts,
+	topicsDiscussed int,
+	loc *time.Location,
+) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\U0001f4ca <b>Report: %s</b>\n", html.EscapeString(event.Name)))
+	response.WriteString(fmt.Sprintf("%s %s", GetTypeEmoji(event.TypeInfo), GetTypeName(event.TypeInfo)))
+	if event.StartedAt != nil && !event.StartedAt.IsZero() {
+		response.WriteString(", " + event.StartedAt.In(loc).Format("02.01.2006 at 15:04"))
+	}
+	response.WriteString("\n\n")
+
+	response.WriteString(fmt.Sprintf("<i>Attendance:</i> %d going, %d maybe, %d can't go, %d on the waitlist\n",
+		counts.Going, counts.Maybe, counts.NotGoing, counts.Waitlist))
+	response.WriteString(fmt.Sprintf("<i>Topics discussed:</i> %d\n", topicsDiscussed))
+
+	if report.Ratings == 0 {
+		response.WriteString("<i>Rating:</i> no ratings yet\n")
+		return response.String()
+	}
+
+	response.WriteString(fmt.Sprintf("<i>Rating:</i> %.1f / 5 from %d members\n", report.AverageRating, report.Ratings))
+	for rating := 5; rating >= 1; rating-- {
+		response.WriteString(fmt.Sprintf("%d \u2b50 \u2014 %d\n", rating, report.RatingCounts[rating-1]))
+	}
+
+	if len(report.Comments) > 0 {
+		response.WriteString(fmt.Sprintf("\n\U0001f4ac <b>Comments</b>: %d\n", len(report.Comments)))
+		for i, comment := range report.Comments {
+			if i == eventFeedbackCommentsLimit {
+				response.WriteString(fmt.Sprintf("<i>...and %d older comments</i>\n", len(report.Comments)-i))
+				break
+			}
+			response.WriteString(fmt.Sprintf("%s <blockquote expandable>%s</blockquote>\n",
+				FormatRatingStars(comment.Rating), html.EscapeString(truncateRunes(comment.Comment, eventFeedbackCommentPreviewLimit))))
+		}
+	}
+
+	return response.String()
+}
+
+// FormatHtmlEventFeedbackSummaries renders the key numbers of finished events, newest first.
+// The average rating of all rated events is shown at the end, so the trend is easy to compare against.
+func FormatHtmlEventFeedbackSummaries(title string, summaries []repositories.EventFeedbackSummary, loc *time.Location) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("%s\n", title))
+
+	if len(summaries) == 0 {
+		response.WriteString("\nNo finished events yet.")
+		return response.String()
+	}
+
+	total := 0.0
+	rated := 0
+	for _, s := range summaries {
+		rating := "no ratings"
+		if s.Ratings > 0 {
+			rating = fmt.Sprintf("\u2b50 %.1f (%d)", s.AverageRating, s.Ratings)
+			total += s.AverageRating
+			rated++
+		}
+		response.WriteString(fmt.Sprintf("\n%s <b>%s</b>\n", GetTypeEmoji(s.TypeInfo), html.EscapeString(s.Name)))
+		response.WriteString(fmt.Sprintf("\u2514   <i>ID</i> %d, %s: %s, \U0001f465 %d going, \U0001f4dd %d topics\n",
+			s.EventID, s.StartedAt.In(loc).Format("02.01.2006"), rating, s.Going, s.Topics))
+	}
+
+	if rated > 0 {
+		response.WriteString(fmt.Sprintf("\n<i>Average rating of %d rated events:</i> %.1f", rated, total/float64(rated)))
+	}
+	return response.String()
+}
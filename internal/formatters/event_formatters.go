@@ -2,45 +2,36 @@ package formatters
 
 import (
 	"fmt"
+	"html"
+	"strconv"
 	"strings"
 	"time"
 
 	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
 )
 
-func GetTypeEmoji(eventType constants.EventType) string {
-	switch eventType {
-	case constants.EventTypeClubCall:
-		return "\U0001f4ac"
-	case constants.EventTypeMeetup:
-		return "\U0001f399"
-	case constants.EventTypeWorkshop:
-		return "\u2699\ufe0f"
-	case constants.EventTypeReadingClub:
-		return "\U0001f4da"
-	case constants.EventTypeConference:
-		return "\U0001f465"
-	default:
+// eventDescriptionPreviewLimit caps descriptions in event lists to keep the message within Telegram limits
+const eventDescriptionPreviewLimit = 300
+
+// defaultEventAnnouncementTemplate is the Markdown header of the start announcement for the types without their own
+const defaultEventAnnouncementTemplate = "\U0001f534 *EVENT STARTING!* \U0001f534\n\n{emoji} *{name}*"
+
+// GetTypeEmoji returns the emoji of the event type, or a generic one if the type has none
+func GetTypeEmoji(eventType repositories.EventType) string {
+	if eventType.Emoji == "" {
 		return "\U0001f504"
 	}
+	return eventType.Emoji
 }
 
-func GetTypeName(eventType constants.EventType) string {
-	switch eventType {
-	case constants.EventTypeClubCall:
-		return "club call"
-	case constants.EventTypeMeetup:
-		return "meetup"
-	case constants.EventTypeWorkshop:
-		return "workshop"
-	case constants.EventTypeReadingClub:
-		return "reading club"
-	case constants.EventTypeConference:
-		return "conference"
-	default:
-		return string(eventType)
+// GetTypeName returns the display name of the event type, or its slug if the type is unknown
+func GetTypeName(eventType repositories.EventType) string {
+	if eventType.Name == "" {
+		return string(eventType.Slug)
 	}
+	return eventType.Name
 }
 
 func GetStatusEmoji(status constants.EventStatus) string {
@@ -54,7 +45,7 @@ func GetStatusEmoji(status constants.EventStatus) string {
 	}
 }
 
-func FormatEventListForTopicsView(events []repositories.Event, title string) string {
+func FormatEventListForTopicsView(events []repositories.Event, title string, loc *time.Location) string {
 	var response strings.Builder
 	response.WriteString(fmt.Sprintf("%s:\n", title))
 
@@ -62,65 +53,59 @@ func FormatEventListForTopicsView(events []repositories.Event, title string) str
 		// Handle optional started_at field
 		startedAtStr := "not set"
 		if event.StartedAt != nil && !event.StartedAt.IsZero() {
-			startedAtStr = event.StartedAt.Format("02.01.2006 at 15:04")
+			startedAtStr = event.StartedAt.In(loc).Format("02.01.2006 at 15:04")
 		}
 
-		typeEmoji := GetTypeEmoji(constants.EventType(event.Type))
-		typeName := GetTypeName(constants.EventType(event.Type))
+		typeEmoji := GetTypeEmoji(event.TypeInfo)
+		typeName := GetTypeName(event.TypeInfo)
 
 		response.WriteString(fmt.Sprintf("\n%s _%s_: *%s*\n", typeEmoji, typeName, event.Name))
 		response.WriteString(fmt.Sprintf("\u2514   _ID_ /%d, _when_: %s\n",
 			event.ID, startedAtStr))
+		if recurrence := FormatEventRecurrence(event, loc); recurrence != "" {
+			response.WriteString(fmt.Sprintf("\u2514   _repeats_: %s\n", recurrence))
+		}
 	}
 
 	return response.String()
 }
 
-func FormatEventListForEventsView(events []repositories.Event, title string) string {
+// FormatHtmlEventListForEventsView renders the upcoming events for members, with the responses from counts keyed by event ID
+func FormatHtmlEventListForEventsView(
+	events []repositories.Event,
+	counts map[int]repositories.EventRSVPCounts,
+	title string,
+	loc *time.Location,
+) string {
 	var response strings.Builder
-	response.WriteString(fmt.Sprintf("%s:\n", title))
+	response.WriteString(fmt.Sprintf("<b>%s</b>\n", title))
 
+	now := time.Now()
 	for _, event := range events {
-		// Handle optional started_at field
-		startedAtStr := "not set"
-		if event.StartedAt != nil && !event.StartedAt.IsZero() {
-			startedAtStr = event.StartedAt.Format("02.01.2006 at 15:04 UTC")
-
-			// Add time remaining if event is in the future
-			utcNow := time.Now().UTC()
-			if event.StartedAt.After(utcNow) {
-				timeUntil := event.StartedAt.Sub(utcNow)
-
-				switch {
-				case timeUntil <= 24*time.Hour:
-					// Less than 24 hours
-					hours := int(timeUntil.Hours())
-					mins := int(timeUntil.Minutes()) % 60
-					if hours > 0 {
-						startedAtStr += fmt.Sprintf(" _(in %dh %dmin)_", hours, mins)
-					} else {
-						startedAtStr += fmt.Sprintf(" _(in %dmin)_", mins)
-					}
-				case timeUntil <= 7*24*time.Hour:
-					// Less than 7 days
-					days := int(timeUntil.Hours() / 24)
-					hours := int(timeUntil.Hours()) % 24
-					startedAtStr += fmt.Sprintf(" _(in %dd %dh)_", days, hours)
-				}
-			}
-		}
+		typeEmoji := GetTypeEmoji(event.TypeInfo)
+		typeName := GetTypeName(event.TypeInfo)
 
-		typeEmoji := GetTypeEmoji(constants.EventType(event.Type))
-		typeName := GetTypeName(constants.EventType(event.Type))
-
-		response.WriteString(fmt.Sprintf("\n%s _%s_: *%s*\n", typeEmoji, typeName, event.Name))
-		response.WriteString(fmt.Sprintf("\u2514   _when_: %s\n", startedAtStr))
+		response.WriteString(fmt.Sprintf("\n%s <i>%s</i>: <b>%s</b>\n", typeEmoji, typeName, html.EscapeString(event.Name)))
+		response.WriteString(fmt.Sprintf("\u2514 <i>when</i>: %s\n", FormatEventWhen(event, loc, now)))
+		if place := FormatHtmlEventPlace(event); place != "" {
+			response.WriteString(fmt.Sprintf("\u2514 <i>where</i>: %s\n", place))
+		}
+		if host := FormatEventHost(event); host != "" {
+			response.WriteString(fmt.Sprintf("\u2514 <i>host</i>: %s\n", html.EscapeString(host)))
+		}
+		if attendance := FormatEventAttendance(event, counts[event.ID]); attendance != "" {
+			response.WriteString(fmt.Sprintf("\u2514 <i>attendance</i>: %s\n", attendance))
+		}
+		if event.Description != "" {
+			response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote>\n",
+				html.EscapeString(truncateRunes(event.Description, eventDescriptionPreviewLimit))))
+		}
 	}
 
 	return response.String()
 }
 
-func FormatEventListForAdmin(events []repositories.Event, title string, cancelCommand string, actionDescription string) string {
+func FormatEventListForAdmin(events []repositories.Event, title string, cancelCommand string, actionDescription string, loc *time.Location) string {
 	var response strings.Builder
 	response.WriteString(fmt.Sprintf("*%s*\n", title))
 
@@ -128,11 +113,11 @@ func FormatEventListForAdmin(events []repositories.Event, title string, cancelCo
 		// Handle optional started_at field
 		startedAtStr := "not set"
 		if event.StartedAt != nil && !event.StartedAt.IsZero() {
-			startedAtStr = event.StartedAt.Format("02.01.2006 at 15:04 UTC")
+			startedAtStr = fmt.Sprintf("%s %s", event.StartedAt.In(loc).Format("02.01.2006 at 15:04"), utils.TimezoneLabel(loc))
 		}
 
 		statusEmoji := GetStatusEmoji(constants.EventStatus(event.Status))
-		typeEmoji := GetTypeEmoji(constants.EventType(event.Type))
+		typeEmoji := GetTypeEmoji(event.TypeInfo)
 
 		response.WriteString(fmt.Sprintf("\n%s ID /%d: *%s*\n", typeEmoji, event.ID, event.Name))
 		response.WriteString(fmt.Sprintf("\u2514 %s _when_: *%s*\n",
@@ -144,28 +129,203 @@ func FormatEventListForAdmin(events []repositories.Event, title string, cancelCo
 	return response.String()
 }
 
-func FormatHtmlTopicListForUsers(topics []repositories.Topic, eventName string, eventType string) string {
+// FormatHtmlEventDetailsForAdmin renders all fields of an event, including the ones that are not set
+func FormatHtmlEventDetailsForAdmin(event repositories.Event, loc *time.Location) string {
+	notSet := "<i>not set</i>"
+
+	startedAtStr := notSet
+	if event.StartedAt != nil && !event.StartedAt.IsZero() {
+		startedAtStr = fmt.Sprintf("%s %s", event.StartedAt.In(loc).Format("02.01.2006 15:04"), utils.TimezoneLabel(loc))
+	}
+	durationStr := notSet
+	if event.DurationMinutes != nil {
+		durationStr = utils.FormatDurationMinutes(*event.DurationMinutes)
+	}
+	locationStr := notSet
+	if event.Location != "" {
+		locationStr = html.EscapeString(event.Location)
+	}
+	linkStr := notSet
+	if event.Link != "" {
+		linkStr = html.EscapeString(event.Link)
+	}
+	hostStr := notSet
+	if host := FormatEventHost(event); host != "" {
+		hostStr = html.EscapeString(host)
+	}
+	repeatsStr := "no"
+	if recurrence := FormatEventRecurrence(event, loc); recurrence != "" {
+		repeatsStr = recurrence
+	}
+	capacityStr := "unlimited"
+	if event.Capacity != nil {
+		capacityStr = strconv.Itoa(*event.Capacity)
+	}
+	descriptionStr := notSet
+	if event.Description != "" {
+		descriptionStr = fmt.Sprintf("<blockquote expandable>%s</blockquote>", html.EscapeString(event.Description))
+	}
+
+	return fmt.Sprintf("%s <b>%s</b> <i>(ID: %d)</i>\n\n", GetTypeEmoji(event.TypeInfo), html.EscapeString(event.Name), event.ID) +
+		fmt.Sprintf("Type: %s\n", GetTypeName(event.TypeInfo)) +
+		fmt.Sprintf("Start: %s\n", startedAtStr) +
+		fmt.Sprintf("Repeats: %s\n", repeatsStr) +
+		fmt.Sprintf("Duration: %s\n", durationStr) +
+		fmt.Sprintf("Location: %s\n", locationStr) +
+		fmt.Sprintf("Link: %s\n", linkStr) +
+		fmt.Sprintf("Host: %s\n", hostStr) +
+		fmt.Sprintf("Capacity: %s\n", capacityStr) +
+		fmt.Sprintf("Description: %s", descriptionStr)
+}
+
+// FormatEventWhen renders the planned start relative to now with the duration, e.g. "in 3 days, 19:00 Kyiv (1h 30min)"
+func FormatEventWhen(event repositories.Event, loc *time.Location, now time.Time) string {
+	if event.StartedAt == nil || event.StartedAt.IsZero() {
+		return "not set"
+	}
+
+	when := utils.FormatRelativeDateTime(*event.StartedAt, now, loc)
+	if event.DurationMinutes != nil {
+		when += fmt.Sprintf(" (%s)", utils.FormatDurationMinutes(*event.DurationMinutes))
+	}
+	return when
+}
+
+// FormatEventRecurrence describes how the event's series repeats, e.g. "every week on Thursday",
+// or returns an empty string for one-time events
+func FormatEventRecurrence(event repositories.Event, loc *time.Location) string {
+	if event.RecurrenceRule == "" || event.StartedAt == nil {
+		return ""
+	}
+	rule, err := utils.ParseRecurrenceRule(event.RecurrenceRule)
+	if err != nil {
+		return ""
+	}
+	return rule.Describe(event.StartedAt.In(loc))
+}
+
+// FormatHtmlEventPlace renders where the event takes place: the address and/or the join link
+func FormatHtmlEventPlace(event repositories.Event) string {
+	var parts []string
+	if event.Location != "" {
+		parts = append(parts, html.EscapeString(event.Location))
+	}
+	if event.Link != "" {
+		parts = append(parts, fmt.Sprintf("<a href=\"%s\">online</a>", html.EscapeString(event.Link)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatEventHost returns the host's @username or first name, or an empty string if there is no host
+func FormatEventHost(event repositories.Event) string {
+	if event.HostUserID == nil {
+		return ""
+	}
+	if event.HostUsername != "" {
+		return "@" + event.HostUsername
+	}
+	return event.HostFirstname
+}
+
+// FormatEventStartAnnouncement renders the Markdown announcement posted to the group when an event starts,
+// followed by the join link button. The header comes from the announcement template of the event type,
+// where {emoji}, {name} and {type} are replaced with the type emoji, the event name and the type name.
+func FormatEventStartAnnouncement(event repositories.Event) string {
+	template := event.TypeInfo.AnnouncementTemplate
+	if template == "" {
+		template = defaultEventAnnouncementTemplate
+	}
+
+	announcementMsg := strings.NewReplacer(
+		"{emoji}", GetTypeEmoji(event.TypeInfo),
+		"{name}", event.Name,
+		"{type}", GetTypeName(event.TypeInfo),
+	).Replace(template) + "\n"
+
+	if event.TypeInfo.RulesLink != "" {
+		announcementMsg += fmt.Sprintf("\U0001f4a1 [About the format and rules of this %s](%s)\n",
+			GetTypeName(event.TypeInfo), event.TypeInfo.RulesLink)
+	}
+
+	announcementMsg += "\nUse the button below to join \u2b07\ufe0f"
+
+	return announcementMsg
+}
+
+// Headers of the group messages that carry the event card, as they appear in the plain text of a message
+const (
+	eventAnnouncementHeader = "\U0001f4e3 New event!"
+	eventReminderHeader     = "\u23f0 Reminder"
+)
+
+// FormatHtmlEventAnnouncement renders the announcement of a new event posted to the announcement topic
+func FormatHtmlEventAnnouncement(event repositories.Event, counts repositories.EventRSVPCounts, loc *time.Location, now time.Time) string {
+	return "\U0001f4e3 <b>New event!</b>\n\n" +
+		FormatHtmlEventCard(event, counts, loc, now) +
+		"\nLet us know if you're coming \u2b07\ufe0f"
+}
+
+// FormatHtmlEventReminder renders the reminder posted to the announcement topic before an event starts
+func FormatHtmlEventReminder(event repositories.Event, counts repositories.EventRSVPCounts, loc *time.Location, now time.Time) string {
+	return "\u23f0 <b>Reminder</b>\n\n" +
+		FormatHtmlEventCard(event, counts, loc, now) +
+		"\nLet us know if you're coming \u2b07\ufe0f"
+}
+
+// FormatHtmlGroupEventMessage renders a group message with the event card again, keeping its kind:
+// the announcement, a reminder, or the bare card pinned in the event's topic. The kind is told by the
+// plain text of the current message.
+func FormatHtmlGroupEventMessage(currentText string, event repositories.Event, counts repositories.EventRSVPCounts, loc *time.Location, now time.Time) string {
+	switch {
+	case strings.HasPrefix(currentText, eventAnnouncementHeader):
+		return FormatHtmlEventAnnouncement(event, counts, loc, now)
+	case strings.HasPrefix(currentText, eventReminderHeader):
+		return FormatHtmlEventReminder(event, counts, loc, now)
+	}
+	return FormatHtmlEventCard(event, counts, loc, now)
+}
+
+// FormatHtmlEventReminderForAttendee renders the reminder sent in DM to the members who responded to an event
+func FormatHtmlEventReminderForAttendee(event repositories.Event, loc *time.Location, now time.Time) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\u23f0 Reminder: %s <b>%s</b> starts %s\n",
+		GetTypeEmoji(event.TypeInfo),
+		html.EscapeString(event.Name),
+		FormatEventWhen(event, loc, now),
+	))
+	if place := FormatHtmlEventPlace(event); place != "" {
+		response.WriteString(fmt.Sprintf("\U0001f4cd <i>Where</i>: %s\n", place))
+	}
+	response.WriteString("\nCan't make it? Please update your response below, so someone else can take your seat.")
+	return response.String()
+}
+
+// FormatHtmlTopicListForUsers renders the approved topics of an event ranked by votes,
+// numbered the same way as the vote buttons
+func FormatHtmlTopicListForUsers(topics []repositories.Topic, eventName string, eventType repositories.EventType) string {
 	var response strings.Builder
 
-	typeEmoji := GetTypeEmoji(constants.EventType(eventType))
-	typeName := GetTypeName(constants.EventType(eventType))
+	typeEmoji := GetTypeEmoji(eventType)
+	typeName := GetTypeName(eventType)
 
-	response.WriteString(fmt.Sprintf("\n %s Event (%s): <b>%s</b>\n", typeEmoji, typeName, eventName))
+	response.WriteString(fmt.Sprintf("\n %s Event (%s): <b>%s</b>\n", typeEmoji, typeName, html.EscapeString(eventName)))
 
 	if len(topics) == 0 {
 		response.WriteString(
 			fmt.Sprintf("\n\U0001f50d No topics or questions for this event yet.\n Use /%s to add one.", constants.TopicAddCommand))
 	} else {
 		topicCount := len(topics)
-		response.WriteString(fmt.Sprintf("\U0001f4cb Topics and questions found: <b>%d</b>\n\n", topicCount))
+		response.WriteString(fmt.Sprintf("\U0001f4cb Topics and questions found: <b>%d</b>, the most upvoted first\n\n", topicCount))
 
 		for i, topic := range topics {
 			// Format date as DD.MM.YYYY for better readability
 			dateFormatted := topic.CreatedAt.Format("02.01.2006")
 			response.WriteString(fmt.Sprintf(
-				"<i>%s</i> <blockquote expandable>%s</blockquote>\n",
+				"<b>%d.</b> \U0001f44d %d / <i>%s</i> <blockquote expandable>%s</blockquote>\n",
+				i+1,
+				topic.Votes,
 				dateFormatted,
-				topic.Topic,
+				html.EscapeString(topic.Topic),
 			))
 
 			// Don't add separator after the last item
@@ -176,8 +336,10 @@ func FormatHtmlTopicListForUsers(topics []repositories.Topic, eventName string,
 
 		response.WriteString(
 			fmt.Sprintf(
-				"\nUse /%s to add new topics and questions, or /%s to view topics for another event.",
+				"\nTap \U0001f44d with a topic's number to upvote it, tap it again to take your vote back.\n"+
+					"Use /%s to add new topics and questions, /%s to edit or withdraw yours, or /%s to view topics for another event.",
 				constants.TopicAddCommand,
+				constants.MyTopicsCommand,
 				constants.TopicsCommand,
 			),
 		)
@@ -186,30 +348,34 @@ func FormatHtmlTopicListForUsers(topics []repositories.Topic, eventName string,
 	return response.String()
 }
 
-func FormatHtmlTopicListForAdmin(topics []repositories.Topic, eventName string, eventType string) string {
+func FormatHtmlTopicListForAdmin(topics []repositories.Topic, eventName string, eventType repositories.EventType) string {
 	var response strings.Builder
 
-	typeEmoji := GetTypeEmoji(constants.EventType(eventType))
-	typeName := GetTypeName(constants.EventType(eventType))
+	typeEmoji := GetTypeEmoji(eventType)
+	typeName := GetTypeName(eventType)
 
-	response.WriteString(fmt.Sprintf("\n %s <i>Event (%s):</i> %s\n\n", typeEmoji, typeName, eventName))
+	response.WriteString(fmt.Sprintf("\n %s <i>Event (%s):</i> %s\n\n", typeEmoji, typeName, html.EscapeString(eventName)))
 
 	if len(topics) == 0 {
 		response.WriteString("No topics or questions for this event yet.")
 	} else {
 		for _, topic := range topics {
 			userNickname := "not specified"
-			if topic.UserNickname != nil {
+			if topic.IsAnonymous {
+				userNickname = "\U0001f576 anonymous"
+			} else if topic.UserNickname != nil {
 				userNickname = "@" + *topic.UserNickname
 			}
 			dateFormatted := topic.CreatedAt.Format("02.01.2006")
 			response.WriteString(fmt.Sprintf(
-				"ID:<code>%d</code> / <i>%s</i> / %s \n",
+				"ID:<code>%d</code> / <i>%s</i> / %s / %s / \U0001f44d %d \n",
 				topic.ID,
 				dateFormatted,
-				userNickname,
+				html.EscapeString(userNickname),
+				GetTopicStatusLabel(topic.Status),
+				topic.Votes,
 			))
-			response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote> \n", topic.Topic))
+			response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote> \n", html.EscapeString(topic.Topic)))
 		}
 	}
 
@@ -0,0 +1,93 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// eventMaterialDescriptionPreviewLimit caps material descriptions in the archive to keep the message within Telegram limits
+const eventMaterialDescriptionPreviewLimit = 100
+
+// GetEventMaterialKindLabel returns a human-readable label for the kind of an event material
+func GetEventMaterialKindLabel(kind constants.EventMaterialKind) string {
+	switch kind {
+	case constants.EventMaterialKindRecording:
+		return "\U0001f3a5 Recording"
+	case constants.EventMaterialKindSlides:
+		return "\U0001f4d1 Slides"
+	case constants.EventMaterialKindLink:
+		return "\U0001f517 Link"
+	default:
+		return string(kind)
+	}
+}
+
+// FormatHtmlEventMaterialPost renders the post of an event material in the Content topic.
+// The same text is saved for /content, so the event name, type and hashtags make the material easy to find.
+func FormatHtmlEventMaterialPost(
+	event repositories.Event,
+	kind constants.EventMaterialKind,
+	description string,
+	url string,
+	loc *time.Location,
+) string {
+	eventType := event.TypeInfo
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("%s: <b>%s</b>\n", GetEventMaterialKindLabel(kind), html.EscapeString(event.Name)))
+	response.WriteString(fmt.Sprintf("%s %s", GetTypeEmoji(eventType), GetTypeName(eventType)))
+	if event.StartedAt != nil && !event.StartedAt.IsZero() {
+		response.WriteString(", " + event.StartedAt.In(loc).Format("02.01.2006"))
+	}
+	response.WriteString("\n")
+
+	if description != "" {
+		response.WriteString("\n" + html.EscapeString(description) + "\n")
+	}
+	if url != "" {
+		response.WriteString("\n" + html.EscapeString(url) + "\n")
+	}
+
+	response.WriteString(fmt.Sprintf("\n#%s #%s", kind, strings.ReplaceAll(string(eventType.Slug), "-", "_")))
+	return response.String()
+}
+
+// FormatHtmlEventsArchive renders the past events with links to their materials in the Content topic
+func FormatHtmlEventsArchive(
+	events []repositories.Event,
+	materials map[int][]repositories.EventMaterial,
+	superGroupChatID int64,
+	contentTopicID int,
+	loc *time.Location,
+) string {
+	var response strings.Builder
+	response.WriteString("\U0001f5c2 <b>Past events</b>\n")
+
+	for _, event := range events {
+		response.WriteString(fmt.Sprintf("\n%s <b>%s</b>", GetTypeEmoji(event.TypeInfo), html.EscapeString(event.Name)))
+		if event.StartedAt != nil && !event.StartedAt.IsZero() {
+			response.WriteString(", " + event.StartedAt.In(loc).Format("02.01.2006"))
+		}
+		response.WriteString("\n")
+
+		if len(materials[event.ID]) == 0 {
+			response.WriteString("\u2514   <i>no materials yet</i>\n")
+			continue
+		}
+		for _, material := range materials[event.ID] {
+			response.WriteString(fmt.Sprintf("\u2514   <a href=\"https://t.me/c/%d/%d/%d\">%s</a>",
+				superGroupChatID, contentTopicID, material.MessageID, GetEventMaterialKindLabel(material.Kind)))
+			if material.Description != "" {
+				response.WriteString(" \u2014 " + html.EscapeString(truncateRunes(material.Description, eventMaterialDescriptionPreviewLimit)))
+			}
+			response.WriteString("\n")
+		}
+	}
+
+	return response.String()
+}
@@ -0,0 +1,84 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// GetEventProposalStatusLabel returns a human-readable label for the review status of an event proposal
+func GetEventProposalStatusLabel(status constants.EventProposalStatus) string {
+	switch status {
+	case constants.EventProposalStatusPending:
+		return "\u23f3 pending"
+	case constants.EventProposalStatusApproved:
+		return "\u2705 approved"
+	case constants.EventProposalStatusRejected:
+		return "\u274c rejected"
+	case constants.EventProposalStatusChangesRequested:
+		return "\u270f\ufe0f changes requested"
+	default:
+		return string(status)
+	}
+}
+
+// FormatHtmlEventProposal renders the details of an event proposal, used for the review and the summary to the proposer
+func FormatHtmlEventProposal(proposal repositories.EventProposal) string {
+	notSet := "<i>not set</i>"
+
+	descriptionStr := notSet
+	if proposal.Description != "" {
+		descriptionStr = fmt.Sprintf("<blockquote expandable>%s</blockquote>", html.EscapeString(proposal.Description))
+	}
+	datesStr := notSet
+	if proposal.PreferredDates != "" {
+		datesStr = html.EscapeString(proposal.PreferredDates)
+	}
+
+	return fmt.Sprintf("%s <b>%s</b>\n\n", GetTypeEmoji(proposal.TypeInfo), html.EscapeString(proposal.Title)) +
+		fmt.Sprintf("Type: %s\n", GetTypeName(proposal.TypeInfo)) +
+		fmt.Sprintf("Preferred dates: %s\n", datesStr) +
+		fmt.Sprintf("Description: %s", descriptionStr)
+}
+
+// FormatHtmlEventProposalForReview renders a new or revised proposal for the administrators
+func FormatHtmlEventProposalForReview(proposal repositories.EventProposal, proposer repositories.User) string {
+	title := "\U0001f4a1 <b>New event proposal</b>"
+	if proposal.UpdatedAt.After(proposal.CreatedAt) {
+		title = "\U0001f4a1 <b>Revised event proposal</b>"
+	}
+
+	author := html.EscapeString(strings.TrimSpace(proposer.Firstname + " " + proposer.Lastname))
+	if proposer.TgUsername != "" {
+		author += " @" + html.EscapeString(proposer.TgUsername)
+	}
+
+	return fmt.Sprintf("%s <i>(ID: %d)</i>\n<i>Proposed by:</i> %s\n\n%s",
+		title, proposal.ID, author, FormatHtmlEventProposal(proposal))
+}
+
+// FormatHtmlEventProposalReviewResult renders the message sent to the proposer once the proposal is reviewed
+func FormatHtmlEventProposalReviewResult(proposal repositories.EventProposal) string {
+	var response strings.Builder
+	title := html.EscapeString(proposal.Title)
+
+	switch proposal.Status {
+	case constants.EventProposalStatusApproved:
+		response.WriteString(fmt.Sprintf("\U0001f389 Your event <b>%s</b> was approved and you're its host! "+
+			"The administrators will set the exact time and announce it in the group.", title))
+	case constants.EventProposalStatusRejected:
+		response.WriteString(fmt.Sprintf("\u274c Your event proposal <b>%s</b> was declined.", title))
+	case constants.EventProposalStatusChangesRequested:
+		response.WriteString(fmt.Sprintf("\u270f\ufe0f The administrators asked for some changes to your event proposal <b>%s</b>.", title))
+	}
+	if proposal.AdminComment != "" {
+		response.WriteString(fmt.Sprintf("\n<i>Comment:</i> %s", html.EscapeString(proposal.AdminComment)))
+	}
+	if proposal.Status == constants.EventProposalStatusChangesRequested {
+		response.WriteString("\n\nPress the button below to revise the proposal and send it again.")
+	}
+	return response.String()
+}
@@ -0,0 +1,155 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// GetRSVPStatusLabel returns a human-readable label for a member's response
+func GetRSVPStatusLabel(status constants.EventRSVPStatus) string {
+	switch status {
+	case constants.EventRSVPStatusGoing:
+		return "\u2705 going"
+	case constants.EventRSVPStatusMaybe:
+		return "\U0001f914 maybe"
+	case constants.EventRSVPStatusNotGoing:
+		return "\u274c not going"
+	case constants.EventRSVPStatusWaitlist:
+		return "\u23f3 on the waitlist"
+	default:
+		return "no response"
+	}
+}
+
+// FormatEventAttendance renders the responses to an event, e.g. "12/20 going, 3 on the waitlist, 4 maybe".
+// Returns an empty string for an event without a capacity that nobody has responded to yet.
+func FormatEventAttendance(event repositories.Event, counts repositories.EventRSVPCounts) string {
+	var parts []string
+	if event.Capacity != nil {
+		parts = append(parts, fmt.Sprintf("%d/%d going", counts.Going, *event.Capacity))
+	} else if counts.Going > 0 {
+		parts = append(parts, fmt.Sprintf("%d going", counts.Going))
+	}
+	if counts.Waitlist > 0 {
+		parts = append(parts, fmt.Sprintf("%d on the waitlist", counts.Waitlist))
+	}
+	if counts.Maybe > 0 {
+		parts = append(parts, fmt.Sprintf("%d maybe", counts.Maybe))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatHtmlEventCard renders a single event with its responses, used for announcements and the RSVP view
+func FormatHtmlEventCard(event repositories.Event, counts repositories.EventRSVPCounts, loc *time.Location, now time.Time) string {
+	var response strings.Builder
+
+	typeEmoji := GetTypeEmoji(event.TypeInfo)
+	typeName := GetTypeName(event.TypeInfo)
+
+	response.WriteString(fmt.Sprintf("%s <i>%s</i>: <b>%s</b>\n\n", typeEmoji, typeName, html.EscapeString(event.Name)))
+	response.WriteString(fmt.Sprintf("\U0001f552 <i>When</i>: %s\n", FormatEventWhen(event, loc, now)))
+	if recurrence := FormatEventRecurrence(event, loc); recurrence != "" {
+		response.WriteString(fmt.Sprintf("\U0001f501 <i>Repeats</i>: %s\n", recurrence))
+	}
+	if place := FormatHtmlEventPlace(event); place != "" {
+		response.WriteString(fmt.Sprintf("\U0001f4cd <i>Where</i>: %s\n", place))
+	}
+	if host := FormatEventHost(event); host != "" {
+		response.WriteString(fmt.Sprintf("\U0001f3a4 <i>Host</i>: %s\n", html.EscapeString(host)))
+	}
+	attendance := FormatEventAttendance(event, counts)
+	if attendance == "" {
+		attendance = "no responses yet"
+	}
+	response.WriteString(fmt.Sprintf("\U0001f465 <i>Attendance</i>: %s\n", attendance))
+	if event.Description != "" {
+		response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote>\n", html.EscapeString(event.Description)))
+	}
+
+	return response.String()
+}
+
+// FormatHtmlEventAttendees renders everyone who responded to the event, grouped by their response
+func FormatHtmlEventAttendees(event repositories.Event, attendees []repositories.EventAttendee, loc *time.Location, now time.Time) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\U0001f465 <b>Attendees: %s</b>\n", html.EscapeString(event.Name)))
+	response.WriteString(fmt.Sprintf("<i>%s</i>\n", FormatEventWhen(event, loc, now)))
+
+	if len(attendees) == 0 {
+		response.WriteString("\nNobody has responded yet.")
+		return response.String()
+	}
+
+	byStatus := make(map[constants.EventRSVPStatus][]repositories.User)
+	for _, attendee := range attendees {
+		byStatus[attendee.Status] = append(byStatus[attendee.Status], attendee.User)
+	}
+
+	going := byStatus[constants.EventRSVPStatusGoing]
+	goingTitle := fmt.Sprintf("\u2705 Going (%d)", len(going))
+	if event.Capacity != nil {
+		goingTitle = fmt.Sprintf("\u2705 Going (%d/%d)", len(going), *event.Capacity)
+	}
+
+	writeUserList(&response, goingTitle, going)
+	writeUserList(&response, fmt.Sprintf("\u23f3 Waitlist (%d)", len(byStatus[constants.EventRSVPStatusWaitlist])),
+		byStatus[constants.EventRSVPStatusWaitlist])
+	writeUserList(&response, fmt.Sprintf("\U0001f914 Maybe (%d)", len(byStatus[constants.EventRSVPStatusMaybe])),
+		byStatus[constants.EventRSVPStatusMaybe])
+	if notGoing := len(byStatus[constants.EventRSVPStatusNotGoing]); notGoing > 0 {
+		response.WriteString(fmt.Sprintf("\n\u274c Not going: %d\n", notGoing))
+	}
+
+	return response.String()
+}
+
+// FormatHtmlMyEvents renders the upcoming events the member is going to, might go to or is waitlisted for
+func FormatHtmlMyEvents(responses []repositories.EventRSVP, loc *time.Location, now time.Time) string {
+	if len(responses) == 0 {
+		return fmt.Sprintf("\U0001f4c5 <b>My events</b>\n\nYou haven't signed up for any upcoming events yet. "+
+			"Check out /%s and tap the event you'd like to join.", constants.EventsCommand)
+	}
+
+	var response strings.Builder
+	response.WriteString("\U0001f4c5 <b>My events</b>\n")
+
+	for _, rsvp := range responses {
+		typeEmoji := GetTypeEmoji(rsvp.Event.TypeInfo)
+
+		status := GetRSVPStatusLabel(rsvp.Status)
+		if rsvp.Status == constants.EventRSVPStatusWaitlist && rsvp.WaitlistPosition > 0 {
+			status += fmt.Sprintf(" (#%d)", rsvp.WaitlistPosition)
+		}
+
+		response.WriteString(fmt.Sprintf("\n%s <b>%s</b>\n", typeEmoji, html.EscapeString(rsvp.Event.Name)))
+		response.WriteString(fmt.Sprintf("\u2514 <i>when</i>: %s\n", FormatEventWhen(rsvp.Event, loc, now)))
+		if place := FormatHtmlEventPlace(rsvp.Event); place != "" {
+			response.WriteString(fmt.Sprintf("\u2514 <i>where</i>: %s\n", place))
+		}
+		response.WriteString(fmt.Sprintf("\u2514 <i>your response</i>: %s\n", status))
+	}
+
+	response.WriteString("\nTap an event below to change your response.")
+
+	return response.String()
+}
+
+func writeUserList(response *strings.Builder, title string, users []repositories.User) {
+	if len(users) == 0 {
+		return
+	}
+
+	response.WriteString(fmt.Sprintf("\n<b>%s</b>\n", title))
+	for i, user := range users {
+		display := html.EscapeString(strings.TrimSpace(user.Firstname + " " + user.Lastname))
+		if user.TgUsername != "" {
+			display += " @" + user.TgUsername
+		}
+		response.WriteString(fmt.Sprintf("%d. %s\n", i+1, display))
+	}
+}
@@ -0,0 +1,42 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"evo-bot-go/internal/database/repositories"
+)
+
+// FormatHtmlEventTypeDetails renders an event type with its announcement settings for admins
+func FormatHtmlEventTypeDetails(eventType repositories.EventType) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("%s <b>%s</b> (<code>%s</code>)\n",
+		GetTypeEmoji(eventType), html.EscapeString(GetTypeName(eventType)), eventType.Slug))
+
+	template := "<i>default</i>"
+	if eventType.AnnouncementTemplate != "" {
+		template = fmt.Sprintf("<code>%s</code>", html.EscapeString(eventType.AnnouncementTemplate))
+	}
+	response.WriteString(fmt.Sprintf("\u2514 <i>announcement</i>: %s\n", template))
+
+	rulesLink := "<i>not set</i>"
+	if eventType.RulesLink != "" {
+		rulesLink = html.EscapeString(eventType.RulesLink)
+	}
+	response.WriteString(fmt.Sprintf("\u2514 <i>rules</i>: %s\n", rulesLink))
+
+	return response.String()
+}
+
+// FormatHtmlEventTypesForAdmin renders the numbered list of event types for /eventTypes
+func FormatHtmlEventTypesForAdmin(eventTypes []repositories.EventType) string {
+	var response strings.Builder
+	response.WriteString("<b>Event types</b>\n")
+
+	for i, eventType := range eventTypes {
+		response.WriteString(fmt.Sprintf("\n/%d. %s", i+1, FormatHtmlEventTypeDetails(eventType)))
+	}
+
+	return response.String()
+}
@@ -0,0 +1,214 @@
+package formatters
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"image/color"
+	"slices"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+)
+
+const (
+	// groupStatsDailyMaxDays is the longest range shown per day, longer ranges are shown per week only
+	groupStatsDailyMaxDays = 31
+	groupStatsTopTopics    = 10
+	groupStatsHourBarWidth = 12
+	groupStatsChartTopics  = 5
+)
+
+// groupStatsChartColors are the colors of the top topics on the chart and of the other topics last,
+// each with the square emoji of the legend
+var groupStatsChartColors = []struct {
+	color  color.RGBA
+	legend string
+}{
+	{color.RGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff}, "\U0001f7e6"},
+	{color.RGBA{R: 0x22, G: 0xc5, B: 0x5e, A: 0xff}, "\U0001f7e9"},
+	{color.RGBA{R: 0xf9, G: 0x73, B: 0x16, A: 0xff}, "\U0001f7e7"},
+	{color.RGBA{R: 0xa8, G: 0x55, B: 0xf7, A: 0xff}, "\U0001f7ea"},
+	{color.RGBA{R: 0xef, G: 0x44, B: 0x44, A: 0xff}, "\U0001f7e5"},
+	{color.RGBA{R: 0x92, G: 0x40, B: 0x0e, A: 0xff}, "\U0001f7eb"},
+}
+
+// groupTopicTotal is the number of messages in a topic over the whole range
+type groupTopicTotal struct {
+	TopicID      int64
+	Name         string
+	Messages     int
+	PeakDay      time.Time
+	PeakMessages int
+}
+
+// FormatHtmlGroupStats renders the activity in the group for "/stats" as text tables
+func FormatHtmlGroupStats(stats *repositories.GroupStats, days int, timezone *time.Location) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\U0001f4ca <b>Group activity</b> <i>(last %d days, %s)</i>\n\n", days, timezone.String()))
+
+	messages := 0
+	for _, day := range stats.Daily {
+		messages += day.Messages
+	}
+	if messages == 0 {
+		response.WriteString("No messages in this period.")
+		return response.String()
+	}
+
+	response.WriteString(fmt.Sprintf("Messages: <b>%d</b>, active members: <b>%d</b>\n", messages, stats.ActiveUsers))
+	response.WriteString(fmt.Sprintf("Active per day on average: <b>%.1f</b>, per week: <b>%.1f</b>\n",
+		averageActiveUsers(stats.Daily), averageActiveUsers(stats.Weekly)))
+	response.WriteString(fmt.Sprintf("Members who ever posted: <b>%d</b> of <b>%d</b> (%s)\n\n",
+		stats.PostedMembers, stats.ClubMembers, formatPercent(stats.PostedMembers, stats.ClubMembers)))
+
+	if days <= groupStatsDailyMaxDays {
+		response.WriteString("<b>Per day</b>\n")
+		writeGroupActivityTable(&response, "Day", "DAU", stats.Daily)
+	}
+	response.WriteString("<b>Per week</b>\n")
+	writeGroupActivityTable(&response, "Week", "WAU", stats.Weekly)
+	response.WriteString("<i>DAU / WAU</i> — members who posted that day or week, <i>New</i> — posted for the first time, " +
+		"<i>Ret</i> — returning members who posted before.\n\n")
+
+	topics := groupTopicTotals(stats.Topics)
+	response.WriteString("<b>Messages per topic</b>\n")
+	response.WriteString("<pre>")
+	response.WriteString(fmt.Sprintf("%-16s %5s %5s %-6s\n", "Topic", "Msgs", "/day", "Peak"))
+	for _, topic := range topics[:min(len(topics), groupStatsTopTopics)] {
+		response.WriteString(fmt.Sprintf("%-16s %5d %5.1f %-6s\n",
+			html.EscapeString(truncateRunes(topic.Name, 16)),
+			topic.Messages,
+			float64(topic.Messages)/float64(days),
+			topic.PeakDay.Format("Jan 02"),
+		))
+	}
+	response.WriteString("</pre>\n")
+
+	busiest := slices.Max(stats.MessagesByHour[:])
+	response.WriteString("<b>Busiest hours</b>\n")
+	response.WriteString("<pre>")
+	for hour, count := range stats.MessagesByHour {
+		bar := 0
+		if busiest > 0 {
+			bar = count * groupStatsHourBarWidth / busiest
+		}
+		response.WriteString(fmt.Sprintf("%02d:00 %-*s %d\n", hour, groupStatsHourBarWidth, strings.Repeat("\u2588", bar), count))
+	}
+	response.WriteString("</pre>")
+
+	return response.String()
+}
+
+// FormatGroupStatsChart returns the series of the chart of messages per day, stacked by topic,
+// and its caption with the legend
+func FormatGroupStatsChart(stats *repositories.GroupStats, days int) ([]utils.ChartSeries, string) {
+	dayIndexes := make(map[string]int, len(stats.Daily))
+	for i, day := range stats.Daily {
+		dayIndexes[day.Start.Format(time.DateOnly)] = i
+	}
+
+	topics := groupTopicTotals(stats.Topics)
+	chartTopics := topics[:min(len(topics), groupStatsChartTopics)]
+	seriesIndexes := make(map[int64]int, len(chartTopics))
+	for i, topic := range chartTopics {
+		seriesIndexes[topic.TopicID] = i
+	}
+
+	// The last series holds the other topics
+	series := make([]utils.ChartSeries, len(chartTopics)+1)
+	for i := range series {
+		series[i].Values = make([]int, len(stats.Daily))
+		series[i].Color = groupStatsChartColors[i].color
+	}
+	series[len(series)-1].Color = groupStatsChartColors[len(groupStatsChartColors)-1].color
+
+	for _, activity := range stats.Topics {
+		day, ok := dayIndexes[activity.Day.Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		seriesIndex, ok := seriesIndexes[activity.TopicID]
+		if !ok {
+			seriesIndex = len(series) - 1
+		}
+		series[seriesIndex].Values[day] += activity.Messages
+	}
+
+	highest := 0
+	for _, day := range stats.Daily {
+		highest = max(highest, day.Messages)
+	}
+
+	var caption strings.Builder
+	caption.WriteString(fmt.Sprintf("\U0001f4c8 <b>Messages per day</b>, last %d days", days))
+	if len(stats.Daily) > 0 {
+		caption.WriteString(fmt.Sprintf(" (%s – %s)",
+			stats.Daily[0].Start.Format("Jan 02"), stats.Daily[len(stats.Daily)-1].Start.Format("Jan 02")))
+	}
+	caption.WriteString(fmt.Sprintf("\nThe highest bar is <b>%d</b> messages, the lines mark every quarter of it\n", highest))
+	for i, topic := range chartTopics {
+		caption.WriteString(fmt.Sprintf("\n%s %s", groupStatsChartColors[i].legend, html.EscapeString(topic.Name)))
+	}
+	if len(topics) > len(chartTopics) {
+		caption.WriteString(fmt.Sprintf("\n%s Other topics", groupStatsChartColors[len(groupStatsChartColors)-1].legend))
+	}
+
+	return series, caption.String()
+}
+
+func writeGroupActivityTable(response *strings.Builder, periodHeader string, activeHeader string, activity []repositories.GroupActivity) {
+	response.WriteString("<pre>")
+	response.WriteString(fmt.Sprintf("%-6s %5s %4s %4s %4s\n", periodHeader, "Msgs", activeHeader, "New", "Ret"))
+	for _, a := range activity {
+		response.WriteString(fmt.Sprintf("%-6s %5d %4d %4d %4d\n",
+			a.Start.Format("Jan 02"), a.Messages, a.ActiveUsers, a.NewSpeakers, a.ActiveUsers-a.NewSpeakers))
+	}
+	response.WriteString("</pre>\n")
+}
+
+func averageActiveUsers(activity []repositories.GroupActivity) float64 {
+	if len(activity) == 0 {
+		return 0
+	}
+	total := 0
+	for _, a := range activity {
+		total += a.ActiveUsers
+	}
+	return float64(total) / float64(len(activity))
+}
+
+// groupTopicTotals sums the messages per topic, the busiest topics first
+func groupTopicTotals(activity []repositories.GroupTopicActivity) []groupTopicTotal {
+	totals := make(map[int64]*groupTopicTotal)
+	for _, a := range activity {
+		total, ok := totals[a.TopicID]
+		if !ok {
+			name := a.TopicName
+			if name == "" {
+				name = fmt.Sprintf("Topic %d", a.TopicID)
+			}
+			total = &groupTopicTotal{TopicID: a.TopicID, Name: name}
+			totals[a.TopicID] = total
+		}
+		total.Messages += a.Messages
+		if a.Messages > total.PeakMessages {
+			total.PeakDay = a.Day
+			total.PeakMessages = a.Messages
+		}
+	}
+
+	result := make([]groupTopicTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	slices.SortFunc(result, func(a, b groupTopicTotal) int {
+		if c := cmp.Compare(b.Messages, a.Messages); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TopicID, b.TopicID)
+	})
+	return result
+}
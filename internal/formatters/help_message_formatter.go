@@ -14,15 +14,22 @@ func FormatHelpMessage(isAdmin bool, config *config.Config) string {
 		"└ /help - Show this command list\n" +
 		"└ /cancel - Force-cancel any active dialog\n\n" +
 		"<b>👤 Profile</b>\n" +
-		"└ /profile - Manage your profile, search members, publish your info in the Intro channel\n\n" +
+		"└ /profile - Manage your profile, search members, publish your info in the Intro channel\n" +
+		fmt.Sprintf("└ /%s - Members with the most karma, thank someone with a \"+1\" reply or a reaction\n\n", constants.KarmaLeaderboardCommand) +
 		"<b>🔍 AI Search</b>\n" +
 		"└ /tools - Find AI tools from the Tools channel\n" +
 		"└ /content - Find content from the Video Content channel\n" +
 		"└ /intro - Find member info from the Intro channel (smart profile search)\n\n" +
+		"<b>☕️ Random Coffee</b>\n" +
+		fmt.Sprintf("└ /%s - Subscribe to every Random Coffee round or pause your subscription\n", constants.RandomCoffeeCommand) +
+		fmt.Sprintf("└ /%s - See your rounds and who you were paired with\n\n", constants.RandomCoffeeHistoryCommand) +
 		"<b>📅 Events</b>\n" +
-		"└ /events - View upcoming events\n" +
-		"└ /topics - View topics and questions for upcoming events\n" +
-		"└ /topicAdd - Suggest a topic or question for an event"
+		"└ /events - View upcoming events, sign up and add them to your calendar, browse past events and recordings\n" +
+		"└ /myEvents - Events you signed up for\n" +
+		fmt.Sprintf("└ /%s - Propose an event you'd like to host, e.g. a workshop\n", constants.EventProposeCommand) +
+		"└ /topics - View topics and questions for upcoming events and upvote the ones you like\n" +
+		"└ /topicAdd - Suggest a topic or question for an event, with your name or anonymously\n" +
+		fmt.Sprintf("└ /%s - Edit or withdraw your topics and questions", constants.MyTopicsCommand)
 
 	helpText += "\n\n" +
 		"<i>📖 <a href=\"https://antikriza.github.io/BBD-evolution-code-clone/telegram-archive/course/twa/index.html\">Open AI Course (42 topics)</a></i>"
@@ -31,9 +38,16 @@ func FormatHelpMessage(isAdmin bool, config *config.Config) string {
 		adminHelpText := "\n\n<b>🔐 Admin Commands</b>\n" +
 			fmt.Sprintf("└ /%s - Start an event\n", constants.EventStartCommand) +
 			fmt.Sprintf("└ /%s - Create a new event\n", constants.EventSetupCommand) +
-			fmt.Sprintf("└ /%s - Edit an event\n", constants.EventEditCommand) +
+			fmt.Sprintf("└ /%s - Edit an event or add its recordings, slides and links\n", constants.EventEditCommand) +
 			fmt.Sprintf("└ /%s - Delete an event\n", constants.EventDeleteCommand) +
+			fmt.Sprintf("└ /%s - Ratings, comments and attendance of finished events\n", constants.EventReportCommand) +
+			fmt.Sprintf("└ /%s - Manage event types and their announcements\n", constants.EventTypesCommand) +
+			fmt.Sprintf("└ /%s - Approve, reject or request changes to events proposed by members\n", constants.EventProposalsQueueCommand) +
 			fmt.Sprintf("└ /%s - View topics with <b>delete option</b>\n", constants.ShowTopicsCommand) +
+			fmt.Sprintf("└ /%s - Approve or reject topics waiting for moderation\n", constants.TopicsQueueCommand) +
+			fmt.Sprintf("└ /%s - Random Coffee statistics (optionally pass the number of rounds)\n", constants.RandomCoffeeStatsCommand) +
+			fmt.Sprintf("└ /%s - Group activity statistics and chart (optionally pass the number of days)\n", constants.GroupStatsCommand) +
+			fmt.Sprintf("└ /%s - Manage matching programs (e.g. monthly mentor/mentee rounds)\n", constants.MatchingProgramsCommand) +
 			fmt.Sprintf("└ /%s - Enter auth code for TG client\n", constants.CodeCommand) +
 			fmt.Sprintf("└ /%s - Manage member profiles", constants.AdminProfilesCommand)
 
@@ -0,0 +1,45 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"evo-bot-go/internal/database/repositories"
+)
+
+// FormatHtmlKarmaLeaderboard renders the members with the highest scores and the place of the current user
+func FormatHtmlKarmaLeaderboard(users []repositories.User, currentUser *repositories.User, rank int) string {
+	var response strings.Builder
+	response.WriteString("\U0001f3c6 <b>Karma leaderboard</b>\n")
+
+	if len(users) == 0 {
+		response.WriteString("\nNobody has got karma points yet. Be the first to thank someone!\n")
+	}
+
+	for i, user := range users {
+		name := user.Firstname
+		if user.Lastname != "" {
+			name += " " + user.Lastname
+		}
+		if user.TgUsername != "" {
+			name += " (@" + user.TgUsername + ")"
+		}
+
+		line := fmt.Sprintf("%d. %s — <b>%d</b>", i+1, html.EscapeString(name), user.Score)
+		if user.ID == currentUser.ID {
+			line = "\U0001f449 " + line
+		}
+		response.WriteString("\n" + line)
+	}
+
+	response.WriteString(fmt.Sprintf("\n\nYour karma: <b>%d</b>", currentUser.Score))
+	if currentUser.Score > 0 {
+		response.WriteString(fmt.Sprintf(", place %d", rank))
+	}
+
+	response.WriteString("\n\n<i>Reply \"+1\" or \"thanks\" to a helpful message in the group, " +
+		"or react to it, to grant its author a point.</i>")
+
+	return response.String()
+}
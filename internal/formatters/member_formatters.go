@@ -0,0 +1,57 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// FormatReengagementMessage renders the friendly DM sent to a member who hasn't posted for a while
+func FormatReengagementMessage(user *repositories.User, silentWeeks int) string {
+	name := user.Firstname
+	if name == "" {
+		name = "there"
+	}
+
+	return fmt.Sprintf("\U0001f44b Hi, %s! We haven't heard from you in the club for %d weeks or more, we miss you.\n\n", html.EscapeString(name), silentWeeks) +
+		fmt.Sprintf("└ /%s - See what's coming up and sign up for an event\n", constants.EventsCommand) +
+		fmt.Sprintf("└ /%s - Update your profile, so members know what you're up to now\n\n", constants.ProfileCommand) +
+		"<i>Just drop a message in the group whenever you like. If you'd rather not get these reminders, tap the button below.</i>"
+}
+
+// FormatHtmlMembershipEvents renders the last join and leave of a member for the profiles manager,
+// the events are newest first
+func FormatHtmlMembershipEvents(events []repositories.ClubMembershipEvent, timezone *time.Location) string {
+	var lastJoin, lastLeave *repositories.ClubMembershipEvent
+	for i := range events {
+		if events[i].Event == constants.ClubMembershipEventJoined {
+			if lastJoin == nil {
+				lastJoin = &events[i]
+			}
+		} else if lastLeave == nil {
+			lastLeave = &events[i]
+		}
+	}
+
+	var text strings.Builder
+	if lastJoin != nil {
+		text.WriteString(fmt.Sprintf("\n<i>Joined:</i> %s", lastJoin.CreatedAt.In(timezone).Format("02 Jan 2006 15:04")))
+		if lastJoin.InviteLinkName != "" {
+			text.WriteString(fmt.Sprintf(" via \"%s\"", html.EscapeString(lastJoin.InviteLinkName)))
+		} else if lastJoin.InviteLink != "" {
+			text.WriteString(fmt.Sprintf(" via %s", html.EscapeString(lastJoin.InviteLink)))
+		}
+	}
+	if lastLeave != nil {
+		label := "Left"
+		if lastLeave.Event == constants.ClubMembershipEventBanned {
+			label = "Banned"
+		}
+		text.WriteString(fmt.Sprintf("\n<i>%s:</i> %s", label, lastLeave.CreatedAt.In(timezone).Format("02 Jan 2006 15:04")))
+	}
+	return text.String()
+}
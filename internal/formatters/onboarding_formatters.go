@@ -0,0 +1,83 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// FormatOnboardingChecklist renders the checklist of a new member, with the steps done so far ticked.
+// The reminder differs only in its greeting, the rules step is left out if it isn't required.
+func FormatOnboardingChecklist(
+	user *repositories.User,
+	onboarding *repositories.Onboarding,
+	rulesRequired bool,
+	isReminder bool,
+) string {
+	var text strings.Builder
+	name := html.EscapeString(onboardingName(user))
+	if isReminder {
+		text.WriteString(fmt.Sprintf("\u23f0 <b>A quick reminder, %s!</b> A few steps are still left to settle into the club:\n\n", name))
+	} else {
+		text.WriteString(fmt.Sprintf("\U0001f44b <b>Welcome to the club, %s!</b> Here are a few steps to get started:\n\n", name))
+	}
+
+	text.WriteString(formatOnboardingStep(onboarding.ProfileFilled,
+		fmt.Sprintf("Fill in your profile with /%s, so members know who you are", constants.ProfileCommand)))
+	if rulesRequired {
+		text.WriteString(formatOnboardingStep(onboarding.RulesReadAt.Valid, "Read the club rules with the button below"))
+	}
+	text.WriteString(formatOnboardingStep(onboarding.RandomCoffeeJoined,
+		fmt.Sprintf("Join Random Coffee with /%s to meet a member every week", constants.RandomCoffeeCommand)))
+	text.WriteString(formatOnboardingStep(onboarding.CourseOpenedAt.Valid, "Open the AI course Mini App with the button below"))
+
+	text.WriteString(fmt.Sprintf("\n<i>Use /%s for everything else I can do. Tap \"Check my progress\" when you're done.</i>", constants.HelpCommand))
+	return text.String()
+}
+
+// FormatOnboardingMention renders the short welcome posted in the group for a new member who hasn't started the bot
+func FormatOnboardingMention(user *repositories.User) string {
+	return fmt.Sprintf("\U0001f44b Welcome, <a href=\"tg://user?id=%d\">%s</a>! Start a chat with me to get your checklist for settling into the club.",
+		user.TgID, html.EscapeString(onboardingName(user)))
+}
+
+// FormatOnboardingCompletedMessage renders the message of a member who went through the whole checklist
+func FormatOnboardingCompletedMessage(user *repositories.User) string {
+	return fmt.Sprintf("\U0001f389 <b>All done, %s!</b> You've gone through the whole checklist, welcome aboard.\n\n", html.EscapeString(onboardingName(user))) +
+		"<i>Say hi in the group whenever you like.</i>"
+}
+
+// FormatOnboardingCompletedReport renders the report to the admins about a member who completed the onboarding
+func FormatOnboardingCompletedReport(user *repositories.User, onboarding *repositories.Onboarding, now time.Time) string {
+	fullName := strings.TrimSpace(user.Firstname + " " + user.Lastname)
+	if user.TgUsername != "" {
+		fullName += " (@" + user.TgUsername + ")"
+	}
+	days := int(math.Ceil(now.Sub(onboarding.StartedAt).Hours() / 24))
+	if days < 1 {
+		days = 1
+	}
+
+	return fmt.Sprintf("\u2705 <b>Onboarding completed</b>\n\n<a href=\"tg://user?id=%d\">%s</a> went through the checklist in %d day(s) with %d reminder(s).",
+		user.TgID, html.EscapeString(fullName), days, onboarding.RemindersSent)
+}
+
+func formatOnboardingStep(done bool, text string) string {
+	mark := "\u2b1c"
+	if done {
+		mark = "\u2705"
+	}
+	return fmt.Sprintf("%s %s\n", mark, text)
+}
+
+func onboardingName(user *repositories.User) string {
+	if user.Firstname == "" {
+		return "there"
+	}
+	return user.Firstname
+}
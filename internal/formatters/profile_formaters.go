@@ -2,15 +2,17 @@ package formatters
 
 import (
 	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
 	"evo-bot-go/internal/utils"
 	"fmt"
+	"html"
 	"strconv"
 	"strings"
 )
 
 // Format a readable view of a user profile
-func FormatProfileView(user *repositories.User, profile *repositories.Profile, showScore bool) string {
+func FormatProfileView(user *repositories.User, profile *repositories.Profile, badges []constants.Badge, showScore bool) string {
 	if profile == nil {
 		return "Your profile was not found.\n\nCreate a profile using the \"Edit my profile\" button."
 	}
@@ -36,15 +38,29 @@ func FormatProfileView(user *repositories.User, profile *repositories.Profile, s
 		text += fmt.Sprintf("\n<blockquote>About</blockquote>\n%s\n", profile.Bio)
 	}
 
-	if showScore && user.Score > 100 {
-		text += fmt.Sprintf("\n<b>%d</b> <i>(what's this? hmm...)</i>\n", user.Score)
+	if profile.HasStructuredFields() {
+		text += "\n" + FormatProfileFields(profile)
+	}
+
+	if len(badges) > 0 {
+		text += fmt.Sprintf("\n<i>Badges:</i> %s\n", FormatBadges(badges))
+	}
+
+	if showScore {
+		text += fmt.Sprintf("\n<i>Karma:</i> <b>%d</b>\n", user.Score)
 	}
 
 	return text
 }
 
 // Format a readable view of a user profile for the admin manager
-func FormatProfileManagerView(user *repositories.User, profile *repositories.Profile, hasCoffeeBan bool, config *config.Config) string {
+func FormatProfileManagerView(
+	user *repositories.User,
+	profile *repositories.Profile,
+	hasCoffeeBan bool,
+	membershipEvents []repositories.ClubMembershipEvent,
+	config *config.Config,
+) string {
 
 	// Format username
 	username := ""
@@ -67,6 +83,9 @@ func FormatProfileManagerView(user *repositories.User, profile *repositories.Pro
 		profile.Bio = strings.ReplaceAll(profile.Bio, ">", "&gt;")
 		text += fmt.Sprintf("<blockquote expandable>%s</blockquote>", profile.Bio)
 	}
+	if profile.HasStructuredFields() {
+		text += "\n" + strings.TrimSuffix(FormatProfileFields(profile), "\n")
+	}
 	text += fmt.Sprintf("\n\n<i>Score:</i> <b>%d</b>", user.Score)
 
 	coffeeBanStatus := "\u2705 Allowed"
@@ -75,6 +94,7 @@ func FormatProfileManagerView(user *repositories.User, profile *repositories.Pro
 	}
 	text += fmt.Sprintf("\n<i>Coffee meetings:</i> %s", coffeeBanStatus)
 	text += fmt.Sprintf("\n<i>Telegram ID:</i> <code>%d</code>", user.TgID)
+	text += FormatHtmlMembershipEvents(membershipEvents, config.EventsTimezone)
 	if profile.PublishedMessageID.Valid {
 		linkToPost := utils.GetIntroMessageLink(config, profile.PublishedMessageID.Int64)
 		text += fmt.Sprintf("\n<i>Profile link:</i> %s", linkToPost)
@@ -82,7 +102,7 @@ func FormatProfileManagerView(user *repositories.User, profile *repositories.Pro
 	return text
 }
 
-func FormatPublicProfileForMessage(user *repositories.User, profile *repositories.Profile, showScore bool) string {
+func FormatPublicProfileForMessage(user *repositories.User, profile *repositories.Profile, badges []constants.Badge, showScore bool) string {
 
 	// Format username
 	username := ""
@@ -106,5 +126,122 @@ func FormatPublicProfileForMessage(user *repositories.User, profile *repositorie
 		text += fmt.Sprintf("\n<blockquote>About</blockquote>\n%s\n", profile.Bio)
 	}
 
+	if profile.HasStructuredFields() {
+		text += "\n" + FormatProfileFields(profile)
+	}
+
+	if len(badges) > 0 {
+		text += fmt.Sprintf("\n%s\n", FormatBadges(badges))
+	}
+
 	return text
 }
+
+// FormatArchivedPublicProfile formats the note that replaces the published intro of a member who left the group
+func FormatArchivedPublicProfile(user *repositories.User) string {
+	fullName := user.Firstname
+	if user.Lastname != "" {
+		fullName += " " + user.Lastname
+	}
+
+	return fmt.Sprintf(
+		"\U0001f5c4 <b>%s</b> has left the club, the intro is archived and will be back if they rejoin.",
+		html.EscapeString(fullName))
+}
+
+// GetProfileFieldEmoji returns the emoji of a structured profile field
+func GetProfileFieldEmoji(field constants.ProfileField) string {
+	switch field {
+	case constants.ProfileFieldRole:
+		return "\U0001f4bc"
+	case constants.ProfileFieldCompany:
+		return "\U0001f3e2"
+	case constants.ProfileFieldSkills:
+		return "\U0001f9f0"
+	case constants.ProfileFieldCity:
+		return "\U0001f4cd"
+	case constants.ProfileFieldTimezone:
+		return "\U0001f552"
+	case constants.ProfileFieldGitHub:
+		return "\U0001f419"
+	case constants.ProfileFieldLinkedIn:
+		return "\U0001f517"
+	case constants.ProfileFieldLookingFor:
+		return "\U0001f50e"
+	case constants.ProfileFieldCanHelpWith:
+		return "\U0001f91d"
+	default:
+		return "\u25ab\ufe0f"
+	}
+}
+
+// GetProfileFieldName returns the display name of a structured profile field
+func GetProfileFieldName(field constants.ProfileField) string {
+	switch field {
+	case constants.ProfileFieldRole:
+		return "Role"
+	case constants.ProfileFieldCompany:
+		return "Company"
+	case constants.ProfileFieldSkills:
+		return "Skills"
+	case constants.ProfileFieldCity:
+		return "City"
+	case constants.ProfileFieldTimezone:
+		return "Timezone"
+	case constants.ProfileFieldGitHub:
+		return "GitHub"
+	case constants.ProfileFieldLinkedIn:
+		return "LinkedIn"
+	case constants.ProfileFieldLookingFor:
+		return "Looking for"
+	case constants.ProfileFieldCanHelpWith:
+		return "Can help with"
+	default:
+		return string(field)
+	}
+}
+
+// GetProfileFieldHint returns what to enter for a structured profile field, shown when editing it
+func GetProfileFieldHint(field constants.ProfileField) string {
+	switch field {
+	case constants.ProfileFieldRole:
+		return "your role, e.g. <i>Backend developer</i> or <i>ML engineer</i>"
+	case constants.ProfileFieldCompany:
+		return "the company or project you work on"
+	case constants.ProfileFieldSkills:
+		return "your skills separated by commas, e.g. <i>Go, PostgreSQL, LLM agents</i>"
+	case constants.ProfileFieldCity:
+		return "the city you live in"
+	case constants.ProfileFieldTimezone:
+		return "your timezone name, e.g. <i>Europe/Kyiv</i> or <i>America/New_York</i>"
+	case constants.ProfileFieldGitHub:
+		return "your GitHub username or profile link"
+	case constants.ProfileFieldLinkedIn:
+		return "the link to your LinkedIn profile"
+	case constants.ProfileFieldLookingFor:
+		return "what you are looking for in the club, e.g. <i>a co-founder</i> or <i>code review</i>"
+	case constants.ProfileFieldCanHelpWith:
+		return "what you can help other members with"
+	default:
+		return "a new value"
+	}
+}
+
+// FormatProfileFields renders the filled structured fields of a profile, one per line
+func FormatProfileFields(profile *repositories.Profile) string {
+	var text strings.Builder
+	for _, field := range constants.AllProfileFields {
+		value := profile.FieldValue(field)
+		if value == "" {
+			continue
+		}
+
+		rendered := html.EscapeString(value)
+		if field == constants.ProfileFieldGitHub || field == constants.ProfileFieldLinkedIn {
+			rendered = fmt.Sprintf("<a href=\"%s\">%s</a>", rendered,
+				html.EscapeString(strings.TrimPrefix(strings.TrimPrefix(value, "https://"), "www.")))
+		}
+		text.WriteString(fmt.Sprintf("%s <i>%s:</i> %s\n", GetProfileFieldEmoji(field), GetProfileFieldName(field), rendered))
+	}
+	return text.String()
+}
@@ -0,0 +1,118 @@
+package formatters
+
+import (
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// FormatRandomCoffeeHistory renders a member's Random Coffee rounds and partners.
+// Rounds joined include the rounds in which the member was left without a partner.
+func FormatRandomCoffeeHistory(pairs []repositories.RandomCoffeeUserPair, roundsJoined int, config *config.Config) string {
+	if len(pairs) == 0 && roundsJoined == 0 {
+		return "☕️ <b>Your Random Coffee history</b>\n\nYou haven't joined Random Coffee yet."
+	}
+
+	partners := make(map[int]bool)
+	pairedRounds := make(map[int]bool)
+	for _, pair := range pairs {
+		partners[pair.Partner.ID] = true
+		pairedRounds[pair.PollID] = true
+	}
+
+	var response strings.Builder
+	response.WriteString("☕️ <b>Your Random Coffee history</b>\n\n")
+	response.WriteString(fmt.Sprintf("Rounds joined: <b>%d</b>\n", roundsJoined))
+	if unpaired := roundsJoined - len(pairedRounds); unpaired > 0 {
+		response.WriteString(fmt.Sprintf("Rounds without a partner: <b>%d</b>\n", unpaired))
+	}
+	response.WriteString(fmt.Sprintf("Different people met: <b>%d</b>\n\n", len(partners)))
+
+	for _, pair := range pairs {
+		partnerDisplay := html.EscapeString(pair.Partner.Firstname)
+		if pair.Partner.TgUsername != "" {
+			partnerDisplay = "@" + pair.Partner.TgUsername
+		}
+		if pair.PartnerPublishedMessageID.Valid && pair.PartnerPublishedMessageID.Int64 > 0 {
+			partnerDisplay += fmt.Sprintf(" <i>(<a href=\"%s\">profile</a>)</i>",
+				utils.GetIntroMessageLink(config, pair.PartnerPublishedMessageID.Int64))
+		}
+		response.WriteString(fmt.Sprintf("➪ <i>%s</i> — %s\n", pair.WeekStartDate.Format("Jan 2, 2006"), partnerDisplay))
+	}
+
+	return response.String()
+}
+
+// FormatRandomCoffeeStats renders round-level statistics and the most connected members as text tables
+func FormatRandomCoffeeStats(
+	rounds []repositories.RandomCoffeeRoundStats,
+	connectedUsers []repositories.RandomCoffeeConnectedUser,
+) string {
+	if len(rounds) == 0 {
+		return "☕️ <b>Random Coffee statistics</b>\n\nNo finished rounds yet."
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("☕️ <b>Random Coffee statistics</b> <i>(last %d rounds)</i>\n\n", len(rounds)))
+
+	response.WriteString("<pre>")
+	response.WriteString(fmt.Sprintf("%-7s %4s %5s %5s %5s\n", "Week", "Ppl", "Pairs", "New", "Ret"))
+	totalPairs, totalRepeatPairs := 0, 0
+	for _, round := range rounds {
+		totalPairs += round.Pairs
+		totalRepeatPairs += round.RepeatPairs
+		response.WriteString(fmt.Sprintf("%-7s %4d %5d %5s %5s\n",
+			round.WeekStartDate.Format("Jan 02"),
+			round.Participants,
+			round.Pairs,
+			formatPercent(round.Pairs-round.RepeatPairs, round.Pairs),
+			formatPercent(round.ReturningParticipants, round.PreviousRoundParticipants),
+		))
+	}
+	response.WriteString("</pre>\n")
+	response.WriteString("<i>Ppl</i> — participants, including those left without a partner, <i>New</i> — share of pairs that never met before, " +
+		"<i>Ret</i> — share of the previous round's participants who came back.\n\n")
+	response.WriteString(fmt.Sprintf("New pairs overall: <b>%s</b>, repeat pairs: <b>%s</b>\n\n",
+		formatPercent(totalPairs-totalRepeatPairs, totalPairs),
+		formatPercent(totalRepeatPairs, totalPairs),
+	))
+
+	if len(connectedUsers) > 0 {
+		response.WriteString("<b>Most connected members</b>\n")
+		response.WriteString("<pre>")
+		response.WriteString(fmt.Sprintf("%-3s %-18s %8s %6s\n", "#", "Member", "Partners", "Rounds"))
+		for i, cu := range connectedUsers {
+			name := cu.User.Firstname
+			if cu.User.TgUsername != "" {
+				name = "@" + cu.User.TgUsername
+			}
+			response.WriteString(fmt.Sprintf("%-3d %-18s %8d %6d\n",
+				i+1,
+				html.EscapeString(truncateRunes(name, 18)),
+				cu.PartnersCount,
+				cu.RoundsCount,
+			))
+		}
+		response.WriteString("</pre>")
+	}
+
+	return response.String()
+}
+
+func formatPercent(part int, total int) string {
+	if total == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d%%", part*100/total)
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,133 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+)
+
+// GetTopicStatusLabel returns a human-readable label for the moderation status of a topic
+func GetTopicStatusLabel(status constants.TopicStatus) string {
+	switch status {
+	case constants.TopicStatusPending:
+		return "\u23f3 pending"
+	case constants.TopicStatusApproved:
+		return "\u2705 approved"
+	case constants.TopicStatusRejected:
+		return "\u274c rejected"
+	default:
+		return string(status)
+	}
+}
+
+// FormatHtmlTopicForModeration renders a new or edited topic for the administrators
+func FormatHtmlTopicForModeration(topic repositories.Topic, eventName string, edited bool) string {
+	title := "\U0001f514 <b>New topic for moderation</b>"
+	if edited {
+		title = "\U0001f514 <b>Edited topic for moderation</b>"
+	}
+
+	author := "not specified"
+	if topic.IsAnonymous {
+		author = "\U0001f576 anonymous"
+	} else if topic.UserNickname != nil {
+		author = "@" + *topic.UserNickname
+	}
+
+	return fmt.Sprintf(
+		"%s\n\n"+
+			"<i>Event:</i> %s\n"+
+			"<i>Author:</i> %s\n"+
+			"<i>Topic:</i>\n<blockquote expandable>%s</blockquote>",
+		title,
+		html.EscapeString(eventName),
+		html.EscapeString(author),
+		html.EscapeString(topic.Topic),
+	)
+}
+
+// FormatHtmlTopicModerationResultForAuthor renders the message sent to the author once a topic is approved or rejected
+func FormatHtmlTopicModerationResultForAuthor(topic repositories.Topic, eventName string) string {
+	var response strings.Builder
+	if topic.Status == constants.TopicStatusApproved {
+		response.WriteString(fmt.Sprintf("\u2705 Your topic for <b>%s</b> was approved, members can now upvote it in /%s.\n",
+			html.EscapeString(eventName), constants.TopicsCommand))
+	} else {
+		response.WriteString(fmt.Sprintf("\u274c Your topic for <b>%s</b> was rejected.\n", html.EscapeString(eventName)))
+		if topic.RejectionReason != "" {
+			response.WriteString(fmt.Sprintf("<i>Reason:</i> %s\n", html.EscapeString(topic.RejectionReason)))
+		}
+		response.WriteString(fmt.Sprintf("You can edit it and send it again with /%s.\n", constants.MyTopicsCommand))
+	}
+	response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote>", html.EscapeString(topic.Topic)))
+	return response.String()
+}
+
+// FormatHtmlMyTopics renders the member's topics for upcoming events, numbered the same way as the edit buttons
+func FormatHtmlMyTopics(topics []repositories.Topic, eventNames map[int]string) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("\U0001f4dd <b>Your topics and questions</b>: %d\n\n", len(topics)))
+
+	for i, topic := range topics {
+		anonymous := ""
+		if topic.IsAnonymous {
+			anonymous = " / \U0001f576 anonymous"
+		}
+		response.WriteString(fmt.Sprintf("<b>%d.</b> %s / %s / \U0001f44d %d%s\n",
+			i+1,
+			html.EscapeString(eventNames[topic.EventID]),
+			GetTopicStatusLabel(topic.Status),
+			topic.Votes,
+			anonymous,
+		))
+		if topic.Status == constants.TopicStatusRejected && topic.RejectionReason != "" {
+			response.WriteString(fmt.Sprintf("<i>Reason:</i> %s\n", html.EscapeString(topic.RejectionReason)))
+		}
+		response.WriteString(fmt.Sprintf("<blockquote expandable>%s</blockquote>\n", html.EscapeString(topic.Topic)))
+	}
+
+	response.WriteString("\nEdited topics go back to moderation, their votes are kept.")
+	return response.String()
+}
+
+// FormatTopicAgenda renders the approved topics of an event ranked by votes as a plain text file for the host
+func FormatTopicAgenda(event repositories.Event, topics []repositories.Topic, loc *time.Location) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("Agenda: %s\n", event.Name))
+	if event.StartedAt != nil && !event.StartedAt.IsZero() {
+		response.WriteString(event.StartedAt.In(loc).Format("Mon, 02 Jan 2006 15:04 MST") + "\n")
+	}
+	response.WriteString(fmt.Sprintf("%d topics and questions, the most upvoted first\n", len(topics)))
+
+	for i, topic := range topics {
+		author := ""
+		if topic.IsAnonymous {
+			author = " (anonymous)"
+		} else if topic.UserNickname != nil {
+			author = fmt.Sprintf(" (by @%s)", *topic.UserNickname)
+		}
+		response.WriteString(fmt.Sprintf("\n%d. [%d votes]%s\n%s\n", i+1, topic.Votes, author, topic.Topic))
+	}
+
+	return response.String()
+}
+
+// FormatHtmlTopicAgendaCaption renders the caption of the agenda file sent to the host
+func FormatHtmlTopicAgendaCaption(event repositories.Event, topics []repositories.Topic) string {
+	return fmt.Sprintf("\U0001f4cb Agenda for <b>%s</b>: %d topics and questions ranked by members' votes",
+		html.EscapeString(event.Name), len(topics))
+}
+
+// FormatHtmlTopicAuthorReveal renders the author of an anonymous topic for the administrator who asked for it
+func FormatHtmlTopicAuthorReveal(topicID int, author repositories.User) string {
+	display := html.EscapeString(strings.TrimSpace(author.Firstname + " " + author.Lastname))
+	if author.TgUsername != "" {
+		display += " @" + html.EscapeString(author.TgUsername)
+	}
+	return fmt.Sprintf("\U0001f575 Author of anonymous topic %d: %s (Telegram ID <code>%d</code>)\n"+
+		"<i>Please keep it confidential.</i>", topicID, display, author.TgID)
+}
@@ -0,0 +1,336 @@
+package adminhandlers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	eventProposalsQueueStateEnterComment = "admin_event_proposals_queue_state_enter_comment"
+
+	// Context data keys
+	eventProposalsQueueCtxDataKeyProposalID        = "admin_event_proposals_queue_ctx_data_proposal_id"
+	eventProposalsQueueCtxDataKeyStatus            = "admin_event_proposals_queue_ctx_data_status"
+	eventProposalsQueueCtxDataKeyReviewMessage     = "admin_event_proposals_queue_ctx_data_review_message"
+	eventProposalsQueueCtxDataKeyPreviousMessageID = "admin_event_proposals_queue_ctx_data_previous_message_id"
+	eventProposalsQueueCtxDataKeyPreviousChatID    = "admin_event_proposals_queue_ctx_data_previous_chat_id"
+
+	// Callback data
+	eventProposalsQueueCallbackConfirmCancel = "admin_event_proposals_queue_callback_confirm_cancel"
+)
+
+// eventProposalsQueueHandler shows the event proposals waiting for review and handles the review buttons,
+// both of the queue and of the notifications about new proposals.
+// Rejecting and requesting changes ask for a comment shown to the proposer.
+type eventProposalsQueueHandler struct {
+	config                  *config.Config
+	eventProposalRepository *repositories.EventProposalRepository
+	eventProposalService    *services.EventProposalService
+	messageSenderService    *services.MessageSenderService
+	userStore               *utils.UserDataStore
+	permissionsService      *services.PermissionsService
+}
+
+func NewEventProposalsQueueHandler(
+	config *config.Config,
+	eventProposalRepository *repositories.EventProposalRepository,
+	eventProposalService *services.EventProposalService,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &eventProposalsQueueHandler{
+		config:                  config,
+		eventProposalRepository: eventProposalRepository,
+		eventProposalService:    eventProposalService,
+		messageSenderService:    messageSenderService,
+		userStore:               utils.NewUserDataStore(),
+		permissionsService:      permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.EventProposalsQueueCommand, h.startEventProposalsQueue),
+			handlers.NewCallback(callbackquery.Prefix(constants.EventProposalReviewApprovePrefix), h.handleCallbackApprove),
+			handlers.NewCallback(callbackquery.Prefix(constants.EventProposalReviewRejectPrefix), h.handleCallbackComment),
+			handlers.NewCallback(callbackquery.Prefix(constants.EventProposalReviewChangesPrefix), h.handleCallbackComment),
+		},
+		map[string][]ext.Handler{
+			eventProposalsQueueStateEnterComment: {
+				handlers.NewMessage(message.Text, h.handleCommentEntry),
+				handlers.NewCallback(callbackquery.Equal(eventProposalsQueueCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits:        []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+			AllowReEntry: true,
+		},
+	)
+}
+
+// 1. startEventProposalsQueue sends every pending proposal with its review buttons, the oldest first
+func (h *eventProposalsQueueHandler) startEventProposalsQueue(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.EventProposalsQueueCommand) {
+		log.Printf("%s: User %d (%s) tried to use /%s without admin permissions.",
+			utils.GetCurrentTypeName(),
+			ctx.EffectiveUser.Id,
+			ctx.EffectiveUser.Username,
+			constants.EventProposalsQueueCommand,
+		)
+		return handlers.EndConversation()
+	}
+
+	proposals, err := h.eventProposalRepository.GetPending(constants.EventProposalsQueueLimit)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving event proposals waiting for review.", nil)
+		log.Printf("%s: Error during pending event proposals retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	if len(proposals) == 0 {
+		h.messageSenderService.Reply(msg, "No event proposals are waiting for review.", nil)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.Reply(msg, fmt.Sprintf("Event proposals waiting for review: %d (up to %d are shown)",
+		len(proposals), constants.EventProposalsQueueLimit), nil)
+
+	for i := range proposals {
+		text, err := h.eventProposalService.FormatForReview(&proposals[i])
+		if err != nil {
+			log.Printf("%s: Error formatting event proposal %d: %v", utils.GetCurrentTypeName(), proposals[i].ID, err)
+			continue
+		}
+		h.messageSenderService.SendHtml(
+			msg.Chat.Id,
+			text,
+			&gotgbot.SendMessageOpts{ReplyMarkup: buttons.EventProposalReviewButtons(proposals[i].ID)},
+		)
+	}
+
+	return handlers.EndConversation()
+}
+
+// handleCallbackApprove approves the proposal right away, it becomes an event with the proposer as host
+func (h *eventProposalsQueueHandler) handleCallbackApprove(b *gotgbot.Bot, ctx *ext.Context) error {
+	proposalID, ok := h.parseReviewCallback(b, ctx, constants.EventProposalReviewApprovePrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	proposal, err := h.eventProposalService.Approve(proposalID)
+	if err != nil {
+		log.Printf("%s: Error approving event proposal %d: %v", utils.GetCurrentTypeName(), proposalID, err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "The proposal has already been reviewed or the event couldn't be created.")
+		return handlers.EndConversation()
+	}
+
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+	h.markReviewed(b, ctx.EffectiveMessage, proposal)
+
+	text := fmt.Sprintf("\U0001f389 Event <b>%s</b> <i>(ID: %d)</i> was created with the proposer as host.",
+		html.EscapeString(proposal.Title), *proposal.EventID)
+	if proposal.PreferredDates != "" {
+		text += fmt.Sprintf("\n<i>Preferred dates:</i> %s", html.EscapeString(proposal.PreferredDates))
+	}
+	text += fmt.Sprintf("\n\nSet the start and the other details with /%s, then announce the event so members can sign up for it.",
+		constants.EventEditCommand)
+	h.messageSenderService.SendHtml(
+		ctx.EffectiveChat.Id,
+		text,
+		&gotgbot.SendMessageOpts{ReplyMarkup: buttons.EventAnnounceButton(*proposal.EventID)},
+	)
+	return handlers.EndConversation()
+}
+
+// 2. handleCallbackComment asks for the comment of the rejection or of the changes requested
+func (h *eventProposalsQueueHandler) handleCallbackComment(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+
+	prefix := constants.EventProposalReviewRejectPrefix
+	status := constants.EventProposalStatusRejected
+	prompt := "Send the reason for rejecting proposal %d, it will be shown to the proposer:"
+	if strings.HasPrefix(cb.Data, constants.EventProposalReviewChangesPrefix) {
+		prefix = constants.EventProposalReviewChangesPrefix
+		status = constants.EventProposalStatusChangesRequested
+		prompt = "Send the changes you'd like in proposal %d, they will be shown to the proposer:"
+	}
+
+	proposalID, ok := h.parseReviewCallback(b, ctx, prefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+	_, _ = cb.Answer(b, nil)
+
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyProposalID, proposalID)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyStatus, status)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyReviewMessage, ctx.EffectiveMessage)
+
+	sentMsg, _ := h.messageSenderService.SendWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		fmt.Sprintf(prompt, proposalID),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(eventProposalsQueueCallbackConfirmCancel),
+		},
+	)
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+
+	return handlers.NextConversationState(eventProposalsQueueStateEnterComment)
+}
+
+// 3. handleCommentEntry rejects the proposal or requests changes with the entered comment
+func (h *eventProposalsQueueHandler) handleCommentEntry(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	comment := strings.TrimSpace(msg.Text)
+	if comment == "" {
+		h.messageSenderService.Reply(msg, "The comment cannot be empty. Please send it or cancel the operation.", nil)
+		return nil // Stay in the same state
+	}
+
+	proposalIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyProposalID)
+	proposalID, isInt := proposalIDVal.(int)
+	statusVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyStatus)
+	status, isStatus := statusVal.(constants.EventProposalStatus)
+	if !ok || !isInt || !isStatus {
+		h.messageSenderService.Reply(msg, "Error: proposal not found in session. Press the button again.", nil)
+		return handlers.EndConversation()
+	}
+
+	reviewMsgVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventProposalsQueueCtxDataKeyReviewMessage)
+	reviewMsg, _ := reviewMsgVal.(*gotgbot.Message)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	var proposal *repositories.EventProposal
+	var err error
+	if status == constants.EventProposalStatusChangesRequested {
+		proposal, err = h.eventProposalService.RequestChanges(proposalID, comment)
+	} else {
+		proposal, err = h.eventProposalService.Reject(proposalID, comment)
+	}
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Could not review proposal %d: it has already been reviewed.", proposalID), nil)
+		log.Printf("%s: Error reviewing event proposal %d: %v", utils.GetCurrentTypeName(), proposalID, err)
+		return handlers.EndConversation()
+	}
+
+	h.markReviewed(b, reviewMsg, proposal)
+
+	h.messageSenderService.Reply(msg, fmt.Sprintf("Proposal %d: %s, the proposer has been notified.",
+		proposalID, formatters.GetEventProposalStatusLabel(proposal.Status)), nil)
+	return handlers.EndConversation()
+}
+
+// handleCallbackCancel processes the cancel button click
+func (h *eventProposalsQueueHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+// 4. handleCancel handles the /cancel command
+func (h *eventProposalsQueueHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.messageSenderService.Reply(msg, "Review canceled, the proposal is still waiting in the queue.", nil)
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// parseReviewCallback checks the admin permissions and returns the proposal ID from the callback data
+func (h *eventProposalsQueueHandler) parseReviewCallback(b *gotgbot.Bot, ctx *ext.Context, prefix string) (int, bool) {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserAdminOrCreator(b, ctx.EffectiveUser.Id, h.config) {
+		h.answerAlert(b, callback, "Only administrators can review event proposals.")
+		return 0, false
+	}
+
+	proposalID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, prefix))
+	if err != nil {
+		h.answerAlert(b, callback, "Unknown proposal.")
+		return 0, false
+	}
+
+	return proposalID, true
+}
+
+// markReviewed replaces the review buttons of the message with the result
+func (h *eventProposalsQueueHandler) markReviewed(b *gotgbot.Bot, msg *gotgbot.Message, proposal *repositories.EventProposal) {
+	if msg == nil {
+		return
+	}
+
+	_, _, err := b.EditMessageText(
+		fmt.Sprintf("%s\n\n<b>%s</b>", html.EscapeString(msg.Text), formatters.GetEventProposalStatusLabel(proposal.Status)),
+		&gotgbot.EditMessageTextOpts{
+			ChatId:    msg.Chat.Id,
+			MessageId: msg.MessageId,
+			ParseMode: "HTML",
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error updating review message of event proposal %d: %v", utils.GetCurrentTypeName(), proposal.ID, err)
+	}
+}
+
+func (h *eventProposalsQueueHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
+
+func (h *eventProposalsQueueHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			eventProposalsQueueCtxDataKeyPreviousMessageID,
+			eventProposalsQueueCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *eventProposalsQueueHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		eventProposalsQueueCtxDataKeyPreviousMessageID, eventProposalsQueueCtxDataKeyPreviousChatID)
+}
@@ -0,0 +1,113 @@
+package eventhandlers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// eventAnnounceHandler posts an event announcement with the RSVP buttons to the announcement topic.
+// The button is attached to the /eventSetup summary after the conversation has ended, so the handler is stateless.
+type eventAnnounceHandler struct {
+	config               *config.Config
+	eventRepository      *repositories.EventRepository
+	eventRSVPRepository  *repositories.EventRSVPRepository
+	messageSenderService *services.MessageSenderService
+}
+
+func NewEventAnnounceHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	eventRSVPRepository *repositories.EventRSVPRepository,
+	messageSenderService *services.MessageSenderService,
+) ext.Handler {
+	h := &eventAnnounceHandler{
+		config:               config,
+		eventRepository:      eventRepository,
+		eventRSVPRepository:  eventRSVPRepository,
+		messageSenderService: messageSenderService,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.EventAnnouncePrefix), h.handleCallback)
+}
+
+func (h *eventAnnounceHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserAdminOrCreator(b, ctx.EffectiveUser.Id, h.config) {
+		log.Printf("%s: User %d tried to announce an event without admin rights", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id)
+		return h.answerAlert(b, callback, "This action is only available to administrators.")
+	}
+
+	eventID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, constants.EventAnnouncePrefix))
+	if err != nil {
+		return h.answerAlert(b, callback, "Unknown event.")
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		return h.answerAlert(b, callback, "This event no longer exists.")
+	}
+	if event.Status != string(constants.EventStatusActual) {
+		return h.answerAlert(b, callback, "This event has already taken place.")
+	}
+
+	counts, err := h.eventRSVPRepository.GetCounts(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting responses of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while preparing the announcement.")
+	}
+
+	announcement := formatters.FormatHtmlEventAnnouncement(*event, counts, h.config.EventsTimezone, time.Now())
+
+	_, err = h.messageSenderService.SendHtmlWithReturnMessage(
+		utils.ChatIdToFullChatId(h.config.SuperGroupChatID),
+		announcement,
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: int64(h.config.AnnouncementTopicID),
+			ReplyMarkup:     buttons.EventRSVPButtons(event.ID),
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error sending announcement of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while sending the announcement.")
+	}
+
+	_, _ = callback.Answer(b, nil)
+
+	if msg := ctx.EffectiveMessage; msg != nil {
+		_ = h.messageSenderService.RemoveInlineKeyboard(msg.Chat.Id, msg.MessageId)
+		h.messageSenderService.SendHtml(
+			msg.Chat.Id,
+			fmt.Sprintf("\U0001f4e2 Event <b>%s</b> was announced in the group.", html.EscapeString(event.Name)),
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func (h *eventAnnounceHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"evo-bot-go/internal/buttons"
 	"evo-bot-go/internal/config"
@@ -29,17 +30,20 @@ const (
 	// Context data keys
 	eventDeleteCtxDataKeySelectedEventID   = "event_delete_ctx_data_selected_event_id"
 	eventDeleteCtxDataKeySelectedEventName = "event_delete_ctx_data_selected_event_name"
+	eventDeleteCtxDataKeySelectedEvent     = "event_delete_ctx_data_selected_event"
 	eventDeleteCtxDataKeyPreviousMessageID = "event_delete_ctx_data_previous_message_id"
 	eventDeleteCtxDataKeyPreviousChatID    = "event_delete_ctx_data_previous_chat_id"
 
 	// Callback data
 	eventDeleteCallbackConfirmCancel = "event_delete_callback_confirm_cancel"
 	eventDeleteCallbackConfirmYes    = "event_delete_callback_confirm_yes"
+	eventDeleteCallbackStopSeries    = "event_delete_callback_stop_series"
 )
 
 type eventDeleteHandler struct {
 	config               *config.Config
 	eventRepository      *repositories.EventRepository
+	eventSeriesService   *services.EventSeriesService
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -48,12 +52,14 @@ type eventDeleteHandler struct {
 func NewEventDeleteHandler(
 	config *config.Config,
 	eventRepository *repositories.EventRepository,
+	eventSeriesService *services.EventSeriesService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
 	h := &eventDeleteHandler{
 		config:               config,
 		eventRepository:      eventRepository,
+		eventSeriesService:   eventSeriesService,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -70,6 +76,7 @@ func NewEventDeleteHandler(
 			},
 			eventDeleteStateConfirm: {
 				handlers.NewCallback(callbackquery.Equal(eventDeleteCallbackConfirmYes), h.handleCallbackConfirmYes),
+				handlers.NewCallback(callbackquery.Equal(eventDeleteCallbackStopSeries), h.handleCallbackStopSeries),
 				handlers.NewCallback(callbackquery.Equal(eventDeleteCallbackConfirmCancel), h.handleCallbackCancel),
 				handlers.NewMessage(message.Text, h.handleMessageDuringConfirmation),
 			},
@@ -110,7 +117,7 @@ func (h *eventDeleteHandler) startDelete(b *gotgbot.Bot, ctx *ext.Context) error
 
 	title := fmt.Sprintf("Last %d events:", len(events))
 	actionDescription := "that you want to delete"
-	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription)
+	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription, h.config.EventsTimezone)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
 		msg,
@@ -143,17 +150,15 @@ func (h *eventDeleteHandler) handleSelectEvent(b *gotgbot.Bot, ctx *ext.Context)
 	}
 
 	// Find the event with the given ID
-	var found bool
-	var eventName string
-	for _, event := range events {
-		if event.ID == eventID {
-			found = true
-			eventName = event.Name
+	var selectedEvent *repositories.Event
+	for i := range events {
+		if events[i].ID == eventID {
+			selectedEvent = &events[i]
 			break
 		}
 	}
 
-	if !found {
+	if selectedEvent == nil {
 		h.messageSenderService.Reply(
 			msg,
 			fmt.Sprintf("Event with ID %d not found. Please enter a valid ID or use the cancel button.", eventID),
@@ -165,20 +170,37 @@ func (h *eventDeleteHandler) handleSelectEvent(b *gotgbot.Bot, ctx *ext.Context)
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
 	// Store event ID and name for confirmation
+	eventName := selectedEvent.Name
 	h.userStore.Set(ctx.EffectiveUser.Id, eventDeleteCtxDataKeySelectedEventID, eventID)
 	h.userStore.Set(ctx.EffectiveUser.Id, eventDeleteCtxDataKeySelectedEventName, eventName)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventDeleteCtxDataKeySelectedEvent, *selectedEvent)
 
 	// Ask for confirmation
 	confirmMessage := fmt.Sprintf(
 		"Are you sure you want to delete event '*%s*' (ID: %d)?\n\nThis will also delete all associated topics and questions.",
 		eventName, eventID)
+	markup := buttons.ConfirmAndCancelButton(eventDeleteCallbackConfirmYes, eventDeleteCallbackConfirmCancel)
+
+	// Occurrences of a recurring event can be deleted on their own, or together with the upcoming ones
+	if selectedEvent.SeriesID != nil {
+		confirmMessage += fmt.Sprintf(
+			"\n\nThis event repeats %s. Delete only this occurrence, or stop the series: "+
+				"this and all upcoming occurrences will be deleted, and no new ones will be created.",
+			formatters.FormatEventRecurrence(*selectedEvent, h.config.EventsTimezone),
+		)
+		markup = buttons.EventDeleteOccurrenceButtons(
+			eventDeleteCallbackConfirmYes,
+			eventDeleteCallbackStopSeries,
+			eventDeleteCallbackConfirmCancel,
+		)
+	}
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
 		msg,
 		confirmMessage,
 		&gotgbot.SendMessageOpts{
 			ParseMode:   "Markdown",
-			ReplyMarkup: buttons.ConfirmAndCancelButton(eventDeleteCallbackConfirmYes, eventDeleteCallbackConfirmCancel),
+			ReplyMarkup: markup,
 		},
 	)
 
@@ -265,6 +287,55 @@ func (h *eventDeleteHandler) handleCallbackConfirmYes(b *gotgbot.Bot, ctx *ext.C
 	return handlers.EndConversation()
 }
 
+// handleCallbackStopSeries deletes the selected occurrence with all upcoming occurrences and stops the series
+func (h *eventDeleteHandler) handleCallbackStopSeries(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	eventVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventDeleteCtxDataKeySelectedEvent)
+	event, isEvent := eventVal.(repositories.Event)
+	if !ok || !isEvent || event.SeriesID == nil {
+		h.messageSenderService.Reply(
+			ctx.EffectiveMessage,
+			fmt.Sprintf(
+				"An error occurred while retrieving the selected event. Please start over with /%s",
+				constants.EventDeleteCommand,
+			),
+			nil,
+		)
+		return handlers.EndConversation()
+	}
+
+	from := time.Now()
+	if event.StartedAt != nil {
+		from = *event.StartedAt
+	}
+
+	deletedCount, err := h.eventSeriesService.StopSeries(*event.SeriesID, from)
+	if err != nil {
+		h.messageSenderService.Reply(ctx.EffectiveMessage, "An error occurred while stopping the series.", nil)
+		log.Printf("%s: Error during series %d stop: %v", utils.GetCurrentTypeName(), *event.SeriesID, err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyMarkdown(
+		ctx.EffectiveMessage,
+		fmt.Sprintf(
+			"Series '*%s*' stopped, %d upcoming occurrences deleted. Past occurrences are kept.\n\nTo view all commands, use /%s",
+			event.Name, deletedCount, constants.HelpCommand,
+		),
+		nil,
+	)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
 // handleCallbackCancel processes the cancel button click
 func (h *eventDeleteHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 	// Answer the callback query to remove the loading state on the button
@@ -0,0 +1,213 @@
+package eventhandlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// eventSkipInput is sent by admins to leave an optional event field empty
+const eventSkipInput = "-"
+
+const eventStartedAtInputLayout = "02.01.2006 15:04"
+
+// eventStartedAtPrompt asks for the start time in the events timezone
+func eventStartedAtPrompt(loc *time.Location) string {
+	return fmt.Sprintf("Enter date and time in DD.MM.YYYY HH:MM format (%s time):", utils.TimezoneLabel(loc))
+}
+
+const (
+	eventRecurrencePrompt = "Does the event repeat? Send a rule, for example:\n" +
+		"FREQ=WEEKLY;BYDAY=TH - every Thursday\n" +
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO - every other Monday\n" +
+		"FREQ=MONTHLY;BYDAY=1SA - the first Saturday of every month\n" +
+		"FREQ=MONTHLY;BYDAY=-1FR - the last Friday of every month\n" +
+		"Add ;COUNT=10 or ;UNTIL=20261231 to end the series. The time of day is taken from the start date.\n" +
+		"Send " + eventSkipInput + " for a one-time event:"
+	eventDurationPrompt = "How long will the event last? Send minutes (e.g. 90) or hours and minutes (e.g. 1h30m), " +
+		"or " + eventSkipInput + " to skip:"
+	eventDescriptionPrompt = "Send a short description of the event (agenda, who it's for), or " + eventSkipInput + " to skip:"
+	eventPlacePrompt       = "Where will the event take place? Send a join link (https://...) for an online event, " +
+		"an address for an offline one, or both on separate lines. Send " + eventSkipInput + " to skip:"
+	eventHostPrompt     = "Who hosts the event? Send their @username or Telegram ID, or " + eventSkipInput + " to skip:"
+	eventCapacityPrompt = "How many seats are available? Send a number, or " + eventSkipInput + " for unlimited:"
+	eventMaterialPrompt = "Send the file (video, PDF, presentation...) or a link (https://...). " +
+		"The file caption or the text next to the link is posted as the description:"
+	eventMaterialLinkPrompt = "Send the link (https://...), the text next to it is posted as the description:"
+)
+
+// eventMaterialDescriptionMaxLength keeps the post within the caption limit of Telegram files
+const eventMaterialDescriptionMaxLength = 500
+
+// eventMaterialPromptFor returns the material prompt matching the selected kind
+func eventMaterialPromptFor(kind constants.EventMaterialKind) string {
+	if kind == constants.EventMaterialKindLink {
+		return eventMaterialLinkPrompt
+	}
+	return eventMaterialPrompt
+}
+
+// eventInputErrorMessage builds the reply to an invalid input from the parse error and the step prompt
+func eventInputErrorMessage(err error, prompt string) string {
+	reason := err.Error()
+	if reason != "" {
+		reason = strings.ToUpper(reason[:1]) + reason[1:]
+	}
+	return fmt.Sprintf("%s.\n\n%s", reason, prompt)
+}
+
+// parseEventStartedAtInput parses the start time entered in the events timezone
+func parseEventStartedAtInput(input string, loc *time.Location) (time.Time, error) {
+	startedAt, err := time.ParseInLocation(eventStartedAtInputLayout, strings.TrimSpace(input), loc)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date format")
+	}
+	return startedAt, nil
+}
+
+// parseEventRecurrenceInput parses the RRULE-style recurrence rule, nil means a one-time event
+func parseEventRecurrenceInput(input string) (*utils.RecurrenceRule, error) {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return nil, nil
+	}
+
+	rule, err := utils.ParseRecurrenceRule(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule: %w", err)
+	}
+	return rule, nil
+}
+
+// parseEventDurationInput accepts minutes ("90") or a Go duration ("1h30m"), nil means not set
+func parseEventDurationInput(input string) (*int, error) {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return nil, nil
+	}
+
+	minutes, err := strconv.Atoi(input)
+	if err != nil {
+		duration, durationErr := time.ParseDuration(strings.ReplaceAll(input, " ", ""))
+		if durationErr != nil {
+			return nil, errors.New("invalid duration")
+		}
+		minutes = int(duration.Minutes())
+	}
+
+	if minutes <= 0 || minutes > 24*60 {
+		return nil, errors.New("the duration must be between 1 minute and 24 hours")
+	}
+	return &minutes, nil
+}
+
+// parseEventDescriptionInput returns an empty description when the step is skipped
+func parseEventDescriptionInput(input string) string {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return ""
+	}
+	return input
+}
+
+// parseEventPlaceInput splits the input into an address and a join link, each line is either of them
+func parseEventPlaceInput(input string) (location string, link string, err error) {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return "", "", nil
+	}
+
+	var locationLines []string
+	for _, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "http://") || strings.HasPrefix(line, "https://"):
+			if link != "" {
+				return "", "", errors.New("only one join link is allowed")
+			}
+			link = line
+		default:
+			locationLines = append(locationLines, line)
+		}
+	}
+
+	return strings.Join(locationLines, ", "), link, nil
+}
+
+// resolveEventHostInput finds the host by @username or Telegram ID, nil means no host
+func resolveEventHostInput(userRepository *repositories.UserRepository, input string) (*repositories.User, error) {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return nil, nil
+	}
+
+	var user *repositories.User
+	var err error
+	if tgID, parseErr := strconv.ParseInt(input, 10, 64); parseErr == nil {
+		user, err = userRepository.GetByTelegramID(tgID)
+	} else {
+		user, err = userRepository.GetByTelegramUsername(strings.TrimPrefix(input, "@"))
+	}
+
+	if err != nil || user == nil {
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to search for the host: %w", err)
+		}
+		return nil, fmt.Errorf("member %s not found, they should have written in the group at least once", input)
+	}
+	return user, nil
+}
+
+// parseEventCapacityInput parses the number of seats, nil means unlimited
+func parseEventCapacityInput(input string) (*int, error) {
+	input = strings.TrimSpace(input)
+	if input == eventSkipInput {
+		return nil, nil
+	}
+
+	capacity, err := strconv.Atoi(input)
+	if err != nil || capacity <= 0 {
+		return nil, errors.New("the capacity must be a positive number")
+	}
+	return &capacity, nil
+}
+
+// parseEventMaterialInput takes the file of the message, if any, and its caption as the description.
+// Text messages must contain a link, the rest of the text is the description.
+func parseEventMaterialInput(msg *gotgbot.Message, kind constants.EventMaterialKind) (description string, url string, file *gotgbot.Message, err error) {
+	hasFile := msg.Document != nil || msg.Video != nil || msg.Audio != nil || msg.Photo != nil
+	if hasFile {
+		if kind == constants.EventMaterialKindLink {
+			return "", "", nil, errors.New("a link is expected, not a file")
+		}
+		description = strings.TrimSpace(msg.Caption)
+		file = msg
+	} else {
+		for _, word := range strings.Fields(msg.Text) {
+			if strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://") {
+				url = word
+				break
+			}
+		}
+		if url == "" {
+			return "", "", nil, errors.New("no link found")
+		}
+		description = strings.TrimSpace(strings.Replace(msg.Text, url, "", 1))
+	}
+
+	if len([]rune(description)) > eventMaterialDescriptionMaxLength {
+		return "", "", nil, fmt.Errorf("the description is longer than %d characters", eventMaterialDescriptionMaxLength)
+	}
+	return description, url, file, nil
+}
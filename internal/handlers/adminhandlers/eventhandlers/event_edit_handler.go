@@ -2,10 +2,10 @@ package eventhandlers
 
 import (
 	"fmt"
+	"html"
 	"log"
 	"strconv"
 	"strings"
-	"time"
 
 	"evo-bot-go/internal/buttons"
 	"evo-bot-go/internal/config"
@@ -29,10 +29,15 @@ const (
 	eventEditStateEditName      = "event_edit_state_edit_name"
 	eventEditStateEditStartedAt = "event_edit_state_edit_started_at"
 	eventEditStateEditType      = "event_edit_state_edit_type"
+	eventEditStateEditDetails   = "event_edit_state_edit_details"
+
+	eventEditStateSelectMaterialKind = "event_edit_state_select_material_kind"
+	eventEditStateEnterMaterial      = "event_edit_state_enter_material"
 
 	// Context data keys
 	eventEditCtxDataKeySelectedEventID   = "event_edit_ctx_data_selected_event_id"
 	eventEditCtxDataKeyEditType          = "event_edit_ctx_data_edit_type"
+	eventEditCtxDataKeyMaterialKind      = "event_edit_ctx_data_material_kind"
 	eventEditCtxDataKeyPreviousMessageID = "event_edit_ctx_data_previous_message_id"
 	eventEditCtxDataKeyPreviousChatID    = "event_edit_ctx_data_previous_chat_id"
 
@@ -43,11 +48,41 @@ const (
 	eventEditTypeName      = "name"
 	eventEditTypeStartDate = "startDate"
 	eventEditTypeType      = "type"
+
+	// Edit types handled by handleEditDetails
+	eventEditTypeDuration    = "duration"
+	eventEditTypeDescription = "description"
+	eventEditTypePlace       = "place"
+	eventEditTypeHost        = "host"
+	eventEditTypeCapacity    = "capacity"
+
+	// Materials are attached to finished events, see handleSelectMaterialKind
+	eventEditTypeMaterials = "materials"
 )
 
+// eventEditOptions lists what can be edited, in the order of the menu numbers
+var eventEditOptions = []struct {
+	editType string
+	title    string
+}{
+	{eventEditTypeName, "Name"},
+	{eventEditTypeStartDate, "Start date"},
+	{eventEditTypeType, "Type"},
+	{eventEditTypeDuration, "Duration"},
+	{eventEditTypeDescription, "Description"},
+	{eventEditTypePlace, "Location or link"},
+	{eventEditTypeHost, "Host"},
+	{eventEditTypeCapacity, "Capacity"},
+	{eventEditTypeMaterials, "Add a recording, slides or a link (finished events)"},
+}
+
 type eventEditHandler struct {
 	config               *config.Config
 	eventRepository      *repositories.EventRepository
+	eventTypeRepository  *repositories.EventTypeRepository
+	userRepository       *repositories.UserRepository
+	eventRSVPService     *services.EventRSVPService
+	eventMaterialService *services.EventMaterialService
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -56,12 +91,20 @@ type eventEditHandler struct {
 func NewEventEditHandler(
 	config *config.Config,
 	eventRepository *repositories.EventRepository,
+	eventTypeRepository *repositories.EventTypeRepository,
+	userRepository *repositories.UserRepository,
+	eventRSVPService *services.EventRSVPService,
+	eventMaterialService *services.EventMaterialService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
 	h := &eventEditHandler{
 		config:               config,
 		eventRepository:      eventRepository,
+		eventTypeRepository:  eventTypeRepository,
+		userRepository:       userRepository,
+		eventRSVPService:     eventRSVPService,
+		eventMaterialService: eventMaterialService,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -92,6 +135,18 @@ func NewEventEditHandler(
 				handlers.NewMessage(message.Text, h.handleEditType),
 				handlers.NewCallback(callbackquery.Equal(eventEditCallbackConfirmCancel), h.handleCallbackCancel),
 			},
+			eventEditStateEditDetails: {
+				handlers.NewMessage(message.Text, h.handleEditDetails),
+				handlers.NewCallback(callbackquery.Equal(eventEditCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventEditStateSelectMaterialKind: {
+				handlers.NewMessage(message.Text, h.handleSelectMaterialKind),
+				handlers.NewCallback(callbackquery.Equal(eventEditCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventEditStateEnterMaterial: {
+				handlers.NewMessage(message.All, h.handleEnterMaterial),
+				handlers.NewCallback(callbackquery.Equal(eventEditCallbackConfirmCancel), h.handleCallbackCancel),
+			},
 		},
 		&handlers.ConversationOpts{
 			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
@@ -130,7 +185,7 @@ func (h *eventEditHandler) startEdit(b *gotgbot.Bot, ctx *ext.Context) error {
 	// Create a list of events to display
 	title := fmt.Sprintf("Last %d events:", len(events))
 	actionDescription := "that you want to edit"
-	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription)
+	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription, h.config.EventsTimezone)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
 		msg,
@@ -156,7 +211,7 @@ func (h *eventEditHandler) handleSelectEvent(b *gotgbot.Bot, ctx *ext.Context) e
 	}
 
 	// Check if content with this ID exists
-	_, err = h.eventRepository.GetEventByID(eventID)
+	event, err := h.eventRepository.GetEventByID(eventID)
 	if err != nil {
 		log.Printf("%s: Error checking content with ID %d: %v", utils.GetCurrentTypeName(), eventID, err)
 		h.messageSenderService.Reply(
@@ -173,10 +228,18 @@ func (h *eventEditHandler) handleSelectEvent(b *gotgbot.Bot, ctx *ext.Context) e
 	h.userStore.Set(ctx.EffectiveUser.Id, eventEditCtxDataKeySelectedEventID, eventID)
 
 	// Ask what the user wants to edit
+	var options strings.Builder
+	for i, option := range eventEditOptions {
+		options.WriteString(fmt.Sprintf("/%d. %s\n", i+1, option.title))
+	}
 	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
 		msg,
-		fmt.Sprintf("What do you want to edit?\n/1. Name\n/2. Start date\n/3. Type\n\nEnter a number:"),
+		fmt.Sprintf("%s\n\nWhat do you want to edit?\n%s\nEnter a number:",
+			formatters.FormatHtmlEventDetailsForAdmin(*event, h.config.EventsTimezone),
+			options.String(),
+		),
 		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
 			ReplyMarkup: buttons.CancelButton(eventEditCallbackConfirmCancel),
 		},
 	)
@@ -219,61 +282,114 @@ func (h *eventEditHandler) handleSelectEditType(b *gotgbot.Bot, ctx *ext.Context
 
 	// Parse the selection
 	selection, err := strconv.Atoi(selectionText)
-	if err != nil || selection < 1 || selection > 3 {
+	if err != nil || selection < 1 || selection > len(eventEditOptions) {
 		h.messageSenderService.Reply(msg, fmt.Sprintf(
-			"Invalid selection. Please enter a number from 1 to 3, or use the cancel button",
+			"Invalid selection. Please enter a number from 1 to %d, or use the cancel button",
+			len(eventEditOptions),
 		), nil)
 		return nil // Stay in the same state
 	}
 
+	editType := eventEditOptions[selection-1].editType
+	if editType == eventEditTypeMaterials && event.Status != string(constants.EventStatusFinished) {
+		h.messageSenderService.Reply(msg,
+			"Materials can be added to finished events only. Please enter another number, or use the cancel button", nil)
+		return nil // Stay in the same state
+	}
+
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
-	var editType string
-	var nextState string
+	nextState := eventEditStateEditDetails
 	var message string
 
-	switch selection {
-	case 1:
-		editType = eventEditTypeName
+	switch editType {
+	case eventEditTypeName:
 		nextState = eventEditStateEditName
-		message = fmt.Sprintf("Current name: *%s*\n\nEnter a new name:", event.Name)
-	case 2:
-		editType = eventEditTypeStartDate
+		message = fmt.Sprintf("Current name: <b>%s</b>\n\nEnter a new name:", html.EscapeString(event.Name))
+	case eventEditTypeStartDate:
 		nextState = eventEditStateEditStartedAt
 		var currentStartedAt string
 		if event.StartedAt != nil {
-			currentStartedAt = event.StartedAt.Format("02.01.2006 15:04")
+			currentStartedAt = event.StartedAt.In(h.config.EventsTimezone).Format(eventStartedAtInputLayout)
 		} else {
 			currentStartedAt = "not set"
 		}
 		message = fmt.Sprintf(
-			"Current start date: `%s` (UTC)\nEnter a new date and time in DD.MM.YYYY HH:MM format (UTC):",
-			currentStartedAt,
+			"Current start date: <code>%s</code> (%s)\n%s",
+			currentStartedAt, utils.TimezoneLabel(h.config.EventsTimezone), eventStartedAtPrompt(h.config.EventsTimezone),
 		)
-	case 3:
-		editType = eventEditTypeType
+	case eventEditTypeType:
 		nextState = eventEditStateEditType
 
+		eventTypes, err := h.eventTypeRepository.GetAll()
+		if err != nil {
+			h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+			log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+			return handlers.EndConversation()
+		}
+
 		// Prepare available event types for display
 		var availableTypes string
-		for i, t := range constants.AllEventTypes {
-			availableTypes += fmt.Sprintf("/%d. %s %s\n", i+1, formatters.GetTypeEmoji(t), t)
+		for i, t := range eventTypes {
+			availableTypes += fmt.Sprintf("/%d. %s %s\n", i+1, formatters.GetTypeEmoji(t), t.Slug)
 		}
 
 		message = fmt.Sprintf(
-			"Current type: *%s*\n\nAvailable types:\n%s\nEnter a new type or its number:",
+			"Current type: <b>%s</b>\n\nAvailable types:\n%s\nEnter a new type or its number:",
 			event.Type, availableTypes,
 		)
+	case eventEditTypeDuration:
+		current := "not set"
+		if event.DurationMinutes != nil {
+			current = utils.FormatDurationMinutes(*event.DurationMinutes)
+		}
+		message = fmt.Sprintf("Current duration: <b>%s</b>\n\n%s", current, html.EscapeString(eventDurationPrompt))
+	case eventEditTypeDescription:
+		current := "<i>not set</i>"
+		if event.Description != "" {
+			current = fmt.Sprintf("<blockquote expandable>%s</blockquote>", html.EscapeString(event.Description))
+		}
+		message = fmt.Sprintf("Current description: %s\n\n%s", current, html.EscapeString(eventDescriptionPrompt))
+	case eventEditTypePlace:
+		current := formatters.FormatHtmlEventPlace(*event)
+		if current == "" {
+			current = "<i>not set</i>"
+		}
+		message = fmt.Sprintf("Current location: %s\n\n%s", current, html.EscapeString(eventPlacePrompt))
+	case eventEditTypeHost:
+		current := formatters.FormatEventHost(*event)
+		if current == "" {
+			current = "not set"
+		}
+		message = fmt.Sprintf("Current host: <b>%s</b>\n\n%s", html.EscapeString(current), html.EscapeString(eventHostPrompt))
+	case eventEditTypeCapacity:
+		current := "unlimited"
+		if event.Capacity != nil {
+			current = strconv.Itoa(*event.Capacity)
+		}
+		message = fmt.Sprintf("Current capacity: <b>%s</b>\n\n%s", current, html.EscapeString(eventCapacityPrompt))
+	case eventEditTypeMaterials:
+		nextState = eventEditStateSelectMaterialKind
+
+		var kinds strings.Builder
+		for i, kind := range constants.AllEventMaterialKinds {
+			kinds.WriteString(fmt.Sprintf("/%d. %s\n", i+1, formatters.GetEventMaterialKindLabel(kind)))
+		}
+		message = fmt.Sprintf(
+			"The material is posted to the Content topic and shown in the archive of /%s.\n\nWhat do you want to add?\n%s\nEnter a number:",
+			constants.EventsCommand, kinds.String(),
+		)
 	}
 
 	// Store the edit type
 	h.userStore.Set(ctx.EffectiveUser.Id, eventEditCtxDataKeyEditType, editType)
 
 	// Prompt for the new value
-	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
 		msg,
 		message,
 		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
 			ReplyMarkup: buttons.CancelButton(eventEditCallbackConfirmCancel),
 		},
 	)
@@ -345,12 +461,10 @@ func (h *eventEditHandler) handleEditStartedAt(b *gotgbot.Bot, ctx *ext.Context)
 	msg := ctx.EffectiveMessage
 	dateTimeStr := strings.TrimSpace(msg.Text)
 
-	// Parse the start date
-	startedAt, err := time.Parse("02.01.2006 15:04", dateTimeStr)
+	// Parse the start date in the events timezone
+	startedAt, err := parseEventStartedAtInput(dateTimeStr, h.config.EventsTimezone)
 	if err != nil {
-		h.messageSenderService.ReplyMarkdown(msg, fmt.Sprintf(
-			"Invalid date format. Please enter date and time in *DD.MM.YYYY HH:MM* format (UTC) or use the cancel button.",
-		), nil)
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventStartedAtPrompt(h.config.EventsTimezone))+" Or use the cancel button.", nil)
 		return nil // Stay in the same state
 	}
 
@@ -387,7 +501,10 @@ func (h *eventEditHandler) handleEditStartedAt(b *gotgbot.Bot, ctx *ext.Context)
 	// Confirmation message
 	h.messageSenderService.ReplyMarkdown(msg, fmt.Sprintf(
 		"Event start date with ID %d successfully updated to *%s* \n\nTo continue editing the event, use the /%s command.\nTo view all commands, use /%s",
-		eventID, startedAt.Format("02.01.2006 15:04 UTC"), constants.EventEditCommand, constants.HelpCommand,
+		eventID,
+		fmt.Sprintf("%s %s", startedAt.Format(eventStartedAtInputLayout), utils.TimezoneLabel(h.config.EventsTimezone)),
+		constants.EventEditCommand,
+		constants.HelpCommand,
 	), nil)
 
 	// Clean up user data
@@ -408,28 +525,33 @@ func (h *eventEditHandler) handleEditType(b *gotgbot.Bot, ctx *ext.Context) erro
 		return nil // Stay in the same state
 	}
 
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
 	// Check if input is a number (index selection)
 	index, err := strconv.Atoi(input)
-	var validEventType constants.EventType
+	var validEventType *repositories.EventType
 
-	if err == nil && index > 0 && index <= len(constants.AllEventTypes) {
+	if err == nil && index > 0 && index <= len(eventTypes) {
 		// User selected by index
-		validEventType = constants.AllEventTypes[index-1]
+		validEventType = &eventTypes[index-1]
 	} else {
 		// User entered the type directly, validate it
-		validEventType = constants.EventType(input)
-		isValid := false
-		for _, eventType := range constants.AllEventTypes {
-			if validEventType == eventType {
-				isValid = true
+		for i := range eventTypes {
+			if eventTypes[i].Slug == constants.EventType(input) {
+				validEventType = &eventTypes[i]
 				break
 			}
 		}
 
-		if !isValid {
+		if validEventType == nil {
 			eventTypesStr := []string{}
-			for _, t := range constants.AllEventTypes {
-				eventTypesStr = append(eventTypesStr, string(t))
+			for _, t := range eventTypes {
+				eventTypesStr = append(eventTypesStr, string(t.Slug))
 			}
 			h.messageSenderService.Reply(msg, fmt.Sprintf(
 				"Invalid event type. Allowed types: %s",
@@ -462,7 +584,7 @@ func (h *eventEditHandler) handleEditType(b *gotgbot.Bot, ctx *ext.Context) erro
 	}
 
 	// Update the event type
-	err = h.eventRepository.UpdateEventType(eventID, validEventType)
+	err = h.eventRepository.UpdateEventType(eventID, validEventType.Slug)
 	if err != nil {
 		h.messageSenderService.Reply(msg, "An error occurred while updating the event type.", nil)
 		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
@@ -475,8 +597,8 @@ func (h *eventEditHandler) handleEditType(b *gotgbot.Bot, ctx *ext.Context) erro
 		fmt.Sprintf(
 			"Event type with ID %d successfully updated to %s *'%s'* \n\nTo continue editing the event, use the /%s command.\nTo view all commands, use /%s",
 			eventID,
-			formatters.GetTypeEmoji(validEventType),
-			validEventType,
+			formatters.GetTypeEmoji(*validEventType),
+			validEventType.Slug,
 			constants.EventEditCommand,
 			constants.HelpCommand,
 		),
@@ -489,6 +611,186 @@ func (h *eventEditHandler) handleEditType(b *gotgbot.Bot, ctx *ext.Context) erro
 	return handlers.EndConversation()
 }
 
+// 4.4. handleEditDetails processes the new value of duration, description, location, host or capacity
+func (h *eventEditHandler) handleEditDetails(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	editTypeVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventEditCtxDataKeyEditType)
+	editType, _ := editTypeVal.(string)
+
+	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventEditCtxDataKeySelectedEventID)
+	eventID, isInt := eventIDVal.(int)
+	if !ok || !isInt {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An error occurred while retrieving the selected event. Please start over with /%s",
+			constants.EventEditCommand,
+		), nil)
+		return handlers.EndConversation()
+	}
+
+	// Validate the input first, so the admin can retry without leaving the current state
+	var update func() error
+	var inputErr error
+	var prompt string
+	switch editType {
+	case eventEditTypeDuration:
+		durationMinutes, err := parseEventDurationInput(msg.Text)
+		inputErr, prompt = err, eventDurationPrompt
+		update = func() error { return h.eventRepository.UpdateEventDuration(eventID, durationMinutes) }
+	case eventEditTypeDescription:
+		description := parseEventDescriptionInput(msg.Text)
+		update = func() error { return h.eventRepository.UpdateEventDescription(eventID, description) }
+	case eventEditTypePlace:
+		location, link, err := parseEventPlaceInput(msg.Text)
+		inputErr, prompt = err, eventPlacePrompt
+		update = func() error { return h.eventRepository.UpdateEventPlace(eventID, location, link) }
+	case eventEditTypeHost:
+		host, err := resolveEventHostInput(h.userRepository, msg.Text)
+		inputErr, prompt = err, eventHostPrompt
+		update = func() error {
+			var hostUserID *int
+			if host != nil {
+				hostUserID = &host.ID
+			}
+			return h.eventRepository.UpdateEventHost(eventID, hostUserID)
+		}
+	case eventEditTypeCapacity:
+		capacity, err := parseEventCapacityInput(msg.Text)
+		inputErr, prompt = err, eventCapacityPrompt
+		update = func() error {
+			if err := h.eventRepository.UpdateEventCapacity(eventID, capacity); err != nil {
+				return err
+			}
+			// A larger or removed capacity frees seats for the waitlist
+			return h.eventRSVPService.PromoteWaitlisted(eventID)
+		}
+	default:
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An internal error occurred (unknown edit type). Please start over with /%s",
+			constants.EventEditCommand,
+		), nil)
+		return handlers.EndConversation()
+	}
+
+	if inputErr != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(inputErr, prompt), nil)
+		return nil // Stay in the same state
+	}
+
+	if err := update(); err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("An error occurred while updating the event %s.", editType), nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Event %s with ID %d successfully updated.", editType, eventID), nil)
+		log.Printf("%s: Error during event retrieval: %v", utils.GetCurrentTypeName(), err)
+		h.userStore.Clear(ctx.EffectiveUser.Id)
+		return handlers.EndConversation()
+	}
+
+	// Confirmation message
+	h.messageSenderService.ReplyHtml(msg, fmt.Sprintf(
+		"Event %s successfully updated.\n\n%s\n\nTo continue editing the event, use the /%s command.\nTo view all commands, use /%s",
+		editType,
+		formatters.FormatHtmlEventDetailsForAdmin(*event, h.config.EventsTimezone),
+		constants.EventEditCommand,
+		constants.HelpCommand,
+	), nil)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// 4.5. handleSelectMaterialKind processes the kind of the material and asks for the file or the link
+func (h *eventEditHandler) handleSelectMaterialKind(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	selection, err := strconv.Atoi(strings.TrimSpace(strings.Replace(msg.Text, "/", "", 1)))
+	if err != nil || selection < 1 || selection > len(constants.AllEventMaterialKinds) {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"Invalid selection. Please enter a number from 1 to %d, or use the cancel button",
+			len(constants.AllEventMaterialKinds),
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	kind := constants.AllEventMaterialKinds[selection-1]
+	h.userStore.Set(ctx.EffectiveUser.Id, eventEditCtxDataKeyMaterialKind, kind)
+
+	prompt := eventMaterialPromptFor(kind)
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		fmt.Sprintf("%s\n\n%s", formatters.GetEventMaterialKindLabel(kind), prompt),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(eventEditCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(eventEditStateEnterMaterial)
+}
+
+// 4.6. handleEnterMaterial posts the material to the Content topic and attaches it to the event
+func (h *eventEditHandler) handleEnterMaterial(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	kindVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventEditCtxDataKeyMaterialKind)
+	kind, isKind := kindVal.(constants.EventMaterialKind)
+	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventEditCtxDataKeySelectedEventID)
+	eventID, isInt := eventIDVal.(int)
+	if !ok || !isInt || !isKind {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An error occurred while retrieving the selected event. Please start over with /%s",
+			constants.EventEditCommand,
+		), nil)
+		return handlers.EndConversation()
+	}
+
+	description, url, file, err := parseEventMaterialInput(msg, kind)
+	if err != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventMaterialPromptFor(kind)), nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Error retrieving event with ID %d", eventID), nil)
+		log.Printf("%s: Error during event retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventMaterialService.Publish(event, kind, description, url, file, b.Id); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while posting the material to the Content topic.", nil)
+		log.Printf("%s: Error publishing material of event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyHtml(msg, fmt.Sprintf(
+		"%s of <b>%s</b> posted to the Content topic, members can find it with /%s and in the archive of /%s.\n\n"+
+			"To add more materials, use the /%s command.",
+		formatters.GetEventMaterialKindLabel(kind),
+		html.EscapeString(event.Name),
+		constants.ContentCommand,
+		constants.EventsCommand,
+		constants.EventEditCommand,
+	), nil)
+
+	return handlers.EndConversation()
+}
+
 // handleCallbackCancel processes the cancel button click
 func (h *eventEditHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 	// Answer the callback query to remove the loading state on the button
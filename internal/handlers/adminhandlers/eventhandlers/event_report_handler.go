@@ -0,0 +1,160 @@
+package eventhandlers
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+)
+
+// eventReportHandler shows the feedback on finished events:
+// "/eventReport" lists the last finished events, "/eventReport <ID>" shows the report of an event
+// followed by the trend of its type, and "/eventReport <type>" shows the trend of the type only.
+type eventReportHandler struct {
+	config                  *config.Config
+	eventRepository         *repositories.EventRepository
+	eventTypeRepository     *repositories.EventTypeRepository
+	eventRSVPRepository     *repositories.EventRSVPRepository
+	eventFeedbackRepository *repositories.EventFeedbackRepository
+	topicRepository         *repositories.TopicRepository
+	messageSenderService    *services.MessageSenderService
+	permissionsService      *services.PermissionsService
+}
+
+func NewEventReportHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	eventTypeRepository *repositories.EventTypeRepository,
+	eventRSVPRepository *repositories.EventRSVPRepository,
+	eventFeedbackRepository *repositories.EventFeedbackRepository,
+	topicRepository *repositories.TopicRepository,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &eventReportHandler{
+		config:                  config,
+		eventRepository:         eventRepository,
+		eventTypeRepository:     eventTypeRepository,
+		eventRSVPRepository:     eventRSVPRepository,
+		eventFeedbackRepository: eventFeedbackRepository,
+		topicRepository:         topicRepository,
+		messageSenderService:    messageSenderService,
+		permissionsService:      permissionsService,
+	}
+
+	return handlers.NewCommand(constants.EventReportCommand, h.handleCommand)
+}
+
+func (h *eventReportHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.EventReportCommand) {
+		return nil
+	}
+
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	args := strings.Fields(msg.Text)
+	if len(args) < 2 {
+		h.sendSummaries(msg, "", fmt.Sprintf(
+			"\U0001f4ca <b>Last finished events</b>\n<i>Use /%s ID for the report of an event, or /%s type for the trend of a type (%s)</i>",
+			constants.EventReportCommand, constants.EventReportCommand, formatEventTypeSlugs(eventTypes)))
+		return nil
+	}
+
+	slug := constants.EventType(strings.ToLower(args[1]))
+	if i := slices.IndexFunc(eventTypes, func(t repositories.EventType) bool { return t.Slug == slug }); i >= 0 {
+		h.sendTrend(msg, eventTypes[i])
+		return nil
+	}
+
+	eventID, err := strconv.Atoi(args[1])
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Send an event ID or one of the types: %s.", formatEventTypeSlugs(eventTypes)), nil)
+		return nil
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Event with ID %d not found.", eventID), nil)
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		return nil
+	}
+
+	report, err := h.eventFeedbackRepository.GetReport(event.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the event feedback.", nil)
+		log.Printf("%s: Error during feedback retrieval for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return nil
+	}
+
+	counts, err := h.eventRSVPRepository.GetCounts(event.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the event attendance.", nil)
+		log.Printf("%s: Error during responses retrieval for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return nil
+	}
+
+	// Approved topics make the agenda of the event, so they are the ones discussed
+	topics, err := h.topicRepository.GetRankedTopicsByEventID(event.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the event topics.", nil)
+		log.Printf("%s: Error during topics retrieval for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return nil
+	}
+
+	h.messageSenderService.ReplyHtml(msg,
+		formatters.FormatHtmlEventReport(*event, *report, counts, len(topics), h.config.EventsTimezone), nil)
+
+	h.sendTrend(msg, event.TypeInfo)
+	return nil
+}
+
+// sendTrend sends the summaries of the last finished events of the type
+func (h *eventReportHandler) sendTrend(msg *gotgbot.Message, eventType repositories.EventType) {
+	h.sendSummaries(msg, eventType.Slug, fmt.Sprintf("\U0001f4c8 <b>Trend: %s</b>\n<i>Last %d finished events of this type, newest first</i>",
+		formatters.GetTypeName(eventType), constants.EventReportTrendLimit))
+}
+
+func (h *eventReportHandler) sendSummaries(msg *gotgbot.Message, eventType constants.EventType, title string) {
+	limit := constants.EventReportListLimit
+	if eventType != "" {
+		limit = constants.EventReportTrendLimit
+	}
+
+	summaries, err := h.eventFeedbackRepository.GetSummaries(eventType, limit)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the finished events.", nil)
+		log.Printf("%s: Error during event summaries retrieval: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatHtmlEventFeedbackSummaries(title, summaries, h.config.EventsTimezone), nil)
+}
+
+// formatEventTypeSlugs lists the slugs of the types, as accepted by the command
+func formatEventTypeSlugs(eventTypes []repositories.EventType) string {
+	slugs := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		slugs = append(slugs, string(eventType.Slug))
+	}
+	return strings.Join(slugs, ", ")
+}
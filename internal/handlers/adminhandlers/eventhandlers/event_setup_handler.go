@@ -11,6 +11,7 @@ import (
 	"evo-bot-go/internal/config"
 	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
 	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
 
@@ -26,10 +27,17 @@ const (
 	eventSetupStateAskEventName      = "event_setup_state_ask_event_name"
 	eventSetupStateAskEventType      = "event_setup_state_ask_event_type"
 	eventSetupStateAskEventStartedAt = "event_setup_state_ask_event_started_at"
+	eventSetupStateAskRecurrence     = "event_setup_state_ask_recurrence"
+	eventSetupStateAskEventDuration  = "event_setup_state_ask_event_duration"
+	eventSetupStateAskDescription    = "event_setup_state_ask_description"
+	eventSetupStateAskPlace          = "event_setup_state_ask_place"
+	eventSetupStateAskHost           = "event_setup_state_ask_host"
+	eventSetupStateAskCapacity       = "event_setup_state_ask_capacity"
 
 	// Context data keys
 	eventSetupCtxDataKeyEventName         = "event_setup_ctx_data_event_name"
 	eventSetupCtxDataKeyEventID           = "event_setup_ctx_data_event_id"
+	eventSetupCtxDataKeyRecurrenceRule    = "event_setup_ctx_data_recurrence_rule"
 	eventSetupCtxDataKeyPreviousMessageID = "event_setup_ctx_data_previous_message_id"
 	eventSetupCtxDataKeyPreviousChatID    = "event_setup_ctx_data_previous_chat_id"
 
@@ -40,6 +48,10 @@ const (
 type eventSetupHandler struct {
 	config               *config.Config
 	eventRepository      *repositories.EventRepository
+	eventTypeRepository  *repositories.EventTypeRepository
+	userRepository       *repositories.UserRepository
+	eventSeriesService   *services.EventSeriesService
+	eventTopicService    *services.EventTopicService
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -48,12 +60,20 @@ type eventSetupHandler struct {
 func NewEventSetupHandler(
 	config *config.Config,
 	eventRepository *repositories.EventRepository,
+	eventTypeRepository *repositories.EventTypeRepository,
+	userRepository *repositories.UserRepository,
+	eventSeriesService *services.EventSeriesService,
+	eventTopicService *services.EventTopicService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
 	h := &eventSetupHandler{
 		config:               config,
 		eventRepository:      eventRepository,
+		eventTypeRepository:  eventTypeRepository,
+		userRepository:       userRepository,
+		eventSeriesService:   eventSeriesService,
+		eventTopicService:    eventTopicService,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -76,6 +96,30 @@ func NewEventSetupHandler(
 				handlers.NewMessage(message.Text, h.handleEventStartedAt),
 				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
 			},
+			eventSetupStateAskRecurrence: {
+				handlers.NewMessage(message.Text, h.handleEventRecurrence),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventSetupStateAskEventDuration: {
+				handlers.NewMessage(message.Text, h.handleEventDuration),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventSetupStateAskDescription: {
+				handlers.NewMessage(message.Text, h.handleEventDescription),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventSetupStateAskPlace: {
+				handlers.NewMessage(message.Text, h.handleEventPlace),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventSetupStateAskHost: {
+				handlers.NewMessage(message.Text, h.handleEventHost),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventSetupStateAskCapacity: {
+				handlers.NewMessage(message.Text, h.handleEventCapacity),
+				handlers.NewCallback(callbackquery.Equal(eventSetupCallbackConfirmCancel), h.handleCallbackCancel),
+			},
 		},
 		&handlers.ConversationOpts{
 			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
@@ -124,6 +168,13 @@ func (h *eventSetupHandler) handleEventName(b *gotgbot.Bot, ctx *ext.Context) er
 		return nil // Stay in the same state
 	}
 
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
 	// Store the event name
@@ -131,8 +182,8 @@ func (h *eventSetupHandler) handleEventName(b *gotgbot.Bot, ctx *ext.Context) er
 
 	// Ask for event type
 	eventTypeOptions := []string{}
-	for i, eventType := range constants.AllEventTypes {
-		eventTypeOptions = append(eventTypeOptions, fmt.Sprintf("/%d. %s", i+1, eventType))
+	for i, eventType := range eventTypes {
+		eventTypeOptions = append(eventTypeOptions, fmt.Sprintf("/%d. %s %s", i+1, formatters.GetTypeEmoji(eventType), eventType.Slug))
 	}
 	typeOptions := fmt.Sprintf("Select event type (enter a number):\n%s",
 		strings.Join(eventTypeOptions, "\n"),
@@ -157,13 +208,20 @@ func (h *eventSetupHandler) handleEventType(b *gotgbot.Bot, ctx *ext.Context) er
 
 	var eventType constants.EventType
 
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
 	// Convert typeSelection to integer
 	index, err := strconv.Atoi(typeSelection)
-	if err != nil || index < 1 || index > len(constants.AllEventTypes) {
+	if err != nil || index < 1 || index > len(eventTypes) {
 		h.messageSenderService.Reply(
 			msg,
 			fmt.Sprintf("Invalid selection. Please enter a number from 1 to %d, or use the cancel button.",
-				len(constants.AllEventTypes),
+				len(eventTypes),
 			),
 			nil,
 		)
@@ -173,7 +231,7 @@ func (h *eventSetupHandler) handleEventType(b *gotgbot.Bot, ctx *ext.Context) er
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
 	// Arrays are 0-indexed but our options start from 1
-	eventType = constants.AllEventTypes[index-1]
+	eventType = eventTypes[index-1].Slug
 
 	// Get the event name from user data store
 	eventNameVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventSetupCtxDataKeyEventName)
@@ -214,7 +272,7 @@ func (h *eventSetupHandler) handleEventType(b *gotgbot.Bot, ctx *ext.Context) er
 	// Ask for start date
 	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
 		msg,
-		"When does the event start? Enter date and time in DD.MM.YYYY HH:MM format:",
+		"When does the event start? "+eventStartedAtPrompt(h.config.EventsTimezone),
 		&gotgbot.SendMessageOpts{
 			ReplyMarkup: buttons.CancelButton(eventSetupCallbackConfirmCancel),
 		},
@@ -227,78 +285,213 @@ func (h *eventSetupHandler) handleEventType(b *gotgbot.Bot, ctx *ext.Context) er
 // 4. handleEventStartedAt processes the start date input and updates the event
 func (h *eventSetupHandler) handleEventStartedAt(b *gotgbot.Bot, ctx *ext.Context) error {
 	msg := ctx.EffectiveMessage
-	dateTimeStr := strings.TrimSpace(msg.Text)
 
-	// Parse the start date
-	startedAt, err := time.Parse("02.01.2006 15:04", dateTimeStr)
+	// Parse the start date in the events timezone
+	startedAt, err := parseEventStartedAtInput(msg.Text, h.config.EventsTimezone)
 	if err != nil {
-		h.messageSenderService.Reply(
-			msg,
-			"Invalid date format. Please enter date and time in DD.MM.YYYY HH:MM format or use the cancel button.",
-			nil,
-		)
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventStartedAtPrompt(h.config.EventsTimezone))+" Or use the cancel button.", nil)
 		return nil // Stay in the same state
 	}
 
-	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	eventID, ok := h.getEventID(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
 
-	// Get event ID from user data store
-	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventSetupCtxDataKeyEventID)
+	// Update the started_at field
+	err = h.eventRepository.UpdateEventStartedAt(eventID, startedAt)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event start date.", nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	return h.askNext(b, ctx, eventRecurrencePrompt, eventSetupStateAskRecurrence)
+}
+
+// 5. handleEventRecurrence processes the recurrence rule input. The series is created once the setup
+// is complete, so its occurrences get all the details of the event.
+func (h *eventSetupHandler) handleEventRecurrence(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	rule, err := parseEventRecurrenceInput(msg.Text)
+	if err != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventRecurrencePrompt), nil)
+		return nil // Stay in the same state
+	}
+
+	if rule != nil {
+		h.userStore.Set(ctx.EffectiveUser.Id, eventSetupCtxDataKeyRecurrenceRule, rule)
+	}
+
+	return h.askNext(b, ctx, eventDurationPrompt, eventSetupStateAskEventDuration)
+}
+
+// 6. handleEventDuration processes the duration input
+func (h *eventSetupHandler) handleEventDuration(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	durationMinutes, err := parseEventDurationInput(msg.Text)
+	if err != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventDurationPrompt), nil)
+		return nil // Stay in the same state
+	}
+
+	eventID, ok := h.getEventID(ctx)
 	if !ok {
-		h.messageSenderService.Reply(
-			msg,
-			fmt.Sprintf("An internal error occurred. Could not find the event ID. Try starting over with /%s.",
-				constants.EventSetupCommand,
-			),
-			nil,
-		)
 		return handlers.EndConversation()
 	}
 
-	eventID, ok := eventIDVal.(int)
+	if err := h.eventRepository.UpdateEventDuration(eventID, durationMinutes); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event duration.", nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	return h.askNext(b, ctx, eventDescriptionPrompt, eventSetupStateAskDescription)
+}
+
+// 7. handleEventDescription processes the description input
+func (h *eventSetupHandler) handleEventDescription(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	eventID, ok := h.getEventID(ctx)
 	if !ok {
-		h.messageSenderService.Reply(
-			msg,
-			fmt.Sprintf("An internal error occurred (invalid ID type). Try starting over with /%s.",
-				constants.EventSetupCommand,
-			),
-			nil,
-		)
 		return handlers.EndConversation()
 	}
 
-	// Update the started_at field
-	err = h.eventRepository.UpdateEventStartedAt(eventID, startedAt)
+	if err := h.eventRepository.UpdateEventDescription(eventID, parseEventDescriptionInput(msg.Text)); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event description.", nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	return h.askNext(b, ctx, eventPlacePrompt, eventSetupStateAskPlace)
+}
+
+// 8. handleEventPlace processes the location and link input
+func (h *eventSetupHandler) handleEventPlace(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	location, link, err := parseEventPlaceInput(msg.Text)
 	if err != nil {
-		h.messageSenderService.Reply(msg, "An error occurred while updating the event start date.", nil)
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventPlacePrompt), nil)
+		return nil // Stay in the same state
+	}
+
+	eventID, ok := h.getEventID(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventRepository.UpdateEventPlace(eventID, location, link); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event location.", nil)
 		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
 		return handlers.EndConversation()
 	}
 
-	// Get event name for the success message
-	eventNameVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventSetupCtxDataKeyEventName)
+	return h.askNext(b, ctx, eventHostPrompt, eventSetupStateAskHost)
+}
+
+// 9. handleEventHost processes the host input
+func (h *eventSetupHandler) handleEventHost(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	host, err := resolveEventHostInput(h.userRepository, msg.Text)
+	if err != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventHostPrompt), nil)
+		return nil // Stay in the same state
+	}
+
+	eventID, ok := h.getEventID(ctx)
 	if !ok {
-		h.messageSenderService.Reply(msg, "Event successfully created with start date.", nil)
-		h.userStore.Clear(ctx.EffectiveUser.Id)
 		return handlers.EndConversation()
 	}
 
-	eventName, ok := eventNameVal.(string)
+	var hostUserID *int
+	if host != nil {
+		hostUserID = &host.ID
+	}
+	if err := h.eventRepository.UpdateEventHost(eventID, hostUserID); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event host.", nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	return h.askNext(b, ctx, eventCapacityPrompt, eventSetupStateAskCapacity)
+}
+
+// 10. handleEventCapacity processes the capacity input and completes the setup
+func (h *eventSetupHandler) handleEventCapacity(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	capacity, err := parseEventCapacityInput(msg.Text)
+	if err != nil {
+		h.messageSenderService.Reply(msg, eventInputErrorMessage(err, eventCapacityPrompt), nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	eventID, ok := h.getEventID(ctx)
 	if !ok {
-		h.messageSenderService.Reply(msg, "Event successfully created with start date.", nil)
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventRepository.UpdateEventCapacity(eventID, capacity); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event capacity.", nil)
+		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	var seriesNote string
+	if ruleVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventSetupCtxDataKeyRecurrenceRule); ok {
+		rule, _ := ruleVal.(*utils.RecurrenceRule)
+		if err := h.eventSeriesService.CreateSeries(eventID, rule, time.Now()); err != nil {
+			log.Printf("%s: Error during event series creation: %v", utils.GetCurrentTypeName(), err)
+			seriesNote = "\n\n\u26a0\ufe0f The event was created, but its repetition couldn't be set up. " +
+				"It's a one-time event for now."
+		} else {
+			seriesNote = fmt.Sprintf("\n\nUpcoming occurrences are created %d days ahead. "+
+				"Each of them can be edited with /%s or deleted with /%s on its own.",
+				h.config.EventSeriesHorizonDays, constants.EventEditCommand, constants.EventDeleteCommand)
+		}
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Event successfully created.", nil)
+		log.Printf("%s: Error during event retrieval: %v", utils.GetCurrentTypeName(), err)
 		h.userStore.Clear(ctx.EffectiveUser.Id)
 		return handlers.EndConversation()
 	}
 
+	var topicNote string
+	if h.eventTopicService.IsEnabled() {
+		groupTopic, err := h.eventTopicService.HandleEventCreated(event.ID)
+		if err != nil {
+			log.Printf("%s: Error during event topic creation: %v", utils.GetCurrentTypeName(), err)
+			topicNote = "\n\n\u26a0\ufe0f The discussion topic of the event couldn't be created. " +
+				"Please check that the bot is allowed to manage topics in the group."
+		} else {
+			topicNote = fmt.Sprintf("\n\n\U0001f4ac The event card is pinned in its <a href=\"https://t.me/c/%d/%d\">discussion topic</a>, "+
+				"which is closed automatically after the event.",
+				h.config.SuperGroupChatID, groupTopic.TopicID)
+		}
+	}
+
 	// Success message
-	h.messageSenderService.Reply(
+	h.messageSenderService.ReplyHtml(
 		msg,
-		fmt.Sprintf(
-			"Event record '*%s*' successfully created with ID: %d and start date: *%s*\n\nTo edit the event, use the /%s command.\nTo view all commands, use /%s",
-			eventName, eventID, startedAt.Format("02.01.2006 15:04"), constants.EventEditCommand, constants.HelpCommand,
-		),
+		"\u2705 Event successfully created!\n\n"+
+			formatters.FormatHtmlEventDetailsForAdmin(*event, h.config.EventsTimezone)+
+			seriesNote+
+			topicNote+
+			fmt.Sprintf("\n\nTo edit the event, use the /%s command.\nTo view all commands, use /%s",
+				constants.EventEditCommand, constants.HelpCommand)+
+			"\n\nWhen the details are final, announce the event so members can sign up for it.",
 		&gotgbot.SendMessageOpts{
-			ParseMode: "Markdown",
+			ReplyMarkup: buttons.EventAnnounceButton(event.ID),
 		},
 	)
 
@@ -308,6 +501,51 @@ func (h *eventSetupHandler) handleEventStartedAt(b *gotgbot.Bot, ctx *ext.Contex
 	return handlers.EndConversation()
 }
 
+// askNext removes the keyboard from the previous prompt and asks for the next event field
+func (h *eventSetupHandler) askNext(b *gotgbot.Bot, ctx *ext.Context, prompt string, nextState string) error {
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		ctx.EffectiveMessage,
+		prompt,
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(eventSetupCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(nextState)
+}
+
+// getEventID returns the ID of the event being set up, replying with an error if it's missing
+func (h *eventSetupHandler) getEventID(ctx *ext.Context) (int, bool) {
+	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventSetupCtxDataKeyEventID)
+	if !ok {
+		h.messageSenderService.Reply(
+			ctx.EffectiveMessage,
+			fmt.Sprintf("An internal error occurred. Could not find the event ID. Try starting over with /%s.",
+				constants.EventSetupCommand,
+			),
+			nil,
+		)
+		return 0, false
+	}
+
+	eventID, ok := eventIDVal.(int)
+	if !ok {
+		h.messageSenderService.Reply(
+			ctx.EffectiveMessage,
+			fmt.Sprintf("An internal error occurred (invalid ID type). Try starting over with /%s.",
+				constants.EventSetupCommand,
+			),
+			nil,
+		)
+		return 0, false
+	}
+
+	return eventID, true
+}
+
 // handleCallbackCancel processes the cancel button click
 func (h *eventSetupHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 	// Answer the callback query to remove the loading state on the button
@@ -317,7 +555,7 @@ func (h *eventSetupHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Contex
 	return h.handleCancel(b, ctx)
 }
 
-// 5. handleCancel handles the /cancel command
+// 11. handleCancel handles the /cancel command
 func (h *eventSetupHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 	msg := ctx.EffectiveMessage
 
@@ -47,6 +47,7 @@ const (
 type eventStartHandler struct {
 	config               *config.Config
 	eventRepository      *repositories.EventRepository
+	eventReminderService *services.EventReminderService
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -55,12 +56,14 @@ type eventStartHandler struct {
 func NewEventStartHandler(
 	config *config.Config,
 	eventRepository *repositories.EventRepository,
+	eventReminderService *services.EventReminderService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
 	h := &eventStartHandler{
 		config:               config,
 		eventRepository:      eventRepository,
+		eventReminderService: eventReminderService,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -121,7 +124,7 @@ func (h *eventStartHandler) startEvent(b *gotgbot.Bot, ctx *ext.Context) error {
 
 	title := fmt.Sprintf("Last %d events:", len(events))
 	actionDescription := "that you want to start"
-	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription)
+	formattedResponse := formatters.FormatEventListForAdmin(events, title, constants.CancelCommand, actionDescription, h.config.EventsTimezone)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(msg, formattedResponse, &gotgbot.SendMessageOpts{
 		ReplyMarkup: buttons.CancelButton(eventStartCallbackConfirmCancel),
@@ -153,13 +156,18 @@ func (h *eventStartHandler) handleSelectEvent(b *gotgbot.Bot, ctx *ext.Context)
 		return handlers.EndConversation()
 	}
 
+	prompt := fmt.Sprintf(
+		"🔗 Send me the link to the event '%s' (ID: %d)\nThis link will be sent to the announcements chat.",
+		event.Name, event.ID,
+	)
+	if event.Link != "" {
+		prompt += fmt.Sprintf("\n\nSaved link: `%s`\nSend `%s` to use it.", event.Link, eventSkipInput)
+	}
+
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
 		msg,
-		fmt.Sprintf(
-			"🔗 Send me the link to the event '%s' (ID: %d)\nThis link will be sent to the announcements chat.",
-			event.Name, event.ID,
-		),
+		prompt,
 		&gotgbot.SendMessageOpts{
 			ReplyMarkup: buttons.CancelButton(eventStartCallbackConfirmCancel),
 		},
@@ -174,18 +182,6 @@ func (h *eventStartHandler) handleEnterLink(b *gotgbot.Bot, ctx *ext.Context) er
 	msg := ctx.EffectiveMessage
 	eventLink := strings.TrimSpace(msg.Text)
 
-	// Simple validation for the link
-	if !strings.HasPrefix(eventLink, "http://") && !strings.HasPrefix(eventLink, "https://") {
-		h.messageSenderService.Reply(msg, fmt.Sprintf(
-			"Please enter a valid link starting with http:// or https:// (or use /%s to cancel):",
-			constants.CancelCommand,
-		), nil)
-		return nil // Stay in the same state
-	}
-
-	// Store the event link
-	h.userStore.Set(ctx.EffectiveUser.Id, eventStartCtxDataKeyEventLink, eventLink)
-
 	// Get the event ID
 	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventStartCtxDataKeySelectedEventID)
 	if !ok {
@@ -216,6 +212,23 @@ func (h *eventStartHandler) handleEnterLink(b *gotgbot.Bot, ctx *ext.Context) er
 		return handlers.EndConversation()
 	}
 
+	// Use the link saved in the event details if asked to
+	if eventLink == eventSkipInput && event.Link != "" {
+		eventLink = event.Link
+	}
+
+	// Simple validation for the link
+	if !strings.HasPrefix(eventLink, "http://") && !strings.HasPrefix(eventLink, "https://") {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"Please enter a valid link starting with http:// or https:// (or use /%s to cancel):",
+			constants.CancelCommand,
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	// Store the event link
+	h.userStore.Set(ctx.EffectiveUser.Id, eventStartCtxDataKeyEventLink, eventLink)
+
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
 	sentMsg, err := h.messageSenderService.ReplyMarkdownWithReturnMessage(msg, fmt.Sprintf(
@@ -287,59 +300,13 @@ func (h *eventStartHandler) handleCallbackYes(b *gotgbot.Bot, ctx *ext.Context)
 		return handlers.EndConversation()
 	}
 
-	// Update the event status to active (or use the appropriate constant)
-	err = h.eventRepository.UpdateEventStatus(eventID, constants.EventStatusFinished) // When ivent already started in DB we need to set status to finished
-	if err != nil {
+	// Mark the event as started and send the announcement with the link to the announcement topic
+	if err := h.eventReminderService.AnnounceStart(event, eventLink); err != nil {
 		h.messageSenderService.Reply(ctx.EffectiveMessage, "An error occurred while updating the event status.", nil)
 		log.Printf("%s: Error during event update: %v", utils.GetCurrentTypeName(), err)
 		return handlers.EndConversation()
 	}
 
-	buttonWithLink := gotgbot.InlineKeyboardMarkup{
-		InlineKeyboard: [][]gotgbot.InlineKeyboardButton{
-			{
-				{
-					Text: "🔗 Zoom Link",
-					Url:  eventLink,
-				},
-			},
-		},
-	}
-
-	// Send announcement message with the event link to the announcement topic if configured
-	announcementMsg := fmt.Sprintf(
-		"🔴 *EVENT STARTING!* 🔴\n\n%s *%s*\n",
-		formatters.GetTypeEmoji(constants.EventType(event.Type)),
-		event.Name,
-	)
-
-	if event.Type == string(constants.EventTypeClubCall) {
-		announcementMsg += fmt.Sprintf("💡 [About the format and rules of community calls](https://t.me/c/2069889012/127/33823)\n")
-	}
-
-	announcementMsg += fmt.Sprintf("\nUse the button below to join ⬇️")
-
-	sentAnnouncementMsg, err := h.messageSenderService.SendMarkdownWithReturnMessage(
-		utils.ChatIdToFullChatId(h.config.SuperGroupChatID),
-		announcementMsg,
-		&gotgbot.SendMessageOpts{
-			MessageThreadId: int64(h.config.AnnouncementTopicID),
-			ReplyMarkup:     buttonWithLink,
-		},
-	)
-
-	// Pin the announcement message with notification for all users
-	if err == nil && sentAnnouncementMsg != nil {
-		err = h.messageSenderService.PinMessage(
-			sentAnnouncementMsg.Chat.Id,
-			sentAnnouncementMsg.MessageId,
-			true,
-		)
-		if err != nil {
-			log.Printf("%s: Error pinning announcement message: %v", utils.GetCurrentTypeName(), err)
-		}
-	}
-
 	// Confirmation message
 	h.messageSenderService.ReplyMarkdown(
 		ctx.EffectiveMessage,
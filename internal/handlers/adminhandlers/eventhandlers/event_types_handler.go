@@ -0,0 +1,599 @@
+package eventhandlers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	eventTypesStateSelectType    = "event_types_state_select_type"
+	eventTypesStateEnterSlug     = "event_types_state_enter_slug"
+	eventTypesStateEnterName     = "event_types_state_enter_name"
+	eventTypesStateSelectField   = "event_types_state_select_field"
+	eventTypesStateEnterValue    = "event_types_state_enter_value"
+	eventTypesStateConfirmDelete = "event_types_state_confirm_delete"
+
+	// Context data keys
+	eventTypesCtxDataKeySelectedType      = "event_types_ctx_data_selected_type"
+	eventTypesCtxDataKeyNewSlug           = "event_types_ctx_data_new_slug"
+	eventTypesCtxDataKeyField             = "event_types_ctx_data_field"
+	eventTypesCtxDataKeyPreviousMessageID = "event_types_ctx_data_previous_message_id"
+	eventTypesCtxDataKeyPreviousChatID    = "event_types_ctx_data_previous_chat_id"
+
+	// Callback data
+	eventTypesCallbackConfirmCancel = "event_types_callback_confirm_cancel"
+	eventTypesCallbackConfirmDelete = "event_types_callback_confirm_delete"
+
+	// eventTypesNewInput is sent instead of a number to add a new type
+	eventTypesNewInput = "new"
+
+	// Fields of a type
+	eventTypesFieldName         = "name"
+	eventTypesFieldEmoji        = "emoji"
+	eventTypesFieldAnnouncement = "announcement"
+	eventTypesFieldRulesLink    = "rulesLink"
+	eventTypesFieldDelete       = "delete"
+
+	eventTypesSlugMaxLength  = 32
+	eventTypesNameMaxLength  = 50
+	eventTypesEmojiMaxLength = 10
+)
+
+// eventTypesSlugPattern allows lowercase words separated by dashes, e.g. "reading-club"
+var eventTypesSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// eventTypesFieldOptions lists what can be changed, in the order of the menu numbers
+var eventTypesFieldOptions = []struct {
+	field string
+	title string
+}{
+	{eventTypesFieldName, "Name"},
+	{eventTypesFieldEmoji, "Emoji"},
+	{eventTypesFieldAnnouncement, "Announcement template"},
+	{eventTypesFieldRulesLink, "Rules link"},
+	{eventTypesFieldDelete, "Delete the type"},
+}
+
+// eventTypesHandler lets admins list, add, change and delete event types
+type eventTypesHandler struct {
+	config               *config.Config
+	eventTypeRepository  *repositories.EventTypeRepository
+	messageSenderService *services.MessageSenderService
+	userStore            *utils.UserDataStore
+	permissionsService   *services.PermissionsService
+}
+
+func NewEventTypesHandler(
+	config *config.Config,
+	eventTypeRepository *repositories.EventTypeRepository,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &eventTypesHandler{
+		config:               config,
+		eventTypeRepository:  eventTypeRepository,
+		messageSenderService: messageSenderService,
+		userStore:            utils.NewUserDataStore(),
+		permissionsService:   permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.EventTypesCommand, h.startEventTypes),
+		},
+		map[string][]ext.Handler{
+			eventTypesStateSelectType: {
+				handlers.NewMessage(message.Text, h.handleSelectType),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventTypesStateEnterSlug: {
+				handlers.NewMessage(message.Text, h.handleEnterSlug),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventTypesStateEnterName: {
+				handlers.NewMessage(message.Text, h.handleEnterName),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventTypesStateSelectField: {
+				handlers.NewMessage(message.Text, h.handleSelectField),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventTypesStateEnterValue: {
+				handlers.NewMessage(message.Text, h.handleEnterValue),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventTypesStateConfirmDelete: {
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmDelete), h.handleCallbackConfirmDelete),
+				handlers.NewCallback(callbackquery.Equal(eventTypesCallbackConfirmCancel), h.handleCallbackCancel),
+				handlers.NewMessage(message.Text, h.handleMessageDuringConfirmation),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+		},
+	)
+}
+
+// 1. startEventTypes is the entry point handler, it lists the types
+func (h *eventTypesHandler) startEventTypes(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.EventTypesCommand) {
+		log.Printf("%s: User %d (%s) tried to use /%s without admin permissions.",
+			utils.GetCurrentTypeName(),
+			ctx.EffectiveUser.Id,
+			ctx.EffectiveUser.Username,
+			constants.EventTypesCommand,
+		)
+		return handlers.EndConversation()
+	}
+
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		fmt.Sprintf("%s\nEnter the number of a type to change it, or <b>%s</b> to add a new type:",
+			formatters.FormatHtmlEventTypesForAdmin(eventTypes), eventTypesNewInput),
+		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.CancelButton(eventTypesCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(eventTypesStateSelectType)
+}
+
+// 2. handleSelectType processes the selection of a type, or starts adding a new one
+func (h *eventTypesHandler) handleSelectType(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	selection := strings.TrimSpace(strings.Replace(msg.Text, "/", "", 1))
+
+	if strings.EqualFold(selection, eventTypesNewInput) {
+		h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+		sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+			msg,
+			"Enter the slug of the new type: lowercase latin letters and digits, words separated by dashes "+
+				"(e.g. book-review). The slug is used in hashtags and commands and can't be changed later:",
+			&gotgbot.SendMessageOpts{
+				ReplyMarkup: buttons.CancelButton(eventTypesCallbackConfirmCancel),
+			},
+		)
+
+		h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+		return handlers.NextConversationState(eventTypesStateEnterSlug)
+	}
+
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while retrieving the event types.", nil)
+		log.Printf("%s: Error during event types retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	index, err := strconv.Atoi(selection)
+	if err != nil || index < 1 || index > len(eventTypes) {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"Invalid selection. Please enter a number from 1 to %d, %s, or use the cancel button.",
+			len(eventTypes), eventTypesNewInput,
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	eventType := eventTypes[index-1]
+	h.userStore.Set(ctx.EffectiveUser.Id, eventTypesCtxDataKeySelectedType, eventType.Slug)
+
+	var options strings.Builder
+	for i, option := range eventTypesFieldOptions {
+		options.WriteString(fmt.Sprintf("/%d. %s\n", i+1, option.title))
+	}
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		fmt.Sprintf("%s\nWhat do you want to change?\n%s\nEnter a number:",
+			formatters.FormatHtmlEventTypeDetails(eventType), options.String()),
+		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.CancelButton(eventTypesCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(eventTypesStateSelectField)
+}
+
+// 3.1. handleEnterSlug processes the slug of a new type
+func (h *eventTypesHandler) handleEnterSlug(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	slug := strings.ToLower(strings.TrimSpace(msg.Text))
+
+	if len(slug) > eventTypesSlugMaxLength || !eventTypesSlugPattern.MatchString(slug) {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"Invalid slug. Use up to %d lowercase latin letters and digits, words separated by dashes, or use the cancel button.",
+			eventTypesSlugMaxLength,
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	if _, err := h.eventTypeRepository.GetBySlug(constants.EventType(slug)); err == nil {
+		h.messageSenderService.Reply(msg, "A type with this slug already exists. Please enter another one or use the cancel button.", nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	h.userStore.Set(ctx.EffectiveUser.Id, eventTypesCtxDataKeyNewSlug, slug)
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		"Enter the name shown to members, e.g. book review:",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(eventTypesCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(eventTypesStateEnterName)
+}
+
+// 3.2. handleEnterName processes the name of a new type and creates the type
+func (h *eventTypesHandler) handleEnterName(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	name := strings.TrimSpace(msg.Text)
+
+	if name == "" || len([]rune(name)) > eventTypesNameMaxLength {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"The name must be 1 to %d characters long. Please enter a name or use the cancel button.",
+			eventTypesNameMaxLength,
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	slugVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventTypesCtxDataKeyNewSlug)
+	slug, ok := slugVal.(string)
+	if !ok {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An error occurred while retrieving the new type. Please start over with /%s",
+			constants.EventTypesCommand,
+		), nil)
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventTypeRepository.Create(constants.EventType(slug), name, ""); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while adding the event type.", nil)
+		log.Printf("%s: Error during event type creation: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyHtml(msg, fmt.Sprintf(
+		"Event type <b>%s</b> (<code>%s</code>) added. It can now be chosen in /%s and /%s.\n\n"+
+			"To set its emoji, announcement template or rules link, use the /%s command.",
+		html.EscapeString(name), slug, constants.EventSetupCommand, constants.EventEditCommand, constants.EventTypesCommand,
+	), nil)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// 4. handleSelectField processes the selection of what to change
+func (h *eventTypesHandler) handleSelectField(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	selection, err := strconv.Atoi(strings.TrimSpace(strings.Replace(msg.Text, "/", "", 1)))
+	if err != nil || selection < 1 || selection > len(eventTypesFieldOptions) {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"Invalid selection. Please enter a number from 1 to %d, or use the cancel button",
+			len(eventTypesFieldOptions),
+		), nil)
+		return nil // Stay in the same state
+	}
+
+	eventType, ok := h.getSelectedType(msg, ctx.EffectiveUser.Id)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	field := eventTypesFieldOptions[selection-1].field
+	if field == eventTypesFieldDelete {
+		return h.askDeleteConfirmation(msg, ctx.EffectiveUser.Id, eventType)
+	}
+
+	var prompt string
+	switch field {
+	case eventTypesFieldName:
+		prompt = fmt.Sprintf("Current name: <b>%s</b>\n\nEnter a new name:", html.EscapeString(eventType.Name))
+	case eventTypesFieldEmoji:
+		prompt = fmt.Sprintf("Current emoji: %s\n\nSend a new emoji, or %s for the default one:",
+			formatters.GetTypeEmoji(*eventType), eventSkipInput)
+	case eventTypesFieldAnnouncement:
+		current := "<i>default</i>"
+		if eventType.AnnouncementTemplate != "" {
+			current = fmt.Sprintf("<code>%s</code>", html.EscapeString(eventType.AnnouncementTemplate))
+		}
+		prompt = fmt.Sprintf(
+			"Current announcement template: %s\n\n"+
+				"The template is the header of the message posted when an event of this type starts, in Markdown. "+
+				"{emoji}, {name} and {type} are replaced with the type emoji, the event name and the type name.\n\n"+
+				"Send a new template, or %s for the default one:",
+			current, eventSkipInput,
+		)
+	case eventTypesFieldRulesLink:
+		current := "<i>not set</i>"
+		if eventType.RulesLink != "" {
+			current = html.EscapeString(eventType.RulesLink)
+		}
+		prompt = fmt.Sprintf(
+			"Current rules link: %s\n\nThe link is added to the start announcement of the events of this type.\n\n"+
+				"Send a new link (https://...), or %s to remove it:",
+			current, eventSkipInput,
+		)
+	}
+
+	h.userStore.Set(ctx.EffectiveUser.Id, eventTypesCtxDataKeyField, field)
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		prompt,
+		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.CancelButton(eventTypesCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(eventTypesStateEnterValue)
+}
+
+// 5.1. handleEnterValue processes the new value of the selected field and updates the type
+func (h *eventTypesHandler) handleEnterValue(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	input := strings.TrimSpace(msg.Text)
+
+	eventType, ok := h.getSelectedType(msg, ctx.EffectiveUser.Id)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	fieldVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, eventTypesCtxDataKeyField)
+	field, _ := fieldVal.(string)
+
+	value := input
+	if input == eventSkipInput && field != eventTypesFieldName {
+		value = ""
+	}
+
+	// Validate the input first, so the admin can retry without leaving the current state
+	switch field {
+	case eventTypesFieldName:
+		if value == "" || len([]rune(value)) > eventTypesNameMaxLength {
+			h.messageSenderService.Reply(msg, fmt.Sprintf(
+				"The name must be 1 to %d characters long. Please enter a name or use the cancel button.",
+				eventTypesNameMaxLength,
+			), nil)
+			return nil // Stay in the same state
+		}
+		eventType.Name = value
+	case eventTypesFieldEmoji:
+		if len([]rune(value)) > eventTypesEmojiMaxLength || strings.ContainsAny(value, " \n") {
+			h.messageSenderService.Reply(msg, "Please send a single emoji, or use the cancel button.", nil)
+			return nil // Stay in the same state
+		}
+		eventType.Emoji = value
+	case eventTypesFieldAnnouncement:
+		eventType.AnnouncementTemplate = value
+	case eventTypesFieldRulesLink:
+		if value != "" && !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			h.messageSenderService.Reply(msg, fmt.Sprintf(
+				"Please enter a valid link starting with http:// or https://, %s to remove it, or use the cancel button.",
+				eventSkipInput,
+			), nil)
+			return nil // Stay in the same state
+		}
+		eventType.RulesLink = value
+	default:
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An internal error occurred. Please start over with /%s", constants.EventTypesCommand), nil)
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	if err := h.eventTypeRepository.Update(*eventType); err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while updating the event type.", nil)
+		log.Printf("%s: Error during event type update: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyHtml(msg, fmt.Sprintf(
+		"Event type updated:\n\n%s\nTo continue editing the event types, use the /%s command.\nTo view all commands, use /%s",
+		formatters.FormatHtmlEventTypeDetails(*eventType), constants.EventTypesCommand, constants.HelpCommand,
+	), nil)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// 5.2. askDeleteConfirmation asks to confirm the deletion, types of existing events or proposals can't be deleted
+func (h *eventTypesHandler) askDeleteConfirmation(msg *gotgbot.Message, userID int64, eventType *repositories.EventType) error {
+	used, err := h.eventTypeRepository.IsUsed(eventType.Slug)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "An error occurred while checking the event type.", nil)
+		log.Printf("%s: Error during event type usage check: %v", utils.GetCurrentTypeName(), err)
+		h.userStore.Clear(userID)
+		return handlers.EndConversation()
+	}
+
+	if used {
+		h.messageSenderService.ReplyHtml(msg, fmt.Sprintf(
+			"The type <b>%s</b> can't be deleted, as some events, series or proposals have it. "+
+				"Change the type of the events with /%s and stop the series with /%s first.",
+			html.EscapeString(formatters.GetTypeName(*eventType)), constants.EventEditCommand, constants.EventDeleteCommand,
+		), nil)
+		h.userStore.Clear(userID)
+		return handlers.EndConversation()
+	}
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		fmt.Sprintf("Are you sure you want to delete the type <b>%s</b> (<code>%s</code>)?",
+			html.EscapeString(formatters.GetTypeName(*eventType)), eventType.Slug),
+		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.ConfirmAndCancelButton(eventTypesCallbackConfirmDelete, eventTypesCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(userID, sentMsg)
+	return handlers.NextConversationState(eventTypesStateConfirmDelete)
+}
+
+// handleMessageDuringConfirmation handles text messages during the confirmation state
+func (h *eventTypesHandler) handleMessageDuringConfirmation(b *gotgbot.Bot, ctx *ext.Context) error {
+	h.messageSenderService.Reply(
+		ctx.EffectiveMessage,
+		"Please use the buttons above to confirm or cancel.",
+		nil,
+	)
+	return nil // Stay in the same state
+}
+
+// handleCallbackConfirmDelete deletes the selected type
+func (h *eventTypesHandler) handleCallbackConfirmDelete(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	eventType, ok := h.getSelectedType(ctx.EffectiveMessage, ctx.EffectiveUser.Id)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	// An event may have got the type since the usage check, the reference in events makes the deletion fail then
+	if err := h.eventTypeRepository.Delete(eventType.Slug); err != nil {
+		h.messageSenderService.Reply(ctx.EffectiveMessage, "An error occurred while deleting the event type.", nil)
+		log.Printf("%s: Error during event type deletion: %v", utils.GetCurrentTypeName(), err)
+		h.userStore.Clear(ctx.EffectiveUser.Id)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyHtml(ctx.EffectiveMessage, fmt.Sprintf(
+		"Event type <b>%s</b> deleted.\n\nTo view all commands, use /%s",
+		html.EscapeString(formatters.GetTypeName(*eventType)), constants.HelpCommand,
+	), nil)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// handleCallbackCancel processes the cancel button click
+func (h *eventTypesHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+// 6. handleCancel handles the /cancel command
+func (h *eventTypesHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.messageSenderService.Reply(msg, "Event types editing canceled.", nil)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// getSelectedType loads the type selected in handleSelectType, replying with an error if it's gone
+func (h *eventTypesHandler) getSelectedType(msg *gotgbot.Message, userID int64) (*repositories.EventType, bool) {
+	slugVal, _ := h.userStore.Get(userID, eventTypesCtxDataKeySelectedType)
+	slug, ok := slugVal.(constants.EventType)
+	if !ok {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"An error occurred while retrieving the selected type. Please start over with /%s",
+			constants.EventTypesCommand,
+		), nil)
+		h.userStore.Clear(userID)
+		return nil, false
+	}
+
+	eventType, err := h.eventTypeRepository.GetBySlug(slug)
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"The selected type no longer exists. Please start over with /%s",
+			constants.EventTypesCommand,
+		), nil)
+		log.Printf("%s: Error during event type retrieval: %v", utils.GetCurrentTypeName(), err)
+		h.userStore.Clear(userID)
+		return nil, false
+	}
+
+	return eventType, true
+}
+
+func (h *eventTypesHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			eventTypesCtxDataKeyPreviousMessageID,
+			eventTypesCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *eventTypesHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		eventTypesCtxDataKeyPreviousMessageID, eventTypesCtxDataKeyPreviousChatID)
+}
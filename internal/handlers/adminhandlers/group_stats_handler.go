@@ -0,0 +1,183 @@
+package adminhandlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+const (
+	groupStatsChartWidth  = 1000
+	groupStatsChartHeight = 480
+)
+
+// groupStatsHandler shows the activity in the group from the saved messages:
+// "/stats" shows the last 30 days, "/stats <days>" another range. The buttons switch between
+// the common ranges and send the chart of messages per day.
+type groupStatsHandler struct {
+	config                 *config.Config
+	groupMessageRepository *repositories.GroupMessageRepository
+	messageSenderService   *services.MessageSenderService
+	permissionsService     *services.PermissionsService
+}
+
+func NewGroupStatsHandler(
+	config *config.Config,
+	groupMessageRepository *repositories.GroupMessageRepository,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &groupStatsHandler{
+		config:                 config,
+		groupMessageRepository: groupMessageRepository,
+		messageSenderService:   messageSenderService,
+		permissionsService:     permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.GroupStatsCommand, h.handleCommand),
+			handlers.NewCallback(callbackquery.Prefix(constants.GroupStatsRangePrefix), h.handleCallbackRange),
+			handlers.NewCallback(callbackquery.Prefix(constants.GroupStatsChartPrefix), h.handleCallbackChart),
+		},
+		map[string][]ext.Handler{},
+		&handlers.ConversationOpts{
+			AllowReEntry: true,
+		},
+	)
+}
+
+func (h *groupStatsHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.GroupStatsCommand) {
+		return handlers.EndConversation()
+	}
+
+	// Optional argument: number of days to show, e.g. "/stats 14"
+	days := constants.GroupStatsDefaultDays
+	if args := strings.Fields(msg.Text); len(args) > 1 {
+		if n, err := strconv.Atoi(args[1]); err == nil && n > 0 {
+			days = min(n, constants.GroupStatsMaxDays)
+		}
+	}
+
+	stats, err := h.getStats(days)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the group statistics.", nil)
+		log.Printf("%s: Error during group stats retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatHtmlGroupStats(stats, days, h.config.EventsTimezone),
+		&gotgbot.SendMessageOpts{ReplyMarkup: buttons.GroupStatsButtons(days)})
+	return handlers.EndConversation()
+}
+
+// handleCallbackRange shows the statistics of another range in the same message
+func (h *groupStatsHandler) handleCallbackRange(b *gotgbot.Bot, ctx *ext.Context) error {
+	days, ok := h.parseCallback(b, ctx, constants.GroupStatsRangePrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	stats, err := h.getStats(days)
+	if err != nil {
+		log.Printf("%s: Error during group stats retrieval: %v", utils.GetCurrentTypeName(), err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "Error retrieving the group statistics.")
+		return handlers.EndConversation()
+	}
+
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+	msg := ctx.EffectiveMessage
+	_, _, err = b.EditMessageText(
+		formatters.FormatHtmlGroupStats(stats, days, h.config.EventsTimezone),
+		&gotgbot.EditMessageTextOpts{
+			ChatId:      msg.Chat.Id,
+			MessageId:   msg.MessageId,
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.GroupStatsButtons(days),
+		})
+	if err != nil && !strings.Contains(err.Error(), "are exactly the same") {
+		log.Printf("%s: Error updating group stats message: %v", utils.GetCurrentTypeName(), err)
+	}
+	return handlers.EndConversation()
+}
+
+// handleCallbackChart sends the chart of messages per day, stacked by topic, as a PNG image
+func (h *groupStatsHandler) handleCallbackChart(b *gotgbot.Bot, ctx *ext.Context) error {
+	days, ok := h.parseCallback(b, ctx, constants.GroupStatsChartPrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	stats, err := h.getStats(days)
+	if err != nil {
+		log.Printf("%s: Error during group stats retrieval: %v", utils.GetCurrentTypeName(), err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "Error retrieving the group statistics.")
+		return handlers.EndConversation()
+	}
+
+	series, caption := formatters.FormatGroupStatsChart(stats, days)
+	chart, err := utils.RenderStackedBarChart(series, groupStatsChartWidth, groupStatsChartHeight)
+	if err != nil {
+		log.Printf("%s: Error rendering group stats chart: %v", utils.GetCurrentTypeName(), err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "There is nothing to draw for this period.")
+		return handlers.EndConversation()
+	}
+
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+	h.messageSenderService.SendPhoto(ctx.EffectiveChat.Id, fmt.Sprintf("stats_%dd.png", days), chart,
+		&gotgbot.SendPhotoOpts{Caption: caption, ParseMode: "HTML"})
+	return handlers.EndConversation()
+}
+
+func (h *groupStatsHandler) getStats(days int) (*repositories.GroupStats, error) {
+	// The range starts at midnight, so the first day is complete
+	now := time.Now().In(h.config.EventsTimezone)
+	since := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, h.config.EventsTimezone)
+	return h.groupMessageRepository.GetGroupStats(since, h.config.EventsTimezone)
+}
+
+// parseCallback checks the admin rights and returns the number of days from the callback data
+func (h *groupStatsHandler) parseCallback(b *gotgbot.Bot, ctx *ext.Context, prefix string) (int, bool) {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserAdminOrCreator(b, ctx.EffectiveUser.Id, h.config) {
+		log.Printf("%s: User %d tried to view the group statistics without admin rights", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id)
+		h.answerAlert(b, callback, "This action is only available to administrators.")
+		return 0, false
+	}
+
+	days, err := strconv.Atoi(strings.TrimPrefix(callback.Data, prefix))
+	if err != nil || days < 1 || days > constants.GroupStatsMaxDays {
+		log.Printf("%s: Invalid group stats callback data %q", utils.GetCurrentTypeName(), callback.Data)
+		h.answerAlert(b, callback, "This button is no longer valid.")
+		return 0, false
+	}
+
+	return days, true
+}
+
+func (h *groupStatsHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
@@ -0,0 +1,553 @@
+package adminhandlers
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states
+	matchingProgramsStateList          = "matching_programs_state_list"
+	matchingProgramsStateView          = "matching_programs_state_view"
+	matchingProgramsStateConfirmDelete = "matching_programs_state_confirm_delete"
+	matchingProgramsStateAwaitName     = "matching_programs_state_await_name"
+	matchingProgramsStateAwaitTopicID  = "matching_programs_state_await_topic_id"
+	matchingProgramsStateAwaitSchedule = "matching_programs_state_await_schedule"
+	matchingProgramsStateAwaitRoles    = "matching_programs_state_await_roles"
+	matchingProgramsStateAwaitQuestion = "matching_programs_state_await_question"
+
+	// UserStore keys
+	matchingProgramsCtxDataKeyPreviousMessageID = "matching_programs_ctx_data_previous_message_id"
+	matchingProgramsCtxDataKeyPreviousChatID    = "matching_programs_ctx_data_previous_chat_id"
+	matchingProgramsCtxDataKeyProgramID         = "matching_programs_ctx_data_program_id"
+	matchingProgramsCtxDataKeyNewProgram        = "matching_programs_ctx_data_new_program"
+
+	// Menu headers
+	matchingProgramsMenuHeader       = "Admin Menu \"Matching Programs\""
+	matchingProgramsMenuCreateHeader = "Matching Programs → New program"
+
+	matchingProgramNameLengthLimit     = 64
+	matchingProgramQuestionLengthLimit = 300 // Telegram limit for poll questions
+)
+
+type matchingProgramsHandler struct {
+	config                 *config.Config
+	messageSenderService   *services.MessageSenderService
+	permissionsService     *services.PermissionsService
+	matchingProgramService *services.MatchingProgramService
+	programRepository      *repositories.MatchingProgramRepository
+	pollRepository         *repositories.RandomCoffeePollRepository
+	userStore              *utils.UserDataStore
+}
+
+func NewMatchingProgramsHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+	matchingProgramService *services.MatchingProgramService,
+	programRepository *repositories.MatchingProgramRepository,
+	pollRepository *repositories.RandomCoffeePollRepository,
+) ext.Handler {
+	h := &matchingProgramsHandler{
+		config:                 config,
+		messageSenderService:   messageSenderService,
+		permissionsService:     permissionsService,
+		matchingProgramService: matchingProgramService,
+		programRepository:      programRepository,
+		pollRepository:         pollRepository,
+		userStore:              utils.NewUserDataStore(),
+	}
+
+	callbackHandler := handlers.NewCallback(callbackquery.Prefix(constants.MatchingProgramsPrefix), h.handleCallback)
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.MatchingProgramsCommand, h.handleCommand),
+		},
+		map[string][]ext.Handler{
+			matchingProgramsStateList:          {callbackHandler},
+			matchingProgramsStateView:          {callbackHandler},
+			matchingProgramsStateConfirmDelete: {callbackHandler},
+			matchingProgramsStateAwaitName:     {handlers.NewMessage(message.Text, h.handleNameInput), callbackHandler},
+			matchingProgramsStateAwaitTopicID:  {handlers.NewMessage(message.Text, h.handleTopicIDInput), callbackHandler},
+			matchingProgramsStateAwaitSchedule: {handlers.NewMessage(message.Text, h.handleScheduleInput), callbackHandler},
+			matchingProgramsStateAwaitRoles:    {handlers.NewMessage(message.Text, h.handleRolesInput), callbackHandler},
+			matchingProgramsStateAwaitQuestion: {handlers.NewMessage(message.Text, h.handleQuestionInput), callbackHandler},
+		},
+		&handlers.ConversationOpts{
+			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+			Fallbacks: []ext.Handler{
+				handlers.NewMessage(message.Text, func(b *gotgbot.Bot, ctx *ext.Context) error {
+					// Delete the message that not matched any state
+					b.DeleteMessage(ctx.EffectiveMessage.Chat.Id, ctx.EffectiveMessage.MessageId, nil)
+					return nil
+				}),
+			},
+		},
+	)
+}
+
+// Entry point for the /programs command
+func (h *matchingProgramsHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.MatchingProgramsCommand) {
+		log.Printf("%s: User %d (%s) tried to use /%s without admin permissions.",
+			utils.GetCurrentTypeName(),
+			ctx.EffectiveUser.Id,
+			ctx.EffectiveUser.Username,
+			constants.MatchingProgramsCommand,
+		)
+		return handlers.EndConversation()
+	}
+
+	return h.showList(b, msg.Chat.Id, ctx.EffectiveUser.Id, "")
+}
+
+// Handles button clicks
+func (h *matchingProgramsHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	_, _ = callback.Answer(b, nil)
+
+	chatID := ctx.EffectiveChat.Id
+	userID := ctx.EffectiveUser.Id
+	data := callback.Data
+
+	if strings.HasPrefix(data, constants.MatchingProgramsViewPrefix) {
+		programID, err := strconv.Atoi(strings.TrimPrefix(data, constants.MatchingProgramsViewPrefix))
+		if err != nil {
+			return h.showList(b, chatID, userID, "")
+		}
+		h.userStore.Set(userID, matchingProgramsCtxDataKeyProgramID, programID)
+		return h.showProgram(b, chatID, userID, "")
+	}
+
+	switch data {
+	case constants.MatchingProgramsStartCallback:
+		return h.showList(b, chatID, userID, "")
+	case constants.MatchingProgramsCreateCallback:
+		h.userStore.Set(userID, matchingProgramsCtxDataKeyNewProgram, &repositories.MatchingProgram{IsActive: true})
+		return h.askForInput(b, chatID, userID,
+			fmt.Sprintf("Send the program name <i>(up to %d characters, e.g. \"Mentoring\")</i>:", matchingProgramNameLengthLimit),
+			matchingProgramsStateAwaitName)
+	case constants.MatchingProgramsBackToProgramCallback:
+		return h.showProgram(b, chatID, userID, "")
+	case constants.MatchingProgramsToggleActiveCallback:
+		return h.handleToggleActive(b, chatID, userID)
+	case constants.MatchingProgramsSendPollCallback:
+		return h.handleRunNow(b, chatID, userID, h.matchingProgramService.SendPoll, "✅ The poll was sent to the program's topic.")
+	case constants.MatchingProgramsMatchCallback:
+		return h.handleRunNow(b, chatID, userID, h.matchingProgramService.CreatePairsDraft, "✅ Participants were matched. The pairs draft was sent to the admin for review, or posted right away if review is off.")
+	case constants.MatchingProgramsDeleteCallback:
+		return h.handleDelete(b, chatID, userID)
+	case constants.MatchingProgramsDeleteConfirmCallback:
+		return h.handleDeleteConfirm(b, chatID, userID)
+	case constants.MatchingProgramsCancelCallback:
+		return h.handleCancel(b, ctx)
+	}
+
+	return nil
+}
+
+// showList renders all programs with a button for each of them
+func (h *matchingProgramsHandler) showList(b *gotgbot.Bot, chatID int64, userID int64, notice string) error {
+	programs, err := h.programRepository.GetAll()
+	if err != nil {
+		return fmt.Errorf("%s: failed to get programs in showList: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	text := fmt.Sprintf("<b>%s</b>", matchingProgramsMenuHeader) +
+		"\n\nMatching programs run alongside the weekly Random Coffee, each with its own topic, schedule and poll. " +
+		"With roles (e.g. Mentor / Mentee), members of one role are matched only with members of the other."
+	if len(programs) == 0 {
+		text += "\n\nThere are no programs yet."
+	} else {
+		text += "\n\nSelect a program:"
+	}
+	if notice != "" {
+		text += "\n\n" + notice
+	}
+
+	return h.sendMenu(b, chatID, userID, text, buttons.MatchingProgramsListButtons(programs), matchingProgramsStateList)
+}
+
+// showProgram renders the selected program with its actions
+func (h *matchingProgramsHandler) showProgram(b *gotgbot.Bot, chatID int64, userID int64, notice string) error {
+	program, err := h.getSelectedProgram(userID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return h.showList(b, chatID, userID, "⚠️ The program no longer exists.")
+	}
+
+	latestPoll, err := h.pollRepository.GetLatestProgramPoll(program.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get latest poll in showProgram: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	status := "▶️ Active"
+	if !program.IsActive {
+		status = "⏸️ Paused"
+	}
+
+	pollDay := program.PollWeekday.String()
+	if program.Frequency == repositories.MatchingProgramFrequencyMonthly {
+		pollDay = fmt.Sprintf("the first %s of the month", program.PollWeekday.String())
+	}
+
+	matching := "everyone with everyone"
+	if program.HasRoles() {
+		matching = fmt.Sprintf("%s ↔ %s", html.EscapeString(program.FirstRole.String), html.EscapeString(program.SecondRole.String))
+	}
+
+	latestRound := "none yet"
+	if latestPoll != nil {
+		roundStatus := "registration open"
+		if latestPoll.ClosedAt.Valid {
+			roundStatus = "matched"
+		}
+		latestRound = fmt.Sprintf("%s (%s)", latestPoll.WeekStartDate.Format("Mon, Jan 2"), roundStatus)
+	}
+
+	text := fmt.Sprintf("<b>Matching Programs → %s</b>", html.EscapeString(program.Name)) +
+		fmt.Sprintf("\n\nStatus: %s", status) +
+		fmt.Sprintf("\nTopic ID: <code>%d</code>", program.TopicID) +
+		fmt.Sprintf("\nPoll: %s at %s UTC", pollDay, program.PollTime) +
+		fmt.Sprintf("\nMatching: next %s at %s UTC", program.PairsWeekday.String(), program.PairsTime) +
+		fmt.Sprintf("\nWho is matched: %s", matching) +
+		fmt.Sprintf("\nPoll question: <i>%s</i>", html.EscapeString(program.PollQuestion)) +
+		fmt.Sprintf("\nLatest round: %s", latestRound)
+	if notice != "" {
+		text += "\n\n" + notice
+	}
+
+	return h.sendMenu(b, chatID, userID, text, buttons.MatchingProgramViewButtons(program.IsActive), matchingProgramsStateView)
+}
+
+func (h *matchingProgramsHandler) handleToggleActive(b *gotgbot.Bot, chatID int64, userID int64) error {
+	program, err := h.getSelectedProgram(userID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return h.showList(b, chatID, userID, "⚠️ The program no longer exists.")
+	}
+
+	if err := h.programRepository.SetActive(program.ID, !program.IsActive); err != nil {
+		return fmt.Errorf("%s: failed to toggle program in handleToggleActive: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	notice := "▶️ The program is running again."
+	if program.IsActive {
+		notice = "⏸️ The program is paused. No polls or matching will happen until you resume it."
+	}
+	return h.showProgram(b, chatID, userID, notice)
+}
+
+// handleRunNow runs a poll or matching of the selected program right away, outside of its schedule
+func (h *matchingProgramsHandler) handleRunNow(b *gotgbot.Bot, chatID int64, userID int64, run func(programID int) error, successNotice string) error {
+	program, err := h.getSelectedProgram(userID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return h.showList(b, chatID, userID, "⚠️ The program no longer exists.")
+	}
+
+	if err := run(program.ID); err != nil {
+		log.Printf("%s: Error running program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+		return h.showProgram(b, chatID, userID, fmt.Sprintf("❌ Error: <code>%s</code>", html.EscapeString(err.Error())))
+	}
+	return h.showProgram(b, chatID, userID, successNotice)
+}
+
+func (h *matchingProgramsHandler) handleDelete(b *gotgbot.Bot, chatID int64, userID int64) error {
+	program, err := h.getSelectedProgram(userID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return h.showList(b, chatID, userID, "⚠️ The program no longer exists.")
+	}
+
+	return h.sendMenu(b, chatID, userID,
+		fmt.Sprintf("<b>Matching Programs → %s → Delete</b>", html.EscapeString(program.Name))+
+			"\n\nDelete the program together with all its rounds and pairs? This cannot be undone.",
+		buttons.ConfirmAndCancelButton(constants.MatchingProgramsDeleteConfirmCallback, constants.MatchingProgramsBackToProgramCallback),
+		matchingProgramsStateConfirmDelete,
+	)
+}
+
+func (h *matchingProgramsHandler) handleDeleteConfirm(b *gotgbot.Bot, chatID int64, userID int64) error {
+	program, err := h.getSelectedProgram(userID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return h.showList(b, chatID, userID, "⚠️ The program no longer exists.")
+	}
+
+	if err := h.programRepository.Delete(program.ID); err != nil {
+		return fmt.Errorf("%s: failed to delete program in handleDeleteConfirm: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return h.showList(b, chatID, userID, fmt.Sprintf("🗑 Program \"%s\" deleted.", html.EscapeString(program.Name)))
+}
+
+func (h *matchingProgramsHandler) handleNameInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userID := ctx.EffectiveUser.Id
+
+	name := strings.TrimSpace(msg.Text)
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	if name == "" || utf8.RuneCountInString(name) > matchingProgramNameLengthLimit {
+		return h.askForInput(b, msg.Chat.Id, userID,
+			fmt.Sprintf("The name must be between 1 and %d characters. Send the program name:", matchingProgramNameLengthLimit),
+			matchingProgramsStateAwaitName)
+	}
+
+	program := h.getNewProgram(userID)
+	program.Name = name
+
+	return h.askForInput(b, msg.Chat.Id, userID,
+		"Send the ID of the topic where polls and pairs should be posted:",
+		matchingProgramsStateAwaitTopicID)
+}
+
+func (h *matchingProgramsHandler) handleTopicIDInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userID := ctx.EffectiveUser.Id
+
+	topicID, err := strconv.Atoi(strings.TrimSpace(msg.Text))
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	if err != nil || topicID <= 0 {
+		return h.askForInput(b, msg.Chat.Id, userID,
+			"The topic ID must be a positive number. Send the ID of the topic:",
+			matchingProgramsStateAwaitTopicID)
+	}
+
+	program := h.getNewProgram(userID)
+	program.TopicID = topicID
+
+	return h.askForInput(b, msg.Chat.Id, userID, matchingProgramsSchedulePrompt(""), matchingProgramsStateAwaitSchedule)
+}
+
+func (h *matchingProgramsHandler) handleScheduleInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userID := ctx.EffectiveUser.Id
+
+	fields := strings.Fields(strings.ToLower(msg.Text))
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+
+	var pollWeekday, pairsWeekday time.Weekday
+	var pollTime, pairsTime time.Time
+	valid := len(fields) == 5 &&
+		(fields[0] == repositories.MatchingProgramFrequencyWeekly || fields[0] == repositories.MatchingProgramFrequencyMonthly)
+	if valid {
+		var pollDayOK, pairsDayOK bool
+		var pollTimeErr, pairsTimeErr error
+		pollWeekday, pollDayOK = parseWeekday(fields[1])
+		pollTime, pollTimeErr = time.Parse("15:04", fields[2])
+		pairsWeekday, pairsDayOK = parseWeekday(fields[3])
+		pairsTime, pairsTimeErr = time.Parse("15:04", fields[4])
+		valid = pollDayOK && pairsDayOK && pollTimeErr == nil && pairsTimeErr == nil
+	}
+	if !valid {
+		return h.askForInput(b, msg.Chat.Id, userID, matchingProgramsSchedulePrompt("⚠️ Could not parse the schedule."), matchingProgramsStateAwaitSchedule)
+	}
+
+	program := h.getNewProgram(userID)
+	program.Frequency = fields[0]
+	program.PollWeekday = pollWeekday
+	program.PollTime = pollTime.Format("15:04")
+	program.PairsWeekday = pairsWeekday
+	program.PairsTime = pairsTime.Format("15:04")
+
+	return h.askForInput(b, msg.Chat.Id, userID,
+		"Who should be matched with whom?"+
+			"\n\nSend two roles separated by a slash, e.g. <code>Mentor / Mentee</code>: members of the first role are matched only with members of the second one."+
+			"\n\nSend <code>-</code> to match everyone with everyone.",
+		matchingProgramsStateAwaitRoles)
+}
+
+func (h *matchingProgramsHandler) handleRolesInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userID := ctx.EffectiveUser.Id
+
+	input := strings.TrimSpace(msg.Text)
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+
+	program := h.getNewProgram(userID)
+	if input == "-" {
+		program.FirstRole = sql.NullString{}
+		program.SecondRole = sql.NullString{}
+	} else {
+		roles := strings.Split(input, "/")
+		if len(roles) != 2 || strings.TrimSpace(roles[0]) == "" || strings.TrimSpace(roles[1]) == "" ||
+			strings.EqualFold(strings.TrimSpace(roles[0]), strings.TrimSpace(roles[1])) {
+			return h.askForInput(b, msg.Chat.Id, userID,
+				"Please send two different roles separated by a slash (e.g. <code>Mentor / Mentee</code>) or <code>-</code>:",
+				matchingProgramsStateAwaitRoles)
+		}
+		program.FirstRole = sql.NullString{String: strings.TrimSpace(roles[0]), Valid: true}
+		program.SecondRole = sql.NullString{String: strings.TrimSpace(roles[1]), Valid: true}
+	}
+
+	return h.askForInput(b, msg.Chat.Id, userID,
+		fmt.Sprintf("Finally, send the poll question <i>(up to %d characters, e.g. \"Will you join the mentoring round this month? 🎓\")</i>:", matchingProgramQuestionLengthLimit),
+		matchingProgramsStateAwaitQuestion)
+}
+
+func (h *matchingProgramsHandler) handleQuestionInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userID := ctx.EffectiveUser.Id
+
+	question := strings.TrimSpace(msg.Text)
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	if question == "" || utf8.RuneCountInString(question) > matchingProgramQuestionLengthLimit {
+		return h.askForInput(b, msg.Chat.Id, userID,
+			fmt.Sprintf("The question must be between 1 and %d characters. Send the poll question:", matchingProgramQuestionLengthLimit),
+			matchingProgramsStateAwaitQuestion)
+	}
+
+	program := h.getNewProgram(userID)
+	program.PollQuestion = question
+
+	programID, err := h.programRepository.Create(*program)
+	if err != nil {
+		log.Printf("%s: Error creating program: %v", utils.GetCurrentTypeName(), err)
+		return h.showList(b, msg.Chat.Id, userID,
+			"❌ Could not create the program. Make sure the name is not taken by another program.")
+	}
+
+	h.userStore.Set(userID, matchingProgramsCtxDataKeyProgramID, programID)
+	return h.showProgram(b, msg.Chat.Id, userID, "✅ Program created! Polls will be sent according to its schedule.")
+}
+
+// askForInput sends a prompt of the program creation flow and moves to the given state
+func (h *matchingProgramsHandler) askForInput(b *gotgbot.Bot, chatID int64, userID int64, prompt string, state string) error {
+	return h.sendMenu(b, chatID, userID,
+		fmt.Sprintf("<b>%s</b>\n\n%s", matchingProgramsMenuCreateHeader, prompt),
+		buttons.MatchingProgramsBackCancelButtons(constants.MatchingProgramsStartCallback),
+		state,
+	)
+}
+
+func (h *matchingProgramsHandler) sendMenu(
+	b *gotgbot.Bot,
+	chatID int64,
+	userID int64,
+	text string,
+	markup gotgbot.InlineKeyboardMarkup,
+	state string,
+) error {
+	h.RemovePreviousMessage(b, &userID)
+	sentMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(chatID, text, &gotgbot.SendMessageOpts{
+		ReplyMarkup: markup,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in sendMenu: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(userID, sentMsg)
+	return handlers.NextConversationState(state)
+}
+
+func (h *matchingProgramsHandler) getSelectedProgram(userID int64) (*repositories.MatchingProgram, error) {
+	programID, ok := h.userStore.Get(userID, matchingProgramsCtxDataKeyProgramID)
+	if !ok {
+		return nil, nil
+	}
+
+	program, err := h.programRepository.GetByID(programID.(int))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get program: %w", utils.GetCurrentTypeName(), err)
+	}
+	return program, nil
+}
+
+func (h *matchingProgramsHandler) getNewProgram(userID int64) *repositories.MatchingProgram {
+	if program, ok := h.userStore.Get(userID, matchingProgramsCtxDataKeyNewProgram); ok {
+		return program.(*repositories.MatchingProgram)
+	}
+
+	program := &repositories.MatchingProgram{IsActive: true}
+	h.userStore.Set(userID, matchingProgramsCtxDataKeyNewProgram, program)
+	return program
+}
+
+func (h *matchingProgramsHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	userID := ctx.EffectiveUser.Id
+
+	h.RemovePreviousMessage(b, &userID)
+	h.messageSenderService.Send(ctx.EffectiveChat.Id, "Matching programs session ended.", nil)
+	h.userStore.Clear(userID)
+
+	return handlers.EndConversation()
+}
+
+func (h *matchingProgramsHandler) RemovePreviousMessage(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			matchingProgramsCtxDataKeyPreviousMessageID,
+			matchingProgramsCtxDataKeyPreviousChatID,
+		)
+	}
+
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	b.DeleteMessage(chatID, messageID, nil)
+}
+
+func (h *matchingProgramsHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	if sentMsg == nil {
+		return
+	}
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		matchingProgramsCtxDataKeyPreviousMessageID, matchingProgramsCtxDataKeyPreviousChatID)
+}
+
+func matchingProgramsSchedulePrompt(notice string) string {
+	prompt := "Send the schedule as <code>&lt;weekly|monthly&gt; &lt;poll day&gt; &lt;poll time&gt; &lt;matching day&gt; &lt;matching time&gt;</code> (UTC)." +
+		"\n\nFor example:" +
+		"\n<code>weekly friday 14:00 monday 12:00</code> — poll every Friday, pairs on the following Monday" +
+		"\n<code>monthly monday 10:00 thursday 10:00</code> — poll on the first Monday of the month, pairs on the following Thursday"
+	if notice != "" {
+		prompt = notice + "\n\n" + prompt
+	}
+	return prompt
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), s) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
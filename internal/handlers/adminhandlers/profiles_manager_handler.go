@@ -10,7 +10,9 @@ import (
 	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
 	"fmt"
+	"html"
 	"log"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +39,7 @@ const (
 	adminProfilesStateAwaitLastname                 = "admin_profiles_state_await_lastname"
 	adminProfilesStateAwaitCoffeeBan                = "admin_profiles_state_await_coffee_ban"
 	adminProfilesStateAwaitUsername                 = "admin_profiles_state_await_username"
+	adminProfilesStateAwaitProfileField             = "admin_profiles_state_await_profile_field"
 
 	// UserStore keys
 	adminProfilesCtxDataKeyField                   = "admin_profiles_ctx_data_field"
@@ -47,6 +50,7 @@ const (
 	adminProfilesCtxDataKeyTelegramID              = "admin_profiles_ctx_data_telegram_id"
 	adminProfilesCtxDataKeyTelegramUsername        = "admin_profiles_ctx_data_telegram_username"
 	adminProfilesCtxDataKeyLastMessageTimeFromUser = "admin_profiles_ctx_data_last_message_time_from_user"
+	adminProfilesCtxDataKeyProfileField            = "admin_profiles_ctx_data_profile_field"
 
 	// Menu headers
 	adminProfilesMenuHeader              = "Admin Menu \"Profile Manager\""
@@ -69,6 +73,8 @@ type adminProfilesHandler struct {
 	profileService       *services.ProfileService
 	userRepository       *repositories.UserRepository
 	profileRepository    *repositories.ProfileRepository
+	badgeRepository      *repositories.BadgeRepository
+	membershipEventRepo  *repositories.ClubMembershipEventRepository
 	userStore            *utils.UserDataStore
 }
 
@@ -79,6 +85,8 @@ func NewAdminProfilesHandler(
 	profileService *services.ProfileService,
 	userRepository *repositories.UserRepository,
 	profileRepository *repositories.ProfileRepository,
+	badgeRepository *repositories.BadgeRepository,
+	membershipEventRepo *repositories.ClubMembershipEventRepository,
 ) ext.Handler {
 	h := &adminProfilesHandler{
 		config:               config,
@@ -87,6 +95,8 @@ func NewAdminProfilesHandler(
 		profileService:       profileService,
 		userRepository:       userRepository,
 		profileRepository:    profileRepository,
+		badgeRepository:      badgeRepository,
+		membershipEventRepo:  membershipEventRepo,
 		userStore:            utils.NewUserDataStore(),
 	}
 
@@ -134,6 +144,7 @@ func NewAdminProfilesHandler(
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditLastnameCallback), h.handleEditFieldCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditUsernameCallback), h.handleEditFieldCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditCoffeeBanCallback), h.handleEditFieldCallback),
+				handlers.NewCallback(callbackquery.Prefix(constants.AdminProfilesEditFieldPrefix), h.handleEditProfileFieldCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditMenuCallback), h.handleEditMenuCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesPublishCallback), h.handlePublishCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesPublishNoPreviewCallback), h.handlePublishNoPreviewCallback),
@@ -160,6 +171,11 @@ func NewAdminProfilesHandler(
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditMenuCallback), h.handleEditMenuCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesCancelCallback), h.handleCancelCallback),
 			},
+			adminProfilesStateAwaitProfileField: {
+				handlers.NewMessage(message.Text, h.handleProfileFieldInput),
+				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditMenuCallback), h.handleEditMenuCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesCancelCallback), h.handleCancelCallback),
+			},
 			adminProfilesStateAwaitCoffeeBan: {
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesToggleCoffeeBanCallback), h.handleToggleCoffeeBanCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.AdminProfilesEditMenuCallback), h.handleEditMenuCallback),
@@ -702,7 +718,13 @@ func (h *adminProfilesHandler) handleSearchByFullNameInput(b *gotgbot.Bot, ctx *
 
 // Shows the profile edit menu
 func (h *adminProfilesHandler) showProfileEditMenu(b *gotgbot.Bot, msg *gotgbot.Message, userId int64, user *repositories.User, profile *repositories.Profile) error {
-	profileText := fmt.Sprintf("<b>%s</b>\n\n%s", adminProfilesMenuEditHeader, formatters.FormatProfileManagerView(user, profile, user.HasCoffeeBan, h.config))
+	membershipEvents, err := h.membershipEventRepo.GetByUserID(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting membership events in showProfileEditMenu: %v", utils.GetCurrentTypeName(), err)
+	}
+
+	profileText := fmt.Sprintf("<b>%s</b>\n\n%s", adminProfilesMenuEditHeader,
+		formatters.FormatProfileManagerView(user, profile, user.HasCoffeeBan, membershipEvents, h.config))
 
 	editedMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
 		msg.Chat.Id,
@@ -905,8 +927,13 @@ func (h *adminProfilesHandler) handlePublishProfile(b *gotgbot.Bot, ctx *ext.Con
 		return nil // Stay in current state
 	}
 
+	badges, err := h.badgeRepository.GetByUserID(dbUser.ID)
+	if err != nil {
+		log.Printf("%s: Error getting badges for publishing: %v", utils.GetCurrentTypeName(), err)
+	}
+
 	// Format profile text for publishing
-	publicMessageText := formatters.FormatPublicProfileForMessage(dbUser, profile, false)
+	publicMessageText := formatters.FormatPublicProfileForMessage(dbUser, profile, badges, false)
 
 	var publishedMsg *gotgbot.Message
 	// Check if we need to update existing message or create a new one
@@ -1157,6 +1184,93 @@ func (h *adminProfilesHandler) handleUsernameInput(b *gotgbot.Bot, ctx *ext.Cont
 	return h.returnToProfileView(b, ctx)
 }
 
+// Handle the button of a structured profile field
+func (h *adminProfilesHandler) handleEditProfileFieldCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userId := ctx.EffectiveUser.Id
+	field := constants.ProfileField(strings.TrimPrefix(ctx.Update.CallbackQuery.Data, constants.AdminProfilesEditFieldPrefix))
+	if !slices.Contains(constants.AllProfileFields, field) {
+		return fmt.Errorf("%s: unknown profile field: %s", utils.GetCurrentTypeName(), field)
+	}
+
+	profileIDVal, ok := h.userStore.Get(userId, adminProfilesCtxDataKeyProfileID)
+	if !ok {
+		return fmt.Errorf("%s: profile ID not found in user store", utils.GetCurrentTypeName())
+	}
+	profile, err := h.profileRepository.GetByID(profileIDVal.(int))
+	if err != nil {
+		return fmt.Errorf("%s: failed to get profile in handleEditProfileFieldCallback: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.userStore.Set(userId, adminProfilesCtxDataKeyProfileField, field)
+
+	oldField := "Current value: not set"
+	if value := profile.FieldValue(field); value != "" {
+		oldField = "Current value: <code>" + html.EscapeString(value) + "</code>"
+	}
+
+	h.RemovePreviousMessage(b, &userId)
+	editedMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		msg.Chat.Id,
+		fmt.Sprintf("<b>%s → %s</b>", adminProfilesMenuEditHeader, formatters.GetProfileFieldName(field))+
+			fmt.Sprintf("\n\n%s", oldField)+
+			fmt.Sprintf("\n\nEnter %s <i>(send %s to clear it)</i>:", formatters.GetProfileFieldHint(field), utils.ProfileFieldClearValue),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ProfilesBackCancelButtons(constants.AdminProfilesEditMenuCallback),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in handleEditProfileFieldCallback: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(userId, editedMsg)
+	return handlers.NextConversationState(adminProfilesStateAwaitProfileField)
+}
+
+// Handle structured profile field input
+func (h *adminProfilesHandler) handleProfileFieldInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	userId := ctx.EffectiveUser.Id
+
+	fieldVal, ok := h.userStore.Get(userId, adminProfilesCtxDataKeyProfileField)
+	if !ok {
+		return fmt.Errorf("%s: profile field not found in user store", utils.GetCurrentTypeName())
+	}
+	field := fieldVal.(constants.ProfileField)
+
+	value, err := utils.NormalizeProfileFieldValue(field, msg.Text)
+	if err != nil {
+		h.RemovePreviousMessage(b, &userId)
+		b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+		errMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+			msg.Chat.Id,
+			fmt.Sprintf("<b>%s → %s</b>", adminProfilesMenuEditHeader, formatters.GetProfileFieldName(field))+
+				fmt.Sprintf("\n\n\u26a0\ufe0f %s.", html.EscapeString(err.Error()))+
+				fmt.Sprintf("\n\nEnter %s <i>(send %s to clear it)</i>:", formatters.GetProfileFieldHint(field), utils.ProfileFieldClearValue),
+			&gotgbot.SendMessageOpts{
+				ReplyMarkup: buttons.ProfilesBackCancelButtons(constants.AdminProfilesEditMenuCallback),
+			})
+
+		h.SavePreviousMessageInfo(userId, errMsg)
+		return nil // Stay in current state
+	}
+
+	// Get profile ID from store
+	profileIDVal, ok := h.userStore.Get(userId, adminProfilesCtxDataKeyProfileID)
+	if !ok {
+		return fmt.Errorf("%s: profile ID not found in user store", utils.GetCurrentTypeName())
+	}
+	profileID := profileIDVal.(int)
+
+	err = h.profileRepository.Update(profileID, map[string]interface{}{
+		string(field): value,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update %s: %w", utils.GetCurrentTypeName(), field, err)
+	}
+
+	return h.returnToProfileView(b, ctx)
+}
+
 // Helper function to return to profile view after an update
 func (h *adminProfilesHandler) returnToProfileView(b *gotgbot.Bot, ctx *ext.Context) error {
 	msg := ctx.EffectiveMessage
@@ -0,0 +1,265 @@
+package adminhandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// randomCoffeeDraftHandler handles the review buttons of pairs drafts, of the weekly Random Coffee and of matching programs.
+// The draft message is sent outside of any conversation (e.g. by the scheduled pairs task),
+// so the handler is stateless and reads everything from the callback data and the service.
+type randomCoffeeDraftHandler struct {
+	config               *config.Config
+	messageSenderService *services.MessageSenderService
+	randomCoffeeService  *services.RandomCoffeeService
+}
+
+func NewRandomCoffeeDraftHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	randomCoffeeService *services.RandomCoffeeService,
+) ext.Handler {
+	h := &randomCoffeeDraftHandler{
+		config:               config,
+		messageSenderService: messageSenderService,
+		randomCoffeeService:  randomCoffeeService,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.RandomCoffeeDraftPrefix), h.handleCallback)
+}
+
+func (h *randomCoffeeDraftHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	data := callback.Data
+	msg := ctx.EffectiveMessage
+
+	if !utils.IsUserAdminOrCreator(b, ctx.EffectiveUser.Id, h.config) {
+		log.Printf("%s: User %d tried to manage a Random Coffee pairs draft without admin rights", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id)
+		return h.answerAlert(b, callback, "This action is only available to administrators.")
+	}
+
+	// Every action is followed by the poll ID of the draft's round and, for member picks, by user IDs
+	var action string
+	for _, prefix := range []string{
+		constants.RandomCoffeeDraftPublishPrefix,
+		constants.RandomCoffeeDraftRerollPrefix,
+		constants.RandomCoffeeDraftSwapPrefix,
+		constants.RandomCoffeeDraftRemovePrefix,
+		constants.RandomCoffeeDraftDiscardPrefix,
+		constants.RandomCoffeeDraftBackPrefix,
+		constants.RandomCoffeeDraftSwapPickPrefix,
+		constants.RandomCoffeeDraftRemovePickPrefix,
+	} {
+		if strings.HasPrefix(data, prefix) {
+			action = prefix
+			break
+		}
+	}
+	ids, err := parseDraftCallbackIDs(strings.TrimPrefix(data, action))
+	if action == "" || err != nil {
+		_, _ = callback.Answer(b, nil)
+		return nil
+	}
+	pollID, userIDs := ids[0], ids[1:]
+
+	draft := h.randomCoffeeService.GetPairsDraft(pollID)
+	if draft == nil {
+		_ = h.messageSenderService.RemoveInlineKeyboard(msg.Chat.Id, msg.MessageId)
+		return h.answerAlert(b, callback, "This draft is no longer available.")
+	}
+
+	switch action {
+	case constants.RandomCoffeeDraftPublishPrefix:
+		return h.handlePublish(b, callback, msg, draft)
+	case constants.RandomCoffeeDraftRerollPrefix:
+		draft, err := h.randomCoffeeService.RerollPairsDraft(pollID)
+		if err != nil {
+			return h.answerAlert(b, callback, err.Error())
+		}
+		return h.showDraft(b, callback, msg, draft)
+	case constants.RandomCoffeeDraftSwapPrefix:
+		return h.showPickMember(b, callback, msg, draft,
+			"🔀 Select the first member to swap:",
+			draft.Members(),
+			fmt.Sprintf("%s%d_", constants.RandomCoffeeDraftSwapPickPrefix, pollID),
+		)
+	case constants.RandomCoffeeDraftSwapPickPrefix:
+		return h.handleSwapPick(b, callback, msg, draft, userIDs)
+	case constants.RandomCoffeeDraftRemovePrefix:
+		return h.showPickMember(b, callback, msg, draft,
+			"➖ Select the member to remove from this round:",
+			draft.Members(),
+			fmt.Sprintf("%s%d_", constants.RandomCoffeeDraftRemovePickPrefix, pollID),
+		)
+	case constants.RandomCoffeeDraftRemovePickPrefix:
+		if len(userIDs) != 1 {
+			return h.answerAlert(b, callback, "Unknown member.")
+		}
+		draft, err := h.randomCoffeeService.RemoveFromPairsDraft(pollID, userIDs[0])
+		if err != nil {
+			return h.answerAlert(b, callback, err.Error())
+		}
+		return h.showDraft(b, callback, msg, draft)
+	case constants.RandomCoffeeDraftDiscardPrefix:
+		h.randomCoffeeService.DiscardPairsDraft(pollID)
+		_, _ = callback.Answer(b, nil)
+		text := fmt.Sprintf("🗑 The %s pairs draft was discarded. Nothing was saved or published.", html.EscapeString(draft.Title()))
+		if draft.Program == nil {
+			text += fmt.Sprintf("\n\nUse /%s to generate a new draft.", constants.TryGenerateCoffeePairsCommand)
+		}
+		return h.editMessage(b, msg, text, nil)
+	case constants.RandomCoffeeDraftBackPrefix:
+		return h.showDraft(b, callback, msg, draft)
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return nil
+}
+
+func (h *randomCoffeeDraftHandler) handlePublish(
+	b *gotgbot.Bot,
+	callback *gotgbot.CallbackQuery,
+	msg *gotgbot.Message,
+	draft *services.CoffeePairsDraft,
+) error {
+	if err := h.randomCoffeeService.PublishPairsDraft(draft.PollID); err != nil {
+		log.Printf("%s: Error publishing pairs draft: %v", utils.GetCurrentTypeName(), err)
+		return h.answerAlert(b, callback, fmt.Sprintf("Error publishing pairs: %s", err.Error()))
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return h.editMessage(b, msg, fmt.Sprintf("✅ %s pairs were published to the supergroup!", html.EscapeString(draft.Title())), nil)
+}
+
+// handleSwapPick handles the member selection for a swap. The picked user IDs are either
+// the first member only, or both members.
+func (h *randomCoffeeDraftHandler) handleSwapPick(
+	b *gotgbot.Bot,
+	callback *gotgbot.CallbackQuery,
+	msg *gotgbot.Message,
+	draft *services.CoffeePairsDraft,
+	userIDs []int,
+) error {
+	if len(userIDs) == 0 || len(userIDs) > 2 {
+		return h.answerAlert(b, callback, "Unknown member.")
+	}
+	firstUserID := userIDs[0]
+
+	if len(userIDs) == 1 {
+		// Swapping with one's own partner changes nothing, so only offer other members
+		partnerID := draft.PartnerOf(firstUserID)
+		var candidates []repositories.User
+		var firstName string
+		for _, member := range draft.Members() {
+			if member.ID == firstUserID {
+				firstName = member.Firstname
+				if member.TgUsername != "" {
+					firstName = "@" + member.TgUsername
+				}
+				continue
+			}
+			if member.ID == partnerID || !draft.CanSwap(firstUserID, member.ID) {
+				continue
+			}
+			candidates = append(candidates, member)
+		}
+
+		return h.showPickMember(b, callback, msg, draft,
+			fmt.Sprintf("🔀 Select a member to swap with <b>%s</b>:", html.EscapeString(firstName)),
+			candidates,
+			fmt.Sprintf("%s%d_%d_", constants.RandomCoffeeDraftSwapPickPrefix, draft.PollID, firstUserID),
+		)
+	}
+
+	draft, err := h.randomCoffeeService.SwapInPairsDraft(draft.PollID, firstUserID, userIDs[1])
+	if err != nil {
+		return h.answerAlert(b, callback, err.Error())
+	}
+	return h.showDraft(b, callback, msg, draft)
+}
+
+func (h *randomCoffeeDraftHandler) showDraft(
+	b *gotgbot.Bot,
+	callback *gotgbot.CallbackQuery,
+	msg *gotgbot.Message,
+	draft *services.CoffeePairsDraft,
+) error {
+	_, _ = callback.Answer(b, nil)
+
+	markup := buttons.RandomCoffeeDraftButtons(draft.PollID)
+	if err := h.editMessage(b, msg, h.randomCoffeeService.FormatPairsDraft(draft), &markup); err != nil {
+		return err
+	}
+
+	// The draft may be reviewed from a different message than the one it was sent in
+	h.randomCoffeeService.SetPairsDraftAdminMessage(draft.PollID, msg.Chat.Id, msg.MessageId)
+	return nil
+}
+
+func (h *randomCoffeeDraftHandler) showPickMember(
+	b *gotgbot.Bot,
+	callback *gotgbot.CallbackQuery,
+	msg *gotgbot.Message,
+	draft *services.CoffeePairsDraft,
+	text string,
+	members []repositories.User,
+	callbackPrefix string,
+) error {
+	_, _ = callback.Answer(b, nil)
+
+	markup := buttons.RandomCoffeeDraftPickMemberButtons(draft.PollID, members, callbackPrefix)
+	return h.editMessage(b, msg, text, &markup)
+}
+
+func (h *randomCoffeeDraftHandler) editMessage(b *gotgbot.Bot, msg *gotgbot.Message, text string, markup *gotgbot.InlineKeyboardMarkup) error {
+	opts := &gotgbot.EditMessageTextOpts{
+		ChatId:             msg.Chat.Id,
+		MessageId:          msg.MessageId,
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	}
+	if markup != nil {
+		opts.ReplyMarkup = *markup
+	}
+
+	if _, _, err := b.EditMessageText(text, opts); err != nil {
+		return fmt.Errorf("%s: failed to edit pairs draft message: %w", utils.GetCurrentTypeName(), err)
+	}
+	return nil
+}
+
+func (h *randomCoffeeDraftHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
+
+// parseDraftCallbackIDs parses the "_"-separated IDs after a draft action: the poll ID, then picked user IDs
+func parseDraftCallbackIDs(payload string) ([]int, error) {
+	parts := strings.Split(payload, "_")
+	ids := make([]int, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
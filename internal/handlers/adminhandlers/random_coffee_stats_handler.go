@@ -0,0 +1,81 @@
+package adminhandlers
+
+import (
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+)
+
+const (
+	randomCoffeeStatsDefaultRounds    = 8
+	randomCoffeeStatsMaxRounds        = 52
+	randomCoffeeStatsMostConnectedTop = 10
+)
+
+type randomCoffeeStatsHandler struct {
+	config               *config.Config
+	messageSenderService *services.MessageSenderService
+	permissionsService   *services.PermissionsService
+	pairRepository       *repositories.RandomCoffeePairRepository
+}
+
+func NewRandomCoffeeStatsHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+	pairRepository *repositories.RandomCoffeePairRepository,
+) ext.Handler {
+	h := &randomCoffeeStatsHandler{
+		config:               config,
+		messageSenderService: messageSenderService,
+		permissionsService:   permissionsService,
+		pairRepository:       pairRepository,
+	}
+
+	return handlers.NewCommand(constants.RandomCoffeeStatsCommand, h.handleCommand)
+}
+
+func (h *randomCoffeeStatsHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.RandomCoffeeStatsCommand) {
+		return nil
+	}
+
+	// Optional argument: number of rounds to show, e.g. "/coffeeStats 12"
+	roundsLimit := randomCoffeeStatsDefaultRounds
+	if args := strings.Fields(msg.Text); len(args) > 1 {
+		if n, err := strconv.Atoi(args[1]); err == nil && n > 0 {
+			roundsLimit = min(n, randomCoffeeStatsMaxRounds)
+		}
+	}
+
+	rounds, err := h.pairRepository.GetRoundStats(roundsLimit)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving Random Coffee statistics.", nil)
+		log.Printf("%s: Error during round stats retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	connectedUsers, err := h.pairRepository.GetMostConnectedUsers(randomCoffeeStatsMostConnectedTop)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving Random Coffee statistics.", nil)
+		log.Printf("%s: Error during most connected users retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatRandomCoffeeStats(rounds, connectedUsers), nil)
+
+	return nil
+}
@@ -116,6 +116,7 @@ func (h *showTopicsHandler) startShowTopics(b *gotgbot.Bot, ctx *ext.Context) er
 		"List of events",
 		constants.CancelCommand,
 		"for which you want to see topics and questions",
+		h.config.EventsTimezone,
 	)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
@@ -170,7 +171,7 @@ func (h *showTopicsHandler) handleEventSelection(b *gotgbot.Bot, ctx *ext.Contex
 	h.userStore.Set(ctx.EffectiveUser.Id, showTopicsCtxDataKeyEventID, eventID)
 
 	// Format and display topics for admin
-	formattedTopics := formatters.FormatHtmlTopicListForAdmin(topics, event.Name, event.Type)
+	formattedTopics := formatters.FormatHtmlTopicListForAdmin(topics, event.Name, event.TypeInfo)
 	h.messageSenderService.ReplyHtml(msg, formattedTopics, nil)
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
@@ -273,7 +274,7 @@ func (h *showTopicsHandler) handleTopicDeletion(b *gotgbot.Bot, ctx *ext.Context
 	}
 
 	// Show updated list of topics
-	formattedTopics := formatters.FormatHtmlTopicListForAdmin(topics, event.Name, event.Type)
+	formattedTopics := formatters.FormatHtmlTopicListForAdmin(topics, event.Name, event.TypeInfo)
 	h.messageSenderService.ReplyHtml(msg, formattedTopics, nil)
 
 	// If there are still topics, allow for more deletions
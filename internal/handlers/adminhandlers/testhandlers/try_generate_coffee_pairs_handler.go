@@ -130,7 +130,8 @@ func (h *tryGenerateCoffeePairsHandler) showConfirmationMenu(b *gotgbot.Bot, msg
 			"\n\nAre you sure you want to generate pairs for the current poll?"+
 			fmt.Sprintf("\n\n📊 Poll: week %s", latestPoll.WeekStartDate.Format("2006-01-02"))+
 			fmt.Sprintf("\n👥 Participants: %d", len(participants))+
-			"\n\n⚠️ Pairs will be sent to the community.",
+			"\n\nThe poll will be closed and a draft of pairs will be shown here. "+
+			"Nothing is saved or sent to the community until you publish the draft.",
 		&gotgbot.SendMessageOpts{
 			ReplyMarkup: buttons.ConfirmAndCancelButton(
 				constants.TryGenerateCoffeePairsConfirmCallback,
@@ -156,15 +157,15 @@ func (h *tryGenerateCoffeePairsHandler) handleConfirmCallback(b *gotgbot.Bot, ct
 	editedMsg, err := h.sender.SendHtmlWithReturnMessage(
 		msg.Chat.Id,
 		fmt.Sprintf("<b>%s</b>", tryGenerateCoffeePairsMenuHeader)+
-			"\n\n⏳ Generating pairs...",
+			"\n\n⏳ Generating pairs draft...",
 		nil)
 	h.SavePreviousMessageInfo(userId, editedMsg)
 	if err != nil {
 		return fmt.Errorf("%s: failed to send processing message: %w", utils.GetCurrentTypeName(), err)
 	}
 
-	// Execute the pairs generation logic
-	err = h.randomCoffeeService.GenerateAndSendPairs()
+	// Generate a draft that is published only after review
+	draft, err := h.randomCoffeeService.CreatePairsDraft(0)
 	if err != nil {
 		h.RemovePreviousMessage(b, &userId)
 
@@ -191,16 +192,19 @@ func (h *tryGenerateCoffeePairsHandler) handleConfirmCallback(b *gotgbot.Bot, ct
 
 	h.RemovePreviousMessage(b, &userId)
 
-	// Send success message
-	err = h.sender.SendHtml(
+	// Send the draft for review, its buttons are handled outside of this conversation
+	draftMsg, err := h.sender.SendHtmlWithReturnMessage(
 		msg.Chat.Id,
-		fmt.Sprintf("<b>%s</b>", tryGenerateCoffeePairsMenuHeader)+
-			"\n\n✅ Pairs successfully generated and sent to the supergroup!",
-		nil)
+		h.randomCoffeeService.FormatPairsDraft(draft),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup:        buttons.RandomCoffeeDraftButtons(draft.PollID),
+			LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		})
 
 	if err != nil {
-		return fmt.Errorf("%s: failed to send success message: %w", utils.GetCurrentTypeName(), err)
+		return fmt.Errorf("%s: failed to send pairs draft: %w", utils.GetCurrentTypeName(), err)
 	}
+	h.randomCoffeeService.SetPairsDraftAdminMessage(draft.PollID, draftMsg.Chat.Id, draftMsg.MessageId)
 
 	h.userStore.Clear(userId)
 	return handlers.EndConversation()
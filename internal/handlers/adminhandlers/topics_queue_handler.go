@@ -0,0 +1,322 @@
+package adminhandlers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	topicsQueueStateEnterReason = "admin_topics_queue_state_enter_reason"
+
+	// Context data keys
+	topicsQueueCtxDataKeyTopicID           = "admin_topics_queue_ctx_data_topic_id"
+	topicsQueueCtxDataKeyModerationMessage = "admin_topics_queue_ctx_data_moderation_message"
+	topicsQueueCtxDataKeyPreviousMessageID = "admin_topics_queue_ctx_data_previous_message_id"
+	topicsQueueCtxDataKeyPreviousChatID    = "admin_topics_queue_ctx_data_previous_chat_id"
+
+	// Callback data
+	topicsQueueCallbackConfirmCancel = "admin_topics_queue_callback_confirm_cancel"
+)
+
+// topicsQueueHandler shows the topics waiting for moderation and handles the Approve / Reject buttons,
+// both of the queue and of the notifications about new topics. Rejecting asks for a reason shown to the author.
+// The author of an anonymous topic is shown only to an administrator who presses Reveal author.
+type topicsQueueHandler struct {
+	config               *config.Config
+	topicRepository      *repositories.TopicRepository
+	eventRepository      *repositories.EventRepository
+	topicService         *services.TopicService
+	messageSenderService *services.MessageSenderService
+	userStore            *utils.UserDataStore
+	permissionsService   *services.PermissionsService
+}
+
+func NewTopicsQueueHandler(
+	config *config.Config,
+	topicRepository *repositories.TopicRepository,
+	eventRepository *repositories.EventRepository,
+	topicService *services.TopicService,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &topicsQueueHandler{
+		config:               config,
+		topicRepository:      topicRepository,
+		eventRepository:      eventRepository,
+		topicService:         topicService,
+		messageSenderService: messageSenderService,
+		userStore:            utils.NewUserDataStore(),
+		permissionsService:   permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.TopicsQueueCommand, h.startTopicsQueue),
+			handlers.NewCallback(callbackquery.Prefix(constants.TopicModerationApprovePrefix), h.handleCallbackApprove),
+			handlers.NewCallback(callbackquery.Prefix(constants.TopicModerationRejectPrefix), h.handleCallbackReject),
+			handlers.NewCallback(callbackquery.Prefix(constants.TopicModerationRevealPrefix), h.handleCallbackReveal),
+		},
+		map[string][]ext.Handler{
+			topicsQueueStateEnterReason: {
+				handlers.NewMessage(message.Text, h.handleReasonEntry),
+				handlers.NewCallback(callbackquery.Equal(topicsQueueCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits:        []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+			AllowReEntry: true,
+		},
+	)
+}
+
+// 1. startTopicsQueue sends every pending topic with its moderation buttons, the oldest first
+func (h *topicsQueueHandler) startTopicsQueue(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Check if user has admin permissions and is in a private chat
+	if !h.permissionsService.CheckAdminAndPrivateChat(msg, constants.TopicsQueueCommand) {
+		log.Printf("%s: User %d (%s) tried to use /%s without admin permissions.",
+			utils.GetCurrentTypeName(),
+			ctx.EffectiveUser.Id,
+			ctx.EffectiveUser.Username,
+			constants.TopicsQueueCommand,
+		)
+		return handlers.EndConversation()
+	}
+
+	topics, err := h.topicRepository.GetPendingTopics(constants.TopicsQueueLimit)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving topics waiting for moderation.", nil)
+		log.Printf("%s: Error during pending topics retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	if len(topics) == 0 {
+		h.messageSenderService.Reply(msg, "No topics are waiting for moderation.", nil)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.Reply(msg, fmt.Sprintf("Topics waiting for moderation: %d (up to %d are shown)",
+		len(topics), constants.TopicsQueueLimit), nil)
+
+	for _, topic := range topics {
+		eventName := ""
+		if event, err := h.eventRepository.GetEventByID(topic.EventID); err == nil {
+			eventName = event.Name
+		}
+		h.messageSenderService.SendHtml(
+			msg.Chat.Id,
+			formatters.FormatHtmlTopicForModeration(topic, eventName, topic.UpdatedAt.After(topic.CreatedAt)),
+			&gotgbot.SendMessageOpts{ReplyMarkup: buttons.TopicModerationButtons(topic.ID, topic.IsAnonymous)},
+		)
+	}
+
+	return handlers.EndConversation()
+}
+
+// handleCallbackApprove approves the topic right away
+func (h *topicsQueueHandler) handleCallbackApprove(b *gotgbot.Bot, ctx *ext.Context) error {
+	topicID, ok := h.parseModerationCallback(b, ctx, constants.TopicModerationApprovePrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	topic, err := h.topicService.Moderate(topicID, constants.TopicStatusApproved, "")
+	if err != nil {
+		log.Printf("%s: Error approving topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "The topic was withdrawn or has already been moderated.")
+		return handlers.EndConversation()
+	}
+
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+	h.markModerated(b, ctx.EffectiveMessage, topic)
+	return handlers.EndConversation()
+}
+
+// handleCallbackReveal sends the author of an anonymous topic to the administrator
+func (h *topicsQueueHandler) handleCallbackReveal(b *gotgbot.Bot, ctx *ext.Context) error {
+	topicID, ok := h.parseModerationCallback(b, ctx, constants.TopicModerationRevealPrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	author, err := h.topicService.RevealAuthor(topicID, ctx.EffectiveUser.Id)
+	if err != nil {
+		log.Printf("%s: Error revealing author of topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		h.answerAlert(b, ctx.Update.CallbackQuery, "The author of this topic is unknown or the topic was withdrawn.")
+		return handlers.EndConversation()
+	}
+
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+	h.messageSenderService.SendHtml(ctx.EffectiveChat.Id, formatters.FormatHtmlTopicAuthorReveal(topicID, *author), nil)
+	return handlers.EndConversation()
+}
+
+// 2. handleCallbackReject asks for the reason of the rejection
+func (h *topicsQueueHandler) handleCallbackReject(b *gotgbot.Bot, ctx *ext.Context) error {
+	topicID, ok := h.parseModerationCallback(b, ctx, constants.TopicModerationRejectPrefix)
+	if !ok {
+		return handlers.EndConversation()
+	}
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, topicsQueueCtxDataKeyTopicID, topicID)
+	h.userStore.Set(ctx.EffectiveUser.Id, topicsQueueCtxDataKeyModerationMessage, ctx.EffectiveMessage)
+
+	sentMsg, _ := h.messageSenderService.SendWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		fmt.Sprintf("Send the reason for rejecting topic %d, it will be shown to the author:", topicID),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(topicsQueueCallbackConfirmCancel),
+		},
+	)
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+
+	return handlers.NextConversationState(topicsQueueStateEnterReason)
+}
+
+// 3. handleReasonEntry rejects the topic with the entered reason
+func (h *topicsQueueHandler) handleReasonEntry(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	reason := strings.TrimSpace(msg.Text)
+	if reason == "" {
+		h.messageSenderService.Reply(msg, "The reason cannot be empty. Please send it or cancel the operation.", nil)
+		return nil // Stay in the same state
+	}
+
+	topicIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, topicsQueueCtxDataKeyTopicID)
+	topicID, isInt := topicIDVal.(int)
+	if !ok || !isInt {
+		h.messageSenderService.Reply(msg, "Error: topic not found in session. Press Reject again.", nil)
+		return handlers.EndConversation()
+	}
+
+	moderationMsgVal, _ := h.userStore.Get(ctx.EffectiveUser.Id, topicsQueueCtxDataKeyModerationMessage)
+	moderationMsg, _ := moderationMsgVal.(*gotgbot.Message)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	topic, err := h.topicService.Moderate(topicID, constants.TopicStatusRejected, reason)
+	if err != nil {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("Could not reject topic %d: it was withdrawn or has already been moderated.", topicID), nil)
+		log.Printf("%s: Error rejecting topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		return handlers.EndConversation()
+	}
+
+	h.markModerated(b, moderationMsg, topic)
+
+	h.messageSenderService.Reply(msg, fmt.Sprintf("❌ Topic %d rejected, the author has been notified.", topicID), nil)
+	return handlers.EndConversation()
+}
+
+// handleCallbackCancel processes the cancel button click
+func (h *topicsQueueHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+// 4. handleCancel handles the /cancel command
+func (h *topicsQueueHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.messageSenderService.Reply(msg, "Topic moderation canceled, the topic is still waiting in the queue.", nil)
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// parseModerationCallback checks the admin permissions and returns the topic ID from the callback data
+func (h *topicsQueueHandler) parseModerationCallback(b *gotgbot.Bot, ctx *ext.Context, prefix string) (int, bool) {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserAdminOrCreator(b, ctx.EffectiveUser.Id, h.config) {
+		h.answerAlert(b, callback, "Only administrators can moderate topics.")
+		return 0, false
+	}
+
+	topicID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, prefix))
+	if err != nil {
+		h.answerAlert(b, callback, "Unknown topic.")
+		return 0, false
+	}
+
+	return topicID, true
+}
+
+// markModerated replaces the moderation buttons of the message with the result
+func (h *topicsQueueHandler) markModerated(b *gotgbot.Bot, msg *gotgbot.Message, topic *repositories.Topic) {
+	if msg == nil {
+		return
+	}
+
+	_, _, err := b.EditMessageText(
+		fmt.Sprintf("%s\n\n<b>%s</b>", html.EscapeString(msg.Text), formatters.GetTopicStatusLabel(topic.Status)),
+		&gotgbot.EditMessageTextOpts{
+			ChatId:    msg.Chat.Id,
+			MessageId: msg.MessageId,
+			ParseMode: "HTML",
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error updating moderation message of topic %d: %v", utils.GetCurrentTypeName(), topic.ID, err)
+	}
+}
+
+func (h *topicsQueueHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
+
+func (h *topicsQueueHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			topicsQueueCtxDataKeyPreviousMessageID,
+			topicsQueueCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *topicsQueueHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		topicsQueueCtxDataKeyPreviousMessageID, topicsQueueCtxDataKeyPreviousChatID)
+}
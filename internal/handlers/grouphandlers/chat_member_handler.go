@@ -20,7 +20,8 @@ func NewChatMemberHandler(joinLeftService *grouphandlersservices.JoinLeftService
 }
 
 func (h *ChatMemberHandler) handle(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !utils.IsMessageFromSuperGroupChat(ctx.EffectiveMessage.Chat) {
+	// Chat member updates have no message, the chat comes with the update
+	if !utils.IsMessageFromSuperGroupChat(ctx.ChatMember.Chat) {
 		return nil
 	}
 
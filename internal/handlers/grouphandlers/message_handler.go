@@ -1,6 +1,8 @@
 package grouphandlers
 
 import (
+	"log"
+
 	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/services/grouphandlersservices"
 	"evo-bot-go/internal/utils"
@@ -19,6 +21,7 @@ type MessageHandler struct {
 	saveTopicService                *grouphandlersservices.SaveTopicService
 	adminSaveMessageService         *grouphandlersservices.AdminSaveMessageService
 	saveMessageService              *grouphandlersservices.SaveMessageService
+	karmaService                    *grouphandlersservices.KarmaService
 }
 
 func NewMessageHandler(
@@ -29,6 +32,7 @@ func NewMessageHandler(
 	saveTopicService *grouphandlersservices.SaveTopicService,
 	adminSaveMessageService *grouphandlersservices.AdminSaveMessageService,
 	saveMessageService *grouphandlersservices.SaveMessageService,
+	karmaService *grouphandlersservices.KarmaService,
 ) ext.Handler {
 	h := &MessageHandler{
 		messageSenderService:            messageSenderService,
@@ -38,6 +42,7 @@ func NewMessageHandler(
 		saveTopicService:                saveTopicService,
 		adminSaveMessageService:         adminSaveMessageService,
 		saveMessageService:              saveMessageService,
+		karmaService:                    karmaService,
 	}
 
 	return handlers.NewMessage(message.All, h.handle).SetAllowEdited(true)
@@ -76,6 +81,13 @@ func (h *MessageHandler) handle(b *gotgbot.Bot, ctx *ext.Context) error {
 		return h.adminSaveMessageService.SaveOrUpdateMessage(msg)
 	}
 
+	// Grant a karma point for "+1" and "thanks" replies, the reply is saved as usual
+	if h.karmaService.IsKarmaReply(msg) {
+		if err := h.karmaService.GrantForReply(msg); err != nil {
+			log.Printf("%s: Error granting karma point: %v", utils.GetCurrentTypeName(), err)
+		}
+	}
+
 	// Save or update or delete message in DB, than finish processing
 	if h.saveMessageService.IsMessageShouldBeSavedOrUpdated(msg) {
 		return h.saveMessageService.SaveOrUpdateMessage(ctx)
@@ -0,0 +1,32 @@
+package grouphandlers
+
+import (
+	"evo-bot-go/internal/services/grouphandlersservices"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/reaction"
+)
+
+type ReactionHandler struct {
+	karmaService *grouphandlersservices.KarmaService
+}
+
+func NewReactionHandler(karmaService *grouphandlersservices.KarmaService) ext.Handler {
+	h := &ReactionHandler{karmaService: karmaService}
+	return handlers.NewReaction(reaction.All, h.handle)
+}
+
+func (h *ReactionHandler) handle(b *gotgbot.Bot, ctx *ext.Context) error {
+	if !utils.IsMessageFromSuperGroupChat(ctx.MessageReaction.Chat) {
+		return nil
+	}
+
+	if h.karmaService.IsKarmaReaction(ctx.MessageReaction) {
+		return h.karmaService.GrantForReaction(ctx.MessageReaction)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// eventCalendarHandler sends .ics files of single events and members' personal calendar feed links.
+// Like the RSVP buttons, the calendar buttons live on messages outside of any conversation, so the handler is stateless.
+type eventCalendarHandler struct {
+	config               *config.Config
+	eventRepository      *repositories.EventRepository
+	userRepository       *repositories.UserRepository
+	eventCalendarService *services.EventCalendarService
+	messageSenderService *services.MessageSenderService
+}
+
+func NewEventCalendarHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	userRepository *repositories.UserRepository,
+	eventCalendarService *services.EventCalendarService,
+	messageSenderService *services.MessageSenderService,
+) ext.Handler {
+	h := &eventCalendarHandler{
+		config:               config,
+		eventRepository:      eventRepository,
+		userRepository:       userRepository,
+		eventCalendarService: eventCalendarService,
+		messageSenderService: messageSenderService,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.EventCalendarPrefix), h.handleCallback)
+}
+
+func (h *eventCalendarHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	data := callback.Data
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		return h.answerAlert(b, callback, "Events are only available to club members.")
+	}
+
+	switch {
+	case strings.HasPrefix(data, constants.EventCalendarFilePrefix):
+		return h.handleFile(b, ctx, strings.TrimPrefix(data, constants.EventCalendarFilePrefix))
+	case data == constants.EventCalendarFeedCallback:
+		return h.handleFeed(b, ctx, false)
+	case data == constants.EventCalendarFeedResetCallback:
+		return h.handleFeed(b, ctx, true)
+	default:
+		return h.answerAlert(b, callback, "Unknown action.")
+	}
+}
+
+// handleFile sends the event as an .ics file, which calendar apps open as a single event
+func (h *eventCalendarHandler) handleFile(b *gotgbot.Bot, ctx *ext.Context, eventIDStr string) error {
+	callback := ctx.Update.CallbackQuery
+
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		return h.answerAlert(b, callback, "Unknown event.")
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		return h.answerAlert(b, callback, "This event no longer exists.")
+	}
+	if event.StartedAt == nil || event.StartedAt.IsZero() {
+		return h.answerAlert(b, callback, "The start time of this event isn't set yet.")
+	}
+
+	ics, err := h.eventCalendarService.BuildEventICS(*event)
+	if err != nil {
+		log.Printf("%s: Error building calendar file of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while preparing the calendar file.")
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return h.messageSenderService.SendDocument(
+		ctx.EffectiveUser.Id,
+		fmt.Sprintf("event-%d.ics", event.ID),
+		ics,
+		&gotgbot.SendDocumentOpts{
+			Caption: "Open the file to add the event to your calendar.",
+		},
+	)
+}
+
+// handleFeed sends the member's personal calendar feed link, or replaces it with a new one on reset
+func (h *eventCalendarHandler) handleFeed(b *gotgbot.Bot, ctx *ext.Context, reset bool) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !h.eventCalendarService.IsFeedEnabled() {
+		return h.answerAlert(b, callback, "The calendar subscription isn't available at the moment.")
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return h.answerAlert(b, callback, "An error occurred. Please try again later.")
+	}
+
+	var feedURL string
+	if reset {
+		feedURL, err = h.eventCalendarService.ResetFeedURL(user.ID)
+	} else {
+		feedURL, err = h.eventCalendarService.GetFeedURL(user.ID)
+	}
+	if err != nil {
+		log.Printf("%s: Error getting calendar feed link of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while preparing your calendar link.")
+	}
+
+	text := "\U0001f5d3 <b>Club events calendar</b>\n\n" +
+		"Add this link to your calendar app to see all club events, kept up to date automatically:\n\n" +
+		fmt.Sprintf("<code>%s</code>\n\n", html.EscapeString(feedURL)) +
+		"<i>Google Calendar</i>: Other calendars → <b>+</b> → From URL.\n" +
+		"<i>Apple Calendar</i>: File → New Calendar Subscription.\n\n" +
+		"The link is personal, please don't share it. If it was shared by mistake, reset it below."
+
+	if !reset {
+		_, _ = callback.Answer(b, nil)
+		return h.messageSenderService.SendHtml(ctx.EffectiveUser.Id, text, &gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.EventCalendarFeedButtons(),
+		})
+	}
+
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      "\U0001f504 The old link no longer works. Update the subscription in your calendar app.",
+		ShowAlert: true,
+	})
+	_, _, err = b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+		ChatId:             ctx.EffectiveMessage.Chat.Id,
+		MessageId:          ctx.EffectiveMessage.MessageId,
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        buttons.EventCalendarFeedButtons(),
+	})
+	if err != nil {
+		log.Printf("%s: Error updating calendar feed message: %v", utils.GetCurrentTypeName(), err)
+	}
+	return nil
+}
+
+func (h *eventCalendarHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
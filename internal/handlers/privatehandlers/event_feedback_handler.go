@@ -0,0 +1,252 @@
+package privatehandlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	eventFeedbackStateEnterComment = "event_feedback_state_enter_comment"
+
+	// Context data keys
+	eventFeedbackCtxDataKeyEventID           = "event_feedback_ctx_data_event_id"
+	eventFeedbackCtxDataKeyPreviousMessageID = "event_feedback_ctx_data_previous_message_id"
+	eventFeedbackCtxDataKeyPreviousChatID    = "event_feedback_ctx_data_previous_chat_id"
+
+	// Callback data
+	eventFeedbackCallbackSkipComment = "event_feedback_callback_skip_comment"
+
+	// eventFeedbackCommentMaxLength keeps the comments short enough to fit in the admin report
+	eventFeedbackCommentMaxLength = 1000
+)
+
+// eventFeedbackHandler handles the rating buttons of the feedback form sent after an event.
+// In the DM the rating is followed by an optional comment, the form posted in the group only takes ratings.
+type eventFeedbackHandler struct {
+	config                  *config.Config
+	eventRepository         *repositories.EventRepository
+	eventFeedbackRepository *repositories.EventFeedbackRepository
+	userRepository          *repositories.UserRepository
+	messageSenderService    *services.MessageSenderService
+	userStore               *utils.UserDataStore
+}
+
+func NewEventFeedbackHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	eventFeedbackRepository *repositories.EventFeedbackRepository,
+	userRepository *repositories.UserRepository,
+	messageSenderService *services.MessageSenderService,
+) ext.Handler {
+	h := &eventFeedbackHandler{
+		config:                  config,
+		eventRepository:         eventRepository,
+		eventFeedbackRepository: eventFeedbackRepository,
+		userRepository:          userRepository,
+		messageSenderService:    messageSenderService,
+		userStore:               utils.NewUserDataStore(),
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCallback(callbackquery.Prefix(constants.EventFeedbackRatePrefix), h.handleCallbackRate),
+		},
+		map[string][]ext.Handler{
+			eventFeedbackStateEnterComment: {
+				handlers.NewMessage(message.Text, h.handleCommentEntry),
+				handlers.NewCallback(callbackquery.Equal(eventFeedbackCallbackSkipComment), h.handleCallbackSkip),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits:        []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+			AllowReEntry: true,
+		},
+	)
+}
+
+// 1. handleCallbackRate saves the rating and asks for a comment if the form was sent in the DM
+func (h *eventFeedbackHandler) handleCallbackRate(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		h.answerAlert(b, callback, "Feedback is only available to club members.")
+		return handlers.EndConversation()
+	}
+
+	eventIDStr, ratingStr, _ := strings.Cut(strings.TrimPrefix(callback.Data, constants.EventFeedbackRatePrefix), "_")
+	eventID, err := strconv.Atoi(eventIDStr)
+	rating, ratingErr := strconv.Atoi(ratingStr)
+	if err != nil || ratingErr != nil || rating < 1 || rating > 5 {
+		h.answerAlert(b, callback, "Unknown rating.")
+		return handlers.EndConversation()
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		h.answerAlert(b, callback, "This event no longer exists.")
+		return handlers.EndConversation()
+	}
+	if event.Status != string(constants.EventStatusFinished) {
+		h.answerAlert(b, callback, "This event hasn't taken place yet.")
+		return handlers.EndConversation()
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		h.answerAlert(b, callback, "An error occurred. Please try again later.")
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventFeedbackRepository.SaveRating(event.ID, user.ID, rating); err != nil {
+		log.Printf("%s: Error saving rating of user %d for event %d: %v", utils.GetCurrentTypeName(), user.ID, event.ID, err)
+		h.answerAlert(b, callback, "An error occurred while saving your rating. Please try again later.")
+		return handlers.EndConversation()
+	}
+
+	// The form in the group is shared by everyone, members can change their rating by pressing another button
+	msg := ctx.EffectiveMessage
+	if msg == nil || msg.Chat.Type != "private" {
+		_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+			Text: fmt.Sprintf("Thanks for your feedback! Your rating: %s", formatters.FormatRatingStars(rating)),
+		})
+		return handlers.EndConversation()
+	}
+
+	_, _ = callback.Answer(b, nil)
+	_ = h.messageSenderService.RemoveInlineKeyboard(msg.Chat.Id, msg.MessageId)
+
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventFeedbackCtxDataKeyEventID, event.ID)
+
+	sentMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		msg.Chat.Id,
+		formatters.FormatHtmlEventFeedbackCommentPrompt(*event, rating),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.EventFeedbackSkipCommentButton(eventFeedbackCallbackSkipComment),
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error asking user %d for a comment: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return handlers.EndConversation()
+	}
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+
+	return handlers.NextConversationState(eventFeedbackStateEnterComment)
+}
+
+// 2. handleCommentEntry saves the comment to the rating
+func (h *eventFeedbackHandler) handleCommentEntry(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	comment := strings.TrimSpace(msg.Text)
+	if comment == "" {
+		h.messageSenderService.Reply(msg, "The comment cannot be empty. Please send it or press Skip.", nil)
+		return nil // Stay in the same state
+	}
+	if len([]rune(comment)) > eventFeedbackCommentMaxLength {
+		h.messageSenderService.Reply(msg, fmt.Sprintf(
+			"The comment is too long, please keep it under %d characters.", eventFeedbackCommentMaxLength), nil)
+		return nil // Stay in the same state
+	}
+
+	eventIDVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventFeedbackCtxDataKeyEventID)
+	eventID, isInt := eventIDVal.(int)
+	if !ok || !isInt {
+		h.messageSenderService.Reply(msg, "Error: event not found in session. Your rating is saved without a comment.", nil)
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return handlers.EndConversation()
+	}
+
+	if err := h.eventFeedbackRepository.SaveComment(eventID, user.ID, comment); err != nil {
+		h.messageSenderService.Reply(msg, "Oops! Something went wrong while saving your comment...", nil)
+		log.Printf("%s: Error saving comment of user %d for event %d: %v", utils.GetCurrentTypeName(), user.ID, eventID, err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.Reply(msg, "\U0001f64f Thanks! Your comment was passed on to the organizers.", nil)
+	return handlers.EndConversation()
+}
+
+// handleCallbackSkip finishes the feedback without a comment
+func (h *eventFeedbackHandler) handleCallbackSkip(b *gotgbot.Bot, ctx *ext.Context) error {
+	_, _ = ctx.Update.CallbackQuery.Answer(b, nil)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	h.messageSenderService.Send(ctx.EffectiveChat.Id, "\U0001f64f Thanks for your feedback!", nil)
+	return handlers.EndConversation()
+}
+
+// 3. handleCancel handles the /cancel command
+func (h *eventFeedbackHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.messageSenderService.Reply(msg, "Got it, your rating is saved without a comment.", nil)
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+func (h *eventFeedbackHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
+
+func (h *eventFeedbackHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			eventFeedbackCtxDataKeyPreviousMessageID,
+			eventFeedbackCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *eventFeedbackHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		eventFeedbackCtxDataKeyPreviousMessageID, eventFeedbackCtxDataKeyPreviousChatID)
+}
@@ -0,0 +1,469 @@
+package privatehandlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	eventProposeStateEnterTitle       = "event_propose_state_enter_title"
+	eventProposeStateSelectType       = "event_propose_state_select_type"
+	eventProposeStateEnterDescription = "event_propose_state_enter_description"
+	eventProposeStateEnterDates       = "event_propose_state_enter_dates"
+	eventProposeStateConfirm          = "event_propose_state_confirm"
+
+	// Context data keys
+	eventProposeCtxDataKeyProposal          = "event_propose_ctx_data_proposal"
+	eventProposeCtxDataKeyPreviousMessageID = "event_propose_ctx_data_previous_message_id"
+	eventProposeCtxDataKeyPreviousChatID    = "event_propose_ctx_data_previous_chat_id"
+
+	// Callback data
+	eventProposeCallbackConfirmCancel = "event_propose_callback_confirm_cancel"
+	eventProposeCallbackSubmit        = "event_propose_callback_submit"
+
+	// eventProposeKeepInput keeps the current value of a field when a proposal is revised
+	eventProposeKeepInput = "-"
+
+	eventProposeTitleMaxLength       = 100
+	eventProposeDescriptionMaxLength = 1000
+	eventProposeDatesMaxLength       = 200
+)
+
+// eventProposeHandler lets members propose an event, the proposal is reviewed by the administrators.
+// The same wizard revises a proposal the administrators asked to change, started from the button in their reply.
+type eventProposeHandler struct {
+	config                  *config.Config
+	eventProposalRepository *repositories.EventProposalRepository
+	eventTypeRepository     *repositories.EventTypeRepository
+	userRepository          *repositories.UserRepository
+	eventProposalService    *services.EventProposalService
+	messageSenderService    *services.MessageSenderService
+	userStore               *utils.UserDataStore
+	permissionsService      *services.PermissionsService
+}
+
+func NewEventProposeHandler(
+	config *config.Config,
+	eventProposalRepository *repositories.EventProposalRepository,
+	eventTypeRepository *repositories.EventTypeRepository,
+	userRepository *repositories.UserRepository,
+	eventProposalService *services.EventProposalService,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &eventProposeHandler{
+		config:                  config,
+		eventProposalRepository: eventProposalRepository,
+		eventTypeRepository:     eventTypeRepository,
+		userRepository:          userRepository,
+		eventProposalService:    eventProposalService,
+		messageSenderService:    messageSenderService,
+		userStore:               utils.NewUserDataStore(),
+		permissionsService:      permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.EventProposeCommand, h.startPropose),
+			handlers.NewCallback(callbackquery.Prefix(constants.EventProposalRevisePrefix), h.handleCallbackRevise),
+		},
+		map[string][]ext.Handler{
+			eventProposeStateEnterTitle: {
+				handlers.NewMessage(message.Text, h.handleTitle),
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventProposeStateSelectType: {
+				handlers.NewMessage(message.Text, h.handleType),
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventProposeStateEnterDescription: {
+				handlers.NewMessage(message.Text, h.handleDescription),
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventProposeStateEnterDates: {
+				handlers.NewMessage(message.Text, h.handleDates),
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			eventProposeStateConfirm: {
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackSubmit), h.handleCallbackSubmit),
+				handlers.NewCallback(callbackquery.Equal(eventProposeCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits:        []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+			AllowReEntry: true,
+		},
+	)
+}
+
+// 1. startPropose is the entry point handler for a new proposal
+func (h *eventProposeHandler) startPropose(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Only proceed if this is a private chat
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return handlers.EndConversation()
+	}
+
+	// Check if user is a club member
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.EventProposeCommand) {
+		return handlers.EndConversation()
+	}
+
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventProposeCtxDataKeyProposal, &repositories.EventProposal{})
+
+	return h.ask(ctx,
+		"\U0001f4a1 Want to run a workshop, a talk or a meetup? Propose it and the administrators will review it.\n\n"+
+			"What's the title of the event?",
+		eventProposeStateEnterTitle,
+	)
+}
+
+// 1.1. handleCallbackRevise starts revising a proposal the administrators asked to change
+func (h *eventProposeHandler) handleCallbackRevise(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		h.answerAlert(b, cb, "This action is only available to club members.")
+		return handlers.EndConversation()
+	}
+
+	proposalID, err := strconv.Atoi(strings.TrimPrefix(cb.Data, constants.EventProposalRevisePrefix))
+	if err != nil {
+		h.answerAlert(b, cb, "Unknown proposal.")
+		return handlers.EndConversation()
+	}
+
+	proposal, err := h.eventProposalRepository.GetByID(proposalID)
+	if err != nil {
+		log.Printf("%s: Error getting event proposal %d: %v", utils.GetCurrentTypeName(), proposalID, err)
+		h.answerAlert(b, cb, "This proposal no longer exists.")
+		return handlers.EndConversation()
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil || user.ID != proposal.UserID || proposal.Status != constants.EventProposalStatusChangesRequested {
+		h.answerAlert(b, cb, "This proposal can't be revised anymore.")
+		return handlers.EndConversation()
+	}
+	_, _ = cb.Answer(b, nil)
+
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, eventProposeCtxDataKeyProposal, proposal)
+
+	return h.ask(ctx,
+		fmt.Sprintf("Let's revise your proposal. Send %s at any step to keep the current value.\n\n"+
+			"Title: %s", eventProposeKeepInput, proposal.Title),
+		eventProposeStateEnterTitle,
+	)
+}
+
+// 2. handleTitle processes the title of the event
+func (h *eventProposeHandler) handleTitle(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	proposal, ok := h.getProposal(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	title, ok := h.parseInput(msg, proposal, proposal.Title, eventProposeTitleMaxLength)
+	if !ok {
+		return nil // Stay in the same state
+	}
+	proposal.Title = title
+
+	eventTypes, err := h.eventTypeRepository.GetAll()
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Sorry, something went wrong. Please try again later.", nil)
+		log.Printf("%s: Error getting event types: %v", utils.GetCurrentTypeName(), err)
+		h.userStore.Clear(ctx.EffectiveUser.Id)
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	eventTypeOptions := []string{}
+	for i, eventType := range eventTypes {
+		eventTypeOptions = append(eventTypeOptions, fmt.Sprintf("/%d. %s %s",
+			i+1, formatters.GetTypeEmoji(eventType), formatters.GetTypeName(eventType)))
+	}
+	prompt := fmt.Sprintf("What kind of event is it? Enter a number:\n%s", strings.Join(eventTypeOptions, "\n"))
+	if h.isRevising(proposal) {
+		prompt += fmt.Sprintf("\n\nCurrent type: %s", formatters.GetTypeName(proposal.TypeInfo))
+	}
+
+	return h.ask(ctx, prompt, eventProposeStateSelectType)
+}
+
+// 3. handleType processes the type of the event
+func (h *eventProposeHandler) handleType(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	proposal, ok := h.getProposal(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	typeSelection := strings.TrimSpace(strings.Replace(msg.Text, "/", "", 1))
+	if !(h.isRevising(proposal) && typeSelection == eventProposeKeepInput) {
+		eventTypes, err := h.eventTypeRepository.GetAll()
+		if err != nil {
+			h.messageSenderService.Reply(msg, "Sorry, something went wrong. Please try again later.", nil)
+			log.Printf("%s: Error getting event types: %v", utils.GetCurrentTypeName(), err)
+			h.userStore.Clear(ctx.EffectiveUser.Id)
+			return handlers.EndConversation()
+		}
+
+		index, err := strconv.Atoi(typeSelection)
+		if err != nil || index < 1 || index > len(eventTypes) {
+			h.messageSenderService.Reply(
+				msg,
+				fmt.Sprintf("Please enter a number from 1 to %d, or use the cancel button.", len(eventTypes)),
+				nil,
+			)
+			return nil // Stay in the same state
+		}
+		// Arrays are 0-indexed but our options start from 1
+		proposal.Type = eventTypes[index-1].Slug
+		proposal.TypeInfo = eventTypes[index-1]
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	return h.ask(ctx, "Describe the event: what it's about, who it's for and what members will get out of it.",
+		eventProposeStateEnterDescription)
+}
+
+// 4. handleDescription processes the description of the event
+func (h *eventProposeHandler) handleDescription(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	proposal, ok := h.getProposal(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	description, ok := h.parseInput(msg, proposal, proposal.Description, eventProposeDescriptionMaxLength)
+	if !ok {
+		return nil // Stay in the same state
+	}
+	proposal.Description = description
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	return h.ask(ctx, "When would you like to run it? Send the preferred dates and times, "+
+		"e.g. \"any Thursday evening in May\" or \"12.05 or 19.05 after 18:00\".",
+		eventProposeStateEnterDates)
+}
+
+// 5. handleDates processes the preferred dates and shows the proposal for confirmation
+func (h *eventProposeHandler) handleDates(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	proposal, ok := h.getProposal(ctx)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	dates, ok := h.parseInput(msg, proposal, proposal.PreferredDates, eventProposeDatesMaxLength)
+	if !ok {
+		return nil // Stay in the same state
+	}
+	proposal.PreferredDates = dates
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		"Here's your proposal:\n\n"+formatters.FormatHtmlEventProposal(*proposal)+"\n\nSend it to the administrators?",
+		&gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: buttons.ConfirmAndCancelButton(eventProposeCallbackSubmit, eventProposeCallbackConfirmCancel),
+		},
+	)
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+
+	return handlers.NextConversationState(eventProposeStateConfirm)
+}
+
+// 6. handleCallbackSubmit saves the proposal and sends it for review
+func (h *eventProposeHandler) handleCallbackSubmit(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	chatID := ctx.EffectiveChat.Id
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	proposal, ok := h.getProposal(ctx)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	if !ok {
+		return handlers.EndConversation()
+	}
+
+	proposalID := proposal.ID
+	if h.isRevising(proposal) {
+		err := h.eventProposalRepository.Revise(
+			proposal.ID, proposal.Title, proposal.Type, proposal.Description, proposal.PreferredDates)
+		if err != nil {
+			h.messageSenderService.Send(chatID, "Could not update the proposal: it has already been reviewed.", nil)
+			log.Printf("%s: Error revising event proposal %d: %v", utils.GetCurrentTypeName(), proposal.ID, err)
+			return handlers.EndConversation()
+		}
+	} else {
+		user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+		if err != nil {
+			h.messageSenderService.Send(chatID, "Oops! Something went wrong...", nil)
+			log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
+			return handlers.EndConversation()
+		}
+
+		proposalID, err = h.eventProposalRepository.Create(
+			user.ID, proposal.Title, proposal.Type, proposal.Description, proposal.PreferredDates)
+		if err != nil {
+			h.messageSenderService.Send(chatID, "Oops! Something went wrong...", nil)
+			log.Printf("%s: Error during event proposal creation: %v", utils.GetCurrentTypeName(), err)
+			return handlers.EndConversation()
+		}
+	}
+
+	if err := h.eventProposalService.SendForReview(proposalID); err != nil {
+		log.Printf("%s: Error sending event proposal %d for review: %v", utils.GetCurrentTypeName(), proposalID, err)
+	}
+
+	h.messageSenderService.Send(
+		chatID,
+		"\u2705 Thanks! Your proposal was sent to the administrators, you'll get their answer here.",
+		nil,
+	)
+	return handlers.EndConversation()
+}
+
+// handleCallbackCancel processes the cancel button click
+func (h *eventProposeHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+// 7. handleCancel handles the /cancel command
+func (h *eventProposeHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.messageSenderService.Reply(msg, "Event proposal canceled.", nil)
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// ask sends the prompt of the next step with the cancel button
+func (h *eventProposeHandler) ask(ctx *ext.Context, prompt string, nextState string) error {
+	sentMsg, _ := h.messageSenderService.SendWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		prompt,
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(eventProposeCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(nextState)
+}
+
+// parseInput validates a text field, a revised proposal keeps the current value on eventProposeKeepInput
+func (h *eventProposeHandler) parseInput(
+	msg *gotgbot.Message,
+	proposal *repositories.EventProposal,
+	current string,
+	maxLength int,
+) (string, bool) {
+	input := strings.TrimSpace(msg.Text)
+	if h.isRevising(proposal) && input == eventProposeKeepInput {
+		return current, true
+	}
+
+	if input == "" || input == eventProposeKeepInput {
+		h.messageSenderService.Reply(msg, "This can't be empty. Please send the text or use the cancel button.", nil)
+		return "", false
+	}
+	if length := utf8.RuneCountInString(input); length > maxLength {
+		h.messageSenderService.Reply(msg, fmt.Sprintf("That's too long (%d characters), please keep it under %d.",
+			length, maxLength), nil)
+		return "", false
+	}
+
+	return input, true
+}
+
+// isRevising reports whether the proposal is an existing one sent back for changes
+func (h *eventProposeHandler) isRevising(proposal *repositories.EventProposal) bool {
+	return proposal.ID != 0
+}
+
+func (h *eventProposeHandler) getProposal(ctx *ext.Context) (*repositories.EventProposal, bool) {
+	proposalVal, ok := h.userStore.Get(ctx.EffectiveUser.Id, eventProposeCtxDataKeyProposal)
+	proposal, isProposal := proposalVal.(*repositories.EventProposal)
+	if !ok || !isProposal {
+		h.messageSenderService.Send(
+			ctx.EffectiveChat.Id,
+			fmt.Sprintf("An error occurred: the proposal was not found. Please start over with /%s.",
+				constants.EventProposeCommand),
+			nil,
+		)
+		return nil, false
+	}
+	return proposal, true
+}
+
+func (h *eventProposeHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
+
+func (h *eventProposeHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			eventProposeCtxDataKeyPreviousMessageID,
+			eventProposeCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *eventProposeHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		eventProposeCtxDataKeyPreviousMessageID, eventProposeCtxDataKeyPreviousChatID)
+}
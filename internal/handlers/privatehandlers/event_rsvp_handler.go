@@ -0,0 +1,269 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// eventRSVPHandler handles the RSVP buttons of event announcements in the group, /events and /myEvents.
+// The buttons live on messages sent outside of any conversation, so the handler is stateless
+// and reads the event ID from the callback data.
+type eventRSVPHandler struct {
+	config               *config.Config
+	eventRepository      *repositories.EventRepository
+	eventRSVPRepository  *repositories.EventRSVPRepository
+	userRepository       *repositories.UserRepository
+	eventRSVPService     *services.EventRSVPService
+	messageSenderService *services.MessageSenderService
+}
+
+func NewEventRSVPHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	eventRSVPRepository *repositories.EventRSVPRepository,
+	userRepository *repositories.UserRepository,
+	eventRSVPService *services.EventRSVPService,
+	messageSenderService *services.MessageSenderService,
+) ext.Handler {
+	h := &eventRSVPHandler{
+		config:               config,
+		eventRepository:      eventRepository,
+		eventRSVPRepository:  eventRSVPRepository,
+		userRepository:       userRepository,
+		eventRSVPService:     eventRSVPService,
+		messageSenderService: messageSenderService,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.EventRSVPPrefix), h.handleCallback)
+}
+
+func (h *eventRSVPHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	data := callback.Data
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		return h.answerAlert(b, callback, "Events are only available to club members.")
+	}
+
+	var action string
+	for _, prefix := range []string{
+		constants.EventRSVPGoingPrefix,
+		constants.EventRSVPMaybePrefix,
+		constants.EventRSVPNotGoingPrefix,
+		constants.EventRSVPViewPrefix,
+		constants.EventRSVPAttendeesPrefix,
+	} {
+		if strings.HasPrefix(data, prefix) {
+			action = prefix
+			break
+		}
+	}
+
+	eventID, err := strconv.Atoi(strings.TrimPrefix(data, action))
+	if action == "" || err != nil {
+		return h.answerAlert(b, callback, "Unknown event.")
+	}
+
+	event, err := h.eventRepository.GetEventByID(eventID)
+	if err != nil {
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		return h.answerAlert(b, callback, "This event no longer exists.")
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return h.answerAlert(b, callback, "An error occurred. Please try again later.")
+	}
+
+	switch action {
+	case constants.EventRSVPGoingPrefix:
+		return h.handleRespond(b, ctx, event, user, constants.EventRSVPStatusGoing)
+	case constants.EventRSVPMaybePrefix:
+		return h.handleRespond(b, ctx, event, user, constants.EventRSVPStatusMaybe)
+	case constants.EventRSVPNotGoingPrefix:
+		return h.handleRespond(b, ctx, event, user, constants.EventRSVPStatusNotGoing)
+	case constants.EventRSVPViewPrefix:
+		return h.handleView(b, ctx, event, user)
+	default:
+		return h.handleAttendees(b, ctx, event, user)
+	}
+}
+
+func (h *eventRSVPHandler) handleRespond(
+	b *gotgbot.Bot,
+	ctx *ext.Context,
+	event *repositories.Event,
+	user *repositories.User,
+	status constants.EventRSVPStatus,
+) error {
+	callback := ctx.Update.CallbackQuery
+
+	if event.Status != string(constants.EventStatusActual) {
+		return h.answerAlert(b, callback, "This event has already taken place.")
+	}
+
+	result, err := h.eventRSVPService.Respond(event, user.ID, status)
+	if err != nil {
+		log.Printf("%s: Error saving response of user %d to event %d: %v", utils.GetCurrentTypeName(), user.ID, event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while saving your response. Please try again later.")
+	}
+
+	var answer string
+	switch result.Status {
+	case constants.EventRSVPStatusGoing:
+		answer = "✅ You're going! See you there."
+	case constants.EventRSVPStatusWaitlist:
+		answer = fmt.Sprintf("⏳ The event is full, you're #%d on the waitlist. "+
+			"We'll send you a message as soon as a seat opens up.", result.WaitlistPosition)
+	case constants.EventRSVPStatusMaybe:
+		answer = "\U0001f914 Marked as maybe."
+	default:
+		answer = "❌ Got it, you're not going."
+	}
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      answer,
+		ShowAlert: result.Status == constants.EventRSVPStatusWaitlist,
+	})
+
+	h.refreshEventCard(b, ctx, event, user)
+	return nil
+}
+
+// handleView sends the event card with the RSVP buttons to the member's DM
+func (h *eventRSVPHandler) handleView(b *gotgbot.Bot, ctx *ext.Context, event *repositories.Event, user *repositories.User) error {
+	callback := ctx.Update.CallbackQuery
+
+	text, markup, err := h.buildEventCard(b, event, user, true)
+	if err != nil {
+		log.Printf("%s: Error building card of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while loading the event.")
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return h.messageSenderService.SendHtml(ctx.EffectiveUser.Id, text, &gotgbot.SendMessageOpts{
+		ReplyMarkup: markup,
+	})
+}
+
+// handleAttendees sends the attendee list to the member's DM, only for the event host and administrators
+func (h *eventRSVPHandler) handleAttendees(b *gotgbot.Bot, ctx *ext.Context, event *repositories.Event, user *repositories.User) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !h.canSeeAttendees(b, event, user) {
+		return h.answerAlert(b, callback, "The attendee list is only available to the host and administrators.")
+	}
+
+	attendees, err := h.eventRSVPRepository.GetAttendees(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting attendees of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while loading the attendee list.")
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return h.messageSenderService.SendHtml(
+		ctx.EffectiveUser.Id,
+		formatters.FormatHtmlEventAttendees(*event, attendees, h.config.EventsTimezone, time.Now()),
+		nil,
+	)
+}
+
+// refreshEventCard re-renders the message the button was pressed on, so it shows the updated responses.
+// Group messages keep their own text (announcement, reminder or topic card) and only the RSVP buttons,
+// while cards in the DM show the member's response and may show the attendees button.
+func (h *eventRSVPHandler) refreshEventCard(b *gotgbot.Bot, ctx *ext.Context, event *repositories.Event, user *repositories.User) {
+	msg := ctx.EffectiveMessage
+	if msg == nil {
+		return
+	}
+
+	var text string
+	var markup gotgbot.InlineKeyboardMarkup
+	if msg.Chat.Type == "private" {
+		var err error
+		text, markup, err = h.buildEventCard(b, event, user, true)
+		if err != nil {
+			log.Printf("%s: Error building card of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			return
+		}
+	} else {
+		counts, err := h.eventRSVPRepository.GetCounts(event.ID)
+		if err != nil {
+			log.Printf("%s: Error getting responses of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			return
+		}
+		text = formatters.FormatHtmlGroupEventMessage(msg.Text, *event, counts, h.config.EventsTimezone, time.Now())
+		markup = buttons.EventRSVPButtons(event.ID)
+	}
+
+	_, _, err := b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+		ChatId:             msg.Chat.Id,
+		MessageId:          msg.MessageId,
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        markup,
+	})
+	// Pressing the same button twice doesn't change the message
+	if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("%s: Error refreshing card of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+}
+
+// buildEventCard renders the event card with the member's own response if withUserStatus is set
+func (h *eventRSVPHandler) buildEventCard(
+	b *gotgbot.Bot,
+	event *repositories.Event,
+	user *repositories.User,
+	withUserStatus bool,
+) (string, gotgbot.InlineKeyboardMarkup, error) {
+	counts, err := h.eventRSVPRepository.GetCounts(event.ID)
+	if err != nil {
+		return "", gotgbot.InlineKeyboardMarkup{}, err
+	}
+
+	text := formatters.FormatHtmlEventCard(*event, counts, h.config.EventsTimezone, time.Now())
+	if withUserStatus {
+		status, err := h.eventRSVPRepository.GetUserStatus(event.ID, user.ID)
+		if err != nil {
+			return "", gotgbot.InlineKeyboardMarkup{}, err
+		}
+		if status != "" {
+			text += fmt.Sprintf("\n<i>Your response</i>: %s", formatters.GetRSVPStatusLabel(status))
+		}
+	}
+	return text, buttons.EventCardButtons(
+		event.ID,
+		event.StartedAt != nil && !event.StartedAt.IsZero(),
+		h.canSeeAttendees(b, event, user),
+	), nil
+}
+
+func (h *eventRSVPHandler) canSeeAttendees(b *gotgbot.Bot, event *repositories.Event, user *repositories.User) bool {
+	if event.HostUserID != nil && *event.HostUserID == user.ID {
+		return true
+	}
+	return utils.IsUserAdminOrCreator(b, user.TgID, h.config)
+}
+
+func (h *eventRSVPHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
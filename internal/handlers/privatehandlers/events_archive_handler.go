@@ -0,0 +1,83 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// eventsArchiveHandler handles the past events button of /events: the last finished events
+// with links to their recordings, slides and links in the Content topic
+type eventsArchiveHandler struct {
+	config                  *config.Config
+	eventRepository         *repositories.EventRepository
+	eventMaterialRepository *repositories.EventMaterialRepository
+	messageSenderService    *services.MessageSenderService
+}
+
+func NewEventsArchiveHandler(
+	config *config.Config,
+	eventRepository *repositories.EventRepository,
+	eventMaterialRepository *repositories.EventMaterialRepository,
+	messageSenderService *services.MessageSenderService,
+) ext.Handler {
+	h := &eventsArchiveHandler{
+		config:                  config,
+		eventRepository:         eventRepository,
+		eventMaterialRepository: eventMaterialRepository,
+		messageSenderService:    messageSenderService,
+	}
+
+	return handlers.NewCallback(callbackquery.Equal(constants.EventsArchiveCallback), h.handleCallback)
+}
+
+func (h *eventsArchiveHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		return h.answerAlert(b, callback, "Events are only available to club members.")
+	}
+
+	events, err := h.eventRepository.GetLastFinishedEvents(constants.EventsArchiveLimit)
+	if err != nil {
+		log.Printf("%s: Error during finished events retrieval: %v", utils.GetCurrentTypeName(), err)
+		return h.answerAlert(b, callback, "An error occurred while loading the past events.")
+	}
+	if len(events) == 0 {
+		return h.answerAlert(b, callback, "There are no past events yet.")
+	}
+
+	materials := make(map[int][]repositories.EventMaterial, len(events))
+	for _, event := range events {
+		eventMaterials, err := h.eventMaterialRepository.GetByEventID(event.ID)
+		if err != nil {
+			log.Printf("%s: Error getting materials of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			continue
+		}
+		materials[event.ID] = eventMaterials
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return h.messageSenderService.SendHtml(
+		ctx.EffectiveUser.Id,
+		formatters.FormatHtmlEventsArchive(events, materials, h.config.SuperGroupChatID, h.config.ContentTopicID, h.config.EventsTimezone),
+		nil,
+	)
+}
+
+func (h *eventsArchiveHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
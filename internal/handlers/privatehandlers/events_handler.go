@@ -1,6 +1,7 @@
 package privatehandlers
 
 import (
+	"evo-bot-go/internal/buttons"
 	"evo-bot-go/internal/config"
 	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
@@ -18,6 +19,8 @@ import (
 type eventsHandler struct {
 	config               *config.Config
 	eventRepository      *repositories.EventRepository
+	eventRSVPRepository  *repositories.EventRSVPRepository
+	eventCalendarService *services.EventCalendarService
 	messageSenderService *services.MessageSenderService
 	permissionsService   *services.PermissionsService
 }
@@ -25,12 +28,16 @@ type eventsHandler struct {
 func NewEventsHandler(
 	config *config.Config,
 	eventRepository *repositories.EventRepository,
+	eventRSVPRepository *repositories.EventRSVPRepository,
+	eventCalendarService *services.EventCalendarService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
 	h := &eventsHandler{
 		config:               config,
 		eventRepository:      eventRepository,
+		eventRSVPRepository:  eventRSVPRepository,
+		eventCalendarService: eventCalendarService,
 		messageSenderService: messageSenderService,
 		permissionsService:   permissionsService,
 	}
@@ -60,19 +67,36 @@ func (h *eventsHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
 	}
 
 	if len(events) == 0 {
-		h.messageSenderService.Reply(msg, "There are no upcoming events at the moment.", nil)
+		h.messageSenderService.Reply(msg, "There are no upcoming events at the moment.", &gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.EventsArchiveButton(),
+		})
 		return nil
 	}
 
+	counts := make(map[int]repositories.EventRSVPCounts, len(events))
+	for _, event := range events {
+		eventCounts, err := h.eventRSVPRepository.GetCounts(event.ID)
+		if err != nil {
+			log.Printf("%s: Error getting responses of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			continue
+		}
+		counts[event.ID] = eventCounts
+	}
+
 	// Format and display event list
-	formattedEvents := formatters.FormatEventListForEventsView(
+	formattedEvents := formatters.FormatHtmlEventListForEventsView(
 		events,
+		counts,
 		"📋 Upcoming Events",
+		h.config.EventsTimezone,
 	)
 	formattedEvents += fmt.Sprintf("\nAdd topics and questions /%s. ", constants.TopicAddCommand)
 	formattedEvents += fmt.Sprintf("View topics and questions /%s. ", constants.TopicsCommand)
-	formattedEvents += "For more event information, check the group for updates."
-	h.messageSenderService.ReplyMarkdown(msg, formattedEvents, nil)
+	formattedEvents += fmt.Sprintf("Your events /%s.\n\n", constants.MyEventsCommand)
+	formattedEvents += "Tap an event below to see the details and let us know if you're coming."
+	h.messageSenderService.ReplyHtml(msg, formattedEvents, &gotgbot.SendMessageOpts{
+		ReplyMarkup: buttons.EventsListButtons(events, h.eventCalendarService.IsFeedEnabled(), true),
+	})
 
 	return nil
 }
@@ -355,12 +355,20 @@ func (h *introHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func (h *introHandler) prepareProfileData() ([]byte, error) {
 	type ProfileData struct {
-		ID        int    `json:"id"`
-		Firstname string `json:"firstname"`
-		Lastname  string `json:"lastname"`
-		Username  string `json:"username"`
-		Bio       string `json:"bio"`
-		MessageId *int64 `json:"message_id"`
+		ID          int      `json:"id"`
+		Firstname   string   `json:"firstname"`
+		Lastname    string   `json:"lastname"`
+		Username    string   `json:"username"`
+		Bio         string   `json:"bio"`
+		Role        string   `json:"role,omitempty"`
+		Company     string   `json:"company,omitempty"`
+		Skills      []string `json:"skills,omitempty"`
+		City        string   `json:"city,omitempty"`
+		Timezone    string   `json:"timezone,omitempty"`
+		Links       []string `json:"links,omitempty"`
+		LookingFor  string   `json:"looking_for,omitempty"`
+		CanHelpWith string   `json:"can_help_with,omitempty"`
+		MessageId   *int64   `json:"message_id"`
 	}
 
 	// Get all profiles with users from the repository
@@ -373,13 +381,32 @@ func (h *introHandler) prepareProfileData() ([]byte, error) {
 	for _, pwu := range profilesWithUsers {
 		// Only include profiles with non-empty bios
 		if pwu.Profile.Bio != "" {
+			var skills []string
+			if pwu.Profile.Skills != "" {
+				skills = strings.Split(pwu.Profile.Skills, ", ")
+			}
+			var links []string
+			for _, link := range []string{pwu.Profile.GitHubURL, pwu.Profile.LinkedInURL} {
+				if link != "" {
+					links = append(links, link)
+				}
+			}
+
 			profileData = append(profileData, ProfileData{
-				ID:        pwu.Profile.ID,
-				Firstname: pwu.User.Firstname,
-				Lastname:  pwu.User.Lastname,
-				Username:  pwu.User.TgUsername,
-				Bio:       pwu.Profile.Bio,
-				MessageId: &pwu.Profile.PublishedMessageID.Int64,
+				ID:          pwu.Profile.ID,
+				Firstname:   pwu.User.Firstname,
+				Lastname:    pwu.User.Lastname,
+				Username:    pwu.User.TgUsername,
+				Bio:         pwu.Profile.Bio,
+				Role:        pwu.Profile.Role,
+				Company:     pwu.Profile.Company,
+				Skills:      skills,
+				City:        pwu.Profile.City,
+				Timezone:    pwu.Profile.Timezone,
+				Links:       links,
+				LookingFor:  pwu.Profile.LookingFor,
+				CanHelpWith: pwu.Profile.CanHelpWith,
+				MessageId:   &pwu.Profile.PublishedMessageID.Int64,
 			})
 		}
 	}
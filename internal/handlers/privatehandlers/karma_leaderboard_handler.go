@@ -0,0 +1,78 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+)
+
+type karmaLeaderboardHandler struct {
+	config               *config.Config
+	karmaRepository      *repositories.KarmaRepository
+	userRepository       *repositories.UserRepository
+	messageSenderService *services.MessageSenderService
+	permissionsService   *services.PermissionsService
+}
+
+func NewKarmaLeaderboardHandler(
+	config *config.Config,
+	karmaRepository *repositories.KarmaRepository,
+	userRepository *repositories.UserRepository,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &karmaLeaderboardHandler{
+		config:               config,
+		karmaRepository:      karmaRepository,
+		userRepository:       userRepository,
+		messageSenderService: messageSenderService,
+		permissionsService:   permissionsService,
+	}
+
+	return handlers.NewCommand(constants.KarmaLeaderboardCommand, h.handleCommand)
+}
+
+func (h *karmaLeaderboardHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Only proceed if this is a private chat
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return nil
+	}
+
+	// Check if user is a club member
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.KarmaLeaderboardCommand) {
+		return nil
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the leaderboard.", nil)
+		log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	users, err := h.karmaRepository.GetLeaderboard(constants.KarmaLeaderboardLimit)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving the leaderboard.", nil)
+		log.Printf("%s: Error getting karma leaderboard: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	rank, err := h.karmaRepository.GetRank(user.Score)
+	if err != nil {
+		log.Printf("%s: Error getting karma rank of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatHtmlKarmaLeaderboard(users, user, rank), nil)
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"log"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+)
+
+type myEventsHandler struct {
+	config               *config.Config
+	eventRSVPRepository  *repositories.EventRSVPRepository
+	userRepository       *repositories.UserRepository
+	messageSenderService *services.MessageSenderService
+	permissionsService   *services.PermissionsService
+}
+
+func NewMyEventsHandler(
+	config *config.Config,
+	eventRSVPRepository *repositories.EventRSVPRepository,
+	userRepository *repositories.UserRepository,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &myEventsHandler{
+		config:               config,
+		eventRSVPRepository:  eventRSVPRepository,
+		userRepository:       userRepository,
+		messageSenderService: messageSenderService,
+		permissionsService:   permissionsService,
+	}
+
+	return handlers.NewCommand(constants.MyEventsCommand, h.handleCommand)
+}
+
+func (h *myEventsHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Only proceed if this is a private chat
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return nil
+	}
+
+	// Check if user is a club member
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.MyEventsCommand) {
+		return nil
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your events.", nil)
+		log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	responses, err := h.eventRSVPRepository.GetUpcomingResponsesForUser(user.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your events.", nil)
+		log.Printf("%s: Error getting responses of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return nil
+	}
+
+	opts := &gotgbot.SendMessageOpts{}
+	if len(responses) > 0 {
+		events := make([]repositories.Event, 0, len(responses))
+		for _, rsvp := range responses {
+			events = append(events, rsvp.Event)
+		}
+		opts.ReplyMarkup = buttons.EventsListButtons(events, false, false)
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatHtmlMyEvents(responses, h.config.EventsTimezone, time.Now()), opts)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"html"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// onboardingHandler handles the buttons of the onboarding checklist. The checklist is sent on join
+// and by a task, so the handler is stateless and refreshes the checklist message after every step.
+type onboardingHandler struct {
+	config               *config.Config
+	messageSenderService *services.MessageSenderService
+	onboardingService    *services.OnboardingService
+	userRepository       *repositories.UserRepository
+	onboardingRepository *repositories.OnboardingRepository
+}
+
+func NewOnboardingHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	onboardingService *services.OnboardingService,
+	userRepository *repositories.UserRepository,
+	onboardingRepository *repositories.OnboardingRepository,
+) ext.Handler {
+	h := &onboardingHandler{
+		config:               config,
+		messageSenderService: messageSenderService,
+		onboardingService:    onboardingService,
+		userRepository:       userRepository,
+		onboardingRepository: onboardingRepository,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.OnboardingPrefix), h.handleCallback)
+}
+
+func (h *onboardingHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+
+	dbUser, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return h.answer(b, callback, "Something went wrong, please try again later.")
+	}
+
+	switch callback.Data {
+	case constants.OnboardingRulesCallback:
+		if !h.onboardingService.RulesRequired() {
+			return h.answer(b, callback, "There are no rules to read, you're all set.")
+		}
+		if err := h.onboardingRepository.MarkRulesRead(dbUser.ID); err != nil {
+			log.Printf("%s: Error marking rules as read for user %d: %v", utils.GetCurrentTypeName(), dbUser.ID, err)
+		}
+		h.messageSenderService.SendHtml(ctx.EffectiveChat.Id,
+			fmt.Sprintf("\U0001f4dc <a href=\"%s\">Here are the club rules</a>, please give them a read.", html.EscapeString(h.config.OnboardingRulesLink)),
+			nil)
+	case constants.OnboardingCourseCallback:
+		if err := h.onboardingRepository.MarkCourseOpened(dbUser.ID); err != nil {
+			log.Printf("%s: Error marking course as opened for user %d: %v", utils.GetCurrentTypeName(), dbUser.ID, err)
+		}
+		h.messageSenderService.SendHtml(ctx.EffectiveChat.Id,
+			"\U0001f4d6 The AI course has 42 topics, open it with the button below.",
+			&gotgbot.SendMessageOpts{ReplyMarkup: buttons.OnboardingCourseButtons()})
+	}
+
+	onboarding, err := h.onboardingService.CheckProgress(dbUser)
+	if err != nil {
+		log.Printf("%s: Error checking onboarding of user %d: %v", utils.GetCurrentTypeName(), dbUser.ID, err)
+		return h.answer(b, callback, "Something went wrong, please try again later.")
+	}
+	if onboarding == nil {
+		return h.answer(b, callback, "Your onboarding hasn't started, use /help to see what I can do.")
+	}
+
+	h.updateChecklist(b, ctx.EffectiveMessage, dbUser, onboarding)
+
+	if onboarding.CompletedAt.Valid {
+		return h.answer(b, callback, "All done, welcome aboard!")
+	}
+	return h.answer(b, callback, "Progress updated.")
+}
+
+// updateChecklist refreshes the ticks of the checklist message, the buttons are removed once it's completed
+func (h *onboardingHandler) updateChecklist(b *gotgbot.Bot, msg *gotgbot.Message, user *repositories.User, onboarding *repositories.Onboarding) {
+	markup := buttons.OnboardingButtons(h.onboardingService.RulesRequired())
+	if onboarding.CompletedAt.Valid {
+		markup = gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{}}
+	}
+
+	_, _, err := b.EditMessageText(
+		formatters.FormatOnboardingChecklist(user, onboarding, h.onboardingService.RulesRequired(), false),
+		&gotgbot.EditMessageTextOpts{
+			ChatId:      msg.Chat.Id,
+			MessageId:   msg.MessageId,
+			ParseMode:   "HTML",
+			ReplyMarkup: markup,
+		},
+	)
+	// Telegram refuses edits that don't change the message, which is expected when nothing was done
+	if err != nil {
+		log.Printf("%s: Error updating the onboarding checklist: %v", utils.GetCurrentTypeName(), err)
+	}
+}
+
+func (h *onboardingHandler) answer(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: text})
+	return err
+}
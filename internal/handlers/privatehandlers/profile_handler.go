@@ -7,28 +7,36 @@ import (
 	"evo-bot-go/internal/clients"
 	"evo-bot-go/internal/config"
 	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/prompts"
 	"evo-bot-go/internal/database/repositories"
 	"evo-bot-go/internal/formatters"
 	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
 	"fmt"
+	"html"
+	"log"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+	"github.com/openai/openai-go/v2"
 )
 
 const (
 	// Conversation states
 	profileStateViewOptions         = "profile_state_view_options"
-	profileStateEditMyProfile       = "profile_state_edit_my_profile"
 	profileStateAwaitQueryForSearch = "profile_state_await_query_for_search"
 	profileStateAwaitBio            = "profile_state_await_bio"
 	profileStateAwaitFirstname      = "profile_state_await_firstname"
 	profileStateAwaitLastname       = "profile_state_await_lastname"
+	profileStateAwaitProfileField   = "profile_state_await_profile_field"
+	profileStateAwaitBioAssistInput = "profile_state_await_bio_assist_input"
+	profileStateBioDraft            = "profile_state_bio_draft"
 
 	// UserStore keys
 	profileCtxDataKeyField                   = "profile_ctx_data_field"
@@ -36,6 +44,12 @@ const (
 	profileCtxDataKeyPreviousChatID          = "profile_ctx_data_previous_chat_id"
 	profileCtxDataKeyLastMessageTimeFromUser = "profile_ctx_data_last_message_time_from_user"
 	profileCtxDataKeyCancelFunc              = "profile_ctx_data_key_cancel_func"
+	profileCtxDataKeyProfileField            = "profile_ctx_data_profile_field"
+	profileCtxDataKeyBioAssistSource         = "profile_ctx_data_bio_assist_source"
+	profileCtxDataKeyBioDraft                = "profile_ctx_data_bio_draft"
+
+	// profileBioDraftMaxAttempts limits the requests for one bio draft, including the requests to shorten it
+	profileBioDraftMaxAttempts = 3
 
 	// Menu headers
 	profileMenuHeader              = "Profile Menu"
@@ -43,6 +57,8 @@ const (
 	profileMenuEditFirstnameHeader = "Profile → Edit → First Name"
 	profileMenuEditLastnameHeader  = "Profile → Edit → Last Name"
 	profileMenuEditBioHeader       = "Profile → Edit → Bio"
+	profileMenuEditBioAssistHeader = "Profile → Edit → Bio → Help"
+	profileMenuEditBioDraftHeader  = "Profile → Edit → Bio → Draft"
 	profileMenuSearchHeader        = "Profile → Search"
 )
 
@@ -53,6 +69,7 @@ type profileHandler struct {
 	profileService              *services.ProfileService
 	userRepository              *repositories.UserRepository
 	profileRepository           *repositories.ProfileRepository
+	badgeRepository             *repositories.BadgeRepository
 	promptingTemplateRepository *repositories.PromptingTemplateRepository
 	openaiClient                *clients.OpenAiClient
 	userStore                   *utils.UserDataStore
@@ -65,6 +82,7 @@ func NewProfileHandler(
 	profileService *services.ProfileService,
 	userRepository *repositories.UserRepository,
 	profileRepository *repositories.ProfileRepository,
+	badgeRepository *repositories.BadgeRepository,
 	promptingTemplateRepository *repositories.PromptingTemplateRepository,
 	openaiClient *clients.OpenAiClient,
 ) ext.Handler {
@@ -75,6 +93,7 @@ func NewProfileHandler(
 		profileService:              profileService,
 		userRepository:              userRepository,
 		profileRepository:           profileRepository,
+		badgeRepository:             badgeRepository,
 		promptingTemplateRepository: promptingTemplateRepository,
 		openaiClient:                openaiClient,
 		userStore:                   utils.NewUserDataStore(),
@@ -97,6 +116,21 @@ func NewProfileHandler(
 			profileStateAwaitBio: {
 				handlers.NewMessage(message.Text, h.handleBioInput),
 				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditMyProfileCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileBioAssistCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileFullCancel), h.handleCallbackCancel),
+			},
+			profileStateAwaitBioAssistInput: {
+				handlers.NewMessage(message.Text, h.handleBioAssistInput),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditBioCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileFullCancel), h.handleCallbackCancel),
+			},
+			profileStateBioDraft: {
+				// An edited draft is sent back as text and saved like a bio written from scratch
+				handlers.NewMessage(message.Text, h.handleBioInput),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileBioDraftSaveCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileBioDraftRegenerateCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditBioCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditMyProfileCallback), h.handleCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.ProfileFullCancel), h.handleCallbackCancel),
 			},
 			profileStateAwaitFirstname: {
@@ -109,6 +143,11 @@ func NewProfileHandler(
 				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditMyProfileCallback), h.handleCallback),
 				handlers.NewCallback(callbackquery.Equal(constants.ProfileFullCancel), h.handleCallbackCancel),
 			},
+			profileStateAwaitProfileField: {
+				handlers.NewMessage(message.Text, h.handleProfileFieldInput),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileEditMyProfileCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.ProfileFullCancel), h.handleCallbackCancel),
+			},
 		},
 		&handlers.ConversationOpts{
 			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
@@ -130,6 +169,10 @@ func (h *profileHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error
 
 	effectiveMsg := ctx.EffectiveMessage
 
+	if field, ok := strings.CutPrefix(data, constants.ProfileEditFieldPrefix); ok {
+		return h.handleEditProfileField(b, ctx, effectiveMsg, constants.ProfileField(field))
+	}
+
 	switch data {
 	case constants.ProfileEditMyProfileCallback:
 		return h.handleEditMyProfile(b, ctx, effectiveMsg)
@@ -137,6 +180,12 @@ func (h *profileHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error
 		return h.handleSearchProfile(b, ctx, effectiveMsg)
 	case constants.ProfileEditBioCallback:
 		return h.handleEditField(b, ctx, effectiveMsg, fmt.Sprintf("your updated bio (up to %d characters)", constants.ProfileBioLengthLimit), profileStateAwaitBio)
+	case constants.ProfileBioAssistCallback:
+		return h.handleBioAssist(b, ctx, effectiveMsg)
+	case constants.ProfileBioDraftRegenerateCallback:
+		return h.draftBio(b, ctx, effectiveMsg.Chat.Id)
+	case constants.ProfileBioDraftSaveCallback:
+		return h.handleSaveBioDraft(b, ctx, effectiveMsg)
 	case constants.ProfileEditFirstnameCallback:
 		return h.handleEditField(b, ctx, effectiveMsg, "your new first name", profileStateAwaitFirstname)
 	case constants.ProfileEditLastnameCallback:
@@ -166,9 +215,15 @@ func (h *profileHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
 	firstNameString := "└ ❌ First Name"
 	lastNameString := "└ ❌ Last Name"
 	bioString := "└ ❌ Bio"
+	optionalFieldsString := ""
 	profileLinkString := ""
+	karmaString := ""
 	dbUser, err := h.userRepository.GetOrCreate(user)
 	if err == nil {
+		karmaString = fmt.Sprintf("\n\nYour karma: <b>%d</b> (/%s)", dbUser.Score, constants.KarmaLeaderboardCommand)
+		if badges, err := h.badgeRepository.GetByUserID(dbUser.ID); err == nil && len(badges) > 0 {
+			karmaString += "\nYour badges: " + formatters.FormatBadges(badges)
+		}
 		if dbUser.Firstname != "" {
 			firstNameString = "└ ✅ First Name" + " <i>(" + dbUser.Firstname + ")</i>"
 		}
@@ -186,6 +241,14 @@ func (h *profileHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
 				if profile.Bio != "" {
 					bioString = "└ ✅ Bio"
 				}
+				filledFields := 0
+				for _, field := range constants.AllProfileFields {
+					if profile.FieldValue(field) != "" {
+						filledFields++
+					}
+				}
+				optionalFieldsString = fmt.Sprintf("\n\nOptional details <i>(role, skills, links...)</i>: %d of %d filled in",
+					filledFields, len(constants.AllProfileFields))
 			}
 		}
 	}
@@ -202,6 +265,8 @@ func (h *profileHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
 		lastNameString +
 		"\n" +
 		bioString +
+		optionalFieldsString +
+		karmaString +
 		"\n\n" +
 		profileLinkString
 
@@ -311,7 +376,12 @@ func (h *profileHandler) handleSearchProfileInput(b *gotgbot.Bot, ctx *ext.Conte
 
 	h.RemovePreviousMessage(b, &userId)
 	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
-	profileText := fmt.Sprintf("<b>%s</b>\n\n%s", profileMenuSearchHeader, formatters.FormatProfileView(dbUser, profile, false))
+	badges, err := h.badgeRepository.GetByUserID(dbUser.ID)
+	if err != nil {
+		log.Printf("%s: Error getting badges in handleSearchProfileInput: %v", utils.GetCurrentTypeName(), err)
+	}
+
+	profileText := fmt.Sprintf("<b>%s</b>\n\n%s", profileMenuSearchHeader, formatters.FormatProfileView(dbUser, profile, badges, true))
 	editedMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
 		msg.Chat.Id,
 		profileText,
@@ -363,6 +433,11 @@ func (h *profileHandler) handleEditField(b *gotgbot.Bot, ctx *ext.Context, msg *
 		oldFieldValue = "not set"
 	}
 
+	replyMarkup := buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback)
+	if nextState == profileStateAwaitBio {
+		replyMarkup = buttons.ProfileBioEditButtons(constants.ProfileEditMyProfileCallback)
+	}
+
 	h.RemovePreviousMessage(b, &user.Id)
 	editedMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
 		msg.Chat.Id,
@@ -370,7 +445,7 @@ func (h *profileHandler) handleEditField(b *gotgbot.Bot, ctx *ext.Context, msg *
 			fmt.Sprintf("\n\n%s", oldFieldValue)+
 			fmt.Sprintf("\n\nEnter %s:", fieldName),
 		&gotgbot.SendMessageOpts{
-			ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback),
+			ReplyMarkup: replyMarkup,
 		})
 
 	if err != nil {
@@ -414,31 +489,221 @@ func (h *profileHandler) handleBioInput(b *gotgbot.Bot, ctx *ext.Context) error
 		return nil
 	}
 
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	return h.saveBio(b, ctx, msg.Chat.Id, bio)
+}
+
+// saveBio saves the bio, publishes the profile if it is complete and shows the confirmation
+func (h *profileHandler) saveBio(b *gotgbot.Bot, ctx *ext.Context, chatID int64, bio string) error {
+	userId := ctx.EffectiveUser.Id
+
 	err := h.saveProfileField(ctx.EffectiveUser, "bio", bio)
 	if err != nil {
-		_ = h.messageSenderService.ReplyMarkdown(msg,
-			fmt.Sprintf("*%s*", profileMenuEditBioHeader)+
+		_ = h.messageSenderService.SendHtml(chatID,
+			fmt.Sprintf("<b>%s</b>", profileMenuEditBioHeader)+
 				"\n\nAn error occurred while saving the bio.", nil)
-		return fmt.Errorf("%s: failed to save bio in handleBioInput: %w", utils.GetCurrentTypeName(), err)
+		return fmt.Errorf("%s: failed to save bio in saveBio: %w", utils.GetCurrentTypeName(), err)
 	}
 
+	h.userStore.Set(userId, profileCtxDataKeyBioAssistSource, "")
+	h.userStore.Set(userId, profileCtxDataKeyBioDraft, "")
+
 	// Try to publish profile if it is complete
 	profilePublishedMessage, _ := h.tryToPublishProfile(b, ctx, true)
 
-	h.RemovePreviousMessage(b, &msg.From.Id)
-	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
-	sendMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(msg.Chat.Id,
-		fmt.Sprintf("*%s*", profileMenuEditBioHeader)+
+	h.RemovePreviousMessage(b, &userId)
+	sendMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(chatID,
+		fmt.Sprintf("<b>%s</b>", profileMenuEditBioHeader)+
 			"\n\n✅ Bio saved!"+profilePublishedMessage,
 		&gotgbot.SendMessageOpts{
 			ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback),
 		})
 	if err != nil {
-		return fmt.Errorf("%s: failed to send message in handleBioInput: %w", utils.GetCurrentTypeName(), err)
+		return fmt.Errorf("%s: failed to send message in saveBio: %w", utils.GetCurrentTypeName(), err)
 	}
 
-	h.SavePreviousMessageInfo(msg.From.Id, sendMsg)
-	return handlers.NextConversationState(profileStateEditMyProfile)
+	h.SavePreviousMessageInfo(userId, sendMsg)
+	return handlers.NextConversationState(profileStateViewOptions)
+}
+
+// handleBioAssist asks for the answers or the pasted CV that the bio is drafted from
+func (h *profileHandler) handleBioAssist(b *gotgbot.Bot, ctx *ext.Context, msg *gotgbot.Message) error {
+	userId := ctx.EffectiveUser.Id
+
+	h.RemovePreviousMessage(b, &userId)
+	sentMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		msg.Chat.Id,
+		fmt.Sprintf("<b>%s</b>", profileMenuEditBioAssistHeader)+
+			"\n\nAnswer a few short questions in one message:"+
+			"\n1. What do you do, and where?"+
+			"\n2. What are you working on or learning right now?"+
+			"\n3. What can you help others with, and what are you looking for in the club?"+
+			"\n4. Anything else you'd like to share, e.g. hobbies?"+
+			"\n\nOr just paste the text of your CV or LinkedIn profile, and I'll draft a bio from it.",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditBioCallback),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in handleBioAssist: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(userId, sentMsg)
+	return handlers.NextConversationState(profileStateAwaitBioAssistInput)
+}
+
+// handleBioAssistInput keeps the answers for regenerating and drafts the bio from them
+func (h *profileHandler) handleBioAssistInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	h.userStore.Set(msg.From.Id, profileCtxDataKeyBioAssistSource, strings.TrimSpace(msg.Text))
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+
+	return h.draftBio(b, ctx, msg.Chat.Id)
+}
+
+// draftBio asks OpenAI for a bio based on the stored answers and the structured fields, and shows the draft
+func (h *profileHandler) draftBio(b *gotgbot.Bot, ctx *ext.Context, chatID int64) error {
+	userId := ctx.EffectiveUser.Id
+
+	source, _ := h.userStore.Get(userId, profileCtxDataKeyBioAssistSource)
+	sourceText, _ := source.(string)
+	if sourceText == "" {
+		return h.handleBioAssist(b, ctx, ctx.EffectiveMessage)
+	}
+
+	dbUser, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in draftBio: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	profile, err := h.profileRepository.GetOrCreate(dbUser.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get/create profile in draftBio: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	templateText, err := h.promptingTemplateRepository.Get(prompts.GetProfileBioPromptKey, prompts.GetProfileBioPromptDefaultValue)
+	if err != nil {
+		h.messageSenderService.Send(chatID, "An error occurred while retrieving the bio template.", nil)
+		return fmt.Errorf("%s: failed to get bio template in draftBio: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	var details []string
+	for _, field := range constants.AllProfileFields {
+		if value := profile.FieldValue(field); value != "" {
+			details = append(details, formatters.GetProfileFieldName(field)+": "+value)
+		}
+	}
+
+	prompt := fmt.Sprintf(
+		templateText,
+		constants.ProfileBioLengthLimit,
+		strings.TrimSpace(dbUser.Firstname+" "+dbUser.Lastname),
+		strings.Join(details, "\n"),
+		sourceText,
+	)
+
+	typingCtx, cancelTyping := context.WithCancel(context.Background())
+	h.userStore.Set(userId, profileCtxDataKeyCancelFunc, cancelTyping)
+	defer cancelTyping()
+
+	h.RemovePreviousMessage(b, &userId)
+	sentMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+		chatID,
+		fmt.Sprintf("<b>%s</b>", profileMenuEditBioDraftHeader)+
+			"\n\nDrafting your bio...",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(constants.ProfileFullCancel),
+		})
+	h.SavePreviousMessageInfo(userId, sentMsg)
+
+	h.messageSenderService.SendTypingAction(chatID)
+	go func() {
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				h.messageSenderService.SendTypingAction(chatID)
+			case <-typingCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// The model doesn't always keep to the limit, so an over-long draft is sent back to be shortened
+	var draft string
+	for attempt := 1; ; attempt++ {
+		draft, err = h.openaiClient.GetCompletionWithReasoning(typingCtx, prompt, openai.ReasoningEffortMinimal)
+		if err != nil || typingCtx.Err() != nil {
+			break
+		}
+
+		draft = strings.TrimSpace(draft)
+		length := utils.Utf16CodeUnitCount(draft)
+		if length <= constants.ProfileBioLengthLimit {
+			break
+		}
+		if attempt == profileBioDraftMaxAttempts {
+			err = fmt.Errorf("draft is still %d characters long after %d attempts", length, attempt)
+			break
+		}
+
+		log.Printf("%s: Bio draft is %d characters long, asking to shorten it", utils.GetCurrentTypeName(), length)
+		prompt = fmt.Sprintf(
+			"The bio below is %d characters long, but it must not exceed %d characters. "+
+				"Shorten it, keeping its language, tone and the most important facts. Reply with the bio only.\n\n%s",
+			length, constants.ProfileBioLengthLimit, draft,
+		)
+	}
+
+	if typingCtx.Err() != nil {
+		log.Printf("%s: Bio draft request was cancelled", utils.GetCurrentTypeName())
+		return handlers.EndConversation()
+	}
+
+	if err != nil {
+		log.Printf("%s: Error drafting bio: %v", utils.GetCurrentTypeName(), err)
+		h.RemovePreviousMessage(b, &userId)
+		errMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+			chatID,
+			fmt.Sprintf("<b>%s</b>", profileMenuEditBioDraftHeader)+
+				"\n\nAn error occurred while drafting the bio. Send your answers again to retry, or go back and write it yourself.",
+			&gotgbot.SendMessageOpts{
+				ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditBioCallback),
+			})
+		h.SavePreviousMessageInfo(userId, errMsg)
+		return handlers.NextConversationState(profileStateAwaitBioAssistInput)
+	}
+
+	h.userStore.Set(userId, profileCtxDataKeyBioDraft, draft)
+
+	h.RemovePreviousMessage(b, &userId)
+	draftMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		chatID,
+		fmt.Sprintf("<b>%s</b>", profileMenuEditBioDraftHeader)+
+			fmt.Sprintf("\n\n<pre>%s</pre>", html.EscapeString(draft))+
+			"\n\nSave the draft as it is or regenerate it. To edit it, tap the draft to copy it, "+
+			"make your changes and send me the new text.",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ProfileBioDraftButtons(),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in draftBio: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(userId, draftMsg)
+	return handlers.NextConversationState(profileStateBioDraft)
+}
+
+// handleSaveBioDraft saves the drafted bio without changes
+func (h *profileHandler) handleSaveBioDraft(b *gotgbot.Bot, ctx *ext.Context, msg *gotgbot.Message) error {
+	draft, _ := h.userStore.Get(ctx.EffectiveUser.Id, profileCtxDataKeyBioDraft)
+	draftText, _ := draft.(string)
+	if draftText == "" {
+		return h.handleBioAssist(b, ctx, msg)
+	}
+
+	return h.saveBio(b, ctx, msg.Chat.Id, draftText)
 }
 
 // Firstname handler
@@ -486,7 +751,7 @@ func (h *profileHandler) handleFirstnameInput(b *gotgbot.Bot, ctx *ext.Context)
 	}
 
 	h.SavePreviousMessageInfo(msg.From.Id, sendMsg)
-	return handlers.NextConversationState(profileStateEditMyProfile)
+	return handlers.NextConversationState(profileStateViewOptions)
 }
 
 // Lastname handler
@@ -534,7 +799,114 @@ func (h *profileHandler) handleLastnameInput(b *gotgbot.Bot, ctx *ext.Context) e
 	}
 
 	h.SavePreviousMessageInfo(msg.From.Id, sendMsg)
-	return handlers.NextConversationState(profileStateEditMyProfile)
+	return handlers.NextConversationState(profileStateViewOptions)
+}
+
+// Starts editing one of the optional structured fields
+func (h *profileHandler) handleEditProfileField(b *gotgbot.Bot, ctx *ext.Context, msg *gotgbot.Message, field constants.ProfileField) error {
+	user := ctx.Update.CallbackQuery.From
+	if !slices.Contains(constants.AllProfileFields, field) {
+		return fmt.Errorf("%s: unknown profile field: %s", utils.GetCurrentTypeName(), field)
+	}
+
+	h.userStore.Set(user.Id, profileCtxDataKeyProfileField, field)
+
+	dbUser, err := h.userRepository.GetOrCreate(&user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleEditProfileField: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	dbProfile, err := h.profileRepository.GetOrCreate(dbUser.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get/create profile in handleEditProfileField: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.RemovePreviousMessage(b, &user.Id)
+	editedMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		msg.Chat.Id,
+		formatProfileFieldPrompt(field, dbProfile.FieldValue(field), ""),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in handleEditProfileField: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(user.Id, editedMsg)
+	return handlers.NextConversationState(profileStateAwaitProfileField)
+}
+
+// Structured field handler
+func (h *profileHandler) handleProfileFieldInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	fieldVal, ok := h.userStore.Get(msg.From.Id, profileCtxDataKeyProfileField)
+	if !ok {
+		return fmt.Errorf("%s: profile field not found in user store", utils.GetCurrentTypeName())
+	}
+	field := fieldVal.(constants.ProfileField)
+
+	value, err := utils.NormalizeProfileFieldValue(field, msg.Text)
+	if err != nil {
+		h.RemovePreviousMessage(b, &msg.From.Id)
+		b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+		errMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+			msg.Chat.Id,
+			formatProfileFieldPrompt(field, "", fmt.Sprintf("\u26a0\ufe0f %s.", html.EscapeString(err.Error()))),
+			&gotgbot.SendMessageOpts{
+				ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback),
+			})
+
+		h.SavePreviousMessageInfo(msg.From.Id, errMsg)
+		return nil
+	}
+
+	if err := h.saveProfileField(ctx.EffectiveUser, string(field), value); err != nil {
+		_ = h.messageSenderService.ReplyHtml(msg,
+			fmt.Sprintf("<b>%s</b>", formatProfileFieldHeader(field))+
+				"\n\nAn error occurred while saving the value.", nil)
+		return fmt.Errorf("%s: failed to save %s in handleProfileFieldInput: %w", utils.GetCurrentTypeName(), field, err)
+	}
+
+	// Refresh the published profile if there is one
+	profilePublishedMessage, _ := h.tryToPublishProfile(b, ctx, true)
+
+	savedText := fmt.Sprintf("\u2705 %s saved!", formatters.GetProfileFieldName(field))
+	if value == "" {
+		savedText = fmt.Sprintf("\u2705 %s cleared!", formatters.GetProfileFieldName(field))
+	}
+
+	h.RemovePreviousMessage(b, &msg.From.Id)
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	sendMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(msg.Chat.Id,
+		fmt.Sprintf("<b>%s</b>", formatProfileFieldHeader(field))+
+			"\n\n"+savedText+profilePublishedMessage,
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ProfileBackCancelButtons(constants.ProfileEditMyProfileCallback),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in handleProfileFieldInput: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(msg.From.Id, sendMsg)
+	return handlers.NextConversationState(profileStateViewOptions)
+}
+
+// formatProfileFieldPrompt renders the request for a structured field with its current value or the validation error
+func formatProfileFieldPrompt(field constants.ProfileField, currentValue string, problem string) string {
+	text := fmt.Sprintf("<b>%s</b>\n\n", formatProfileFieldHeader(field))
+	if problem != "" {
+		text += problem + "\n\n"
+	} else if currentValue != "" {
+		text += fmt.Sprintf("Current value: <code>%s</code>\n\n", html.EscapeString(currentValue))
+	} else {
+		text += "Current value: not set\n\n"
+	}
+	return text + fmt.Sprintf("Enter %s <i>(send %s to clear it)</i>:", formatters.GetProfileFieldHint(field), utils.ProfileFieldClearValue)
+}
+
+func formatProfileFieldHeader(field constants.ProfileField) string {
+	return profileMenuEditHeader + " → " + formatters.GetProfileFieldName(field)
 }
 
 func (h *profileHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
@@ -581,7 +953,12 @@ func (h *profileHandler) tryToPublishProfile(b *gotgbot.Bot, ctx *ext.Context, w
 		return "", fmt.Errorf("%s: profile is not complete", utils.GetCurrentTypeName())
 	}
 
-	publicMessageText := formatters.FormatPublicProfileForMessage(dbUser, profile, false)
+	badges, err := h.badgeRepository.GetByUserID(dbUser.ID)
+	if err != nil {
+		log.Printf("%s: Error getting badges for publishing: %v", utils.GetCurrentTypeName(), err)
+	}
+
+	publicMessageText := formatters.FormatPublicProfileForMessage(dbUser, profile, badges, false)
 
 	var messageID int64
 	if profile.PublishedMessageID.Valid {
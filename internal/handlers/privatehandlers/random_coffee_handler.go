@@ -0,0 +1,356 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states
+	randomCoffeeStateViewOptions    = "random_coffee_state_view_options"
+	randomCoffeeStateAwaitPauseDate = "random_coffee_state_await_pause_date"
+
+	// UserStore keys
+	randomCoffeeCtxDataKeyPreviousMessageID = "random_coffee_ctx_data_previous_message_id"
+	randomCoffeeCtxDataKeyPreviousChatID    = "random_coffee_ctx_data_previous_chat_id"
+
+	// Menu headers
+	randomCoffeeMenuHeader      = "Random Coffee"
+	randomCoffeeMenuPauseHeader = "Random Coffee → Pause"
+
+	randomCoffeeDateLayout = "02.01.2006"
+)
+
+type randomCoffeeHandler struct {
+	config                 *config.Config
+	messageSenderService   *services.MessageSenderService
+	permissionsService     *services.PermissionsService
+	userRepository         *repositories.UserRepository
+	subscriptionRepository *repositories.RandomCoffeeSubscriptionRepository
+	reminderMuteRepository *repositories.RandomCoffeeReminderMuteRepository
+	userStore              *utils.UserDataStore
+}
+
+func NewRandomCoffeeHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+	userRepository *repositories.UserRepository,
+	subscriptionRepository *repositories.RandomCoffeeSubscriptionRepository,
+	reminderMuteRepository *repositories.RandomCoffeeReminderMuteRepository,
+) ext.Handler {
+	h := &randomCoffeeHandler{
+		config:                 config,
+		messageSenderService:   messageSenderService,
+		permissionsService:     permissionsService,
+		userRepository:         userRepository,
+		subscriptionRepository: subscriptionRepository,
+		reminderMuteRepository: reminderMuteRepository,
+		userStore:              utils.NewUserDataStore(),
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.RandomCoffeeCommand, h.handleStart),
+		},
+		map[string][]ext.Handler{
+			randomCoffeeStateViewOptions: {
+				handlers.NewCallback(callbackquery.Prefix(constants.RandomCoffeePrefix), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.RandomCoffeeFullCancel), h.handleCallbackCancel),
+			},
+			randomCoffeeStateAwaitPauseDate: {
+				handlers.NewMessage(message.Text, h.handlePauseDateInput),
+				handlers.NewCallback(callbackquery.Equal(constants.RandomCoffeeStartCallback), h.handleCallback),
+				handlers.NewCallback(callbackquery.Equal(constants.RandomCoffeeFullCancel), h.handleCallbackCancel),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+		},
+	)
+}
+
+// Handles button clicks
+func (h *randomCoffeeHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	_, _ = callback.Answer(b, nil)
+
+	switch callback.Data {
+	case constants.RandomCoffeeSubscribeCallback:
+		return h.handleSubscribe(b, ctx)
+	case constants.RandomCoffeePauseCallback:
+		return h.handlePause(b, ctx)
+	case constants.RandomCoffeeUnsubscribeCallback:
+		return h.handleUnsubscribe(b, ctx)
+	case constants.RandomCoffeeMuteRemindersCallback:
+		return h.handleRemindersMute(b, ctx, true)
+	case constants.RandomCoffeeUnmuteRemindersCallback:
+		return h.handleRemindersMute(b, ctx, false)
+	case constants.RandomCoffeeStartCallback:
+		return h.handleBack(b, ctx)
+	}
+
+	return nil
+}
+
+// Entry point for the /coffee command
+func (h *randomCoffeeHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	user := ctx.EffectiveUser
+
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return handlers.EndConversation()
+	}
+
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.RandomCoffeeCommand) {
+		return handlers.EndConversation()
+	}
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleStart: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if dbUser.HasCoffeeBan {
+		h.RemovePreviousMessage(b, &user.Id)
+		_ = h.messageSenderService.SendHtml(msg.Chat.Id,
+			"🚫 You cannot participate in Random Coffee because you are banned. "+
+				"Please contact an administrator to get unbanned.", nil)
+		h.userStore.Clear(user.Id)
+		return handlers.EndConversation()
+	}
+
+	return h.showMenu(b, msg.Chat.Id, user.Id, dbUser, "")
+}
+
+// Returns to the main menu without re-running the entry checks,
+// since the effective message of a callback is sent by the bot itself
+func (h *randomCoffeeHandler) handleBack(b *gotgbot.Bot, ctx *ext.Context) error {
+	user := ctx.EffectiveUser
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleBack: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return h.showMenu(b, ctx.EffectiveChat.Id, user.Id, dbUser, "")
+}
+
+func (h *randomCoffeeHandler) handleSubscribe(b *gotgbot.Bot, ctx *ext.Context) error {
+	user := ctx.EffectiveUser
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleSubscribe: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if err := h.subscriptionRepository.Subscribe(int64(dbUser.ID)); err != nil {
+		return fmt.Errorf("%s: failed to subscribe in handleSubscribe: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return h.showMenu(b, ctx.EffectiveChat.Id, user.Id, dbUser, "✅ You are subscribed to every Random Coffee round!")
+}
+
+func (h *randomCoffeeHandler) handleUnsubscribe(b *gotgbot.Bot, ctx *ext.Context) error {
+	user := ctx.EffectiveUser
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleUnsubscribe: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if err := h.subscriptionRepository.Unsubscribe(int64(dbUser.ID)); err != nil {
+		return fmt.Errorf("%s: failed to unsubscribe in handleUnsubscribe: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return h.showMenu(b, ctx.EffectiveChat.Id, user.Id, dbUser,
+		"✅ Subscription cancelled. You can still join any round by voting in the weekly poll.")
+}
+
+func (h *randomCoffeeHandler) handleRemindersMute(b *gotgbot.Bot, ctx *ext.Context, mute bool) error {
+	user := ctx.EffectiveUser
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handleRemindersMute: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	notice := "🔔 Reminders are on again."
+	if mute {
+		err = h.reminderMuteRepository.Mute(int64(dbUser.ID))
+		notice = "🔕 Reminders muted. I won't DM you about open Random Coffee polls anymore."
+	} else {
+		err = h.reminderMuteRepository.Unmute(int64(dbUser.ID))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: failed to update reminders mute in handleRemindersMute: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return h.showMenu(b, ctx.EffectiveChat.Id, user.Id, dbUser, notice)
+}
+
+func (h *randomCoffeeHandler) handlePause(b *gotgbot.Bot, ctx *ext.Context) error {
+	user := ctx.EffectiveUser
+
+	h.RemovePreviousMessage(b, &user.Id)
+	sentMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		fmt.Sprintf("<b>%s</b>", randomCoffeeMenuPauseHeader)+
+			"\n\nUntil which date should I skip you? Send it in the format <code>DD.MM.YYYY</code>"+
+			fmt.Sprintf(" <i>(for example, %s)</i>.", time.Now().AddDate(0, 0, 14).Format(randomCoffeeDateLayout))+
+			"\n\nYou will be enrolled again starting with the first round after this date.",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.RandomCoffeeBackCancelButtons(constants.RandomCoffeeStartCallback),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in handlePause: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(user.Id, sentMsg)
+	return handlers.NextConversationState(randomCoffeeStateAwaitPauseDate)
+}
+
+func (h *randomCoffeeHandler) handlePauseDateInput(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	user := ctx.EffectiveUser
+
+	pausedUntil, err := time.Parse(randomCoffeeDateLayout, strings.TrimSpace(msg.Text))
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if err != nil || pausedUntil.Before(today) {
+		h.RemovePreviousMessage(b, &user.Id)
+		b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+		sentMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+			msg.Chat.Id,
+			fmt.Sprintf("<b>%s</b>", randomCoffeeMenuPauseHeader)+
+				"\n\nPlease send a date that is not in the past, in the format <code>DD.MM.YYYY</code>:",
+			&gotgbot.SendMessageOpts{
+				ReplyMarkup: buttons.RandomCoffeeBackCancelButtons(constants.RandomCoffeeStartCallback),
+			})
+		h.SavePreviousMessageInfo(user.Id, sentMsg)
+		return nil
+	}
+
+	dbUser, err := h.userRepository.GetOrCreate(user)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get user in handlePauseDateInput: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	if err := h.subscriptionRepository.PauseUntil(int64(dbUser.ID), pausedUntil); err != nil {
+		_ = h.messageSenderService.Reply(msg, "An error occurred while pausing the subscription.", nil)
+		return fmt.Errorf("%s: failed to pause subscription in handlePauseDateInput: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	b.DeleteMessage(msg.Chat.Id, msg.MessageId, nil)
+	return h.showMenu(b, msg.Chat.Id, user.Id, dbUser,
+		fmt.Sprintf("⏸️ Subscription paused until %s.", pausedUntil.Format(randomCoffeeDateLayout)))
+}
+
+// showMenu renders the subscription status with the available actions
+func (h *randomCoffeeHandler) showMenu(b *gotgbot.Bot, chatID int64, userID int64, dbUser *repositories.User, notice string) error {
+	subscription, err := h.subscriptionRepository.GetByUserID(int64(dbUser.ID))
+	if err != nil {
+		return fmt.Errorf("%s: failed to get subscription in showMenu: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	isRemindersMuted, err := h.reminderMuteRepository.IsMuted(int64(dbUser.ID))
+	if err != nil {
+		return fmt.Errorf("%s: failed to get reminders mute in showMenu: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	isSubscribed := subscription != nil
+	isPaused := isSubscribed && subscription.IsPausedFor(time.Now().UTC().Truncate(24*time.Hour))
+
+	statusString := "❌ Not subscribed. You take part only in the rounds you vote \"Yes\" for in the weekly poll."
+	if isPaused {
+		statusString = fmt.Sprintf("⏸️ Paused until %s. You will be enrolled again from the first round after this date.",
+			subscription.PausedUntil.Time.Format(randomCoffeeDateLayout))
+	} else if isSubscribed {
+		statusString = "✅ Subscribed. You are enrolled in every round automatically."
+	}
+
+	text := fmt.Sprintf("<b>%s</b>", randomCoffeeMenuHeader) +
+		"\n\nWith a subscription you don't need to vote in the weekly poll: you are paired every week until you pause or unsubscribe." +
+		"\n\n<blockquote>To skip a single round, just vote \"Not this time\" in that week's poll.</blockquote>" +
+		"\n\nStatus: " + statusString
+	if !isSubscribed {
+		if isRemindersMuted {
+			text += "\nReminders: 🔕 muted"
+		} else {
+			text += "\nReminders: 🔔 on <i>(a DM if you joined the last round but haven't voted yet)</i>"
+		}
+	}
+	if notice != "" {
+		text += "\n\n" + notice
+	}
+
+	h.RemovePreviousMessage(b, &userID)
+	sentMsg, err := h.messageSenderService.SendHtmlWithReturnMessage(
+		chatID,
+		text,
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.RandomCoffeeMainButtons(isSubscribed, isPaused, isRemindersMuted),
+		})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send message in showMenu: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	h.SavePreviousMessageInfo(userID, sentMsg)
+	return handlers.NextConversationState(randomCoffeeStateViewOptions)
+}
+
+func (h *randomCoffeeHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+func (h *randomCoffeeHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	userId := ctx.EffectiveUser.Id
+
+	h.messageSenderService.Send(ctx.EffectiveChat.Id, "Random Coffee session ended.", nil)
+
+	h.RemovePreviousMessage(b, &userId)
+	h.userStore.Clear(userId)
+
+	return handlers.EndConversation()
+}
+
+func (h *randomCoffeeHandler) RemovePreviousMessage(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			randomCoffeeCtxDataKeyPreviousMessageID,
+			randomCoffeeCtxDataKeyPreviousChatID,
+		)
+	}
+
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	b.DeleteMessage(chatID, messageID, nil)
+}
+
+func (h *randomCoffeeHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	if sentMsg == nil {
+		return
+	}
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		randomCoffeeCtxDataKeyPreviousMessageID, randomCoffeeCtxDataKeyPreviousChatID)
+}
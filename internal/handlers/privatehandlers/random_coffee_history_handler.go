@@ -0,0 +1,82 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+)
+
+type randomCoffeeHistoryHandler struct {
+	config               *config.Config
+	messageSenderService *services.MessageSenderService
+	permissionsService   *services.PermissionsService
+	userRepository       *repositories.UserRepository
+	pairRepository       *repositories.RandomCoffeePairRepository
+}
+
+func NewRandomCoffeeHistoryHandler(
+	config *config.Config,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+	userRepository *repositories.UserRepository,
+	pairRepository *repositories.RandomCoffeePairRepository,
+) ext.Handler {
+	h := &randomCoffeeHistoryHandler{
+		config:               config,
+		messageSenderService: messageSenderService,
+		permissionsService:   permissionsService,
+		userRepository:       userRepository,
+		pairRepository:       pairRepository,
+	}
+
+	return handlers.NewCommand(constants.RandomCoffeeHistoryCommand, h.handleCommand)
+}
+
+func (h *randomCoffeeHistoryHandler) handleCommand(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Only proceed if this is a private chat
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return nil
+	}
+
+	// Check if user is a club member
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.RandomCoffeeHistoryCommand) {
+		return nil
+	}
+
+	dbUser, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your Random Coffee history.", nil)
+		log.Printf("%s: Error during user retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	pairs, err := h.pairRepository.GetPairsForUser(dbUser.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your Random Coffee history.", nil)
+		log.Printf("%s: Error during pairs retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	roundsJoined, err := h.pairRepository.CountRoundsJoined(dbUser.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your Random Coffee history.", nil)
+		log.Printf("%s: Error during rounds retrieval: %v", utils.GetCurrentTypeName(), err)
+		return nil
+	}
+
+	h.messageSenderService.ReplyHtml(msg, formatters.FormatRandomCoffeeHistory(pairs, roundsJoined, h.config), &gotgbot.SendMessageOpts{
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+
+	return nil
+}
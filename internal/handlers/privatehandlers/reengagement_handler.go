@@ -0,0 +1,69 @@
+package privatehandlers
+
+import (
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// reengagementHandler handles the button of the re-engagement message, which opts out of the reminders
+// or back in. The message is sent by a task, so the handler is stateless.
+type reengagementHandler struct {
+	userRepository         *repositories.UserRepository
+	reengagementRepository *repositories.ReengagementRepository
+}
+
+func NewReengagementHandler(
+	userRepository *repositories.UserRepository,
+	reengagementRepository *repositories.ReengagementRepository,
+) ext.Handler {
+	h := &reengagementHandler{
+		userRepository:         userRepository,
+		reengagementRepository: reengagementRepository,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.ReengagementPrefix), h.handleCallback)
+}
+
+func (h *reengagementHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+	optOut := callback.Data == constants.ReengagementOptOutCallback
+
+	dbUser, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return h.answer(b, callback, "Something went wrong, please try again later.")
+	}
+
+	if err := h.reengagementRepository.SetOptedOut(dbUser.ID, optOut); err != nil {
+		log.Printf("%s: Error updating reminder opt-out of user %d: %v", utils.GetCurrentTypeName(), dbUser.ID, err)
+		return h.answer(b, callback, "Something went wrong, please try again later.")
+	}
+
+	msg := ctx.EffectiveMessage
+	_, _, err = b.EditMessageReplyMarkup(&gotgbot.EditMessageReplyMarkupOpts{
+		ChatId:      msg.Chat.Id,
+		MessageId:   msg.MessageId,
+		ReplyMarkup: buttons.ReengagementButtons(optOut),
+	})
+	if err != nil {
+		log.Printf("%s: Error updating re-engagement message buttons: %v", utils.GetCurrentTypeName(), err)
+	}
+
+	if optOut {
+		return h.answer(b, callback, "Got it, you won't get these reminders anymore.")
+	}
+	return h.answer(b, callback, "You'll get a reminder again if you're quiet for a while.")
+}
+
+func (h *reengagementHandler) answer(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: text})
+	return err
+}
@@ -0,0 +1,381 @@
+package topicshandlers
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
+)
+
+const (
+	// Conversation states names
+	myTopicsStateSelectTopic     = "my_topics_state_select_topic"
+	myTopicsStateEnterTopic      = "my_topics_state_enter_topic"
+	myTopicsStateConfirmWithdraw = "my_topics_state_confirm_withdraw"
+
+	// Context data keys
+	myTopicsCtxDataKeySelectedTopicID   = "my_topics_ctx_data_selected_topic_id"
+	myTopicsCtxDataKeyPreviousMessageID = "my_topics_ctx_data_previous_message_id"
+	myTopicsCtxDataKeyPreviousChatID    = "my_topics_ctx_data_previous_chat_id"
+
+	// Callback data
+	myTopicsCallbackEditPrefix      = "my_topics_callback_edit_"     // + "<topicID>"
+	myTopicsCallbackWithdrawPrefix  = "my_topics_callback_withdraw_" // + "<topicID>"
+	myTopicsCallbackConfirmWithdraw = "my_topics_callback_confirm_withdraw"
+	myTopicsCallbackConfirmCancel   = "my_topics_callback_confirm_cancel"
+)
+
+// myTopicsHandler lets members edit or withdraw the topics they suggested for upcoming events
+type myTopicsHandler struct {
+	config               *config.Config
+	topicRepository      *repositories.TopicRepository
+	eventRepository      *repositories.EventRepository
+	userRepository       *repositories.UserRepository
+	topicService         *services.TopicService
+	messageSenderService *services.MessageSenderService
+	userStore            *utils.UserDataStore
+	permissionsService   *services.PermissionsService
+}
+
+func NewMyTopicsHandler(
+	config *config.Config,
+	topicRepository *repositories.TopicRepository,
+	eventRepository *repositories.EventRepository,
+	userRepository *repositories.UserRepository,
+	topicService *services.TopicService,
+	messageSenderService *services.MessageSenderService,
+	permissionsService *services.PermissionsService,
+) ext.Handler {
+	h := &myTopicsHandler{
+		config:               config,
+		topicRepository:      topicRepository,
+		eventRepository:      eventRepository,
+		userRepository:       userRepository,
+		topicService:         topicService,
+		messageSenderService: messageSenderService,
+		userStore:            utils.NewUserDataStore(),
+		permissionsService:   permissionsService,
+	}
+
+	return handlers.NewConversation(
+		[]ext.Handler{
+			handlers.NewCommand(constants.MyTopicsCommand, h.startMyTopics),
+		},
+		map[string][]ext.Handler{
+			myTopicsStateSelectTopic: {
+				handlers.NewCallback(callbackquery.Prefix(myTopicsCallbackEditPrefix), h.handleCallbackEdit),
+				handlers.NewCallback(callbackquery.Prefix(myTopicsCallbackWithdrawPrefix), h.handleCallbackWithdraw),
+				handlers.NewCallback(callbackquery.Equal(myTopicsCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			myTopicsStateEnterTopic: {
+				handlers.NewMessage(message.All, h.handleTopicEntry),
+				handlers.NewCallback(callbackquery.Equal(myTopicsCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+			myTopicsStateConfirmWithdraw: {
+				handlers.NewCallback(callbackquery.Equal(myTopicsCallbackConfirmWithdraw), h.handleCallbackConfirmWithdraw),
+				handlers.NewCallback(callbackquery.Equal(myTopicsCallbackConfirmCancel), h.handleCallbackCancel),
+			},
+		},
+		&handlers.ConversationOpts{
+			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
+		},
+	)
+}
+
+// 1. startMyTopics shows the member's topics for upcoming events with the Edit / Withdraw buttons
+func (h *myTopicsHandler) startMyTopics(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+
+	// Only proceed if this is a private chat
+	if !h.permissionsService.CheckPrivateChatType(msg) {
+		return handlers.EndConversation()
+	}
+
+	// Check if user is a club member
+	if !h.permissionsService.CheckClubMemberPermissions(msg, constants.MyTopicsCommand) {
+		return handlers.EndConversation()
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	topics, err := h.topicRepository.GetTopicsByAuthor(user.ID)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Error retrieving your topics and questions.", nil)
+		log.Printf("%s: Error during topics retrieval: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	if len(topics) == 0 {
+		h.messageSenderService.Reply(
+			msg,
+			fmt.Sprintf("You haven't suggested any topics for upcoming events yet. Use /%s to add one.", constants.TopicAddCommand),
+			nil,
+		)
+		return handlers.EndConversation()
+	}
+
+	eventNames := make(map[int]string)
+	for _, topic := range topics {
+		if _, ok := eventNames[topic.EventID]; ok {
+			continue
+		}
+		if event, err := h.eventRepository.GetEventByID(topic.EventID); err == nil {
+			eventNames[topic.EventID] = event.Name
+		}
+	}
+
+	sentMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+		msg.Chat.Id,
+		formatters.FormatHtmlMyTopics(topics, eventNames),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.MyTopicsButtons(
+				topics,
+				myTopicsCallbackEditPrefix,
+				myTopicsCallbackWithdrawPrefix,
+				myTopicsCallbackConfirmCancel,
+			),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(myTopicsStateSelectTopic)
+}
+
+// 2. handleCallbackEdit asks for the new text of the selected topic
+func (h *myTopicsHandler) handleCallbackEdit(b *gotgbot.Bot, ctx *ext.Context) error {
+	topic, ok := h.getOwnTopic(b, ctx, myTopicsCallbackEditPrefix)
+	if !ok {
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, myTopicsCtxDataKeySelectedTopicID, topic.ID)
+
+	sentMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		"Send me the new text of the topic. It will go back to moderation, the votes are kept.",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.CancelButton(myTopicsCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(myTopicsStateEnterTopic)
+}
+
+// 3. handleTopicEntry saves the new text and sends the topic to moderation again
+func (h *myTopicsHandler) handleTopicEntry(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	topicText := strings.TrimSpace(msg.Text)
+
+	if topicText == "" {
+		h.messageSenderService.Reply(
+			msg,
+			"Topic cannot be empty. Please enter the topic text or cancel the operation.",
+			nil,
+		)
+		return nil // Stay in the same state
+	}
+
+	topicID, ok := h.getSelectedTopicID(ctx.EffectiveUser.Id)
+	if !ok {
+		h.messageSenderService.Reply(msg, "An error occurred: the selected topic was not found. Please start over.", nil)
+		return handlers.EndConversation()
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	if err := h.topicRepository.UpdateTopicText(topicID, topicText); err != nil {
+		h.messageSenderService.Reply(msg, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error updating topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		return handlers.EndConversation()
+	}
+
+	if err := h.topicService.SendForModeration(topicID, true); err != nil {
+		log.Printf("%s: Error sending topic %d for moderation: %v", utils.GetCurrentTypeName(), topicID, err)
+	}
+
+	h.messageSenderService.Reply(
+		msg,
+		fmt.Sprintf("Saved! The topic will appear in /%s again once an administrator approves it.", constants.TopicsCommand),
+		nil,
+	)
+	return handlers.EndConversation()
+}
+
+// 4. handleCallbackWithdraw asks to confirm withdrawing the selected topic
+func (h *myTopicsHandler) handleCallbackWithdraw(b *gotgbot.Bot, ctx *ext.Context) error {
+	topic, ok := h.getOwnTopic(b, ctx, myTopicsCallbackWithdrawPrefix)
+	if !ok {
+		return nil // Stay in the same state
+	}
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, myTopicsCtxDataKeySelectedTopicID, topic.ID)
+
+	sentMsg, _ := h.messageSenderService.SendHtmlWithReturnMessage(
+		ctx.EffectiveChat.Id,
+		fmt.Sprintf("Withdraw this topic? Its votes will be lost.\n<blockquote expandable>%s</blockquote>",
+			html.EscapeString(topic.Topic)),
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.ConfirmAndCancelButton(myTopicsCallbackConfirmWithdraw, myTopicsCallbackConfirmCancel),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(myTopicsStateConfirmWithdraw)
+}
+
+// 5. handleCallbackConfirmWithdraw deletes the selected topic
+func (h *myTopicsHandler) handleCallbackConfirmWithdraw(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	topicID, ok := h.getSelectedTopicID(ctx.EffectiveUser.Id)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	if !ok {
+		h.messageSenderService.Send(ctx.EffectiveChat.Id, "An error occurred: the selected topic was not found. Please start over.", nil)
+		return handlers.EndConversation()
+	}
+
+	if err := h.topicRepository.DeleteTopic(topicID); err != nil {
+		h.messageSenderService.Send(ctx.EffectiveChat.Id, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error deleting topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		return handlers.EndConversation()
+	}
+
+	h.messageSenderService.Send(
+		ctx.EffectiveChat.Id,
+		fmt.Sprintf("\U0001f5d1 The topic has been withdrawn. Use /%s to see your other topics.", constants.MyTopicsCommand),
+		nil,
+	)
+	return handlers.EndConversation()
+}
+
+// handleCallbackCancel processes the cancel button click
+func (h *myTopicsHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	// Answer the callback query to remove the loading state on the button
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	return h.handleCancel(b, ctx)
+}
+
+// 6. handleCancel handles the /cancel command
+func (h *myTopicsHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
+	h.messageSenderService.Send(ctx.EffectiveChat.Id, "Topic editing operation cancelled.", nil)
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+
+	return handlers.EndConversation()
+}
+
+// getOwnTopic returns the topic from the callback data if it still exists and belongs to the member
+func (h *myTopicsHandler) getOwnTopic(b *gotgbot.Bot, ctx *ext.Context, prefix string) (*repositories.Topic, bool) {
+	callback := ctx.Update.CallbackQuery
+
+	topicID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, prefix))
+	if err != nil {
+		h.answerAlert(b, callback, "Unknown topic.")
+		return nil, false
+	}
+
+	topic, err := h.topicRepository.GetTopicByID(topicID)
+	if err != nil {
+		log.Printf("%s: Error getting topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		h.answerAlert(b, callback, "This topic no longer exists.")
+		return nil, false
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		h.answerAlert(b, callback, "An error occurred. Please try again later.")
+		return nil, false
+	}
+
+	// Anonymous topics keep their author apart from the topic
+	authorUserID, err := h.topicRepository.GetTopicAuthorUserID(topic.ID)
+	if err != nil {
+		log.Printf("%s: Error getting author of topic %d: %v", utils.GetCurrentTypeName(), topic.ID, err)
+		h.answerAlert(b, callback, "An error occurred. Please try again later.")
+		return nil, false
+	}
+
+	if authorUserID == nil || *authorUserID != user.ID {
+		h.answerAlert(b, callback, "You can only change your own topics.")
+		return nil, false
+	}
+
+	_, _ = callback.Answer(b, nil)
+	return topic, true
+}
+
+func (h *myTopicsHandler) getSelectedTopicID(userID int64) (int, bool) {
+	topicIDVal, ok := h.userStore.Get(userID, myTopicsCtxDataKeySelectedTopicID)
+	if !ok {
+		return 0, false
+	}
+	topicID, ok := topicIDVal.(int)
+	return topicID, ok
+}
+
+func (h *myTopicsHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) {
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+}
+
+func (h *myTopicsHandler) MessageRemoveInlineKeyboard(b *gotgbot.Bot, userID *int64) {
+	var chatID, messageID int64
+
+	// If userID provided, get stored message info using the utility method
+	if userID != nil {
+		messageID, chatID = h.userStore.GetPreviousMessageInfo(
+			*userID,
+			myTopicsCtxDataKeyPreviousMessageID,
+			myTopicsCtxDataKeyPreviousChatID,
+		)
+	}
+
+	// Skip if we don't have valid chat and message IDs
+	if chatID == 0 || messageID == 0 {
+		return
+	}
+
+	// Use message sender service to remove the inline keyboard
+	_ = h.messageSenderService.RemoveInlineKeyboard(chatID, messageID)
+}
+
+func (h *myTopicsHandler) SavePreviousMessageInfo(userID int64, sentMsg *gotgbot.Message) {
+	h.userStore.SetPreviousMessageInfo(userID, sentMsg.MessageId, sentMsg.Chat.Id,
+		myTopicsCtxDataKeyPreviousMessageID, myTopicsCtxDataKeyPreviousChatID)
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"evo-bot-go/internal/buttons"
 	"evo-bot-go/internal/config"
@@ -24,24 +25,29 @@ import (
 
 const (
 	// Conversation states names
-	topicAddStateSelectEvent = "topic_add_state_select_event"
-	topicAddStateEnterTopic  = "topic_add_state_enter_topic"
+	topicAddStateSelectEvent      = "topic_add_state_select_event"
+	topicAddStateEnterTopic       = "topic_add_state_enter_topic"
+	topicAddStateSelectAuthorship = "topic_add_state_select_authorship"
 
 	// Context data keys
 	topicAddCtxDataKeySelectedEventID   = "topic_add_ctx_data_selected_event_id"
-	topicAddCtxDataKeySelectedEventName = "topic_add_ctx_data_selected_event_name"
+	topicAddCtxDataKeyTopicText         = "topic_add_ctx_data_topic_text"
 	topicAddCtxDataKeyCancelFunc        = "topic_add_ctx_data_cancel_func"
 	topicAddCtxDataKeyPreviousMessageID = "topic_add_ctx_data_previous_message_id"
 	topicAddCtxDataKeyPreviousChatID    = "topic_add_ctx_data_previous_chat_id"
 
 	// Callback data
 	topicAddCallbackConfirmCancel = "topic_add_callback_confirm_cancel"
+	topicAddCallbackWithName      = "topic_add_callback_with_name"
+	topicAddCallbackAnonymous     = "topic_add_callback_anonymous"
 )
 
 type topicAddHandler struct {
 	config               *config.Config
 	topicRepository      *repositories.TopicRepository
 	eventRepository      *repositories.EventRepository
+	userRepository       *repositories.UserRepository
+	topicService         *services.TopicService
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -51,6 +57,8 @@ func NewTopicAddHandler(
 	config *config.Config,
 	topicRepository *repositories.TopicRepository,
 	eventRepository *repositories.EventRepository,
+	userRepository *repositories.UserRepository,
+	topicService *services.TopicService,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
@@ -58,6 +66,8 @@ func NewTopicAddHandler(
 		config:               config,
 		topicRepository:      topicRepository,
 		eventRepository:      eventRepository,
+		userRepository:       userRepository,
+		topicService:         topicService,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -76,6 +86,11 @@ func NewTopicAddHandler(
 				handlers.NewMessage(message.All, h.handleTopicEntry),
 				handlers.NewCallback(callbackquery.Equal(topicAddCallbackConfirmCancel), h.handleCallbackCancel),
 			},
+			topicAddStateSelectAuthorship: {
+				handlers.NewCallback(callbackquery.Equal(topicAddCallbackWithName), h.handleCallbackAuthorship),
+				handlers.NewCallback(callbackquery.Equal(topicAddCallbackAnonymous), h.handleCallbackAuthorship),
+				handlers.NewCallback(callbackquery.Equal(topicAddCallbackConfirmCancel), h.handleCallbackCancel),
+			},
 		},
 		&handlers.ConversationOpts{
 			Exits: []ext.Handler{handlers.NewCommand(constants.CancelCommand, h.handleCancel)},
@@ -98,7 +113,7 @@ func (h *topicAddHandler) startTopicAdd(b *gotgbot.Bot, ctx *ext.Context) error
 	}
 
 	// Get last actual events to show for selection
-	events, err := h.eventRepository.GetLastActualEvents(10)
+	events, err := h.eventRepository.GetLastActualEventsWithNextOccurrences(10)
 	if err != nil {
 		h.messageSenderService.Reply(msg, "Error retrieving the list of events.", nil)
 		log.Printf("%s: Error during events retrieval: %v", utils.GetCurrentTypeName(), err)
@@ -114,6 +129,7 @@ func (h *topicAddHandler) startTopicAdd(b *gotgbot.Bot, ctx *ext.Context) error
 	formattedEvents := formatters.FormatEventListForTopicsView(
 		events,
 		fmt.Sprintf("Select the event ID you want to add topics or questions to"),
+		h.config.EventsTimezone,
 	)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
@@ -156,11 +172,21 @@ func (h *topicAddHandler) handleEventSelection(b *gotgbot.Bot, ctx *ext.Context)
 		return nil // Stay in the same state
 	}
 
+	// Topics for a recurring event go to its next occurrence, e.g. when a later one was picked from /events
+	if event.SeriesID != nil {
+		occurrences, err := h.eventRepository.GetActualSeriesEventsStartingSince(*event.SeriesID, time.Time{})
+		if err != nil {
+			log.Printf("%s: Error getting occurrences of series %d: %v", utils.GetCurrentTypeName(), *event.SeriesID, err)
+		} else if len(occurrences) > 0 {
+			event = &occurrences[0]
+			eventID = event.ID
+		}
+	}
+
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
 
 	// Store the selected event ID for later use when creating a new topic
 	h.userStore.Set(ctx.EffectiveUser.Id, topicAddCtxDataKeySelectedEventID, eventID)
-	h.userStore.Set(ctx.EffectiveUser.Id, topicAddCtxDataKeySelectedEventName, event.Name)
 
 	// Prompt user to enter a topic
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
@@ -190,62 +216,96 @@ func (h *topicAddHandler) handleTopicEntry(b *gotgbot.Bot, ctx *ext.Context) err
 		return nil // Stay in the same state
 	}
 
-	// Get the selected event ID from user store
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+	h.userStore.Set(ctx.EffectiveUser.Id, topicAddCtxDataKeyTopicText, topicText)
+
+	// Ask whether to show the author, shy members can ask anonymously
+	sentMsg, _ := h.messageSenderService.ReplyWithReturnMessage(
+		msg,
+		"Send it with your name or anonymously? Members never see who suggested a topic, "+
+			"but administrators do unless you choose anonymously.",
+		&gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.TopicAuthorshipButtons(
+				topicAddCallbackWithName,
+				topicAddCallbackAnonymous,
+				topicAddCallbackConfirmCancel,
+			),
+		},
+	)
+
+	h.SavePreviousMessageInfo(ctx.EffectiveUser.Id, sentMsg)
+	return handlers.NextConversationState(topicAddStateSelectAuthorship)
+}
+
+// 4. handleCallbackAuthorship creates the topic with the author's name or anonymously
+func (h *topicAddHandler) handleCallbackAuthorship(b *gotgbot.Bot, ctx *ext.Context) error {
+	cb := ctx.Update.CallbackQuery
+	_, _ = cb.Answer(b, nil)
+
+	chatID := ctx.EffectiveChat.Id
+	isAnonymous := cb.Data == topicAddCallbackAnonymous
+
+	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
+
+	// Get the selected event ID and the topic text from user store
 	eventIDInterface, ok := h.userStore.Get(ctx.EffectiveUser.Id, topicAddCtxDataKeySelectedEventID)
-	if !ok {
-		h.messageSenderService.Reply(
-			msg,
+	topicTextInterface, hasText := h.userStore.Get(ctx.EffectiveUser.Id, topicAddCtxDataKeyTopicText)
+	// Clean up user data
+	h.userStore.Clear(ctx.EffectiveUser.Id)
+	if !ok || !hasText {
+		h.messageSenderService.Send(
+			chatID,
 			"An error occurred: the selected event was not found. Please start over.",
 			nil,
 		)
-		log.Printf("%s: Event ID not found in user store", utils.GetCurrentTypeName())
+		log.Printf("%s: Event ID or topic text not found in user store", utils.GetCurrentTypeName())
 		return handlers.EndConversation()
 	}
 
 	eventID := eventIDInterface.(int)
+	topicText := topicTextInterface.(string)
 	userNickname := "not specified"
 	if ctx.EffectiveUser.Username != "" {
 		userNickname = ctx.EffectiveUser.Username
 	}
 
-	// Create the new topic
-	_, err := h.topicRepository.CreateTopic(topicText, userNickname, eventID)
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
 	if err != nil {
-		h.messageSenderService.Reply(msg, "Oops! Something went wrong...", nil)
-		log.Printf("%s: Error during topic creation in database: %v", utils.GetCurrentTypeName(), err)
+		h.messageSenderService.Send(chatID, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
 		return handlers.EndConversation()
 	}
 
-	// Send notification to admin about new topic
-	eventName, _ := h.userStore.Get(ctx.EffectiveUser.Id, topicAddCtxDataKeySelectedEventName)
-	adminChatID := h.config.AdminUserID
-
-	adminMsg := fmt.Sprintf(
-		"🔔 *New topic added*\n\n"+
-			"_Event:_ %s\n"+
-			"_Author:_ @%s\n"+
-			"_Topic:_ %s",
-		eventName,
-		userNickname,
-		topicText,
-	)
+	// Create the new topic, it waits for moderation before members can see it
+	topicID, err := h.topicRepository.CreateTopic(topicText, userNickname, eventID, user.ID, isAnonymous)
+	if err != nil {
+		h.messageSenderService.Send(chatID, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error during topic creation in database: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
 
-	h.messageSenderService.SendMarkdown(adminChatID, adminMsg, nil)
+	// Send the topic to the admin for moderation
+	if err := h.topicService.SendForModeration(topicID, false); err != nil {
+		log.Printf("%s: Error sending topic %d for moderation: %v", utils.GetCurrentTypeName(), topicID, err)
+	}
 
-	h.messageSenderService.Reply(
-		msg,
+	added := "Added!"
+	if isAnonymous {
+		added = "Added anonymously!"
+	}
+	h.messageSenderService.Send(
+		chatID,
 		fmt.Sprintf(
-			"Added! \nUse the /%s command to view all topics and questions for the event, or /%s to add new topics and questions.",
+			"%s The topic will appear in /%s once an administrator approves it.\n"+
+				"Use /%s to edit or withdraw your topics, or /%s to add new topics and questions.",
+			added,
 			constants.TopicsCommand,
+			constants.MyTopicsCommand,
 			constants.TopicAddCommand,
 		),
 		nil,
 	)
 
-	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
-	// Clean up user data
-	h.userStore.Clear(ctx.EffectiveUser.Id)
-
 	return handlers.EndConversation()
 }
 
@@ -258,7 +318,7 @@ func (h *topicAddHandler) handleCallbackCancel(b *gotgbot.Bot, ctx *ext.Context)
 	return h.handleCancel(b, ctx)
 }
 
-// 4. handleCancel handles the /cancel command
+// 5. handleCancel handles the /cancel command
 func (h *topicAddHandler) handleCancel(b *gotgbot.Bot, ctx *ext.Context) error {
 	msg := ctx.EffectiveMessage
 
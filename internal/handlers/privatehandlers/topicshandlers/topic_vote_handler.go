@@ -0,0 +1,149 @@
+package topicshandlers
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/callbackquery"
+)
+
+// topicVoteHandler handles the upvote buttons of the topic list sent by /topics.
+// The list is sent at the end of the /topics conversation, so the handler is stateless
+// and reads the topic ID from the callback data.
+type topicVoteHandler struct {
+	config          *config.Config
+	topicRepository *repositories.TopicRepository
+	eventRepository *repositories.EventRepository
+	userRepository  *repositories.UserRepository
+}
+
+func NewTopicVoteHandler(
+	config *config.Config,
+	topicRepository *repositories.TopicRepository,
+	eventRepository *repositories.EventRepository,
+	userRepository *repositories.UserRepository,
+) ext.Handler {
+	h := &topicVoteHandler{
+		config:          config,
+		topicRepository: topicRepository,
+		eventRepository: eventRepository,
+		userRepository:  userRepository,
+	}
+
+	return handlers.NewCallback(callbackquery.Prefix(constants.TopicVotePrefix), h.handleCallback)
+}
+
+func (h *topicVoteHandler) handleCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	callback := ctx.Update.CallbackQuery
+
+	if !utils.IsUserClubMember(b, ctx.EffectiveUser.Id, h.config) {
+		return h.answerAlert(b, callback, "Voting is only available to club members.")
+	}
+
+	topicID, err := strconv.Atoi(strings.TrimPrefix(callback.Data, constants.TopicVotePrefix))
+	if err != nil {
+		return h.answerAlert(b, callback, "Unknown topic.")
+	}
+
+	topic, err := h.topicRepository.GetTopicByID(topicID)
+	if err != nil {
+		log.Printf("%s: Error getting topic %d: %v", utils.GetCurrentTypeName(), topicID, err)
+		return h.answerAlert(b, callback, "This topic has been withdrawn.")
+	}
+	if topic.Status != constants.TopicStatusApproved {
+		return h.answerAlert(b, callback, "This topic is under moderation again, try voting later.")
+	}
+
+	event, err := h.eventRepository.GetEventByID(topic.EventID)
+	if err != nil {
+		log.Printf("%s: Error getting event %d: %v", utils.GetCurrentTypeName(), topic.EventID, err)
+		return h.answerAlert(b, callback, "This event no longer exists.")
+	}
+	if event.Status != string(constants.EventStatusActual) {
+		return h.answerAlert(b, callback, "This event has already taken place.")
+	}
+
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), ctx.EffectiveUser.Id, err)
+		return h.answerAlert(b, callback, "An error occurred. Please try again later.")
+	}
+
+	voted, err := h.topicRepository.ToggleVote(topic.ID, user.ID)
+	if err != nil {
+		log.Printf("%s: Error saving vote of user %d for topic %d: %v", utils.GetCurrentTypeName(), user.ID, topic.ID, err)
+		return h.answerAlert(b, callback, "An error occurred while saving your vote. Please try again later.")
+	}
+
+	answer := "Your vote was taken back."
+	if voted {
+		answer = "\U0001f44d Upvoted!"
+	}
+	_, _ = callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: answer})
+
+	h.refreshTopicList(b, ctx, event, user)
+	return nil
+}
+
+// refreshTopicList re-renders the list the button was pressed on, so it shows the new ranking
+func (h *topicVoteHandler) refreshTopicList(b *gotgbot.Bot, ctx *ext.Context, event *repositories.Event, user *repositories.User) {
+	msg := ctx.EffectiveMessage
+	if msg == nil {
+		return
+	}
+
+	text, markup, err := buildRankedTopicList(h.topicRepository, event, user)
+	if err != nil {
+		log.Printf("%s: Error building topics of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return
+	}
+
+	_, _, err = b.EditMessageText(text, &gotgbot.EditMessageTextOpts{
+		ChatId:             msg.Chat.Id,
+		MessageId:          msg.MessageId,
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        markup,
+	})
+	if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("%s: Error refreshing topics of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+}
+
+func (h *topicVoteHandler) answerAlert(b *gotgbot.Bot, callback *gotgbot.CallbackQuery, text string) error {
+	_, err := callback.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
+		Text:      text,
+		ShowAlert: true,
+	})
+	return err
+}
+
+// buildRankedTopicList renders the approved topics of the event ranked by votes with the member's vote buttons
+func buildRankedTopicList(
+	topicRepository *repositories.TopicRepository,
+	event *repositories.Event,
+	user *repositories.User,
+) (string, gotgbot.InlineKeyboardMarkup, error) {
+	topics, err := topicRepository.GetRankedTopicsByEventID(event.ID)
+	if err != nil {
+		return "", gotgbot.InlineKeyboardMarkup{}, err
+	}
+
+	voted, err := topicRepository.GetUserVotedTopicIDs(event.ID, user.ID)
+	if err != nil {
+		return "", gotgbot.InlineKeyboardMarkup{}, err
+	}
+
+	return formatters.FormatHtmlTopicListForUsers(topics, event.Name, event.TypeInfo), buttons.TopicVoteButtons(topics, voted), nil
+}
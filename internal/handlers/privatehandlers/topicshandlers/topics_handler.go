@@ -39,6 +39,7 @@ type topicsHandler struct {
 	config               *config.Config
 	topicRepository      *repositories.TopicRepository
 	eventRepository      *repositories.EventRepository
+	userRepository       *repositories.UserRepository
 	messageSenderService *services.MessageSenderService
 	userStore            *utils.UserDataStore
 	permissionsService   *services.PermissionsService
@@ -48,6 +49,7 @@ func NewTopicsHandler(
 	config *config.Config,
 	topicRepository *repositories.TopicRepository,
 	eventRepository *repositories.EventRepository,
+	userRepository *repositories.UserRepository,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
 ) ext.Handler {
@@ -55,6 +57,7 @@ func NewTopicsHandler(
 		config:               config,
 		topicRepository:      topicRepository,
 		eventRepository:      eventRepository,
+		userRepository:       userRepository,
 		messageSenderService: messageSenderService,
 		userStore:            utils.NewUserDataStore(),
 		permissionsService:   permissionsService,
@@ -91,7 +94,7 @@ func (h *topicsHandler) startTopics(b *gotgbot.Bot, ctx *ext.Context) error {
 	}
 
 	// Get last actual events to show for selection
-	events, err := h.eventRepository.GetLastActualEvents(10)
+	events, err := h.eventRepository.GetLastActualEventsWithNextOccurrences(10)
 	if err != nil {
 		h.messageSenderService.Reply(msg, "Error retrieving the list of events.", nil)
 		log.Printf("%s: Error during events retrieval: %v", utils.GetCurrentTypeName(), err)
@@ -107,6 +110,7 @@ func (h *topicsHandler) startTopics(b *gotgbot.Bot, ctx *ext.Context) error {
 	formattedEvents := formatters.FormatEventListForTopicsView(
 		events,
 		fmt.Sprintf("Select the event ID for which you want to see topics and questions"),
+		h.config.EventsTimezone,
 	)
 
 	sentMsg, _ := h.messageSenderService.ReplyMarkdownWithReturnMessage(
@@ -149,8 +153,15 @@ func (h *topicsHandler) handleEventSelection(b *gotgbot.Bot, ctx *ext.Context) e
 		return nil // Stay in the same state
 	}
 
-	// Get topics for this event
-	topics, err := h.topicRepository.GetTopicsByEventID(eventID)
+	user, err := h.userRepository.GetOrCreate(ctx.EffectiveUser)
+	if err != nil {
+		h.messageSenderService.Reply(msg, "Oops! Something went wrong...", nil)
+		log.Printf("%s: Error getting user: %v", utils.GetCurrentTypeName(), err)
+		return handlers.EndConversation()
+	}
+
+	// Get approved topics for this event ranked by votes
+	formattedTopics, markup, err := buildRankedTopicList(h.topicRepository, event, user)
 	if err != nil {
 		h.messageSenderService.Reply(msg, "Error retrieving topics and questions for the selected event.", nil)
 		log.Printf("%s: Error during topics retrieval: %v", utils.GetCurrentTypeName(), err)
@@ -158,9 +169,8 @@ func (h *topicsHandler) handleEventSelection(b *gotgbot.Bot, ctx *ext.Context) e
 	}
 
 	h.MessageRemoveInlineKeyboard(b, &ctx.EffectiveUser.Id)
-	// Format and display topics
-	formattedTopics := formatters.FormatHtmlTopicListForUsers(topics, event.Name, event.Type)
-	h.messageSenderService.ReplyHtml(msg, formattedTopics, nil)
+	// Display topics with the vote buttons
+	h.messageSenderService.ReplyHtml(msg, formattedTopics, &gotgbot.SendMessageOpts{ReplyMarkup: markup})
 
 	// Clean up user data
 	h.userStore.Clear(ctx.EffectiveUser.Id)
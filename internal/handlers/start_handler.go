@@ -3,9 +3,11 @@ package handlers
 import (
 	"evo-bot-go/internal/config"
 	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
 	"evo-bot-go/internal/formatters"
 	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
+	"log"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
@@ -24,17 +26,23 @@ type startHandler struct {
 	config               *config.Config
 	messageSenderService *services.MessageSenderService
 	permissionsService   *services.PermissionsService
+	onboardingService    *services.OnboardingService
+	userRepository       *repositories.UserRepository
 }
 
 func NewStartHandler(
 	config *config.Config,
 	messageSenderService *services.MessageSenderService,
 	permissionsService *services.PermissionsService,
+	onboardingService *services.OnboardingService,
+	userRepository *repositories.UserRepository,
 ) ext.Handler {
 	h := &startHandler{
 		config:               config,
 		messageSenderService: messageSenderService,
 		permissionsService:   permissionsService,
+		onboardingService:    onboardingService,
+		userRepository:       userRepository,
 	}
 	return handlers.NewConversation(
 		[]ext.Handler{
@@ -90,7 +98,7 @@ func (h *startHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
 				{
 					{
 						Text: "📖 Open AI Course",
-						Url:  "https://antikriza.github.io/BBD-evolution-code-clone/telegram-archive/course/twa/index.html",
+						Url:  constants.CourseMiniAppURL,
 					},
 				},
 			},
@@ -121,6 +129,16 @@ func (h *startHandler) handleStart(b *gotgbot.Bot, ctx *ext.Context) error {
 		},
 	)
 
+	// New members mentioned in the group get their onboarding checklist once they start the bot
+	if isGroupMember {
+		dbUser, err := h.userRepository.GetOrCreate(user)
+		if err != nil {
+			log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), user.Id, err)
+		} else {
+			h.onboardingService.SendChecklistIfInProgress(dbUser)
+		}
+	}
+
 	return handlers.NextConversationState(startHandlerStateProcessCallback)
 }
 
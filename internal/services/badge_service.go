@@ -0,0 +1,166 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// badgeRule awards a badge to the members whose count reaches the threshold
+type badgeRule struct {
+	badge     constants.Badge
+	threshold int
+	count     func() (map[int]int, error)
+}
+
+// BadgeService awards the badges by evaluating the rules against the existing data
+type BadgeService struct {
+	bot               *gotgbot.Bot
+	config            *config.Config
+	messageSender     *MessageSenderService
+	badgeRepository   *repositories.BadgeRepository
+	userRepository    *repositories.UserRepository
+	profileRepository *repositories.ProfileRepository
+}
+
+// NewBadgeService creates a new badge service
+func NewBadgeService(
+	bot *gotgbot.Bot,
+	config *config.Config,
+	messageSender *MessageSenderService,
+	badgeRepository *repositories.BadgeRepository,
+	userRepository *repositories.UserRepository,
+	profileRepository *repositories.ProfileRepository,
+) *BadgeService {
+	return &BadgeService{
+		bot:               bot,
+		config:            config,
+		messageSender:     messageSender,
+		badgeRepository:   badgeRepository,
+		userRepository:    userRepository,
+		profileRepository: profileRepository,
+	}
+}
+
+// rules returns the badge rules, in the order of constants.AllBadges
+func (s *BadgeService) rules() []badgeRule {
+	return []badgeRule{
+		{constants.BadgePublishedProfile, 1, s.badgeRepository.CountPublishedProfiles},
+		{constants.BadgeFirstCoffee, 1, s.badgeRepository.CountCoffeeRounds},
+		{constants.BadgeCoffeeRegular, constants.BadgeCoffeeRegularRounds, s.badgeRepository.CountCoffeeRounds},
+		{constants.BadgeFirstTopic, 1, s.badgeRepository.CountApprovedTopics},
+		{constants.BadgeEventHost, 1, s.badgeRepository.CountHostedEvents},
+		{constants.BadgeToolScout, s.config.BadgeToolsCount, func() (map[int]int, error) {
+			return s.badgeRepository.CountSavedTools(s.config.ToolTopicID)
+		}},
+	}
+}
+
+// AwardBadges awards the badges the club members have earned since the last run.
+// Every member gets a congratulatory DM per badge and their published profile is updated to list it.
+// The first run of a badge awards it for the earlier contributions silently, so the members aren't
+// flooded with DMs once the badge is introduced.
+func (s *BadgeService) AwardBadges() {
+	awardedUsers := make(map[int]*repositories.User)
+
+	for _, rule := range s.rules() {
+		counts, err := rule.count()
+		if err != nil {
+			log.Printf("%s: Error counting for badge %s: %v", utils.GetCurrentTypeName(), rule.badge, err)
+			continue
+		}
+
+		awarded, err := s.badgeRepository.GetAwardedUserIDs(rule.badge)
+		if err != nil {
+			log.Printf("%s: Error getting members with badge %s: %v", utils.GetCurrentTypeName(), rule.badge, err)
+			continue
+		}
+
+		backfilled, err := s.badgeRepository.IsBackfilled(rule.badge)
+		if err != nil {
+			log.Printf("%s: Error checking backfill of badge %s: %v", utils.GetCurrentTypeName(), rule.badge, err)
+			continue
+		}
+
+		for userID, count := range counts {
+			if count < rule.threshold || awarded[userID] {
+				continue
+			}
+
+			user, err := s.userRepository.GetByID(userID)
+			if err != nil {
+				log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), userID, err)
+				continue
+			}
+			if !user.IsClubMember {
+				continue
+			}
+
+			isNew, err := s.badgeRepository.Award(userID, rule.badge)
+			if err != nil {
+				log.Printf("%s: Error awarding badge %s to user %d: %v", utils.GetCurrentTypeName(), rule.badge, userID, err)
+				continue
+			}
+			if !isNew {
+				continue
+			}
+
+			log.Printf("%s: Awarded badge %s to user %d", utils.GetCurrentTypeName(), rule.badge, userID)
+			awardedUsers[userID] = user
+
+			if !backfilled {
+				continue
+			}
+			err = s.messageSender.SendHtml(user.TgID, formatters.FormatBadgeAwardedMessage(rule.badge, s.config.BadgeToolsCount), nil)
+			if err != nil {
+				log.Printf("%s: Error sending badge %s to user %d: %v", utils.GetCurrentTypeName(), rule.badge, userID, err)
+			}
+		}
+
+		if !backfilled {
+			if err := s.badgeRepository.MarkBackfilled(rule.badge); err != nil {
+				log.Printf("%s: Error marking badge %s as backfilled: %v", utils.GetCurrentTypeName(), rule.badge, err)
+			}
+		}
+	}
+
+	for _, user := range awardedUsers {
+		s.updatePublishedProfile(user)
+	}
+}
+
+// updatePublishedProfile edits the published profile of a member, so it lists their new badges
+func (s *BadgeService) updatePublishedProfile(user *repositories.User) {
+	profile, err := s.profileRepository.GetOrCreate(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting profile of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	if !profile.PublishedMessageID.Valid {
+		return
+	}
+
+	badges, err := s.badgeRepository.GetByUserID(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting badges of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+
+	_, _, err = s.bot.EditMessageText(
+		formatters.FormatPublicProfileForMessage(user, profile, badges, false),
+		&gotgbot.EditMessageTextOpts{
+			ChatId:    utils.ChatIdToFullChatId(s.config.SuperGroupChatID),
+			MessageId: profile.PublishedMessageID.Int64,
+			ParseMode: "HTML",
+		})
+	if err != nil && !strings.Contains(err.Error(), "are exactly the same") {
+		log.Printf("%s: Error updating published profile of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+)
+
+const (
+	// eventCalendarName is shown by calendar apps as the name of the subscribed calendar
+	eventCalendarName = "Community events"
+	// eventCalendarDefaultDuration is used for events without a duration, since calendar entries need an end
+	eventCalendarDefaultDuration = time.Hour
+	// eventCalendarFeedHistory limits how far back the feed goes, to keep it small
+	eventCalendarFeedHistory = 180 * 24 * time.Hour
+	// calendarFeedTokenBytes is the length of the random part of the feed links
+	calendarFeedTokenBytes = 24
+)
+
+// EventCalendarService renders events as iCalendar files and manages members' personal calendar feed links
+type EventCalendarService struct {
+	config    *config.Config
+	eventRepo *repositories.EventRepository
+	tokenRepo *repositories.CalendarFeedTokenRepository
+}
+
+// NewEventCalendarService creates a new event calendar service
+func NewEventCalendarService(
+	config *config.Config,
+	eventRepo *repositories.EventRepository,
+	tokenRepo *repositories.CalendarFeedTokenRepository,
+) *EventCalendarService {
+	return &EventCalendarService{
+		config:    config,
+		eventRepo: eventRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// IsFeedEnabled reports whether the calendar feed server is configured
+func (s *EventCalendarService) IsFeedEnabled() bool {
+	return s.config.CalendarFeedAddr != ""
+}
+
+// BuildEventICS renders a single event as an .ics file
+func (s *EventCalendarService) BuildEventICS(event repositories.Event) (string, error) {
+	if event.StartedAt == nil || event.StartedAt.IsZero() {
+		return "", fmt.Errorf("%s: event %d has no planned start", utils.GetCurrentTypeName(), event.ID)
+	}
+	return utils.BuildICalendar("", []utils.ICalEvent{eventToICal(event)}, time.Now()), nil
+}
+
+// BuildFeed renders the calendar feed: events from the last months on, including the deleted ones as cancelled
+func (s *EventCalendarService) BuildFeed(now time.Time) (string, error) {
+	since := now.Add(-eventCalendarFeedHistory)
+
+	events, err := s.eventRepo.GetEventsStartingSince(since)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get events: %w", utils.GetCurrentTypeName(), err)
+	}
+	cancelledEvents, err := s.eventRepo.GetCancelledEventsSince(since)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to get cancelled events: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	icalEvents := make([]utils.ICalEvent, 0, len(events)+len(cancelledEvents))
+	for _, event := range events {
+		icalEvents = append(icalEvents, eventToICal(event))
+	}
+	for _, event := range cancelledEvents {
+		icalEvents = append(icalEvents, utils.ICalEvent{
+			UID:          eventUID(event.EventID),
+			Summary:      event.Name,
+			Start:        event.StartedAt,
+			End:          event.StartedAt.Add(eventDuration(event.DurationMinutes)),
+			LastModified: event.CancelledAt,
+			Sequence:     event.Sequence,
+			Cancelled:    true,
+		})
+	}
+
+	return utils.BuildICalendar(eventCalendarName, icalEvents, now), nil
+}
+
+// GetFeedURL returns the member's personal feed link, creating it on first use
+func (s *EventCalendarService) GetFeedURL(userID int) (string, error) {
+	token, err := s.tokenRepo.GetByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return s.ResetFeedURL(userID)
+	}
+	return s.feedURL(token), nil
+}
+
+// ResetFeedURL replaces the member's feed link with a new one, e.g. if the old one was shared by mistake
+func (s *EventCalendarService) ResetFeedURL(userID int) (string, error) {
+	tokenBytes := make([]byte, calendarFeedTokenBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("%s: failed to generate calendar feed token: %w", utils.GetCurrentTypeName(), err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	if err := s.tokenRepo.Set(userID, token); err != nil {
+		return "", err
+	}
+	return s.feedURL(token), nil
+}
+
+// GetUserIDByFeedToken returns the owner of a feed link token, sql.ErrNoRows if the token is unknown
+func (s *EventCalendarService) GetUserIDByFeedToken(token string) (int, error) {
+	return s.tokenRepo.GetUserIDByToken(token)
+}
+
+func (s *EventCalendarService) feedURL(token string) string {
+	return fmt.Sprintf("%s/calendar/%s.ics", s.config.CalendarFeedBaseURL, token)
+}
+
+func eventToICal(event repositories.Event) utils.ICalEvent {
+	var description []string
+	if event.Description != "" {
+		description = append(description, event.Description)
+	}
+	if host := formatters.FormatEventHost(event); host != "" {
+		description = append(description, "Host: "+host)
+	}
+	if event.Link != "" {
+		description = append(description, "Join: "+event.Link)
+	}
+
+	location := event.Location
+	if location == "" {
+		location = event.Link
+	}
+
+	return utils.ICalEvent{
+		UID:          eventUID(event.ID),
+		Summary:      event.Name,
+		Description:  strings.Join(description, "\n\n"),
+		Location:     location,
+		URL:          event.Link,
+		Start:        *event.StartedAt,
+		End:          event.StartedAt.Add(eventDuration(event.DurationMinutes)),
+		LastModified: event.UpdatedAt,
+		Sequence:     event.Sequence,
+	}
+}
+
+// eventUID is the stable identifier of an event in calendars, kept across updates and cancellation
+func eventUID(eventID int) string {
+	return fmt.Sprintf("event-%d@evo-bot-go", eventID)
+}
+
+func eventDuration(durationMinutes *int) time.Duration {
+	if durationMinutes == nil {
+		return eventCalendarDefaultDuration
+	}
+	return time.Duration(*durationMinutes) * time.Minute
+}
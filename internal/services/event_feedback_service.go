@@ -0,0 +1,118 @@
+package services
+
+import (
+	"log"
+	"slices"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// eventFeedbackGracePeriod is how late the feedback form may still be sent, e.g. after the bot was restarted.
+// Older events are skipped, so enabling the feature doesn't send forms for all the past events.
+const eventFeedbackGracePeriod = 24 * time.Hour
+
+// EventFeedbackService asks the attendees to rate the events once they are over
+type EventFeedbackService struct {
+	config        *config.Config
+	messageSender *MessageSenderService
+	eventRepo     *repositories.EventRepository
+	rsvpRepo      *repositories.EventRSVPRepository
+	feedbackRepo  *repositories.EventFeedbackRepository
+}
+
+// NewEventFeedbackService creates a new event feedback service
+func NewEventFeedbackService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	eventRepo *repositories.EventRepository,
+	rsvpRepo *repositories.EventRSVPRepository,
+	feedbackRepo *repositories.EventFeedbackRepository,
+) *EventFeedbackService {
+	return &EventFeedbackService{
+		config:        config,
+		messageSender: messageSender,
+		eventRepo:     eventRepo,
+		rsvpRepo:      rsvpRepo,
+		feedbackRepo:  feedbackRepo,
+	}
+}
+
+// SendDueFeedbackRequests sends the feedback form of the finished events that are over by the given time.
+// Every form is recorded before it is sent, so it's sent at most once per event.
+func (s *EventFeedbackService) SendDueFeedbackRequests(now time.Time) {
+	events, err := s.eventRepo.GetFinishedEventsEndedBetween(now.Add(-eventFeedbackGracePeriod), now, s.config.EventFeedbackDelay)
+	if err != nil {
+		log.Printf("%s: Error getting finished events: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	for i := range events {
+		event := &events[i]
+
+		claimed, err := s.feedbackRepo.MarkRequested(event.ID)
+		if err != nil {
+			log.Printf("%s: Error recording feedback request for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		log.Printf("%s: Sending feedback form for event %d", utils.GetCurrentTypeName(), event.ID)
+		s.sendFeedbackRequest(event)
+	}
+}
+
+// sendFeedbackRequest sends the feedback form to the members who were going or maybe going.
+// If nobody responded to the event, e.g. it was never announced with the RSVP buttons,
+// the form is posted to the announcement topic instead.
+func (s *EventFeedbackService) sendFeedbackRequest(event *repositories.Event) {
+	attendees, err := s.rsvpRepo.GetAttendees(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting attendees of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return
+	}
+
+	statuses := []constants.EventRSVPStatus{constants.EventRSVPStatusGoing, constants.EventRSVPStatusMaybe}
+	sent := 0
+	for _, attendee := range attendees {
+		if !slices.Contains(statuses, attendee.Status) {
+			continue
+		}
+
+		err := s.messageSender.SendHtml(
+			attendee.User.TgID,
+			formatters.FormatHtmlEventFeedbackRequest(*event, false),
+			&gotgbot.SendMessageOpts{ReplyMarkup: buttons.EventFeedbackRatingButtons(event.ID)},
+		)
+		if err != nil {
+			log.Printf("%s: Error sending feedback form of event %d to user %d: %v",
+				utils.GetCurrentTypeName(), event.ID, attendee.User.ID, err)
+			continue
+		}
+		sent++
+	}
+	if sent > 0 {
+		return
+	}
+
+	err = s.messageSender.SendHtml(
+		utils.ChatIdToFullChatId(s.config.SuperGroupChatID),
+		formatters.FormatHtmlEventFeedbackRequest(*event, true),
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: int64(s.config.AnnouncementTopicID),
+			ReplyMarkup:     buttons.EventFeedbackRatingButtons(event.ID),
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error posting feedback form of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+}
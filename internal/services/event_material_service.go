@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventMaterialService publishes the recordings, slides and links of finished events to the Content topic
+type EventMaterialService struct {
+	config                 *config.Config
+	messageSender          *MessageSenderService
+	eventMaterialRepo      *repositories.EventMaterialRepository
+	groupMessageRepository *repositories.GroupMessageRepository
+}
+
+// NewEventMaterialService creates a new event material service
+func NewEventMaterialService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	eventMaterialRepo *repositories.EventMaterialRepository,
+	groupMessageRepository *repositories.GroupMessageRepository,
+) *EventMaterialService {
+	return &EventMaterialService{
+		config:                 config,
+		messageSender:          messageSender,
+		eventMaterialRepo:      eventMaterialRepo,
+		groupMessageRepository: groupMessageRepository,
+	}
+}
+
+// Publish posts the material to the Content topic and saves the post, so /content finds it the same way
+// as the messages saved with "update". A file sent to the bot is copied with the post as its caption.
+// botTgID is the Telegram ID of the bot, the author of the post.
+func (s *EventMaterialService) Publish(
+	event *repositories.Event,
+	kind constants.EventMaterialKind,
+	description string,
+	url string,
+	file *gotgbot.Message,
+	botTgID int64,
+) error {
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	post := formatters.FormatHtmlEventMaterialPost(*event, kind, description, url, s.config.EventsTimezone)
+
+	var messageID int64
+	if file != nil {
+		copiedID, err := s.messageSender.CopyMessage(chatID, file.Chat.Id, file.MessageId, &gotgbot.CopyMessageOpts{
+			MessageThreadId: int64(s.config.ContentTopicID),
+			Caption:         &post,
+			ParseMode:       "HTML",
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to post material of event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+		}
+		messageID = copiedID
+	} else {
+		sentMsg, err := s.messageSender.SendHtmlWithReturnMessage(chatID, post, &gotgbot.SendMessageOpts{
+			MessageThreadId:    int64(s.config.ContentTopicID),
+			LinkPreviewOptions: &gotgbot.LinkPreviewOptions{Url: url},
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to post material of event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+		}
+		messageID = sentMsg.MessageId
+	}
+
+	// The bot doesn't receive its own messages, so the post is saved here, as sent by the bot
+	if _, err := s.groupMessageRepository.Create(messageID, post, nil, botTgID, int64(s.config.ContentTopicID)); err != nil {
+		log.Printf("%s: Error saving material post %d of event %d: %v", utils.GetCurrentTypeName(), messageID, event.ID, err)
+	}
+
+	if _, err := s.eventMaterialRepo.Create(event.ID, kind, description, url, messageID); err != nil {
+		return err
+	}
+	return nil
+}
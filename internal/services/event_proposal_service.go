@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventProposalService sends the events proposed by members for review and turns the approved ones into events
+type EventProposalService struct {
+	config            *config.Config
+	messageSender     *MessageSenderService
+	proposalRepo      *repositories.EventProposalRepository
+	eventRepo         *repositories.EventRepository
+	userRepo          *repositories.UserRepository
+	eventTopicService *EventTopicService
+}
+
+// NewEventProposalService creates a new event proposal service
+func NewEventProposalService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	proposalRepo *repositories.EventProposalRepository,
+	eventRepo *repositories.EventRepository,
+	userRepo *repositories.UserRepository,
+	eventTopicService *EventTopicService,
+) *EventProposalService {
+	return &EventProposalService{
+		config:            config,
+		messageSender:     messageSender,
+		proposalRepo:      proposalRepo,
+		eventRepo:         eventRepo,
+		userRepo:          userRepo,
+		eventTopicService: eventTopicService,
+	}
+}
+
+// FormatForReview renders the proposal with its proposer for the administrators
+func (s *EventProposalService) FormatForReview(proposal *repositories.EventProposal) (string, error) {
+	proposer, err := s.userRepo.GetByID(proposal.UserID)
+	if err != nil {
+		return "", err
+	}
+	return formatters.FormatHtmlEventProposalForReview(*proposal, *proposer), nil
+}
+
+// SendForReview sends a new or revised proposal to the administrator with the review buttons
+func (s *EventProposalService) SendForReview(proposalID int) error {
+	proposal, err := s.proposalRepo.GetByID(proposalID)
+	if err != nil {
+		return err
+	}
+
+	text, err := s.FormatForReview(proposal)
+	if err != nil {
+		return err
+	}
+
+	return s.messageSender.SendHtml(
+		s.config.AdminUserID,
+		text,
+		&gotgbot.SendMessageOpts{ReplyMarkup: buttons.EventProposalReviewButtons(proposal.ID)},
+	)
+}
+
+// Approve creates the event from the proposal with the proposer as its host and lets the proposer know.
+// The start is left empty: the administrators set it with /eventEdit using the preferred dates.
+func (s *EventProposalService) Approve(proposalID int) (*repositories.EventProposal, error) {
+	proposal, err := s.getPending(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := s.eventRepo.CreateEvent(proposal.Title, proposal.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.UpdateEventDescription(eventID, proposal.Description); err != nil {
+		log.Printf("%s: Error setting description of event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+	}
+	if err := s.eventRepo.UpdateEventHost(eventID, &proposal.UserID); err != nil {
+		log.Printf("%s: Error setting host of event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	if err := s.proposalRepo.UpdateReviewStatus(proposalID, constants.EventProposalStatusApproved, "", &eventID); err != nil {
+		// Another administrator has reviewed the proposal in the meantime
+		if deleteErr := s.eventRepo.DeleteEvent(eventID); deleteErr != nil {
+			log.Printf("%s: Error deleting event %d of proposal %d: %v", utils.GetCurrentTypeName(), eventID, proposalID, deleteErr)
+		}
+		return nil, err
+	}
+	proposal.Status = constants.EventProposalStatusApproved
+	proposal.EventID = &eventID
+
+	if _, err := s.eventTopicService.HandleEventCreated(eventID); err != nil {
+		log.Printf("%s: Error creating topic of event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	s.notifyProposer(proposal)
+	return proposal, nil
+}
+
+// Reject declines the proposal with the comment shown to the proposer
+func (s *EventProposalService) Reject(proposalID int, comment string) (*repositories.EventProposal, error) {
+	return s.review(proposalID, constants.EventProposalStatusRejected, comment)
+}
+
+// RequestChanges sends the proposal back to the proposer with the comment, it can be revised and sent again
+func (s *EventProposalService) RequestChanges(proposalID int, comment string) (*repositories.EventProposal, error) {
+	return s.review(proposalID, constants.EventProposalStatusChangesRequested, comment)
+}
+
+func (s *EventProposalService) review(
+	proposalID int,
+	status constants.EventProposalStatus,
+	comment string,
+) (*repositories.EventProposal, error) {
+	proposal, err := s.getPending(proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.proposalRepo.UpdateReviewStatus(proposalID, status, comment, nil); err != nil {
+		return nil, err
+	}
+	proposal.Status = status
+	proposal.AdminComment = comment
+
+	s.notifyProposer(proposal)
+	return proposal, nil
+}
+
+func (s *EventProposalService) getPending(proposalID int) (*repositories.EventProposal, error) {
+	proposal, err := s.proposalRepo.GetByID(proposalID)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != constants.EventProposalStatusPending {
+		return nil, fmt.Errorf("%s: event proposal %d is already %s", utils.GetCurrentTypeName(), proposalID, proposal.Status)
+	}
+	return proposal, nil
+}
+
+// notifyProposer sends the result of the review to the member, with the revise button if changes were requested
+func (s *EventProposalService) notifyProposer(proposal *repositories.EventProposal) {
+	proposer, err := s.userRepo.GetByID(proposal.UserID)
+	if err != nil {
+		log.Printf("%s: Error getting proposer of event proposal %d: %v", utils.GetCurrentTypeName(), proposal.ID, err)
+		return
+	}
+
+	var opts *gotgbot.SendMessageOpts
+	if proposal.Status == constants.EventProposalStatusChangesRequested {
+		opts = &gotgbot.SendMessageOpts{ReplyMarkup: buttons.EventProposalReviseButton(proposal.ID)}
+	}
+
+	err = s.messageSender.SendHtml(proposer.TgID, formatters.FormatHtmlEventProposalReviewResult(*proposal), opts)
+	if err != nil {
+		log.Printf("%s: Error notifying proposer of event proposal %d: %v", utils.GetCurrentTypeName(), proposal.ID, err)
+	}
+}
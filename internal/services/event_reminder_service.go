@@ -0,0 +1,227 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"slices"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// eventStartAnnouncementGracePeriod is how late the join link may still be sent automatically,
+// e.g. after the bot was restarted around the planned start
+const eventStartAnnouncementGracePeriod = 30 * time.Minute
+
+// EventReminderService reminds members about upcoming events and announces their start
+type EventReminderService struct {
+	config        *config.Config
+	messageSender *MessageSenderService
+	eventRepo     *repositories.EventRepository
+	rsvpRepo      *repositories.EventRSVPRepository
+	reminderRepo  *repositories.EventReminderRepository
+	topicService  *TopicService
+}
+
+// NewEventReminderService creates a new event reminder service
+func NewEventReminderService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	eventRepo *repositories.EventRepository,
+	rsvpRepo *repositories.EventRSVPRepository,
+	reminderRepo *repositories.EventReminderRepository,
+	topicService *TopicService,
+) *EventReminderService {
+	return &EventReminderService{
+		config:        config,
+		messageSender: messageSender,
+		eventRepo:     eventRepo,
+		rsvpRepo:      rsvpRepo,
+		reminderRepo:  reminderRepo,
+		topicService:  topicService,
+	}
+}
+
+// SendDueReminders sends the reminders that are due at the given time and the join links of the events
+// that have just started. Every reminder is recorded before it is sent, so it's sent at most once.
+func (s *EventReminderService) SendDueReminders(now time.Time) {
+	var maxOffset time.Duration
+	if len(s.config.EventReminderOffsets) > 0 {
+		maxOffset = s.config.EventReminderOffsets[0]
+	}
+
+	events, err := s.eventRepo.GetActualEventsStartingBetween(now.Add(-eventStartAnnouncementGracePeriod), now.Add(maxOffset))
+	if err != nil {
+		log.Printf("%s: Error getting upcoming events: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	for i := range events {
+		event := &events[i]
+		if now.Before(*event.StartedAt) {
+			s.sendReminder(event, now)
+		} else {
+			s.sendStartAnnouncement(event)
+		}
+	}
+}
+
+// AnnounceStart marks the event as started, posts the join link to the announcement topic and pins it,
+// sends the link to the members who are going and the ranked agenda to the host
+func (s *EventReminderService) AnnounceStart(event *repositories.Event, link string) error {
+	// When event already started in DB we need to set status to finished
+	if err := s.eventRepo.UpdateEventStatus(event.ID, constants.EventStatusFinished); err != nil {
+		return fmt.Errorf("%s: failed to update status of event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	sentAnnouncementMsg, err := s.messageSender.SendMarkdownWithReturnMessage(
+		utils.ChatIdToFullChatId(s.config.SuperGroupChatID),
+		formatters.FormatEventStartAnnouncement(*event),
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: int64(s.config.AnnouncementTopicID),
+			ReplyMarkup:     buttons.EventJoinLinkButton(link),
+		},
+	)
+
+	// Pin the announcement message with notification for all users
+	if err == nil && sentAnnouncementMsg != nil {
+		if err := s.messageSender.PinMessage(sentAnnouncementMsg.Chat.Id, sentAnnouncementMsg.MessageId, true); err != nil {
+			log.Printf("%s: Error pinning announcement message: %v", utils.GetCurrentTypeName(), err)
+		}
+	}
+
+	s.sendToAttendees(event, []constants.EventRSVPStatus{constants.EventRSVPStatusGoing},
+		fmt.Sprintf("\U0001f534 <b>%s</b> is starting now! Use the button below to join ⬇️", html.EscapeString(event.Name)),
+		buttons.EventJoinLinkButton(link),
+	)
+
+	s.sendAgenda(event)
+	return nil
+}
+
+// sendReminder sends the reminder for the nearest offset that is due. The longer offsets that are due as well
+// (e.g. the event was created an hour before its start) are recorded without sending them.
+func (s *EventReminderService) sendReminder(event *repositories.Event, now time.Time) {
+	untilStart := event.StartedAt.Sub(now)
+
+	var dueOffsets []time.Duration
+	for _, offset := range s.config.EventReminderOffsets {
+		if offset >= untilStart {
+			dueOffsets = append(dueOffsets, offset)
+		}
+	}
+	if len(dueOffsets) == 0 {
+		return
+	}
+
+	shouldSend := false
+	for i, offset := range dueOffsets {
+		claimed, err := s.reminderRepo.MarkSent(event.ID, *event.StartedAt, int(offset.Minutes()))
+		if err != nil {
+			log.Printf("%s: Error recording reminder for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+			return
+		}
+		// Offsets are sorted longest first, so the last one is the nearest
+		if i == len(dueOffsets)-1 {
+			shouldSend = claimed
+		}
+	}
+	if !shouldSend {
+		return
+	}
+
+	log.Printf("%s: Sending reminder for event %d", utils.GetCurrentTypeName(), event.ID)
+
+	counts, err := s.rsvpRepo.GetCounts(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting responses of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	err = s.messageSender.SendHtml(
+		utils.ChatIdToFullChatId(s.config.SuperGroupChatID),
+		formatters.FormatHtmlEventReminder(*event, counts, s.config.EventsTimezone, now),
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: int64(s.config.AnnouncementTopicID),
+			ReplyMarkup:     buttons.EventRSVPButtons(event.ID),
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error sending reminder for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	s.sendToAttendees(event,
+		[]constants.EventRSVPStatus{constants.EventRSVPStatusGoing, constants.EventRSVPStatusMaybe},
+		formatters.FormatHtmlEventReminderForAttendee(*event, s.config.EventsTimezone, now),
+		buttons.EventRSVPButtons(event.ID),
+	)
+}
+
+// sendStartAnnouncement sends the join link of an event that has just started.
+// Events without a link (e.g. offline meetups) have no link to send, so they are only marked as finished
+// and the host gets the agenda.
+func (s *EventReminderService) sendStartAnnouncement(event *repositories.Event) {
+	claimed, err := s.reminderRepo.MarkSent(event.ID, *event.StartedAt, 0)
+	if err != nil {
+		log.Printf("%s: Error recording start announcement for event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if event.Link == "" {
+		// Finished events count for feedback, badges and materials, as with AnnounceStart
+		if err := s.eventRepo.UpdateEventStatus(event.ID, constants.EventStatusFinished); err != nil {
+			log.Printf("%s: Error updating status of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		}
+		s.sendAgenda(event)
+		return
+	}
+
+	log.Printf("%s: Announcing start of event %d", utils.GetCurrentTypeName(), event.ID)
+
+	if err := s.AnnounceStart(event, event.Link); err != nil {
+		log.Printf("%s: Error announcing start of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+}
+
+// sendAgenda sends the ranked agenda of the event to the host
+func (s *EventReminderService) sendAgenda(event *repositories.Event) {
+	if err := s.topicService.SendAgenda(event); err != nil {
+		log.Printf("%s: Error sending agenda of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+}
+
+// sendToAttendees sends a DM to every member who responded to the event with one of the statuses
+func (s *EventReminderService) sendToAttendees(
+	event *repositories.Event,
+	statuses []constants.EventRSVPStatus,
+	message string,
+	markup gotgbot.InlineKeyboardMarkup,
+) {
+	attendees, err := s.rsvpRepo.GetAttendees(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting attendees of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return
+	}
+
+	for _, attendee := range attendees {
+		if !slices.Contains(statuses, attendee.Status) {
+			continue
+		}
+
+		err := s.messageSender.SendHtml(attendee.User.TgID, message, &gotgbot.SendMessageOpts{ReplyMarkup: markup})
+		if err != nil {
+			log.Printf("%s: Error sending message about event %d to user %d: %v",
+				utils.GetCurrentTypeName(), event.ID, attendee.User.ID, err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// EventRSVPService stores members' responses to events and notifies the ones promoted from the waitlist
+type EventRSVPService struct {
+	config        *config.Config
+	messageSender *MessageSenderService
+	eventRepo     *repositories.EventRepository
+	rsvpRepo      *repositories.EventRSVPRepository
+	userRepo      *repositories.UserRepository
+}
+
+// NewEventRSVPService creates a new event RSVP service
+func NewEventRSVPService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	eventRepo *repositories.EventRepository,
+	rsvpRepo *repositories.EventRSVPRepository,
+	userRepo *repositories.UserRepository,
+) *EventRSVPService {
+	return &EventRSVPService{
+		config:        config,
+		messageSender: messageSender,
+		eventRepo:     eventRepo,
+		rsvpRepo:      rsvpRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// Respond stores the member's response to the event and lets the members who got a freed seat know about it
+func (s *EventRSVPService) Respond(
+	event *repositories.Event,
+	userID int,
+	status constants.EventRSVPStatus,
+) (*repositories.EventRSVPResult, error) {
+	result, err := s.rsvpRepo.Respond(event.ID, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to respond to event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	s.notifyPromoted(event, result.PromotedUserIDs)
+
+	return result, nil
+}
+
+// PromoteWaitlisted gives the free seats of the event to the waitlist, e.g. after its capacity was changed
+func (s *EventRSVPService) PromoteWaitlisted(eventID int) error {
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	promotedUserIDs, err := s.rsvpRepo.PromoteWaitlisted(event.ID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to promote waitlist of event %d: %w", utils.GetCurrentTypeName(), eventID, err)
+	}
+
+	s.notifyPromoted(event, promotedUserIDs)
+
+	return nil
+}
+
+// notifyPromoted sends a DM to every member who got a seat from the waitlist
+func (s *EventRSVPService) notifyPromoted(event *repositories.Event, userIDs []int) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("\U0001f389 A seat opened up for <b>%s</b> (%s) — you're going now!\n\n"+
+		"If your plans have changed, please update your response below so someone else can take the seat.",
+		html.EscapeString(event.Name),
+		formatters.FormatEventWhen(*event, s.config.EventsTimezone, time.Now()),
+	)
+
+	for _, userID := range userIDs {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			log.Printf("%s: failed to get promoted user %d: %v", utils.GetCurrentTypeName(), userID, err)
+			continue
+		}
+
+		err = s.messageSender.SendHtml(user.TgID, message, &gotgbot.SendMessageOpts{
+			ReplyMarkup: buttons.EventRSVPButtons(event.ID),
+		})
+		if err != nil {
+			log.Printf("%s: failed to notify user %d about the seat for event %d: %v",
+				utils.GetCurrentTypeName(), userID, event.ID, err)
+		}
+	}
+}
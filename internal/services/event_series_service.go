@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+)
+
+// EventSeriesService manages recurring events. Occurrences are created as regular events ahead of time,
+// so members can sign up for them and admins can edit or delete each of them without touching the series.
+type EventSeriesService struct {
+	config            *config.Config
+	eventRepo         *repositories.EventRepository
+	seriesRepo        *repositories.EventSeriesRepository
+	eventTopicService *EventTopicService
+}
+
+// NewEventSeriesService creates a new event series service
+func NewEventSeriesService(
+	config *config.Config,
+	eventRepo *repositories.EventRepository,
+	seriesRepo *repositories.EventSeriesRepository,
+	eventTopicService *EventTopicService,
+) *EventSeriesService {
+	return &EventSeriesService{
+		config:            config,
+		eventRepo:         eventRepo,
+		seriesRepo:        seriesRepo,
+		eventTopicService: eventTopicService,
+	}
+}
+
+// CreateSeries makes the event the first occurrence of a new series and creates the upcoming occurrences
+func (s *EventSeriesService) CreateSeries(eventID int, rule *utils.RecurrenceRule, now time.Time) error {
+	seriesID, err := s.seriesRepo.CreateFromEvent(eventID, rule.String())
+	if err != nil {
+		return err
+	}
+
+	series, err := s.seriesRepo.GetByID(seriesID)
+	if err != nil {
+		return err
+	}
+
+	return s.materialize(*series, now)
+}
+
+// MaterializeOccurrences creates the occurrences of all series that start within the horizon
+func (s *EventSeriesService) MaterializeOccurrences(now time.Time) {
+	seriesList, err := s.seriesRepo.GetAll()
+	if err != nil {
+		log.Printf("%s: Error getting event series: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	for _, series := range seriesList {
+		if err := s.materialize(series, now); err != nil {
+			log.Printf("%s: Error creating occurrences of series %d: %v", utils.GetCurrentTypeName(), series.ID, err)
+		}
+	}
+}
+
+// StopSeries deletes the series and its upcoming occurrences starting at or after from.
+// Earlier occurrences stay as one-time events. Returns the number of deleted occurrences.
+func (s *EventSeriesService) StopSeries(seriesID int, from time.Time) (int, error) {
+	events, err := s.eventRepo.GetActualSeriesEventsStartingSince(seriesID, from)
+	if err != nil {
+		return 0, err
+	}
+
+	// Delete the series first, so no new occurrences are created meanwhile
+	if err := s.seriesRepo.Delete(seriesID); err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := s.eventRepo.DeleteEvent(event.ID); err != nil {
+			return 0, fmt.Errorf("%s: failed to delete occurrence %d of series %d: %w",
+				utils.GetCurrentTypeName(), event.ID, seriesID, err)
+		}
+	}
+
+	return len(events), nil
+}
+
+// materialize creates the occurrences after the last created one, up to the horizon
+func (s *EventSeriesService) materialize(series repositories.EventSeries, now time.Time) error {
+	rule, err := utils.ParseRecurrenceRule(series.Rule)
+	if err != nil {
+		return fmt.Errorf("%s: invalid rule of series %d: %w", utils.GetCurrentTypeName(), series.ID, err)
+	}
+
+	// The rule keeps the time of day in the events timezone
+	start := series.StartedAt.In(s.config.EventsTimezone)
+	horizon := now.AddDate(0, 0, s.config.EventSeriesHorizonDays)
+
+	occurrences := rule.Occurrences(start, series.MaterializedUntil, horizon)
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	log.Printf("%s: Creating %d occurrences of series %d", utils.GetCurrentTypeName(), len(occurrences), series.ID)
+	eventIDs, err := s.seriesRepo.AddOccurrences(series, occurrences)
+	if err != nil {
+		return err
+	}
+
+	// Each occurrence is discussed in its own topic, like any other event
+	for _, eventID := range eventIDs {
+		if _, err := s.eventTopicService.HandleEventCreated(eventID); err != nil {
+			log.Printf("%s: Error creating topic of event %d: %v", utils.GetCurrentTypeName(), eventID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// eventTopicNameMaxLength is the limit of Telegram for forum topic names
+const eventTopicNameMaxLength = 128
+
+// EventTopicService creates a forum topic for the discussion of every new event and closes it after the event.
+// The IDs of the closed topics are kept in memory, as they are checked for every group message.
+type EventTopicService struct {
+	config         *config.Config
+	messageSender  *MessageSenderService
+	eventRepo      *repositories.EventRepository
+	rsvpRepo       *repositories.EventRSVPRepository
+	groupTopicRepo *repositories.GroupTopicRepository
+
+	closedTopicsMutex  sync.RWMutex
+	closedTopicIDs     map[int64]bool
+	closedTopicsLoaded bool
+}
+
+// NewEventTopicService creates a new event topic service
+func NewEventTopicService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	eventRepo *repositories.EventRepository,
+	rsvpRepo *repositories.EventRSVPRepository,
+	groupTopicRepo *repositories.GroupTopicRepository,
+) *EventTopicService {
+	return &EventTopicService{
+		config:         config,
+		messageSender:  messageSender,
+		eventRepo:      eventRepo,
+		rsvpRepo:       rsvpRepo,
+		groupTopicRepo: groupTopicRepo,
+	}
+}
+
+// IsEnabled reports whether topics are created for the new events
+func (s *EventTopicService) IsEnabled() bool {
+	return s.config.EventTopicsEnabled
+}
+
+// HandleEventCreated creates the topic of a new event if topics are enabled, returns nil otherwise.
+// Every way of creating events goes through it: the setup by admins, series occurrences and approved proposals.
+func (s *EventTopicService) HandleEventCreated(eventID int) (*repositories.GroupTopic, error) {
+	if !s.IsEnabled() {
+		return nil, nil
+	}
+
+	event, err := s.eventRepo.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.createTopic(event)
+}
+
+// createTopic creates the topic of the event, registers it in group_topics and pins the event card there.
+// The topic is registered right away, as the bot doesn't get the service message about the topic it created.
+func (s *EventTopicService) createTopic(event *repositories.Event) (*repositories.GroupTopic, error) {
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+
+	name := event.Name
+	if runes := []rune(name); len(runes) > eventTopicNameMaxLength {
+		name = string(runes[:eventTopicNameMaxLength])
+	}
+
+	topicID, err := s.messageSender.CreateForumTopic(chatID, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create topic of event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	groupTopic, err := s.groupTopicRepo.AddEventGroupTopic(topicID, name, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to save topic of event %d: %w", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	counts, err := s.rsvpRepo.GetCounts(event.ID)
+	if err != nil {
+		log.Printf("%s: Error getting responses of event %d: %v", utils.GetCurrentTypeName(), event.ID, err)
+	}
+
+	card, err := s.messageSender.SendHtmlWithReturnMessage(
+		chatID,
+		formatters.FormatHtmlEventCard(*event, counts, s.config.EventsTimezone, time.Now()),
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: topicID,
+			ReplyMarkup:     buttons.EventRSVPButtons(event.ID),
+		},
+	)
+	if err != nil {
+		// The topic is usable without the card, so the error is only logged
+		log.Printf("%s: Error posting card of event %d to its topic: %v", utils.GetCurrentTypeName(), event.ID, err)
+		return groupTopic, nil
+	}
+	_ = s.messageSender.PinMessage(chatID, card.MessageId, false)
+
+	return groupTopic, nil
+}
+
+// CloseDueTopics closes the topics of the finished events that ended longer than the close delay ago
+func (s *EventTopicService) CloseDueTopics(now time.Time) {
+	groupTopics, err := s.groupTopicRepo.GetOpenEventTopicsEndedBefore(now.Add(-s.config.EventTopicCloseDelay))
+	if err != nil {
+		log.Printf("%s: Error getting open event topics: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	for _, groupTopic := range groupTopics {
+		log.Printf("%s: Closing topic %d of event %d", utils.GetCurrentTypeName(), groupTopic.TopicID, *groupTopic.EventID)
+
+		err := s.messageSender.SendHtml(
+			chatID,
+			"\U0001f512 The event is over, so this topic is closed now. "+
+				"Thanks for the discussion, let's keep it going in the main chat!",
+			&gotgbot.SendMessageOpts{MessageThreadId: groupTopic.TopicID},
+		)
+		if err != nil {
+			log.Printf("%s: Error posting closing note to topic %d: %v", utils.GetCurrentTypeName(), groupTopic.TopicID, err)
+		}
+
+		// The topic may have been closed or deleted by hand, it's recorded as closed anyway so it isn't retried forever
+		if err := s.messageSender.CloseForumTopic(chatID, groupTopic.TopicID); err != nil {
+			log.Printf("%s: Error closing topic %d: %v", utils.GetCurrentTypeName(), groupTopic.TopicID, err)
+		}
+		if err := s.SetTopicClosed(groupTopic.TopicID, true); err != nil {
+			log.Printf("%s: Error recording topic %d as closed: %v", utils.GetCurrentTypeName(), groupTopic.TopicID, err)
+		}
+	}
+}
+
+// SetTopicClosed records whether the event topic is closed, it's a no-op for the other topics
+func (s *EventTopicService) SetTopicClosed(topicID int64, closed bool) error {
+	isEventTopic, err := s.groupTopicRepo.SetEventTopicClosed(topicID, closed)
+	if err != nil {
+		return err
+	}
+	if !isEventTopic {
+		return nil
+	}
+
+	s.closedTopicsMutex.Lock()
+	defer s.closedTopicsMutex.Unlock()
+	// An unloaded cache gets the new state from the database on the first check
+	if s.closedTopicsLoaded {
+		if closed {
+			s.closedTopicIDs[topicID] = true
+		} else {
+			delete(s.closedTopicIDs, topicID)
+		}
+	}
+	return nil
+}
+
+// IsClosedEventTopic checks whether the topic was created for an event and is closed now
+func (s *EventTopicService) IsClosedEventTopic(topicID int64) bool {
+	s.closedTopicsMutex.RLock()
+	if s.closedTopicsLoaded {
+		defer s.closedTopicsMutex.RUnlock()
+		return s.closedTopicIDs[topicID]
+	}
+	s.closedTopicsMutex.RUnlock()
+
+	s.closedTopicsMutex.Lock()
+	defer s.closedTopicsMutex.Unlock()
+	if !s.closedTopicsLoaded {
+		topicIDs, err := s.groupTopicRepo.GetClosedEventTopicIDs()
+		if err != nil {
+			// Retried on the next check
+			log.Printf("%s: Error loading closed event topics: %v", utils.GetCurrentTypeName(), err)
+			return false
+		}
+		s.closedTopicIDs = make(map[int64]bool, len(topicIDs))
+		for _, id := range topicIDs {
+			s.closedTopicIDs[id] = true
+		}
+		s.closedTopicsLoaded = true
+	}
+	return s.closedTopicIDs[topicID]
+}
@@ -17,12 +17,14 @@ type CleanClosedThreadsService struct {
 	closedTopics         map[int]bool
 	messageSenderService *services.MessageSenderService
 	groupTopicRepository *repositories.GroupTopicRepository
+	eventTopicService    *services.EventTopicService
 }
 
 func NewCleanClosedThreadsService(
 	config *config.Config,
 	messageSenderService *services.MessageSenderService,
 	groupTopicRepository *repositories.GroupTopicRepository,
+	eventTopicService *services.EventTopicService,
 ) *CleanClosedThreadsService {
 	// Create map of closed topics
 	closedTopics := make(map[int]bool)
@@ -34,6 +36,7 @@ func NewCleanClosedThreadsService(
 		messageSenderService: messageSenderService,
 		closedTopics:         closedTopics,
 		groupTopicRepository: groupTopicRepository,
+		eventTopicService:    eventTopicService,
 	}
 }
 
@@ -107,13 +110,12 @@ func (h *CleanClosedThreadsService) CleanClosedThreads(msg *gotgbot.Message, b *
 
 func (h *CleanClosedThreadsService) IsTopicShouldBeCleaned(msg *gotgbot.Message, b *gotgbot.Bot) bool {
 	// Do nothing if message is not in closed topics
-	if !h.closedTopics[int(msg.MessageThreadId)] {
+	if !h.isClosedTopic(msg.MessageThreadId) {
 		return false
 	}
 
 	// Don't trigger if message is reply to another message in thread (this already handled by RepliesFromThreadsHandler)
-	if h.closedTopics[int(msg.MessageThreadId)] &&
-		msg.ReplyToMessage != nil &&
+	if msg.ReplyToMessage != nil &&
 		msg.ReplyToMessage.MessageId != msg.MessageThreadId {
 		return false
 	}
@@ -130,3 +132,13 @@ func (h *CleanClosedThreadsService) IsTopicShouldBeCleaned(msg *gotgbot.Message,
 
 	return true
 }
+
+// isClosedTopic checks whether the topic is one of the closed topics from the config
+// or a topic of a past event that was closed after the event
+func (h *CleanClosedThreadsService) isClosedTopic(topicID int64) bool {
+	if h.closedTopics[int(topicID)] {
+		return true
+	}
+
+	return h.eventTopicService.IsClosedEventTopic(topicID)
+}
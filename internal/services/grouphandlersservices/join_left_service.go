@@ -1,30 +1,48 @@
 package grouphandlersservices
 
 import (
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 )
 
 type JoinLeftService struct {
-	userRepo *repositories.UserRepository
+	userRepo            *repositories.UserRepository
+	membershipEventRepo *repositories.ClubMembershipEventRepository
+	onboardingService   *services.OnboardingService
+	introArchiveService *services.IntroArchiveService
 }
 
-func NewJoinLeftService(userRepo *repositories.UserRepository) *JoinLeftService {
-	return &JoinLeftService{userRepo: userRepo}
+func NewJoinLeftService(
+	userRepo *repositories.UserRepository,
+	membershipEventRepo *repositories.ClubMembershipEventRepository,
+	onboardingService *services.OnboardingService,
+	introArchiveService *services.IntroArchiveService,
+) *JoinLeftService {
+	return &JoinLeftService{
+		userRepo:            userRepo,
+		membershipEventRepo: membershipEventRepo,
+		onboardingService:   onboardingService,
+		introArchiveService: introArchiveService,
+	}
 }
 
 func (h *JoinLeftService) HandleJoinLeftMember(b *gotgbot.Bot, ctx *ext.Context) error {
 	chatMember := ctx.ChatMember
 	user := chatMember.NewChatMember.GetUser()
 
+	oldStatus := chatMember.OldChatMember.GetStatus()
 	newStatus := chatMember.NewChatMember.GetStatus()
 
-	isNowMember := newStatus == "member" || newStatus == "administrator" || newStatus == "creator"
+	wasMember := isMemberStatus(oldStatus)
+	isNowMember := isMemberStatus(newStatus)
 	isNowLeftOrBanned := newStatus == "left" || newStatus == "kicked"
 
 	if isNowMember {
@@ -38,6 +56,17 @@ func (h *JoinLeftService) HandleJoinLeftMember(b *gotgbot.Bot, ctx *ext.Context)
 			return fmt.Errorf("%s: failed to set club member status to true for user %d: %w", utils.GetCurrentTypeName(), dbUser.ID, err)
 		}
 
+		// Promotions to administrator and the like are updates of members too, only joins are recorded
+		if !wasMember {
+			inviteLink, inviteLinkName := "", ""
+			if chatMember.InviteLink != nil {
+				inviteLink, inviteLinkName = chatMember.InviteLink.InviteLink, chatMember.InviteLink.Name
+			}
+			h.recordMembershipEvent(dbUser.ID, constants.ClubMembershipEventJoined, inviteLink, inviteLinkName, chatMember.Date)
+			h.onboardingService.StartOnboarding(dbUser)
+			h.introArchiveService.RestoreIntro(dbUser)
+		}
+
 	} else if isNowLeftOrBanned {
 		dbUser, _, err := h.userRepo.GetOrFullCreate(&user)
 		if err != nil {
@@ -52,7 +81,36 @@ func (h *JoinLeftService) HandleJoinLeftMember(b *gotgbot.Bot, ctx *ext.Context)
 				return fmt.Errorf("%s: failed to set club member status to false for user %d: %w", utils.GetCurrentTypeName(), dbUser.ID, err)
 			}
 		}
+
+		if wasMember {
+			event := constants.ClubMembershipEventLeft
+			if newStatus == "kicked" {
+				event = constants.ClubMembershipEventBanned
+			}
+			h.recordMembershipEvent(dbUser.ID, event, "", "", chatMember.Date)
+		}
+
+		// Search and Random Coffee skip non-members already, the intro post has to be hidden explicitly
+		h.introArchiveService.ArchiveIntro(dbUser)
 	}
 
 	return nil
 }
+
+// recordMembershipEvent keeps the join or leave, a failure is only logged so the member status is still updated
+func (h *JoinLeftService) recordMembershipEvent(
+	userID int,
+	event constants.ClubMembershipEvent,
+	inviteLink string,
+	inviteLinkName string,
+	date int64,
+) {
+	err := h.membershipEventRepo.Create(userID, event, inviteLink, inviteLinkName, time.Unix(date, 0))
+	if err != nil {
+		log.Printf("%s: Error recording %s of user %d: %v", utils.GetCurrentTypeName(), event, userID, err)
+	}
+}
+
+func isMemberStatus(status string) bool {
+	return status == "member" || status == "administrator" || status == "creator"
+}
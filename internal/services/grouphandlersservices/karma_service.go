@@ -0,0 +1,119 @@
+package grouphandlersservices
+
+import (
+	"database/sql"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+	"fmt"
+	"log"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// KarmaService grants karma points to the authors of the messages members reply "+1" or "thanks" to,
+// or react to with one of the karma reactions
+type KarmaService struct {
+	config                 *config.Config
+	karmaRepository        *repositories.KarmaRepository
+	userRepository         *repositories.UserRepository
+	groupMessageRepository *repositories.GroupMessageRepository
+}
+
+func NewKarmaService(
+	config *config.Config,
+	karmaRepository *repositories.KarmaRepository,
+	userRepository *repositories.UserRepository,
+	groupMessageRepository *repositories.GroupMessageRepository,
+) *KarmaService {
+	return &KarmaService{
+		config:                 config,
+		karmaRepository:        karmaRepository,
+		userRepository:         userRepository,
+		groupMessageRepository: groupMessageRepository,
+	}
+}
+
+func (s *KarmaService) IsKarmaReply(msg *gotgbot.Message) bool {
+	return s.config.KarmaEnabled &&
+		msg.ReplyToMessage != nil &&
+		// By default all messages in topics are replies to the topic itself, so check it
+		msg.ReplyToMessage.MessageThreadId != msg.ReplyToMessage.MessageId &&
+		utils.IsKarmaThanks(msg.Text)
+}
+
+// GrantForReply grants a point to the author of the message the member replied to
+func (s *KarmaService) GrantForReply(msg *gotgbot.Message) error {
+	receiver := msg.ReplyToMessage.From
+	if msg.From == nil || receiver == nil || receiver.IsBot {
+		return nil
+	}
+
+	receiverUser, err := s.userRepository.GetOrCreate(receiver)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get or create user: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return s.grant(msg.From, receiverUser, msg.ReplyToMessage.MessageId, constants.KarmaVoteSourceReply)
+}
+
+func (s *KarmaService) IsKarmaReaction(reaction *gotgbot.MessageReactionUpdated) bool {
+	return s.config.KarmaEnabled &&
+		reaction.User != nil &&
+		utils.HasNewKarmaReaction(reaction.OldReaction, reaction.NewReaction, s.config.KarmaReactions)
+}
+
+// GrantForReaction grants a point to the author of the message the member reacted to.
+// Reactions don't tell the author, so only the messages saved in group_messages count.
+func (s *KarmaService) GrantForReaction(reaction *gotgbot.MessageReactionUpdated) error {
+	groupMessage, err := s.groupMessageRepository.GetByMessageID(reaction.MessageId)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	receiverUser, err := s.userRepository.GetByTelegramID(groupMessage.UserTgID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.grant(reaction.User, receiverUser, reaction.MessageId, constants.KarmaVoteSourceReaction)
+}
+
+// grant adds the point unless it's a self-vote, the giver has reached the daily limit
+// or has granted a point to the same member within the cooldown
+func (s *KarmaService) grant(
+	giver *gotgbot.User,
+	receiverUser *repositories.User,
+	messageID int64,
+	source constants.KarmaVoteSource,
+) error {
+	if giver.IsBot || giver.Id == receiverUser.TgID {
+		return nil
+	}
+
+	giverUser, err := s.userRepository.GetOrCreate(giver)
+	if err != nil {
+		return fmt.Errorf("%s: failed to get or create user: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	granted, err := s.karmaRepository.AddVote(
+		giverUser.ID, receiverUser.ID, messageID, source, s.config.KarmaDailyLimit, s.config.KarmaPairCooldown)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		log.Printf("%s: User %d has already granted this point, reached the daily limit or granted a point to user %d recently, skipping",
+			utils.GetCurrentTypeName(), giver.Id, receiverUser.TgID)
+		return nil
+	}
+	log.Printf("%s: User %d granted a point to user %d by %s", utils.GetCurrentTypeName(), giver.Id, receiverUser.TgID, source)
+
+	return nil
+}
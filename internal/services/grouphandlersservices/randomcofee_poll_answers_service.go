@@ -1,6 +1,7 @@
 package grouphandlersservices
 
 import (
+	"database/sql"
 	"evo-bot-go/internal/config"
 	"evo-bot-go/internal/database/repositories"
 	"evo-bot-go/internal/services"
@@ -55,15 +56,13 @@ func (s *RandomCoffeePollAnswersService) ProcessAnswer(pollAnswer *gotgbot.PollA
 			log.Printf("%s: Participant (PollID: %d, UserID: %d) removed due to vote retraction.", utils.GetCurrentTypeName(), retrievedPoll.ID, internalUser.ID)
 		}
 	} else { // New vote or changed vote
-		// Assuming "Yes, I'll participate" is the first option (index 0) and "No" is the second (index 1)
-		// The poll options are: {Text: "Yes, I'll participate"}, {Text: "No, not this week"}
-		// So, OptionIds[0] being 0 means "Yes", OptionIds[0] being 1 means "No".
-		isParticipating := pollAnswer.OptionIds[0] == 0
+		isParticipating, role := s.resolveVote(retrievedPoll, pollAnswer.OptionIds[0])
 
 		participant := repositories.RandomCoffeeParticipant{
 			PollID:          retrievedPoll.ID,
 			UserID:          int64(internalUser.ID),
 			IsParticipating: isParticipating,
+			Role:            role,
 		}
 		err = s.participantRepo.UpsertParticipant(participant)
 		if err != nil {
@@ -84,7 +83,7 @@ func (s *RandomCoffeePollAnswersService) IsAnswerShouldBeProcessed(pollAnswer *g
 
 	// Do nothing if user is bot and send message to admin
 	if pollAnswer.User.IsBot {
-		if len(pollAnswer.OptionIds) > 0 && pollAnswer.OptionIds[0] == 0 {
+		if s.isParticipatingVote(pollAnswer) {
 			s.messageSenderService.SendHtml(
 				s.config.AdminUserID,
 				"🚫 Unfortunately, bots cannot participate in the Random Coffee poll. Please retract your vote.",
@@ -96,7 +95,7 @@ func (s *RandomCoffeePollAnswersService) IsAnswerShouldBeProcessed(pollAnswer *g
 	// Do nothing if user is banned from coffee and send message to user
 	if internalUser.HasCoffeeBan {
 		log.Printf("%s: User %d is banned. Ignoring.", utils.GetCurrentTypeName(), pollAnswer.User.Id)
-		if len(pollAnswer.OptionIds) > 0 && pollAnswer.OptionIds[0] == 0 {
+		if s.isParticipatingVote(pollAnswer) {
 			s.messageSenderService.SendHtml(
 				internalUser.TgID,
 				"🚫 Unfortunately, you cannot participate in the Random Coffee poll because you are banned. "+
@@ -114,3 +113,28 @@ func (s *RandomCoffeePollAnswersService) GetInternalUser(pollAnswer *gotgbot.Pol
 	internalUser, _ := s.userRepo.GetOrCreate(pollAnswer.User)
 	return internalUser
 }
+
+// resolveVote maps the selected option to participation and role.
+// Weekly Random Coffee polls have "Yes" as the first option and "No" as the second,
+// matching program polls may have one "Yes" option per role saved on the poll.
+func (s *RandomCoffeePollAnswersService) resolveVote(poll *repositories.RandomCoffeePoll, optionID int64) (bool, sql.NullString) {
+	if !poll.ProgramID.Valid {
+		return optionID == 0, sql.NullString{}
+	}
+	return services.ResolveMatchingProgramVote(poll, optionID)
+}
+
+// isParticipatingVote reports whether the answer is a "Yes" vote
+func (s *RandomCoffeePollAnswersService) isParticipatingVote(pollAnswer *gotgbot.PollAnswer) bool {
+	if len(pollAnswer.OptionIds) == 0 {
+		return false
+	}
+
+	poll, err := s.pollRepo.GetPollByTelegramPollID(pollAnswer.PollId)
+	if err != nil || poll == nil {
+		return pollAnswer.OptionIds[0] == 0
+	}
+
+	isParticipating, _ := s.resolveVote(poll, pollAnswer.OptionIds[0])
+	return isParticipating
+}
@@ -19,6 +19,7 @@ type RepliesFromClosedThreadsService struct {
 	closedTopics             map[int]bool
 	messageSenderService     *services.MessageSenderService
 	groupTopicRepository     *repositories.GroupTopicRepository
+	eventTopicService        *services.EventTopicService
 	saveUpdateMessageService *SaveUpdateMessageService
 }
 
@@ -26,6 +27,7 @@ func NewRepliesFromClosedThreadsService(
 	config *config.Config,
 	messageSenderService *services.MessageSenderService,
 	groupTopicRepository *repositories.GroupTopicRepository,
+	eventTopicService *services.EventTopicService,
 	saveUpdateMessageService *SaveUpdateMessageService,
 ) *RepliesFromClosedThreadsService {
 	// Create map of closed topics
@@ -38,6 +40,7 @@ func NewRepliesFromClosedThreadsService(
 		closedTopics:             closedTopics,
 		messageSenderService:     messageSenderService,
 		groupTopicRepository:     groupTopicRepository,
+		eventTopicService:        eventTopicService,
 		saveUpdateMessageService: saveUpdateMessageService,
 	}
 }
@@ -78,7 +81,7 @@ func (h *RepliesFromClosedThreadsService) IsReplyShouldBeForwarded(msg *gotgbot.
 	}
 
 	// Trigger if message is in closed topics and not reply to itself
-	return h.closedTopics[int(msg.MessageThreadId)] &&
+	return h.isClosedTopic(msg.MessageThreadId) &&
 		msg.ReplyToMessage.MessageId != msg.MessageThreadId
 
 }
@@ -183,3 +186,13 @@ func (h *RepliesFromClosedThreadsService) forwardReplyMessage(ctx *ext.Context)
 
 	return nil
 }
+
+// isClosedTopic checks whether the topic is one of the closed topics from the config
+// or a topic of a past event that was closed after the event
+func (h *RepliesFromClosedThreadsService) isClosedTopic(topicID int64) bool {
+	if h.closedTopics[int(topicID)] {
+		return true
+	}
+
+	return h.eventTopicService.IsClosedEventTopic(topicID)
+}
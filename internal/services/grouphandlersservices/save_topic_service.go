@@ -2,6 +2,7 @@ package grouphandlersservices
 
 import (
 	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"log"
@@ -11,10 +12,17 @@ import (
 
 type SaveTopicService struct {
 	groupTopicRepository *repositories.GroupTopicRepository
+	eventTopicService    *services.EventTopicService
 }
 
-func NewSaveTopicService(groupTopicRepository *repositories.GroupTopicRepository) *SaveTopicService {
-	return &SaveTopicService{groupTopicRepository: groupTopicRepository}
+func NewSaveTopicService(
+	groupTopicRepository *repositories.GroupTopicRepository,
+	eventTopicService *services.EventTopicService,
+) *SaveTopicService {
+	return &SaveTopicService{
+		groupTopicRepository: groupTopicRepository,
+		eventTopicService:    eventTopicService,
+	}
 }
 
 func (s *SaveTopicService) SaveOrUpdateTopic(msg *gotgbot.Message) error {
@@ -28,12 +36,18 @@ func (s *SaveTopicService) SaveOrUpdateTopic(msg *gotgbot.Message) error {
 		return s.handleForumTopicEdited(msg)
 	}
 
+	if msg.ForumTopicClosed != nil || msg.ForumTopicReopened != nil {
+		// Handle event topic closed or reopened by admins
+		return s.handleForumTopicClosedOrReopened(msg)
+	}
+
 	return nil
 }
 
 func (s *SaveTopicService) IsTopicShouldBeSavedOrUpdated(msg *gotgbot.Message) bool {
-	// Handle forum topic created or edited messages
-	return msg.ForumTopicCreated != nil || msg.ForumTopicEdited != nil
+	// Handle forum topic created, edited, closed or reopened messages
+	return msg.ForumTopicCreated != nil || msg.ForumTopicEdited != nil ||
+		msg.ForumTopicClosed != nil || msg.ForumTopicReopened != nil
 }
 
 func (h *SaveTopicService) handleForumTopicCreated(msg *gotgbot.Message) error {
@@ -43,6 +57,13 @@ func (h *SaveTopicService) handleForumTopicCreated(msg *gotgbot.Message) error {
 
 	log.Printf("%s: Forum topic created - ID: %d, Name: %s", utils.GetCurrentTypeName(), topicID, topicName)
 
+	// Topics created by the bot for events are already saved together with their event
+	if existingTopic, err := h.groupTopicRepository.GetGroupTopicByTopicID(topicID); err == nil {
+		log.Printf("%s: Forum topic already saved - DB ID: %d, Topic ID: %d",
+			utils.GetCurrentTypeName(), existingTopic.ID, existingTopic.TopicID)
+		return nil
+	}
+
 	// Save the new topic to database
 	groupTopic, err := h.groupTopicRepository.AddGroupTopic(topicID, topicName)
 	if err != nil {
@@ -95,3 +116,17 @@ func (h *SaveTopicService) handleForumTopicEdited(msg *gotgbot.Message) error {
 
 	return nil
 }
+
+func (h *SaveTopicService) handleForumTopicClosedOrReopened(msg *gotgbot.Message) error {
+	topicID := msg.MessageThreadId
+	closed := msg.ForumTopicClosed != nil
+
+	log.Printf("%s: Forum topic closed: %t - ID: %d", utils.GetCurrentTypeName(), closed, topicID)
+
+	// Only event topics keep their closed state, the closed topics from the config are read-only anyway
+	if err := h.eventTopicService.SetTopicClosed(topicID, closed); err != nil {
+		return fmt.Errorf("%s: failed to update closed state of forum topic: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	return nil
+}
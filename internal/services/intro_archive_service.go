@@ -0,0 +1,141 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// IntroArchiveService hides the published intro of members who left the group and publishes it again when they rejoin
+type IntroArchiveService struct {
+	bot               *gotgbot.Bot
+	config            *config.Config
+	messageSender     *MessageSenderService
+	profileRepository *repositories.ProfileRepository
+	badgeRepository   *repositories.BadgeRepository
+}
+
+// NewIntroArchiveService creates a new intro archive service
+func NewIntroArchiveService(
+	bot *gotgbot.Bot,
+	config *config.Config,
+	messageSender *MessageSenderService,
+	profileRepository *repositories.ProfileRepository,
+	badgeRepository *repositories.BadgeRepository,
+) *IntroArchiveService {
+	return &IntroArchiveService{
+		bot:               bot,
+		config:            config,
+		messageSender:     messageSender,
+		profileRepository: profileRepository,
+		badgeRepository:   badgeRepository,
+	}
+}
+
+// ArchiveIntro archives or deletes the published intro of a member who left, based on the configured policy
+func (s *IntroArchiveService) ArchiveIntro(user *repositories.User) {
+	if s.config.LeftMemberIntroPolicy == constants.LeftMemberIntroPolicyKeep {
+		return
+	}
+
+	profile, err := s.profileRepository.GetOrCreate(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting profile of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	if !profile.PublishedMessageID.Valid || profile.ArchivedAt.Valid {
+		return
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	messageDeleted := s.config.LeftMemberIntroPolicy == constants.LeftMemberIntroPolicyDelete
+	if messageDeleted {
+		_, err = s.bot.DeleteMessage(chatID, profile.PublishedMessageID.Int64, nil)
+	} else {
+		_, _, err = s.bot.EditMessageText(
+			formatters.FormatArchivedPublicProfile(user),
+			&gotgbot.EditMessageTextOpts{
+				ChatId:    chatID,
+				MessageId: profile.PublishedMessageID.Int64,
+				ParseMode: "HTML",
+			})
+		if err != nil && strings.Contains(err.Error(), "are exactly the same") {
+			err = nil
+		}
+	}
+	if err != nil {
+		log.Printf("%s: Error archiving intro of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+
+	if err := s.profileRepository.MarkArchived(profile.ID, messageDeleted); err != nil {
+		log.Printf("%s: Error marking intro of user %d as archived: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	log.Printf("%s: Archived intro of user %d (%s)", utils.GetCurrentTypeName(), user.ID, s.config.LeftMemberIntroPolicy)
+}
+
+// RestoreIntro publishes the archived intro of a member who rejoined, the archived post is edited back
+// or a new one is sent if it was deleted
+func (s *IntroArchiveService) RestoreIntro(user *repositories.User) {
+	profile, err := s.profileRepository.GetOrCreate(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting profile of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	if !profile.ArchivedAt.Valid {
+		return
+	}
+
+	badges, err := s.badgeRepository.GetByUserID(user.ID)
+	if err != nil {
+		log.Printf("%s: Error getting badges of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	text := formatters.FormatPublicProfileForMessage(user, profile, badges, false)
+
+	restored := false
+	if profile.PublishedMessageID.Valid {
+		_, _, err := s.bot.EditMessageText(
+			text,
+			&gotgbot.EditMessageTextOpts{
+				ChatId:    chatID,
+				MessageId: profile.PublishedMessageID.Int64,
+				ParseMode: "HTML",
+			})
+		restored = err == nil || strings.Contains(err.Error(), "are exactly the same")
+		if !restored {
+			log.Printf("%s: Error restoring intro of user %d, publishing it again: %v", utils.GetCurrentTypeName(), user.ID, err)
+		}
+	}
+
+	if !restored {
+		msg, err := s.messageSender.SendHtmlWithReturnMessage(
+			chatID,
+			text,
+			&gotgbot.SendMessageOpts{
+				MessageThreadId: int64(s.config.IntroTopicID),
+			})
+		if err != nil {
+			log.Printf("%s: Error publishing intro of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+			return
+		}
+		if err := s.profileRepository.UpdatePublishedMessageID(profile.ID, msg.MessageId); err != nil {
+			log.Printf("%s: Error saving published intro of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		}
+	}
+
+	if err := s.profileRepository.ClearArchived(profile.ID); err != nil {
+		log.Printf("%s: Error clearing archived intro of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	log.Printf("%s: Restored intro of user %d", utils.GetCurrentTypeName(), user.ID)
+}
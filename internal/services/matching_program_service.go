@@ -0,0 +1,399 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+const (
+	// How late a missed poll may still be sent, e.g. after a restart
+	matchingProgramPollGracePeriod = time.Hour
+	// How many past rounds of a program are considered to avoid repeat pairs
+	matchingProgramHistoryRounds = 4
+)
+
+// MatchingProgramService runs rounds of admin-configured matching programs:
+// it sends the registration polls and matches participants according to the program's rules
+type MatchingProgramService struct {
+	config              *config.Config
+	pollSender          *PollSenderService
+	messageSender       *MessageSenderService
+	programRepo         *repositories.MatchingProgramRepository
+	pollRepo            *repositories.RandomCoffeePollRepository
+	participantRepo     *repositories.RandomCoffeeParticipantRepository
+	randomCoffeeService *RandomCoffeeService
+}
+
+// NewMatchingProgramService creates a new matching program service
+func NewMatchingProgramService(
+	config *config.Config,
+	pollSender *PollSenderService,
+	messageSender *MessageSenderService,
+	programRepo *repositories.MatchingProgramRepository,
+	pollRepo *repositories.RandomCoffeePollRepository,
+	participantRepo *repositories.RandomCoffeeParticipantRepository,
+	randomCoffeeService *RandomCoffeeService,
+) *MatchingProgramService {
+	return &MatchingProgramService{
+		config:              config,
+		pollSender:          pollSender,
+		messageSender:       messageSender,
+		programRepo:         programRepo,
+		pollRepo:            pollRepo,
+		participantRepo:     participantRepo,
+		randomCoffeeService: randomCoffeeService,
+	}
+}
+
+// MatchingProgramPollAnswers returns the poll options of a program. With roles, there is one "Yes" option per role.
+func MatchingProgramPollAnswers(program *repositories.MatchingProgram) []gotgbot.InputPollOption {
+	if program.HasRoles() {
+		return []gotgbot.InputPollOption{
+			{Text: fmt.Sprintf("Yes, as %s", program.FirstRole.String)},
+			{Text: fmt.Sprintf("Yes, as %s", program.SecondRole.String)},
+			{Text: "Not this time 💁🏽"},
+		}
+	}
+	return []gotgbot.InputPollOption{
+		{Text: "Yes! 🤗"},
+		{Text: "Not this time 💁🏽"},
+	}
+}
+
+// ResolveMatchingProgramVote maps a poll option of a program round to participation and role.
+// The roles are the ones saved on the poll when it was sent, as its options were built from them.
+func ResolveMatchingProgramVote(poll *repositories.RandomCoffeePoll, optionID int64) (bool, sql.NullString) {
+	if !poll.HasRoles() {
+		return optionID == 0, sql.NullString{}
+	}
+
+	switch optionID {
+	case 0:
+		return true, poll.FirstRole
+	case 1:
+		return true, poll.SecondRole
+	}
+	return false, sql.NullString{}
+}
+
+// RunDueRounds sends polls and matches participants for every active program whose schedule is due
+func (s *MatchingProgramService) RunDueRounds(now time.Time) {
+	programs, err := s.programRepo.GetActive()
+	if err != nil {
+		log.Printf("%s: Error getting active matching programs: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	for i := range programs {
+		program := &programs[i]
+
+		latestPoll, err := s.pollRepo.GetLatestProgramPoll(program.ID)
+		if err != nil {
+			log.Printf("%s: Error getting latest poll for program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+			continue
+		}
+
+		// Match the open round once its start time has come
+		if latestPoll != nil && !latestPoll.ClosedAt.Valid {
+			pairsAt, err := atTimeOfDay(latestPoll.WeekStartDate, program.PairsTime)
+			if err == nil && !now.Before(pairsAt) {
+				if err := s.CreatePairsDraft(program.ID); err != nil {
+					log.Printf("%s: Error matching program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+				}
+				continue
+			}
+		}
+
+		// Open registration for the next round
+		pollAt, err := previousOccurrence(now, program.PollWeekday, program.PollTime, program.Frequency == repositories.MatchingProgramFrequencyMonthly)
+		if err != nil {
+			log.Printf("%s: Invalid poll schedule of program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+			continue
+		}
+		if pollAt.IsZero() || now.Sub(pollAt) > matchingProgramPollGracePeriod {
+			continue
+		}
+		if latestPoll != nil && !latestPoll.CreatedAt.Before(pollAt) {
+			continue
+		}
+		if err := s.SendPoll(program.ID); err != nil {
+			log.Printf("%s: Error sending poll for program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+		}
+	}
+}
+
+// SendPoll opens registration for the next round of a program in its topic
+func (s *MatchingProgramService) SendPoll(programID int) error {
+	program, err := s.getProgram(programID)
+	if err != nil {
+		return err
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	now := time.Now().UTC()
+	roundStart, err := nextOccurrence(now, program.PairsWeekday, program.PairsTime)
+	if err != nil {
+		return fmt.Errorf("%s: invalid pairs schedule of program %d: %w", utils.GetCurrentTypeName(), program.ID, err)
+	}
+
+	message := fmt.Sprintf("🤝 <b>%s</b>: registration for the round starting on <b>%s</b> is open! Vote in the poll below if you want to participate ⬇️",
+		html.EscapeString(program.Name),
+		roundStart.Format("Mon, Jan 2"),
+	)
+	if program.HasRoles() {
+		message += fmt.Sprintf("\n\n<i>Each %s is matched with a %s, so please pick your role.</i>",
+			html.EscapeString(strings.ToLower(program.FirstRole.String)),
+			html.EscapeString(strings.ToLower(program.SecondRole.String)),
+		)
+	}
+
+	err = s.messageSender.SendHtml(chatID, message, &gotgbot.SendMessageOpts{
+		MessageThreadId: int64(program.TopicID),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to send poll message for program %d: %w", utils.GetCurrentTypeName(), program.ID, err)
+	}
+
+	sentPollMsg, err := s.pollSender.SendPoll(chatID, program.PollQuestion, MatchingProgramPollAnswers(program), &gotgbot.SendPollOpts{
+		IsAnonymous:           false,
+		AllowsMultipleAnswers: false,
+		MessageThreadId:       int64(program.TopicID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.messageSender.PinMessage(sentPollMsg.Chat.Id, sentPollMsg.MessageId, true); err != nil {
+		log.Printf("%s: Failed to pin poll of program %d: %v", utils.GetCurrentTypeName(), program.ID, err)
+	}
+
+	// The round is stored by its start date, when participants are matched
+	pollID, err := s.pollRepo.CreatePoll(repositories.RandomCoffeePoll{
+		MessageID:      sentPollMsg.MessageId,
+		TelegramPollID: sentPollMsg.Poll.Id,
+		WeekStartDate:  time.Date(roundStart.Year(), roundStart.Month(), roundStart.Day(), 0, 0, 0, 0, time.UTC),
+		ProgramID:      sql.NullInt64{Int64: int64(program.ID), Valid: true},
+		FirstRole:      program.FirstRole,
+		SecondRole:     program.SecondRole,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to save poll for program %d: %w", utils.GetCurrentTypeName(), program.ID, err)
+	}
+
+	log.Printf("%s: Poll for program %d saved with ID %d, round starts %s",
+		utils.GetCurrentTypeName(), program.ID, pollID, roundStart.Format("2006-01-02"))
+	return nil
+}
+
+// CreatePairsDraft closes the open round of a program and matches its participants into a pairs draft.
+// The draft goes through the same admin review as the weekly Random Coffee pairs before it is posted.
+func (s *MatchingProgramService) CreatePairsDraft(programID int) error {
+	program, err := s.getProgram(programID)
+	if err != nil {
+		return err
+	}
+
+	latestPoll, err := s.pollRepo.GetLatestProgramPoll(program.ID)
+	if err != nil {
+		return err
+	}
+	if latestPoll == nil || latestPoll.ClosedAt.Valid {
+		return fmt.Errorf("%s: program %q has no open round", utils.GetCurrentTypeName(), program.Name)
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	if _, err := s.pollSender.StopPoll(chatID, latestPoll.MessageID, nil); err != nil {
+		log.Printf("%s: Warning - failed to stop poll (message ID %d): %v", utils.GetCurrentTypeName(), latestPoll.MessageID, err)
+	}
+
+	// Close the round first, so a failure below doesn't make it re-run every minute
+	if err := s.pollRepo.ClosePoll(latestPoll.ID); err != nil {
+		return err
+	}
+
+	participants, err := s.participantRepo.GetProgramParticipants(latestPoll.ID)
+	if err != nil {
+		return err
+	}
+
+	draft := s.randomCoffeeService.CreateProgramPairsDraft(
+		programForPoll(program, latestPoll),
+		latestPoll,
+		participants,
+		s.config.RandomCoffeePairsDraftTimeout,
+	)
+	if len(draft.Pairs) == 0 {
+		s.randomCoffeeService.DiscardPairsDraft(draft.PollID)
+		err := s.messageSender.SendHtml(chatID,
+			fmt.Sprintf("🤝 <b>%s</b>: not enough participants to match anyone this round 😔", html.EscapeString(program.Name)),
+			&gotgbot.SendMessageOpts{MessageThreadId: int64(program.TopicID)})
+		if err != nil {
+			return fmt.Errorf("%s: error sending message for program %d: %w", utils.GetCurrentTypeName(), program.ID, err)
+		}
+		return nil
+	}
+
+	log.Printf("%s: Created pairs draft for program %d (poll ID %d): %d pairs, %d unmatched.",
+		utils.GetCurrentTypeName(), program.ID, latestPoll.ID, len(draft.Pairs), len(draft.Unpaired))
+
+	// Without a review timeout the pairs are published right away, like the weekly ones
+	if s.config.RandomCoffeePairsDraftTimeout == 0 {
+		return s.randomCoffeeService.PublishPairsDraft(draft.PollID)
+	}
+	// The draft is still auto-published on timeout if the admin can't be reached
+	if err := s.randomCoffeeService.SendPairsDraftToAdmin(draft.PollID); err != nil {
+		log.Printf("%s: Error sending pairs draft of program %d to admin: %v", utils.GetCurrentTypeName(), program.ID, err)
+	}
+	return nil
+}
+
+func (s *MatchingProgramService) getProgram(programID int) (*repositories.MatchingProgram, error) {
+	program, err := s.programRepo.GetByID(programID)
+	if err != nil {
+		return nil, err
+	}
+	if program == nil {
+		return nil, fmt.Errorf("%s: matching program %d not found", utils.GetCurrentTypeName(), programID)
+	}
+	return program, nil
+}
+
+// programForPoll returns the program with the roles it had when the round's poll was sent
+func programForPoll(program *repositories.MatchingProgram, poll *repositories.RandomCoffeePoll) *repositories.MatchingProgram {
+	roundProgram := *program
+	roundProgram.FirstRole = poll.FirstRole
+	roundProgram.SecondRole = poll.SecondRole
+	return &roundProgram
+}
+
+// matchProgramParticipants pairs participants while avoiding recent repeats. With roles, members of the
+// first role are matched only with members of the second role and everyone left over stays unmatched.
+func matchProgramParticipants(
+	program *repositories.MatchingProgram,
+	participants []repositories.RandomCoffeeProgramParticipant,
+	history map[string][]int,
+) ([]CoffeePair, []repositories.User) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var firsts, seconds []repositories.User
+	for _, participant := range participants {
+		if program.HasRoles() && participant.Role.String == program.SecondRole.String {
+			seconds = append(seconds, participant.User)
+		} else {
+			firsts = append(firsts, participant.User)
+		}
+	}
+	r.Shuffle(len(firsts), func(i, j int) { firsts[i], firsts[j] = firsts[j], firsts[i] })
+	r.Shuffle(len(seconds), func(i, j int) { seconds[i], seconds[j] = seconds[j], seconds[i] })
+
+	var pairs []CoffeePair
+	used := make(map[int]bool)
+	for i, user := range firsts {
+		if used[user.ID] {
+			continue
+		}
+
+		// Without roles, everyone can be matched with anyone who is still free
+		candidates := seconds
+		if !program.HasRoles() {
+			candidates = firsts[i+1:]
+		}
+
+		partner := bestPartnerIndex(user, candidates, used, history)
+		if partner < 0 {
+			continue
+		}
+		pairs = append(pairs, CoffeePair{User1: user, User2: candidates[partner]})
+		used[user.ID] = true
+		used[candidates[partner].ID] = true
+	}
+
+	var unmatched []repositories.User
+	for _, user := range append(firsts, seconds...) {
+		if !used[user.ID] {
+			unmatched = append(unmatched, user)
+		}
+	}
+	return pairs, unmatched
+}
+
+// bestPartnerIndex returns the index of a free candidate the user has never been paired with,
+// or the one paired longest ago. Returns -1 if there are no free candidates.
+func bestPartnerIndex(user repositories.User, candidates []repositories.User, used map[int]bool, history map[string][]int) int {
+	best := -1
+	oldestPoll := 0
+	for j, candidate := range candidates {
+		if used[candidate.ID] || candidate.ID == user.ID {
+			continue
+		}
+
+		pollIDs := history[fmt.Sprintf("%d-%d", user.ID, candidate.ID)]
+		if len(pollIDs) == 0 {
+			return j
+		}
+
+		lastPairPoll := pollIDs[0]
+		for _, pollID := range pollIDs {
+			lastPairPoll = max(lastPairPoll, pollID)
+		}
+		if best < 0 || lastPairPoll < oldestPoll {
+			best = j
+			oldestPoll = lastPairPoll
+		}
+	}
+	return best
+}
+
+// previousOccurrence returns the latest moment at or before now that matches the weekday and "15:04" time.
+// For monthly schedules only the first such weekday of a month counts.
+func previousOccurrence(now time.Time, weekday time.Weekday, clock string, monthly bool) (time.Time, error) {
+	for daysBack := 0; daysBack <= 37; daysBack++ {
+		candidate, err := atTimeOfDay(now.AddDate(0, 0, -daysBack), clock)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if candidate.Weekday() != weekday || candidate.After(now) {
+			continue
+		}
+		if monthly && candidate.Day() > 7 {
+			continue
+		}
+		return candidate, nil
+	}
+	return time.Time{}, nil
+}
+
+// nextOccurrence returns the first moment after now that matches the weekday and "15:04" time
+func nextOccurrence(now time.Time, weekday time.Weekday, clock string) (time.Time, error) {
+	for daysAhead := 0; daysAhead <= 7; daysAhead++ {
+		candidate, err := atTimeOfDay(now.AddDate(0, 0, daysAhead), clock)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if candidate.Weekday() == weekday && candidate.After(now) {
+			return candidate, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("no occurrence of %s found", weekday)
+}
+
+// atTimeOfDay returns the given day at the "15:04" time in UTC
+func atTimeOfDay(day time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", clock, err)
+	}
+	day = day.UTC()
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, time.UTC), nil
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"evo-bot-go/internal/database/repositories"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func programParticipant(id int, role string) repositories.RandomCoffeeProgramParticipant {
+	return repositories.RandomCoffeeProgramParticipant{
+		User: repositories.User{ID: id},
+		Role: sql.NullString{String: role, Valid: role != ""},
+	}
+}
+
+func TestMatchProgramParticipants(t *testing.T) {
+	withoutRoles := &repositories.MatchingProgram{}
+	withRoles := &repositories.MatchingProgram{
+		FirstRole:  sql.NullString{String: "mentee", Valid: true},
+		SecondRole: sql.NullString{String: "mentor", Valid: true},
+	}
+
+	tests := []struct {
+		name              string
+		program           *repositories.MatchingProgram
+		participants      []repositories.RandomCoffeeProgramParticipant
+		history           map[string][]int
+		expectedPairs     int
+		expectedUnmatched int
+		expectedPartners  map[int]int // Partners that must be paired regardless of the shuffle
+	}{
+		{
+			name:              "Even count without roles",
+			program:           withoutRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, ""), programParticipant(2, ""), programParticipant(3, ""), programParticipant(4, "")},
+			expectedPairs:     2,
+			expectedUnmatched: 0,
+		},
+		{
+			name:              "Odd count without roles leaves one member unmatched",
+			program:           withoutRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, ""), programParticipant(2, ""), programParticipant(3, ""), programParticipant(4, ""), programParticipant(5, "")},
+			expectedPairs:     2,
+			expectedUnmatched: 1,
+		},
+		{
+			name:              "Single participant",
+			program:           withoutRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, "")},
+			expectedPairs:     0,
+			expectedUnmatched: 1,
+		},
+		{
+			name:              "Roles pair the first role only with the second role",
+			program:           withRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, "mentee"), programParticipant(2, "mentee"), programParticipant(3, "mentee"), programParticipant(4, "mentor")},
+			expectedPairs:     1,
+			expectedUnmatched: 2,
+		},
+		{
+			name:              "Roles with an odd total but equal sides",
+			program:           withRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, "mentee"), programParticipant(2, "mentor"), programParticipant(3, "mentee"), programParticipant(4, "mentor"), programParticipant(5, "mentor")},
+			expectedPairs:     2,
+			expectedUnmatched: 1,
+		},
+		{
+			name:              "Repeat partner is avoided",
+			program:           withRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, "mentee"), programParticipant(2, "mentor"), programParticipant(3, "mentor")},
+			history:           map[string][]int{"1-2": {5}, "2-1": {5}},
+			expectedPairs:     1,
+			expectedUnmatched: 1,
+			expectedPartners:  map[int]int{1: 3},
+		},
+		{
+			name:              "Partner met longest ago is chosen when all are repeats",
+			program:           withRoles,
+			participants:      []repositories.RandomCoffeeProgramParticipant{programParticipant(1, "mentee"), programParticipant(2, "mentor"), programParticipant(3, "mentor")},
+			history:           map[string][]int{"1-2": {5, 2}, "2-1": {5, 2}, "1-3": {3}, "3-1": {3}},
+			expectedPairs:     1,
+			expectedUnmatched: 1,
+			expectedPartners:  map[int]int{1: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := tt.history
+			if history == nil {
+				history = make(map[string][]int)
+			}
+
+			pairs, unmatched := matchProgramParticipants(tt.program, tt.participants, history)
+
+			assert.Len(t, pairs, tt.expectedPairs)
+			assert.Len(t, unmatched, tt.expectedUnmatched)
+
+			roles := make(map[int]string)
+			for _, participant := range tt.participants {
+				roles[participant.User.ID] = participant.Role.String
+			}
+
+			seen := make(map[int]bool)
+			partners := make(map[int]int)
+			for _, pair := range pairs {
+				assert.NotEqual(t, pair.User1.ID, pair.User2.ID)
+				if tt.program.HasRoles() {
+					assert.NotEqual(t, roles[pair.User1.ID], roles[pair.User2.ID], "pair must join both roles")
+				}
+				partners[pair.User1.ID] = pair.User2.ID
+				partners[pair.User2.ID] = pair.User1.ID
+				for _, id := range []int{pair.User1.ID, pair.User2.ID} {
+					assert.False(t, seen[id], "member %d is in more than one pair", id)
+					seen[id] = true
+				}
+			}
+			for _, user := range unmatched {
+				assert.False(t, seen[user.ID], "member %d is both paired and unmatched", user.ID)
+				seen[user.ID] = true
+			}
+			assert.Len(t, seen, len(tt.participants))
+
+			for userID, partnerID := range tt.expectedPartners {
+				assert.Equal(t, partnerID, partners[userID])
+			}
+		})
+	}
+}
+
+func TestBestPartnerIndex(t *testing.T) {
+	user := repositories.User{ID: 1}
+	candidates := []repositories.User{{ID: 2}, {ID: 3}, {ID: 4}}
+
+	tests := []struct {
+		name       string
+		candidates []repositories.User
+		used       map[int]bool
+		history    map[string][]int
+		expected   int
+	}{
+		{
+			name:       "First free candidate without history",
+			candidates: candidates,
+			expected:   0,
+		},
+		{
+			name:       "Never-paired candidate wins over repeats",
+			candidates: candidates,
+			history:    map[string][]int{"1-2": {7}, "1-3": {1}},
+			expected:   2,
+		},
+		{
+			name:       "Candidate paired longest ago when all are repeats",
+			candidates: candidates,
+			history:    map[string][]int{"1-2": {7}, "1-3": {2, 9}, "1-4": {4}},
+			expected:   2,
+		},
+		{
+			name:       "Used candidates are skipped",
+			candidates: candidates,
+			used:       map[int]bool{2: true, 3: true},
+			expected:   2,
+		},
+		{
+			name:       "The user themselves is skipped",
+			candidates: []repositories.User{{ID: 1}, {ID: 2}},
+			expected:   1,
+		},
+		{
+			name:       "No free candidates",
+			candidates: candidates,
+			used:       map[int]bool{2: true, 3: true, 4: true},
+			expected:   -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := tt.used
+			if used == nil {
+				used = make(map[int]bool)
+			}
+			assert.Equal(t, tt.expected, bestPartnerIndex(user, tt.candidates, used, tt.history))
+		})
+	}
+}
+
+func TestPreviousOccurrence(t *testing.T) {
+	at := func(day string, clock string) time.Time {
+		parsed, _ := time.Parse("2006-01-02 15:04", day+" "+clock)
+		return parsed
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		monthly  bool
+		expected time.Time
+	}{
+		{
+			name:     "Weekly, clock exactly at now",
+			now:      at("2025-10-06", "10:00"),
+			expected: at("2025-10-06", "10:00"),
+		},
+		{
+			name:     "Weekly, a minute before the clock",
+			now:      at("2025-10-06", "09:59"),
+			expected: at("2025-09-29", "10:00"),
+		},
+		{
+			name:     "Weekly, later in the week",
+			now:      at("2025-10-08", "12:00"),
+			expected: at("2025-10-06", "10:00"),
+		},
+		{
+			name:     "Monthly, clock exactly at now on the first weekday of the month",
+			now:      at("2025-10-06", "10:00"),
+			monthly:  true,
+			expected: at("2025-10-06", "10:00"),
+		},
+		{
+			name:     "Monthly, second weekday of the month",
+			now:      at("2025-10-13", "10:00"),
+			monthly:  true,
+			expected: at("2025-10-06", "10:00"),
+		},
+		{
+			name:     "Monthly, before the first weekday of the month",
+			now:      at("2025-10-03", "10:00"),
+			monthly:  true,
+			expected: at("2025-09-01", "10:00"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := previousOccurrence(tt.now, time.Monday, "10:00", tt.monthly)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("Invalid clock", func(t *testing.T) {
+		_, err := previousOccurrence(at("2025-10-06", "10:00"), time.Monday, "25:00", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestNextOccurrence(t *testing.T) {
+	at := func(day string, clock string) time.Time {
+		parsed, _ := time.Parse("2006-01-02 15:04", day+" "+clock)
+		return parsed
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Clock exactly at now moves to the next week",
+			now:      at("2025-10-06", "10:00"),
+			expected: at("2025-10-13", "10:00"),
+		},
+		{
+			name:     "A minute before the clock",
+			now:      at("2025-10-06", "09:59"),
+			expected: at("2025-10-06", "10:00"),
+		},
+		{
+			name:     "The day before",
+			now:      at("2025-10-05", "23:00"),
+			expected: at("2025-10-06", "10:00"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := nextOccurrence(tt.now, time.Monday, "10:00")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("Invalid clock", func(t *testing.T) {
+		_, err := nextOccurrence(at("2025-10-06", "10:00"), time.Monday, "25:00")
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"evo-bot-go/internal/utils"
 	"fmt"
 	"log"
@@ -397,6 +398,34 @@ func (s *MessageSenderService) SendCopy(
 	return sentMessage, nil
 }
 
+// SendDocument sends the content as a file with the given name
+func (s *MessageSenderService) SendDocument(chatId int64, fileName string, content string, opts *gotgbot.SendDocumentOpts) error {
+	_, err := s.bot.SendDocument(chatId, gotgbot.InputFileByReader(fileName, strings.NewReader(content)), opts)
+	if err != nil {
+		log.Printf("%s: SendDocument: Failed to send document: %v", utils.GetCurrentTypeName(), err)
+	}
+	return err
+}
+
+// SendPhoto sends the content, e.g. a PNG image, as a photo with the given file name
+func (s *MessageSenderService) SendPhoto(chatId int64, fileName string, content []byte, opts *gotgbot.SendPhotoOpts) error {
+	_, err := s.bot.SendPhoto(chatId, gotgbot.InputFileByReader(fileName, bytes.NewReader(content)), opts)
+	if err != nil {
+		log.Printf("%s: SendPhoto: Failed to send photo: %v", utils.GetCurrentTypeName(), err)
+	}
+	return err
+}
+
+// CopyMessage copies a message of any kind, e.g. a file sent to the bot, to the chat and returns the ID of the copy
+func (s *MessageSenderService) CopyMessage(chatId int64, fromChatId int64, messageId int64, opts *gotgbot.CopyMessageOpts) (int64, error) {
+	copied, err := s.bot.CopyMessage(chatId, fromChatId, messageId, opts)
+	if err != nil {
+		log.Printf("%s: CopyMessage: Failed to copy message: %v", utils.GetCurrentTypeName(), err)
+		return 0, err
+	}
+	return copied.MessageId, nil
+}
+
 // SendTypingAction sends a typing action to the specified chat.
 func (s *MessageSenderService) SendTypingAction(chatId int64) error {
 	_, err := s.bot.Request("sendChatAction", map[string]string{
@@ -441,6 +470,25 @@ func (s *MessageSenderService) PinMessage(chatID int64, messageID int64, withNot
 	return err
 }
 
+// CreateForumTopic creates a topic in the forum supergroup and returns its thread ID
+func (s *MessageSenderService) CreateForumTopic(chatID int64, name string) (int64, error) {
+	topic, err := s.bot.CreateForumTopic(chatID, name, nil)
+	if err != nil {
+		log.Printf("%s: Error creating forum topic: %v", utils.GetCurrentTypeName(), err)
+		return 0, err
+	}
+	return topic.MessageThreadId, nil
+}
+
+// CloseForumTopic closes the topic, so only admins can post in it
+func (s *MessageSenderService) CloseForumTopic(chatID int64, topicID int64) error {
+	_, err := s.bot.CloseForumTopic(chatID, topicID, nil)
+	if err != nil {
+		log.Printf("%s: Error closing forum topic: %v", utils.GetCurrentTypeName(), err)
+	}
+	return err
+}
+
 func (s *MessageSenderService) isTopicClosedError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "TOPIC_CLOSED")
 }
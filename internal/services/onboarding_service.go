@@ -0,0 +1,203 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// OnboardingService guides new members through the checklist: profile, rules, Random Coffee and the course
+type OnboardingService struct {
+	bot                  *gotgbot.Bot
+	config               *config.Config
+	messageSender        *MessageSenderService
+	onboardingRepository *repositories.OnboardingRepository
+}
+
+// NewOnboardingService creates a new onboarding service
+func NewOnboardingService(
+	bot *gotgbot.Bot,
+	config *config.Config,
+	messageSender *MessageSenderService,
+	onboardingRepository *repositories.OnboardingRepository,
+) *OnboardingService {
+	return &OnboardingService{
+		bot:                  bot,
+		config:               config,
+		messageSender:        messageSender,
+		onboardingRepository: onboardingRepository,
+	}
+}
+
+// RulesRequired reports whether the rules step is part of the checklist
+func (s *OnboardingService) RulesRequired() bool {
+	return s.config.OnboardingRulesLink != ""
+}
+
+// StartOnboarding sends the checklist to a member who has just joined. Members who haven't started the bot
+// can't be messaged, they are mentioned in the group instead and get the checklist once they start it.
+func (s *OnboardingService) StartOnboarding(user *repositories.User) {
+	if !s.config.OnboardingEnabled {
+		return
+	}
+
+	started, err := s.onboardingRepository.Start(user.ID)
+	if err != nil {
+		log.Printf("%s: Error starting onboarding of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	if !started {
+		return
+	}
+
+	onboarding, err := s.onboardingRepository.GetByUserID(user.ID)
+	if err != nil || onboarding == nil {
+		log.Printf("%s: Error getting onboarding of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+
+	if err := s.sendChecklist(user, onboarding, false); err != nil {
+		log.Printf("%s: Couldn't send the checklist to user %d, mentioning them in the group: %v", utils.GetCurrentTypeName(), user.ID, err)
+		s.mentionInGroup(user)
+	}
+}
+
+// SendChecklistIfInProgress sends the checklist again to a member who hasn't completed the onboarding,
+// e.g. when they start the bot after the group mention
+func (s *OnboardingService) SendChecklistIfInProgress(user *repositories.User) {
+	if !s.config.OnboardingEnabled {
+		return
+	}
+
+	onboarding, err := s.CheckProgress(user)
+	if err != nil {
+		log.Printf("%s: Error checking onboarding of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	if onboarding == nil || onboarding.CompletedAt.Valid {
+		return
+	}
+
+	if err := s.sendChecklist(user, onboarding, false); err != nil {
+		log.Printf("%s: Error sending the checklist to user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+}
+
+// CheckProgress returns the current onboarding of a member, or nil if it was never started.
+// A checklist that has just been done is completed, which is reported to the member and the admins.
+func (s *OnboardingService) CheckProgress(user *repositories.User) (*repositories.Onboarding, error) {
+	onboarding, err := s.onboardingRepository.GetByUserID(user.ID)
+	if err != nil || onboarding == nil {
+		return nil, err
+	}
+
+	if !onboarding.CompletedAt.Valid && onboarding.IsComplete(s.RulesRequired()) {
+		s.complete(user, onboarding, time.Now())
+	}
+	return onboarding, nil
+}
+
+// ProcessOnboardings completes the checklists done with other commands and reminds the members who are
+// behind, at most the configured number of times
+func (s *OnboardingService) ProcessOnboardings(now time.Time) {
+	onboardings, err := s.onboardingRepository.GetInProgress()
+	if err != nil {
+		log.Printf("%s: Error getting onboardings in progress: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	for i := range onboardings {
+		onboarding := &onboardings[i].Onboarding
+		user := &onboardings[i].User
+
+		if onboarding.IsComplete(s.RulesRequired()) {
+			s.complete(user, onboarding, now)
+			continue
+		}
+
+		if onboarding.RemindersSent >= s.config.OnboardingReminderCount {
+			continue
+		}
+		lastContactAt := onboarding.StartedAt
+		if onboarding.LastReminderAt.Valid {
+			lastContactAt = onboarding.LastReminderAt.Time
+		}
+		if now.Sub(lastContactAt) < s.config.OnboardingReminderInterval {
+			continue
+		}
+
+		// The reminder is counted even if it can't be delivered, so members who never start the bot
+		// aren't retried forever
+		if err := s.onboardingRepository.MarkReminderSent(user.ID); err != nil {
+			log.Printf("%s: Error recording onboarding reminder for user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+			continue
+		}
+		if err := s.sendChecklist(user, onboarding, true); err != nil {
+			log.Printf("%s: Error sending onboarding reminder to user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		}
+	}
+}
+
+func (s *OnboardingService) sendChecklist(user *repositories.User, onboarding *repositories.Onboarding, isReminder bool) error {
+	return s.messageSender.SendHtml(
+		user.TgID,
+		formatters.FormatOnboardingChecklist(user, onboarding, s.RulesRequired(), isReminder),
+		&gotgbot.SendMessageOpts{ReplyMarkup: buttons.OnboardingButtons(s.RulesRequired())},
+	)
+}
+
+// mentionInGroup posts the short welcome to the intro topic and deletes it after the configured delay
+func (s *OnboardingService) mentionInGroup(user *repositories.User) {
+	message, err := s.messageSender.SendHtmlWithReturnMessage(
+		utils.ChatIdToFullChatId(s.config.SuperGroupChatID),
+		formatters.FormatOnboardingMention(user),
+		&gotgbot.SendMessageOpts{
+			MessageThreadId: int64(s.config.IntroTopicID),
+			ReplyMarkup:     buttons.OnboardingMentionButtons(s.bot.User.Username),
+		},
+	)
+	if err != nil {
+		log.Printf("%s: Error mentioning user %d in the group: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+
+	go func() {
+		time.Sleep(s.config.OnboardingMentionCleanupDelay)
+		if _, err := message.Delete(s.bot, nil); err != nil {
+			log.Printf("%s: Error deleting the group mention of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		}
+	}()
+}
+
+// complete records the completion and reports it, only once even if it's checked concurrently
+func (s *OnboardingService) complete(user *repositories.User, onboarding *repositories.Onboarding, now time.Time) {
+	completed, err := s.onboardingRepository.MarkCompleted(user.ID)
+	if err != nil {
+		log.Printf("%s: Error completing onboarding of user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
+		return
+	}
+	onboarding.CompletedAt.Time, onboarding.CompletedAt.Valid = now, true
+	if !completed {
+		return
+	}
+
+	log.Printf("%s: User %d completed the onboarding", utils.GetCurrentTypeName(), user.ID)
+	if err := s.messageSender.SendHtml(user.TgID, formatters.FormatOnboardingCompletedMessage(user), nil); err != nil {
+		log.Printf("%s: Error congratulating user %d on the onboarding: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+
+	if s.config.AdminUserID == 0 {
+		return
+	}
+	err = s.messageSender.SendHtml(s.config.AdminUserID, formatters.FormatOnboardingCompletedReport(user, onboarding, now), nil)
+	if err != nil {
+		log.Printf("%s: Error reporting onboarding of user %d to the admin: %v", utils.GetCurrentTypeName(), user.ID, err)
+	}
+}
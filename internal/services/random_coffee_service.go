@@ -2,13 +2,19 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"html"
 	"log"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
+	"evo-bot-go/internal/buttons"
 	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
 	"evo-bot-go/internal/database/repositories"
 	"evo-bot-go/internal/utils"
 
@@ -24,7 +30,14 @@ type RandomCoffeeService struct {
 	participantRepo *repositories.RandomCoffeeParticipantRepository
 	profileRepo     *repositories.ProfileRepository
 	pairRepo        *repositories.RandomCoffeePairRepository
+	draftRepo       *repositories.RandomCoffeePairDraftRepository
 	userRepo        *repositories.UserRepository
+	programRepo     *repositories.MatchingProgramRepository
+
+	// Pairs drafts awaiting admin review by poll ID, kept in the database so that they survive restarts
+	draftMutex   sync.Mutex
+	drafts       map[int]*CoffeePairsDraft
+	draftsLoaded bool
 }
 
 // NewRandomCoffeeService creates a new random coffee poll service
@@ -37,7 +50,9 @@ func NewRandomCoffeeService(
 	participantRepo *repositories.RandomCoffeeParticipantRepository,
 	profileRepo *repositories.ProfileRepository,
 	pairRepo *repositories.RandomCoffeePairRepository,
+	draftRepo *repositories.RandomCoffeePairDraftRepository,
 	userRepo *repositories.UserRepository,
+	programRepo *repositories.MatchingProgramRepository,
 ) *RandomCoffeeService {
 	return &RandomCoffeeService{
 		bot:             bot,
@@ -48,7 +63,9 @@ func NewRandomCoffeeService(
 		participantRepo: participantRepo,
 		profileRepo:     profileRepo,
 		pairRepo:        pairRepo,
+		draftRepo:       draftRepo,
 		userRepo:        userRepo,
+		programRepo:     programRepo,
 	}
 }
 
@@ -69,7 +86,9 @@ func (s *RandomCoffeeService) SendPoll(ctx context.Context) error {
 			s.config.SuperGroupChatID,
 			s.config.RandomCoffeeTopicID,
 			s.config.RandomCoffeeTopicID+1, // next message id (small hack)
-		) + " Vote in the poll below if you want to participate ⬇️"
+		) + " Vote in the poll below if you want to participate ⬇️" +
+			fmt.Sprintf("\n\n<i>Tired of voting every week? Subscribe via /%s in a DM with the bot to be enrolled automatically. "+
+				"Subscribers can still skip a round by voting \"Not this time\".</i>", constants.RandomCoffeeCommand)
 
 	opts := &gotgbot.SendMessageOpts{
 		MessageThreadId: int64(s.config.RandomCoffeeTopicID),
@@ -163,13 +182,206 @@ func (s *RandomCoffeeService) savePollToDB(sentPollMsg *gotgbot.Message) error {
 	return nil
 }
 
-func (s *RandomCoffeeService) GenerateAndSendPairs() error {
+// SendReminders posts the current participant count in the Random Coffee topic and
+// reminds last round's participants who haven't voted in the open poll yet
+func (s *RandomCoffeeService) SendReminders() error {
 	latestPoll, err := s.pollRepo.GetLatestPoll()
 	if err != nil {
 		return fmt.Errorf("%s: error getting latest poll: %w", utils.GetCurrentTypeName(), err)
 	}
 	if latestPoll == nil {
-		return fmt.Errorf("%s: random coffee poll not found", utils.GetCurrentTypeName())
+		log.Printf("%s: No random coffee poll found. Skipping reminders.", utils.GetCurrentTypeName())
+		return nil
+	}
+
+	// Registration closes when the pairs are generated, on the first pairs day after the poll was sent
+	pairsClock := fmt.Sprintf("%02d:%02d", s.config.RandomCoffeePairsTime.Hour(), s.config.RandomCoffeePairsTime.Minute())
+	closesAt, err := nextOccurrence(latestPoll.CreatedAt, s.config.RandomCoffeePairsDay, pairsClock)
+	if err != nil {
+		return fmt.Errorf("%s: error calculating when registration closes: %w", utils.GetCurrentTypeName(), err)
+	}
+	if !closesAt.After(time.Now().UTC()) {
+		log.Printf("%s: Latest poll (ID %d) is already closed. Skipping reminders.", utils.GetCurrentTypeName(), latestPoll.ID)
+		return nil
+	}
+
+	participants, err := s.participantRepo.GetParticipatingUsers(latestPoll.ID)
+	if err != nil {
+		return fmt.Errorf("%s: error getting participants for poll ID %d: %w", utils.GetCurrentTypeName(), latestPoll.ID, err)
+	}
+
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	pollLink := fmt.Sprintf("https://t.me/c/%d/%d/%d",
+		s.config.SuperGroupChatID,
+		s.config.RandomCoffeeTopicID,
+		latestPoll.MessageID,
+	)
+	closingTime := fmt.Sprintf("%s at %02d:%02d UTC",
+		s.config.RandomCoffeePairsDay.String(),
+		s.config.RandomCoffeePairsTime.Hour(),
+		s.config.RandomCoffeePairsTime.Minute(),
+	)
+
+	topicMessage := fmt.Sprintf(
+		"☕️ <b>%d</b> members are already in for Random Coffee next week!\n\n"+
+			"Registration closes on <b>%s</b>. Haven't decided yet? <a href=\"%s\">Vote in the poll</a> ⬆️",
+		len(participants),
+		closingTime,
+		pollLink,
+	)
+	err = s.messageSender.SendHtml(chatID, topicMessage, &gotgbot.SendMessageOpts{
+		MessageThreadId: int64(s.config.RandomCoffeeTopicID),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: error sending reminder to random coffee topic: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	usersToRemind, err := s.participantRepo.GetUsersToRemind(latestPoll.ID)
+	if err != nil {
+		return fmt.Errorf("%s: error getting users to remind for poll ID %d: %w", utils.GetCurrentTypeName(), latestPoll.ID, err)
+	}
+
+	directMessage := fmt.Sprintf(
+		"Hey! ☕️ You took part in the last Random Coffee round, but haven't voted for the next one yet.\n\n"+
+			"<a href=\"%s\">Vote in the poll</a> before registration closes on <b>%s</b>.\n\n"+
+			"<i>Use /%s to subscribe to every round or to mute these reminders.</i>",
+		pollLink,
+		closingTime,
+		constants.RandomCoffeeCommand,
+	)
+
+	remindedCount := 0
+	for _, user := range usersToRemind {
+		if err := s.messageSender.SendHtml(user.TgID, directMessage, nil); err != nil {
+			// The user may have never started the bot or blocked it
+			log.Printf("%s: Failed to send reminder to user %d: %v", utils.GetCurrentTypeName(), user.TgID, err)
+			continue
+		}
+		remindedCount++
+	}
+
+	log.Printf("%s: Sent reminders for poll ID %d: %d participants so far, %d of %d users reminded in DM.",
+		utils.GetCurrentTypeName(), latestPoll.ID, len(participants), remindedCount, len(usersToRemind))
+	return nil
+}
+
+// CoffeePairsDraft holds generated pairs of a round that are neither saved to the database nor published yet,
+// so admins can review and adjust them first. There is at most one draft per round.
+type CoffeePairsDraft struct {
+	PollID int
+	// Program is nil for the weekly Random Coffee. Its roles are the ones of the round's poll.
+	Program       *repositories.MatchingProgram
+	WeekStartDate time.Time
+	Participants  []repositories.User
+	// Roles holds the role each program participant voted for, by user ID
+	Roles     map[int]string
+	Pairs     []CoffeePair
+	Unpaired  []repositories.User
+	CreatedAt time.Time
+	// AutoPublishAt is zero if the draft is only published manually
+	AutoPublishAt  time.Time
+	AdminChatID    int64
+	AdminMessageID int64
+}
+
+// Title returns the name of the round: "Random Coffee" for the weekly round, or the program's name
+func (d *CoffeePairsDraft) Title() string {
+	if d.Program == nil {
+		return "Random Coffee"
+	}
+	return d.Program.Name
+}
+
+// hasRoles reports whether members are matched only across the program's roles
+func (d *CoffeePairsDraft) hasRoles() bool {
+	return d.Program != nil && d.Program.HasRoles()
+}
+
+// Members returns everyone in the draft: paired members in order, followed by the unpaired ones
+func (d *CoffeePairsDraft) Members() []repositories.User {
+	members := make([]repositories.User, 0, len(d.Pairs)*2+len(d.Unpaired))
+	for _, pair := range d.Pairs {
+		members = append(members, pair.User1, pair.User2)
+	}
+	return append(members, d.Unpaired...)
+}
+
+// PartnerOf returns the user ID of the member's partner, or 0 if the member is unpaired or not in the draft
+func (d *CoffeePairsDraft) PartnerOf(userID int) int {
+	for _, pair := range d.Pairs {
+		if pair.User1.ID == userID {
+			return pair.User2.ID
+		}
+		if pair.User2.ID == userID {
+			return pair.User1.ID
+		}
+	}
+	return 0
+}
+
+// CanSwap reports whether two members may swap places. With roles, only members of the same role can,
+// so that every pair still joins both roles.
+func (d *CoffeePairsDraft) CanSwap(firstUserID int, secondUserID int) bool {
+	return !d.hasRoles() || d.Roles[firstUserID] == d.Roles[secondUserID]
+}
+
+// copy returns a copy of the draft that is safe to read outside of the draft lock.
+// Program and Roles are shared, they don't change after the draft is created.
+func (d *CoffeePairsDraft) copy() *CoffeePairsDraft {
+	draftCopy := *d
+	draftCopy.Participants = append([]repositories.User(nil), d.Participants...)
+	draftCopy.Pairs = append([]CoffeePair(nil), d.Pairs...)
+	draftCopy.Unpaired = append([]repositories.User(nil), d.Unpaired...)
+	return &draftCopy
+}
+
+// memberSlot returns a pointer to the place the member occupies in the draft, or nil if they're not in it
+func (d *CoffeePairsDraft) memberSlot(userID int) *repositories.User {
+	for i := range d.Pairs {
+		if d.Pairs[i].User1.ID == userID {
+			return &d.Pairs[i].User1
+		}
+		if d.Pairs[i].User2.ID == userID {
+			return &d.Pairs[i].User2
+		}
+	}
+	for i := range d.Unpaired {
+		if d.Unpaired[i].ID == userID {
+			return &d.Unpaired[i]
+		}
+	}
+	return nil
+}
+
+// replacementIndex returns the index of the unpaired member who can take the place of the given member,
+// or -1 if there is none
+func (d *CoffeePairsDraft) replacementIndex(userID int) int {
+	for i, user := range d.Unpaired {
+		if d.CanSwap(userID, user.ID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GenerateAndSendPairs generates pairs for the latest poll and publishes them right away, skipping admin review
+func (s *RandomCoffeeService) GenerateAndSendPairs() error {
+	draft, err := s.CreatePairsDraft(0)
+	if err != nil {
+		return err
+	}
+	return s.PublishPairsDraft(draft.PollID)
+}
+
+// CreatePairsDraft closes the latest weekly poll and generates a pairs draft for it, replacing the previous draft
+// of the same round. If autoPublishAfter is positive, the draft becomes due for publishing after that duration.
+func (s *RandomCoffeeService) CreatePairsDraft(autoPublishAfter time.Duration) (*CoffeePairsDraft, error) {
+	latestPoll, err := s.pollRepo.GetLatestPoll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: error getting latest poll: %w", utils.GetCurrentTypeName(), err)
+	}
+	if latestPoll == nil {
+		return nil, fmt.Errorf("%s: random coffee poll not found", utils.GetCurrentTypeName())
 	}
 
 	// Stop the poll first before generating pairs
@@ -184,14 +396,544 @@ func (s *RandomCoffeeService) GenerateAndSendPairs() error {
 
 	participants, err := s.participantRepo.GetParticipatingUsers(latestPoll.ID)
 	if err != nil {
-		return fmt.Errorf("%s: error getting participants for poll ID %d: %w", utils.GetCurrentTypeName(), latestPoll.ID, err)
+		return nil, fmt.Errorf("%s: error getting participants for poll ID %d: %w", utils.GetCurrentTypeName(), latestPoll.ID, err)
 	}
 
 	if len(participants) < 2 {
-		return fmt.Errorf("not enough participants to create pairs (minimum 2 required, %d registered)", len(participants))
+		return nil, fmt.Errorf("not enough participants to create pairs (minimum 2 required, %d registered)", len(participants))
 	}
 
-	// Update participant info using Telegram Bot API if any field has changed
+	s.refreshParticipantsInfo(chatID, participants)
+
+	draft := &CoffeePairsDraft{
+		PollID:        int(latestPoll.ID),
+		WeekStartDate: latestPoll.WeekStartDate,
+		Participants:  participants,
+	}
+	s.matchDraft(draft)
+	return s.addDraft(draft, autoPublishAfter), nil
+}
+
+// CreateProgramPairsDraft matches the participants of a closed matching program round and keeps the result as
+// a draft for admin review, like the weekly pairs. The program must carry the roles of the round's poll.
+func (s *RandomCoffeeService) CreateProgramPairsDraft(
+	program *repositories.MatchingProgram,
+	poll *repositories.RandomCoffeePoll,
+	participants []repositories.RandomCoffeeProgramParticipant,
+	autoPublishAfter time.Duration,
+) *CoffeePairsDraft {
+	draft := &CoffeePairsDraft{
+		PollID:        int(poll.ID),
+		Program:       program,
+		WeekStartDate: poll.WeekStartDate,
+		Roles:         make(map[int]string),
+	}
+	for _, participant := range participants {
+		draft.Participants = append(draft.Participants, participant.User)
+		if participant.Role.Valid {
+			draft.Roles[participant.User.ID] = participant.Role.String
+		}
+	}
+
+	s.refreshParticipantsInfo(utils.ChatIdToFullChatId(s.config.SuperGroupChatID), draft.Participants)
+	s.matchDraft(draft)
+	return s.addDraft(draft, autoPublishAfter)
+}
+
+// addDraft stores a new draft, replacing the previous draft of the same round, and returns a copy of it
+func (s *RandomCoffeeService) addDraft(draft *CoffeePairsDraft, autoPublishAfter time.Duration) *CoffeePairsDraft {
+	now := time.Now().UTC()
+	draft.CreatedAt = now
+	if autoPublishAfter > 0 {
+		draft.AutoPublishAt = now.Add(autoPublishAfter)
+	}
+
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+	s.drafts[draft.PollID] = draft
+	s.saveDraftLocked(draft.PollID)
+
+	log.Printf("%s: Created %s pairs draft for poll ID %d: %d pairs, %d unpaired",
+		utils.GetCurrentTypeName(), draft.Title(), draft.PollID, len(draft.Pairs), len(draft.Unpaired))
+	return draft.copy()
+}
+
+// GetPairsDraft returns the pairs draft of a round, or nil if there is none
+func (s *RandomCoffeeService) GetPairsDraft(pollID int) *CoffeePairsDraft {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return nil
+	}
+	return draft.copy()
+}
+
+// RerollPairsDraft generates new pairs for the draft's participants
+func (s *RandomCoffeeService) RerollPairsDraft(pollID int) (*CoffeePairsDraft, error) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return nil, fmt.Errorf("%s: there is no pairs draft for this round", utils.GetCurrentTypeName())
+	}
+	if len(draft.Participants) < 2 {
+		return nil, fmt.Errorf("not enough participants to create pairs (minimum 2 required, %d left)", len(draft.Participants))
+	}
+
+	s.matchDraft(draft)
+	s.saveDraftLocked(pollID)
+	return draft.copy(), nil
+}
+
+// SwapInPairsDraft swaps the places of two members, e.g. to pair someone with an unpaired member
+func (s *RandomCoffeeService) SwapInPairsDraft(pollID int, firstUserID int, secondUserID int) (*CoffeePairsDraft, error) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return nil, fmt.Errorf("%s: there is no pairs draft for this round", utils.GetCurrentTypeName())
+	}
+
+	first := draft.memberSlot(firstUserID)
+	second := draft.memberSlot(secondUserID)
+	if first == nil || second == nil {
+		return nil, fmt.Errorf("%s: member is not in the pairs draft", utils.GetCurrentTypeName())
+	}
+	if !draft.CanSwap(firstUserID, secondUserID) {
+		return nil, fmt.Errorf("%s: members with different roles can't be swapped", utils.GetCurrentTypeName())
+	}
+
+	*first, *second = *second, *first
+	s.saveDraftLocked(pollID)
+	return draft.copy(), nil
+}
+
+// RemoveFromPairsDraft removes a member from the draft. Their place in the pair is taken by an unpaired member
+// who can be matched with the partner, if there is one, otherwise the partner becomes unpaired.
+func (s *RandomCoffeeService) RemoveFromPairsDraft(pollID int, userID int) (*CoffeePairsDraft, error) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return nil, fmt.Errorf("%s: there is no pairs draft for this round", utils.GetCurrentTypeName())
+	}
+
+	participants := make([]repositories.User, 0, len(draft.Participants))
+	for _, participant := range draft.Participants {
+		if participant.ID != userID {
+			participants = append(participants, participant)
+		}
+	}
+	if len(participants) == len(draft.Participants) {
+		return nil, fmt.Errorf("%s: member is not in the pairs draft", utils.GetCurrentTypeName())
+	}
+	draft.Participants = participants
+
+	for i, unpaired := range draft.Unpaired {
+		if unpaired.ID == userID {
+			draft.Unpaired = append(draft.Unpaired[:i], draft.Unpaired[i+1:]...)
+			s.saveDraftLocked(pollID)
+			return draft.copy(), nil
+		}
+	}
+
+	for i, pair := range draft.Pairs {
+		var slot *repositories.User
+		var partner repositories.User
+		switch userID {
+		case pair.User1.ID:
+			slot, partner = &draft.Pairs[i].User1, pair.User2
+		case pair.User2.ID:
+			slot, partner = &draft.Pairs[i].User2, pair.User1
+		default:
+			continue
+		}
+
+		if replacement := draft.replacementIndex(userID); replacement >= 0 {
+			*slot = draft.Unpaired[replacement]
+			draft.Unpaired = append(draft.Unpaired[:replacement], draft.Unpaired[replacement+1:]...)
+		} else {
+			draft.Pairs = append(draft.Pairs[:i], draft.Pairs[i+1:]...)
+			draft.Unpaired = append(draft.Unpaired, partner)
+		}
+		break
+	}
+
+	s.saveDraftLocked(pollID)
+	return draft.copy(), nil
+}
+
+// SetPairsDraftAdminMessage remembers the admin message showing the draft, so it can be updated on auto-publish
+func (s *RandomCoffeeService) SetPairsDraftAdminMessage(pollID int, chatID int64, messageID int64) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return
+	}
+	draft.AdminChatID = chatID
+	draft.AdminMessageID = messageID
+	s.saveDraftLocked(pollID)
+}
+
+// DiscardPairsDraft drops the draft of a round without saving or publishing anything
+func (s *RandomCoffeeService) DiscardPairsDraft(pollID int) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	delete(s.drafts, pollID)
+	s.saveDraftLocked(pollID)
+}
+
+// PublishPairsDraft saves the draft pairs to the database and announces them in the round's topic
+func (s *RandomCoffeeService) PublishPairsDraft(pollID int) error {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	return s.publishDraftLocked(pollID)
+}
+
+// PublishExpiredPairsDrafts publishes every draft whose auto-publish time has passed.
+// Returns how many drafts were published.
+func (s *RandomCoffeeService) PublishExpiredPairsDrafts() (int, error) {
+	s.draftMutex.Lock()
+	defer s.draftMutex.Unlock()
+	s.loadDraftsLocked()
+
+	now := time.Now().UTC()
+	var due []*CoffeePairsDraft
+	for _, draft := range s.drafts {
+		if !draft.AutoPublishAt.IsZero() && !now.Before(draft.AutoPublishAt) {
+			due = append(due, draft)
+		}
+	}
+
+	published := 0
+	var errs []error
+	for _, draft := range due {
+		if err := s.publishDraftLocked(draft.PollID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		published++
+
+		if draft.AdminChatID != 0 && draft.AdminMessageID != 0 {
+			_ = s.messageSender.RemoveInlineKeyboard(draft.AdminChatID, draft.AdminMessageID)
+			err := s.messageSender.SendHtml(draft.AdminChatID,
+				fmt.Sprintf("☕️ The %s pairs draft was published automatically.", html.EscapeString(draft.Title())), nil)
+			if err != nil {
+				log.Printf("%s: Failed to notify admin about auto-published pairs: %v", utils.GetCurrentTypeName(), err)
+			}
+		}
+	}
+
+	return published, errors.Join(errs...)
+}
+
+// SendPairsDraftToAdmin sends the draft of a round with review buttons to the admin's DM
+func (s *RandomCoffeeService) SendPairsDraftToAdmin(pollID int) error {
+	draft := s.GetPairsDraft(pollID)
+	if draft == nil {
+		return fmt.Errorf("%s: there is no pairs draft for this round", utils.GetCurrentTypeName())
+	}
+	if s.config.AdminUserID == 0 {
+		return fmt.Errorf("%s: AdminUserID is not configured", utils.GetCurrentTypeName())
+	}
+
+	message, err := s.messageSender.SendHtmlWithReturnMessage(s.config.AdminUserID, s.FormatPairsDraft(draft), &gotgbot.SendMessageOpts{
+		ReplyMarkup:        buttons.RandomCoffeeDraftButtons(draft.PollID),
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: error sending pairs draft to admin: %w", utils.GetCurrentTypeName(), err)
+	}
+
+	s.SetPairsDraftAdminMessage(draft.PollID, message.Chat.Id, message.MessageId)
+	return nil
+}
+
+// FormatPairsDraft renders the draft for admin review
+func (s *RandomCoffeeService) FormatPairsDraft(draft *CoffeePairsDraft) string {
+	var messageBuilder strings.Builder
+	if draft.Program == nil {
+		messageBuilder.WriteString(fmt.Sprintf("☕️ <b>Random Coffee pairs draft</b> ➪ <i>week of %s</i>\n\n", draft.WeekStartDate.Format("Mon, Jan 2")))
+	} else {
+		messageBuilder.WriteString(fmt.Sprintf("🤝 <b>%s pairs draft</b> ➪ <i>round of %s</i>\n\n",
+			html.EscapeString(draft.Program.Name), draft.WeekStartDate.Format("Mon, Jan 2")))
+	}
+	for _, pair := range draft.Pairs {
+		messageBuilder.WriteString(s.formatDraftPair(draft, pair))
+	}
+	if len(draft.Unpaired) > 0 {
+		messageBuilder.WriteString(fmt.Sprintf("\n😔 Unpaired: %s\n", s.formatDraftUnpaired(draft)))
+	}
+	messageBuilder.WriteString("\n<i>Nothing is saved or posted until you publish.</i>")
+	if !draft.AutoPublishAt.IsZero() {
+		messageBuilder.WriteString(fmt.Sprintf("\n<i>The draft will be published automatically at %s UTC.</i>", draft.AutoPublishAt.Format("Mon 15:04")))
+	}
+	return messageBuilder.String()
+}
+
+// formatDraftPair renders a pair as a line, with the members' roles if the program has roles
+func (s *RandomCoffeeService) formatDraftPair(draft *CoffeePairsDraft, pair CoffeePair) string {
+	if draft.hasRoles() {
+		return fmt.Sprintf("➪ %s <i>(%s)</i> x %s <i>(%s)</i>\n",
+			s.formatUserDisplay(&pair.User1), html.EscapeString(draft.Roles[pair.User1.ID]),
+			s.formatUserDisplay(&pair.User2), html.EscapeString(draft.Roles[pair.User2.ID]),
+		)
+	}
+	return fmt.Sprintf("➪ %s x %s\n", s.formatUserDisplay(&pair.User1), s.formatUserDisplay(&pair.User2))
+}
+
+// formatDraftUnpaired renders the unpaired members as a comma-separated list
+func (s *RandomCoffeeService) formatDraftUnpaired(draft *CoffeePairsDraft) string {
+	names := make([]string, len(draft.Unpaired))
+	for i := range draft.Unpaired {
+		names[i] = s.formatUserDisplay(&draft.Unpaired[i])
+	}
+	return strings.Join(names, ", ")
+}
+
+// publishDraftLocked publishes the draft of a round. The caller must hold draftMutex.
+func (s *RandomCoffeeService) publishDraftLocked(pollID int) error {
+	draft := s.drafts[pollID]
+	if draft == nil {
+		return fmt.Errorf("%s: there is no pairs draft for this round", utils.GetCurrentTypeName())
+	}
+	if len(draft.Pairs) == 0 {
+		return fmt.Errorf("%s: the pairs draft has no pairs", utils.GetCurrentTypeName())
+	}
+
+	var messageBuilder strings.Builder
+	topicID := s.config.RandomCoffeeTopicID
+	if draft.Program == nil {
+		messageBuilder.WriteString(fmt.Sprintf("☕️ Random Coffee pairs ➪ <b><i>week of %s</i></b>:\n\n", draft.WeekStartDate.Format("Mon, Jan 2")))
+		for _, pair := range draft.Pairs {
+			messageBuilder.WriteString(s.formatDraftPair(draft, pair))
+		}
+		if len(draft.Unpaired) > 0 {
+			messageBuilder.WriteString(fmt.Sprintf("\n😔 %s is unpaired and looking for company this week!\n", s.formatDraftUnpaired(draft)))
+		}
+		messageBuilder.WriteString("\n🗓 You choose the day, time, and format of the meeting. Just message your partner directly to arrange when and how you'd like to meet.")
+	} else {
+		topicID = draft.Program.TopicID
+		messageBuilder.WriteString(fmt.Sprintf("🤝 <b>%s</b> pairs ➪ <b><i>round of %s</i></b>:\n\n", html.EscapeString(draft.Program.Name), draft.WeekStartDate.Format("Mon, Jan 2")))
+		for _, pair := range draft.Pairs {
+			messageBuilder.WriteString(s.formatDraftPair(draft, pair))
+		}
+		if len(draft.Unpaired) > 0 {
+			messageBuilder.WriteString(fmt.Sprintf("\n😔 Without a match this round: %s\n", s.formatDraftUnpaired(draft)))
+		}
+		messageBuilder.WriteString("\n🗓 Message your partner directly to agree on the time and format of your meeting.")
+	}
+
+	// Send the pairing message
+	chatID := utils.ChatIdToFullChatId(s.config.SuperGroupChatID)
+	opts := &gotgbot.SendMessageOpts{
+		MessageThreadId: int64(topicID),
+	}
+
+	message, err := s.messageSender.SendHtmlWithReturnMessage(chatID, messageBuilder.String(), opts)
+	if err != nil {
+		return fmt.Errorf("%s: error sending pairing message to chat %d: %w", utils.GetCurrentTypeName(), chatID, err)
+	}
+
+	// The pairs are announced, so the draft is done even if saving or pinning fails below
+	delete(s.drafts, pollID)
+	s.saveDraftLocked(pollID)
+	s.savePairs(draft.PollID, draft.Pairs)
+	s.saveRoundMembers(draft)
+
+	// Pin the message without notification
+	err = s.messageSender.PinMessage(message.Chat.Id, message.MessageId, false)
+	if err != nil {
+		log.Printf("%s: Failed to pin message: %v", utils.GetCurrentTypeName(), err)
+	}
+
+	log.Printf("%s: Successfully sent %s pairings for poll ID %d to chat %d.", utils.GetCurrentTypeName(), draft.Title(), draft.PollID, s.config.SuperGroupChatID)
+	return nil
+}
+
+// matchDraft pairs the draft's participants: the weekly round uses the smart pairing,
+// program rounds are matched according to the program's rules and pairing history
+func (s *RandomCoffeeService) matchDraft(draft *CoffeePairsDraft) {
+	if draft.Program == nil {
+		pairs, unpaired := s.buildPairs(draft.Participants)
+		draft.Pairs, draft.Unpaired = pairs, nil
+		if unpaired != nil {
+			draft.Unpaired = []repositories.User{*unpaired}
+		}
+		return
+	}
+
+	participants := make([]repositories.RandomCoffeeProgramParticipant, len(draft.Participants))
+	userIDs := make([]int, len(draft.Participants))
+	for i, user := range draft.Participants {
+		role, ok := draft.Roles[user.ID]
+		participants[i] = repositories.RandomCoffeeProgramParticipant{User: user, Role: sql.NullString{String: role, Valid: ok}}
+		userIDs[i] = user.ID
+	}
+	history, err := s.pairRepo.GetProgramPairsHistoryForUsers(draft.Program.ID, userIDs, matchingProgramHistoryRounds)
+	if err != nil {
+		log.Printf("%s: Failed to get pair history for program %d, matching without it: %v", utils.GetCurrentTypeName(), draft.Program.ID, err)
+		history = make(map[string][]int)
+	}
+	draft.Pairs, draft.Unpaired = matchProgramParticipants(draft.Program, participants, history)
+}
+
+// loadDraftsLocked restores the drafts saved before a restart, once. Drafts created since then are kept.
+// If any draft can't be restored completely, none are and loading is retried on the next access.
+// The caller must hold draftMutex.
+func (s *RandomCoffeeService) loadDraftsLocked() {
+	if s.draftsLoaded {
+		return
+	}
+	if s.drafts == nil {
+		s.drafts = make(map[int]*CoffeePairsDraft)
+	}
+
+	saved, err := s.draftRepo.GetAll()
+	if err != nil {
+		log.Printf("%s: Error loading saved pairs drafts: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	drafts := make(map[int]*CoffeePairsDraft, len(saved))
+	for i := range saved {
+		draft, err := s.restoreDraft(&saved[i])
+		if err != nil {
+			log.Printf("%s: Error restoring pairs draft for poll ID %d, drafts will be restored later: %v",
+				utils.GetCurrentTypeName(), saved[i].PollID, err)
+			return
+		}
+		drafts[draft.PollID] = draft
+	}
+	for pollID, draft := range s.drafts {
+		drafts[pollID] = draft
+	}
+
+	s.drafts = drafts
+	s.draftsLoaded = true
+	log.Printf("%s: Restored %d pairs drafts", utils.GetCurrentTypeName(), len(saved))
+}
+
+// restoreDraft rebuilds a saved draft. A member who can't be found would break their pair,
+// so any lookup error fails the whole draft.
+func (s *RandomCoffeeService) restoreDraft(saved *repositories.RandomCoffeePairDraft) (*CoffeePairsDraft, error) {
+	draft := &CoffeePairsDraft{
+		PollID:         saved.PollID,
+		WeekStartDate:  saved.WeekStartDate,
+		CreatedAt:      saved.CreatedAt,
+		AdminChatID:    saved.AdminChatID,
+		AdminMessageID: saved.AdminMessageID,
+	}
+	if saved.AutoPublishAt.Valid {
+		draft.AutoPublishAt = saved.AutoPublishAt.Time
+	}
+
+	poll, err := s.pollRepo.GetPollByID(int64(saved.PollID))
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, fmt.Errorf("poll %d not found", saved.PollID)
+	}
+	if poll.ProgramID.Valid {
+		program, err := s.programRepo.GetByID(int(poll.ProgramID.Int64))
+		if err != nil {
+			return nil, err
+		}
+		if program == nil {
+			return nil, fmt.Errorf("matching program %d not found", poll.ProgramID.Int64)
+		}
+		draft.Program = programForPoll(program, poll)
+
+		draft.Roles, err = s.participantRepo.GetRoles(poll.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	getUser := func(userID int) (repositories.User, error) {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return repositories.User{}, fmt.Errorf("error getting draft member %d: %w", userID, err)
+		}
+		return *user, nil
+	}
+	for _, savedPair := range saved.Pairs {
+		user1, err := getUser(savedPair.User1ID)
+		if err != nil {
+			return nil, err
+		}
+		user2, err := getUser(savedPair.User2ID)
+		if err != nil {
+			return nil, err
+		}
+		draft.Pairs = append(draft.Pairs, CoffeePair{User1: user1, User2: user2})
+	}
+	for _, userID := range saved.UnpairedUserIDs {
+		user, err := getUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		draft.Unpaired = append(draft.Unpaired, user)
+	}
+	draft.Participants = draft.Members()
+	return draft, nil
+}
+
+// saveDraftLocked stores the draft of a round, or deletes the stored one if there is no draft anymore.
+// A failure is only logged, the draft in memory stays usable. The caller must hold draftMutex.
+func (s *RandomCoffeeService) saveDraftLocked(pollID int) {
+	draft := s.drafts[pollID]
+	if draft == nil {
+		if err := s.draftRepo.Delete(pollID); err != nil {
+			log.Printf("%s: Error deleting saved pairs draft for poll ID %d: %v", utils.GetCurrentTypeName(), pollID, err)
+		}
+		return
+	}
+
+	pairs := make([]repositories.RandomCoffeePairDraftPair, 0, len(draft.Pairs))
+	for _, pair := range draft.Pairs {
+		pairs = append(pairs, repositories.RandomCoffeePairDraftPair{User1ID: pair.User1.ID, User2ID: pair.User2.ID})
+	}
+	unpairedUserIDs := make([]int, 0, len(draft.Unpaired))
+	for _, user := range draft.Unpaired {
+		unpairedUserIDs = append(unpairedUserIDs, user.ID)
+	}
+
+	err := s.draftRepo.Save(&repositories.RandomCoffeePairDraft{
+		PollID:          draft.PollID,
+		WeekStartDate:   draft.WeekStartDate,
+		Pairs:           pairs,
+		UnpairedUserIDs: unpairedUserIDs,
+		AutoPublishAt:   sql.NullTime{Time: draft.AutoPublishAt, Valid: !draft.AutoPublishAt.IsZero()},
+		AdminChatID:     draft.AdminChatID,
+		AdminMessageID:  draft.AdminMessageID,
+		CreatedAt:       draft.CreatedAt,
+	})
+	if err != nil {
+		log.Printf("%s: Error saving pairs draft for poll ID %d: %v", utils.GetCurrentTypeName(), draft.PollID, err)
+	}
+}
+
+// refreshParticipantsInfo updates participant info using Telegram Bot API if any field has changed
+func (s *RandomCoffeeService) refreshParticipantsInfo(chatID int64, participants []repositories.User) {
 	for i := range participants {
 		participant := &participants[i]
 		user, err := s.userRepo.GetByTelegramID(participant.TgID)
@@ -242,71 +984,69 @@ func (s *RandomCoffeeService) GenerateAndSendPairs() error {
 			}
 		}
 	}
+}
 
+// buildPairs runs the smart pairing and falls back to random pairing if it fails
+func (s *RandomCoffeeService) buildPairs(participants []repositories.User) ([]CoffeePair, *repositories.User) {
 	// Smart Pairing Logic with History Consideration
-	pairs, unpaired, err := s.generateSmartPairs(participants, int(latestPoll.ID))
+	pairs, unpaired, err := s.generateSmartPairs(participants)
 	if err != nil {
 		log.Printf("%s: Smart pairing failed, falling back to random: %v", utils.GetCurrentTypeName(), err)
 		// Fallback to old random logic
+		shuffled := make([]repositories.User, len(participants))
+		copy(shuffled, participants)
 		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		r.Shuffle(len(participants), func(i, j int) {
-			participants[i], participants[j] = participants[j], participants[i]
+		r.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
 		})
-		pairs, unpaired = s.createPairsFromShuffled(participants, int(latestPoll.ID))
+		pairs, unpaired = s.createPairsFromShuffled(shuffled)
 	}
+	return pairs, unpaired
+}
 
-	// Format pairs display text
-	var pairsText []string
-	var unpairedUserText string
-
-	for _, pair := range pairs {
-		user1Display := s.formatUserDisplay(&pair.User1)
-		user2Display := s.formatUserDisplay(&pair.User2)
-		pairsText = append(pairsText, fmt.Sprintf("%s x %s", user1Display, user2Display))
+// savePairs saves published pairs to the pairing history
+func (s *RandomCoffeeService) savePairs(pollID int, pairs []CoffeePair) {
+	if s.pairRepo == nil {
+		return
 	}
 
-	if unpaired != nil {
-		unpairedUserText = s.formatUserDisplay(unpaired)
+	for _, pair := range pairs {
+		u1ID, u2ID := pair.User1.ID, pair.User2.ID
+		if u1ID > u2ID {
+			u1ID, u2ID = u2ID, u1ID
+		}
+		err := s.pairRepo.CreatePair(pollID, u1ID, u2ID)
+		if err != nil {
+			log.Printf("%s: failed to save pair to DB: %v", utils.GetCurrentTypeName(), err)
+		}
 	}
+}
 
-	var messageBuilder strings.Builder
-	messageBuilder.WriteString(fmt.Sprintf("☕️ Random Coffee pairs ➪ <b><i>week of %s</i></b>:\n\n", latestPoll.WeekStartDate.Format("Mon, Jan 2")))
-	for _, pair := range pairsText {
-		messageBuilder.WriteString(fmt.Sprintf("➪ %s\n", pair))
-	}
-	if unpairedUserText != "" {
-		messageBuilder.WriteString(fmt.Sprintf("\n😔 %s is unpaired and looking for company this week!\n", unpairedUserText))
+// saveRoundMembers records everyone in the published draft as a member of the round, for statistics and history
+func (s *RandomCoffeeService) saveRoundMembers(draft *CoffeePairsDraft) {
+	members := draft.Members()
+	userIDs := make([]int, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.ID)
 	}
-	messageBuilder.WriteString("\n🗓 You choose the day, time, and format of the meeting. Just message your partner directly to arrange when and how you'd like to meet.")
-
-	// Send the pairing message
-	opts := &gotgbot.SendMessageOpts{
-		MessageThreadId: int64(s.config.RandomCoffeeTopicID),
+	if err := s.participantRepo.AddRoundMembers(int64(draft.PollID), userIDs); err != nil {
+		log.Printf("%s: failed to save members of round %d: %v", utils.GetCurrentTypeName(), draft.PollID, err)
 	}
-
-	message, err := s.messageSender.SendHtmlWithReturnMessage(chatID, messageBuilder.String(), opts)
-	if err != nil {
-		return fmt.Errorf("%s: error sending pairing message to chat %d: %w", utils.GetCurrentTypeName(), chatID, err)
-	}
-
-	// Pin the message without notification
-	err = s.messageSender.PinMessage(message.Chat.Id, message.MessageId, false)
-	if err != nil {
-		log.Printf("%s: Failed to pin message: %v", utils.GetCurrentTypeName(), err)
-	}
-
-	log.Printf("%s: Successfully sent pairings for poll ID %d to chat %d.", utils.GetCurrentTypeName(), latestPoll.ID, s.config.SuperGroupChatID)
-	return nil
 }
 
 func (s *RandomCoffeeService) formatUserDisplay(user *repositories.User) string {
+	return formatMatchedUserDisplay(s.config, s.profileRepo, user)
+}
+
+// formatMatchedUserDisplay renders a matched user as @username (or first name) with a link to their intro, if published
+func formatMatchedUserDisplay(config *config.Config, profileRepo *repositories.ProfileRepository, user *repositories.User) string {
 	userDisplay := user.Firstname
 
 	if user.TgUsername != "" {
 		userDisplay = fmt.Sprintf("@%s", user.TgUsername)
 	}
 
-	profile, err := s.profileRepo.GetOrCreate(user.ID)
+	profile, err := profileRepo.GetOrCreate(user.ID)
 	if err != nil {
 		log.Printf("%s: Error getting profile for user %d: %v", utils.GetCurrentTypeName(), user.ID, err)
 		return userDisplay
@@ -314,7 +1054,7 @@ func (s *RandomCoffeeService) formatUserDisplay(user *repositories.User) string
 
 	if profile.PublishedMessageID.Valid &&
 		profile.PublishedMessageID.Int64 > 0 {
-		profileLink := utils.GetIntroMessageLink(s.config, profile.PublishedMessageID.Int64)
+		profileLink := utils.GetIntroMessageLink(config, profile.PublishedMessageID.Int64)
 		linkedName := fmt.Sprintf(" <i>(<a href=\"%s\">profile</a>)</i>", profileLink)
 
 		userDisplay += linkedName
@@ -330,7 +1070,7 @@ type CoffeePair struct {
 }
 
 // generateSmartPairs creates pairs considering history to avoid recent repeats
-func (s *RandomCoffeeService) generateSmartPairs(participants []repositories.User, pollID int) ([]CoffeePair, *repositories.User, error) {
+func (s *RandomCoffeeService) generateSmartPairs(participants []repositories.User) ([]CoffeePair, *repositories.User, error) {
 	if len(participants) < 2 {
 		return nil, nil, fmt.Errorf("not enough participants for pairing")
 	}
@@ -419,18 +1159,6 @@ func (s *RandomCoffeeService) generateSmartPairs(participants []repositories.Use
 					utils.GetCurrentTypeName(), user1.Firstname, user2.Firstname, oldestPoll)
 			}
 
-			// Save to database
-			if s.pairRepo != nil {
-				u1ID, u2ID := user1.ID, user2.ID
-				if u1ID > u2ID {
-					u1ID, u2ID = u2ID, u1ID
-				}
-				err := s.pairRepo.CreatePair(pollID, u1ID, u2ID)
-				if err != nil {
-					log.Printf("%s: failed to save smart pair to DB: %v", utils.GetCurrentTypeName(), err)
-				}
-			}
-
 			used[user1.ID] = true
 			used[user2.ID] = true
 		}
@@ -449,7 +1177,7 @@ func (s *RandomCoffeeService) generateSmartPairs(participants []repositories.Use
 }
 
 // createPairsFromShuffled creates pairs from already shuffled participants (fallback method)
-func (s *RandomCoffeeService) createPairsFromShuffled(participants []repositories.User, pollID int) ([]CoffeePair, *repositories.User) {
+func (s *RandomCoffeeService) createPairsFromShuffled(participants []repositories.User) ([]CoffeePair, *repositories.User) {
 	var pairs []CoffeePair
 	var unpaired *repositories.User
 
@@ -459,17 +1187,6 @@ func (s *RandomCoffeeService) createPairsFromShuffled(participants []repositorie
 		if i+1 < len(participants) {
 			user2 := participants[i+1]
 			pairs = append(pairs, CoffeePair{User1: user1, User2: user2})
-
-			if s.pairRepo != nil {
-				u1ID, u2ID := user1.ID, user2.ID
-				if u1ID > u2ID {
-					u1ID, u2ID = u2ID, u1ID
-				}
-				err := s.pairRepo.CreatePair(pollID, u1ID, u2ID)
-				if err != nil {
-					log.Printf("%s: failed to save fallback pair to DB: %v", utils.GetCurrentTypeName(), err)
-				}
-			}
 		} else {
 			unpaired = &user1
 		}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// ReengagementService reminds the members who have been silent for a while about the club
+type ReengagementService struct {
+	config                 *config.Config
+	messageSender          *MessageSenderService
+	reengagementRepository *repositories.ReengagementRepository
+}
+
+// NewReengagementService creates a new re-engagement service
+func NewReengagementService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	reengagementRepository *repositories.ReengagementRepository,
+) *ReengagementService {
+	return &ReengagementService{
+		config:                 config,
+		messageSender:          messageSender,
+		reengagementRepository: reengagementRepository,
+	}
+}
+
+// SendReengagementMessages sends the friendly DM to the members who haven't been active for the configured
+// number of weeks. Every member is reminded once per silent period, the reminder is recorded before it's sent.
+func (s *ReengagementService) SendReengagementMessages(now time.Time) {
+	silentSince := now.AddDate(0, 0, -7*s.config.ReengagementSilentWeeks)
+	members, err := s.reengagementRepository.GetSilentMembers(silentSince)
+	if err != nil {
+		log.Printf("%s: Error getting silent members: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	log.Printf("%s: Sending re-engagement messages to %d members", utils.GetCurrentTypeName(), len(members))
+	for i := range members {
+		member := &members[i]
+
+		if err := s.reengagementRepository.MarkSent(member.ID); err != nil {
+			log.Printf("%s: Error recording re-engagement message for user %d: %v", utils.GetCurrentTypeName(), member.ID, err)
+			continue
+		}
+
+		// Members who never started the bot can't be messaged, it's expected for some of them
+		err := s.messageSender.SendHtml(
+			member.TgID,
+			formatters.FormatReengagementMessage(member, s.config.ReengagementSilentWeeks),
+			&gotgbot.SendMessageOpts{ReplyMarkup: buttons.ReengagementButtons(false)},
+		)
+		if err != nil {
+			log.Printf("%s: Error sending re-engagement message to user %d: %v", utils.GetCurrentTypeName(), member.ID, err)
+		}
+	}
+}
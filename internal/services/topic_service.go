@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"evo-bot-go/internal/buttons"
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/constants"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/formatters"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// TopicService moderates the topics members suggest for events and sends the ranked agenda to the host
+type TopicService struct {
+	config        *config.Config
+	messageSender *MessageSenderService
+	topicRepo     *repositories.TopicRepository
+	eventRepo     *repositories.EventRepository
+	userRepo      *repositories.UserRepository
+}
+
+// NewTopicService creates a new topic service
+func NewTopicService(
+	config *config.Config,
+	messageSender *MessageSenderService,
+	topicRepo *repositories.TopicRepository,
+	eventRepo *repositories.EventRepository,
+	userRepo *repositories.UserRepository,
+) *TopicService {
+	return &TopicService{
+		config:        config,
+		messageSender: messageSender,
+		topicRepo:     topicRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// SendForModeration sends a new or edited topic to the administrator with the Approve / Reject buttons
+func (s *TopicService) SendForModeration(topicID int, edited bool) error {
+	topic, err := s.topicRepo.GetTopicByID(topicID)
+	if err != nil {
+		return err
+	}
+
+	event, err := s.eventRepo.GetEventByID(topic.EventID)
+	if err != nil {
+		return err
+	}
+
+	return s.messageSender.SendHtml(
+		s.config.AdminUserID,
+		formatters.FormatHtmlTopicForModeration(*topic, event.Name, edited),
+		&gotgbot.SendMessageOpts{ReplyMarkup: buttons.TopicModerationButtons(topic.ID, topic.IsAnonymous)},
+	)
+}
+
+// Moderate approves or rejects a pending topic and lets the author know. Returns the updated topic.
+func (s *TopicService) Moderate(topicID int, status constants.TopicStatus, rejectionReason string) (*repositories.Topic, error) {
+	topic, err := s.topicRepo.GetTopicByID(topicID)
+	if err != nil {
+		return nil, err
+	}
+	if topic.Status != constants.TopicStatusPending {
+		return nil, fmt.Errorf("%s: topic %d is already %s", utils.GetCurrentTypeName(), topicID, topic.Status)
+	}
+
+	if err := s.topicRepo.UpdateTopicStatus(topicID, status, rejectionReason); err != nil {
+		return nil, err
+	}
+	topic.Status = status
+	if status == constants.TopicStatusRejected {
+		topic.RejectionReason = rejectionReason
+	}
+
+	s.notifyAuthor(topic)
+	return topic, nil
+}
+
+// SendAgenda sends the approved topics of the event ranked by votes to the host,
+// or to the administrator if the event has no host. Events without topics have no agenda.
+func (s *TopicService) SendAgenda(event *repositories.Event) error {
+	topics, err := s.topicRepo.GetRankedTopicsByEventID(event.ID)
+	if err != nil {
+		return err
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+
+	chatID := s.config.AdminUserID
+	if event.HostUserID != nil {
+		host, err := s.userRepo.GetByID(*event.HostUserID)
+		if err != nil {
+			log.Printf("%s: Error getting host of event %d, sending agenda to the administrator: %v",
+				utils.GetCurrentTypeName(), event.ID, err)
+		} else {
+			chatID = host.TgID
+		}
+	}
+
+	return s.messageSender.SendDocument(
+		chatID,
+		fmt.Sprintf("agenda-%d.txt", event.ID),
+		formatters.FormatTopicAgenda(*event, topics, s.config.EventsTimezone),
+		&gotgbot.SendDocumentOpts{
+			Caption:   formatters.FormatHtmlTopicAgendaCaption(*event, topics),
+			ParseMode: "HTML",
+		},
+	)
+}
+
+// RevealAuthor returns the author of an anonymous topic to an administrator dealing with abuse.
+// Every reveal is logged, so it can be checked later.
+func (s *TopicService) RevealAuthor(topicID int, adminTgID int64) (*repositories.User, error) {
+	authorUserID, err := s.topicRepo.GetTopicAuthorUserID(topicID)
+	if err != nil {
+		return nil, err
+	}
+	if authorUserID == nil {
+		return nil, fmt.Errorf("%s: author of topic %d is unknown", utils.GetCurrentTypeName(), topicID)
+	}
+
+	log.Printf("%s: Administrator %d revealed the author of topic %d", utils.GetCurrentTypeName(), adminTgID, topicID)
+	return s.userRepo.GetByID(*authorUserID)
+}
+
+// notifyAuthor lets the author know the topic was approved or rejected, topics without a known author are skipped
+func (s *TopicService) notifyAuthor(topic *repositories.Topic) {
+	authorUserID, err := s.topicRepo.GetTopicAuthorUserID(topic.ID)
+	if err != nil {
+		log.Printf("%s: Error getting author of topic %d: %v", utils.GetCurrentTypeName(), topic.ID, err)
+		return
+	}
+	if authorUserID == nil {
+		return
+	}
+
+	author, err := s.userRepo.GetByID(*authorUserID)
+	if err != nil {
+		log.Printf("%s: Error getting author of topic %d: %v", utils.GetCurrentTypeName(), topic.ID, err)
+		return
+	}
+
+	eventName := ""
+	if event, err := s.eventRepo.GetEventByID(topic.EventID); err == nil {
+		eventName = event.Name
+	}
+
+	err = s.messageSender.SendHtml(author.TgID, formatters.FormatHtmlTopicModerationResultForAuthor(*topic, eventName), nil)
+	if err != nil {
+		log.Printf("%s: Error notifying author of topic %d: %v", utils.GetCurrentTypeName(), topic.ID, err)
+	}
+}
@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// BadgesTask awards the badges the members have earned, once an hour
+type BadgesTask struct {
+	config       *config.Config
+	badgeService *services.BadgeService
+	stop         chan struct{}
+}
+
+// NewBadgesTask creates a new badges task
+func NewBadgesTask(config *config.Config, badgeService *services.BadgeService) *BadgesTask {
+	return &BadgesTask{
+		config:       config,
+		badgeService: badgeService,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start starts the badges task
+func (t *BadgesTask) Start() {
+	if !t.config.BadgesEnabled {
+		log.Printf("%s: Badges task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting badges task", utils.GetCurrentTypeName())
+	go t.run()
+}
+
+// Stop stops the badges task
+func (t *BadgesTask) Stop() {
+	log.Printf("%s: Stopping badges task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the badges task
+func (t *BadgesTask) run() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.badgeService.AwardBadges()
+		}
+	}
+}
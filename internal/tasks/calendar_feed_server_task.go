@@ -0,0 +1,164 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/database/repositories"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// calendarFeedMembershipCacheTTL is how long a club membership check is reused, calendar apps
+// poll feeds often and each check is a Telegram API call
+const calendarFeedMembershipCacheTTL = 5 * time.Minute
+
+type calendarFeedMembership struct {
+	isMember  bool
+	checkedAt time.Time
+}
+
+// CalendarFeedServerTask serves members' personal calendar feeds over HTTP.
+// Instead of running on a schedule, it lives for as long as the bot does.
+type CalendarFeedServerTask struct {
+	config               *config.Config
+	bot                  *gotgbot.Bot
+	eventCalendarService *services.EventCalendarService
+	userRepository       *repositories.UserRepository
+	server               *http.Server
+
+	membershipMutex sync.Mutex
+	membershipCache map[int64]calendarFeedMembership
+}
+
+// NewCalendarFeedServerTask creates a new calendar feed server task
+func NewCalendarFeedServerTask(
+	config *config.Config,
+	bot *gotgbot.Bot,
+	eventCalendarService *services.EventCalendarService,
+	userRepository *repositories.UserRepository,
+) *CalendarFeedServerTask {
+	return &CalendarFeedServerTask{
+		config:               config,
+		bot:                  bot,
+		eventCalendarService: eventCalendarService,
+		userRepository:       userRepository,
+		membershipCache:      make(map[int64]calendarFeedMembership),
+	}
+}
+
+// Start starts the calendar feed server
+func (t *CalendarFeedServerTask) Start() {
+	if !t.eventCalendarService.IsFeedEnabled() {
+		log.Printf("%s: Calendar feed server is disabled", utils.GetCurrentTypeName())
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /calendar/{file}", t.handleFeed)
+
+	t.server = &http.Server{
+		Addr:              t.config.CalendarFeedAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("%s: Starting calendar feed server on %s", utils.GetCurrentTypeName(), t.config.CalendarFeedAddr)
+	go func() {
+		if err := t.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("%s: Calendar feed server stopped: %v", utils.GetCurrentTypeName(), err)
+		}
+	}()
+}
+
+// Stop stops the calendar feed server
+func (t *CalendarFeedServerTask) Stop() {
+	if t.server == nil {
+		return
+	}
+	log.Printf("%s: Stopping calendar feed server", utils.GetCurrentTypeName())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := t.server.Shutdown(ctx); err != nil {
+		log.Printf("%s: Error stopping calendar feed server: %v", utils.GetCurrentTypeName(), err)
+	}
+}
+
+// handleFeed serves GET /calendar/<token>.ics to club members only
+func (t *CalendarFeedServerTask) handleFeed(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutSuffix(r.PathValue("file"), ".ics")
+	if !ok || token == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	userID, err := t.eventCalendarService.GetUserIDByFeedToken(token)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Printf("%s: Error checking calendar feed token: %v", utils.GetCurrentTypeName(), err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := t.userRepository.GetByID(userID)
+	if err != nil {
+		log.Printf("%s: Error getting user %d: %v", utils.GetCurrentTypeName(), userID, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	// Links of members who left the club stop working
+	if !t.isClubMember(user.TgID) {
+		http.NotFound(w, r)
+		return
+	}
+
+	feed, err := t.eventCalendarService.BuildFeed(time.Now())
+	if err != nil {
+		log.Printf("%s: Error building calendar feed: %v", utils.GetCurrentTypeName(), err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="events.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	if _, err := w.Write([]byte(feed)); err != nil {
+		log.Printf("%s: Error writing calendar feed: %v", utils.GetCurrentTypeName(), err)
+	}
+}
+
+// isClubMember checks the club membership of the feed owner, reusing recent checks
+func (t *CalendarFeedServerTask) isClubMember(tgID int64) bool {
+	t.membershipMutex.Lock()
+	cached, ok := t.membershipCache[tgID]
+	t.membershipMutex.Unlock()
+	if ok && time.Since(cached.checkedAt) < calendarFeedMembershipCacheTTL {
+		return cached.isMember
+	}
+
+	isMember := utils.IsUserClubMember(t.bot, tgID, t.config)
+
+	t.membershipMutex.Lock()
+	defer t.membershipMutex.Unlock()
+	// Drop expired checks so the cache doesn't grow with every member who ever synced
+	for id, entry := range t.membershipCache {
+		if time.Since(entry.checkedAt) >= calendarFeedMembershipCacheTTL {
+			delete(t.membershipCache, id)
+		}
+	}
+	t.membershipCache[tgID] = calendarFeedMembership{isMember: isMember, checkedAt: time.Now()}
+	return isMember
+}
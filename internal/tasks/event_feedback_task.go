@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// EventFeedbackTask sends the feedback form to the attendees once an event is over.
+// Every event has its own planned end, so the task checks the finished events every five minutes.
+type EventFeedbackTask struct {
+	config               *config.Config
+	eventFeedbackService *services.EventFeedbackService
+	stop                 chan struct{}
+}
+
+// NewEventFeedbackTask creates a new event feedback task
+func NewEventFeedbackTask(config *config.Config, eventFeedbackService *services.EventFeedbackService) *EventFeedbackTask {
+	return &EventFeedbackTask{
+		config:               config,
+		eventFeedbackService: eventFeedbackService,
+		stop:                 make(chan struct{}),
+	}
+}
+
+// Start starts the event feedback task
+func (t *EventFeedbackTask) Start() {
+	if !t.config.EventFeedbackTaskEnabled {
+		log.Printf("%s: Event feedback task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting event feedback task with delay %v", utils.GetCurrentTypeName(), t.config.EventFeedbackDelay)
+	go t.run()
+}
+
+// Stop stops the event feedback task
+func (t *EventFeedbackTask) Stop() {
+	log.Printf("%s: Stopping event feedback task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the event feedback task
+func (t *EventFeedbackTask) run() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.eventFeedbackService.SendDueFeedbackRequests(now.UTC())
+		}
+	}
+}
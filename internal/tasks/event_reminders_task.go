@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// EventRemindersTask sends reminders before events and their join links when they start.
+// Every event has its own planned start, so the task checks the upcoming events every minute.
+type EventRemindersTask struct {
+	config               *config.Config
+	eventReminderService *services.EventReminderService
+	stop                 chan struct{}
+}
+
+// NewEventRemindersTask creates a new event reminders task
+func NewEventRemindersTask(config *config.Config, eventReminderService *services.EventReminderService) *EventRemindersTask {
+	return &EventRemindersTask{
+		config:               config,
+		eventReminderService: eventReminderService,
+		stop:                 make(chan struct{}),
+	}
+}
+
+// Start starts the event reminders task
+func (t *EventRemindersTask) Start() {
+	if !t.config.EventRemindersTaskEnabled {
+		log.Printf("%s: Event reminders task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting event reminders task with offsets %v", utils.GetCurrentTypeName(), t.config.EventReminderOffsets)
+	go t.run()
+}
+
+// Stop stops the event reminders task
+func (t *EventRemindersTask) Stop() {
+	log.Printf("%s: Stopping event reminders task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the event reminders task
+func (t *EventRemindersTask) run() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.eventReminderService.SendDueReminders(now.UTC())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// EventSeriesTask creates the upcoming occurrences of recurring events.
+// Occurrences are created days ahead, so checking every hour is enough.
+type EventSeriesTask struct {
+	config             *config.Config
+	eventSeriesService *services.EventSeriesService
+	stop               chan struct{}
+}
+
+// NewEventSeriesTask creates a new event series task
+func NewEventSeriesTask(config *config.Config, eventSeriesService *services.EventSeriesService) *EventSeriesTask {
+	return &EventSeriesTask{
+		config:             config,
+		eventSeriesService: eventSeriesService,
+		stop:               make(chan struct{}),
+	}
+}
+
+// Start starts the event series task
+func (t *EventSeriesTask) Start() {
+	log.Printf("%s: Starting event series task, creating occurrences %d days ahead",
+		utils.GetCurrentTypeName(), t.config.EventSeriesHorizonDays)
+	go t.run()
+}
+
+// Stop stops the event series task
+func (t *EventSeriesTask) Stop() {
+	log.Printf("%s: Stopping event series task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the event series task
+func (t *EventSeriesTask) run() {
+	// Catch up right away, e.g. after the bot was down
+	t.eventSeriesService.MaterializeOccurrences(time.Now().UTC())
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.eventSeriesService.MaterializeOccurrences(now.UTC())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// EventTopicsTask closes the forum topics of the events once their close delay has passed.
+// Every event has its own planned end, so the task checks the open topics every five minutes.
+type EventTopicsTask struct {
+	config            *config.Config
+	eventTopicService *services.EventTopicService
+	stop              chan struct{}
+}
+
+// NewEventTopicsTask creates a new event topics task
+func NewEventTopicsTask(config *config.Config, eventTopicService *services.EventTopicService) *EventTopicsTask {
+	return &EventTopicsTask{
+		config:            config,
+		eventTopicService: eventTopicService,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start starts the event topics task
+func (t *EventTopicsTask) Start() {
+	if !t.config.EventTopicsEnabled {
+		log.Printf("%s: Event topics task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting event topics task with close delay %v", utils.GetCurrentTypeName(), t.config.EventTopicCloseDelay)
+	go t.run()
+}
+
+// Stop stops the event topics task
+func (t *EventTopicsTask) Stop() {
+	log.Printf("%s: Stopping event topics task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the event topics task
+func (t *EventTopicsTask) run() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.eventTopicService.CloseDueTopics(now.UTC())
+		}
+	}
+}
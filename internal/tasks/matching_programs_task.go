@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// MatchingProgramsTask runs polls and matching of admin-configured matching programs.
+// Every program has its own schedule, so the task checks all of them every minute.
+type MatchingProgramsTask struct {
+	config                 *config.Config
+	matchingProgramService *services.MatchingProgramService
+	stop                   chan struct{}
+}
+
+// NewMatchingProgramsTask creates a new matching programs task
+func NewMatchingProgramsTask(config *config.Config, matchingProgramService *services.MatchingProgramService) *MatchingProgramsTask {
+	return &MatchingProgramsTask{
+		config:                 config,
+		matchingProgramService: matchingProgramService,
+		stop:                   make(chan struct{}),
+	}
+}
+
+// Start starts the matching programs task
+func (t *MatchingProgramsTask) Start() {
+	if !t.config.MatchingProgramsTaskEnabled {
+		log.Printf("%s: Matching programs task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting matching programs task", utils.GetCurrentTypeName())
+	go t.run()
+}
+
+// Stop stops the matching programs task
+func (t *MatchingProgramsTask) Stop() {
+	log.Printf("%s: Stopping matching programs task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the matching programs task
+func (t *MatchingProgramsTask) run() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.matchingProgramService.RunDueRounds(now.UTC())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// OnboardingTask completes the onboardings done with other commands and reminds the new members, once an hour
+type OnboardingTask struct {
+	config            *config.Config
+	onboardingService *services.OnboardingService
+	stop              chan struct{}
+}
+
+// NewOnboardingTask creates a new onboarding task
+func NewOnboardingTask(config *config.Config, onboardingService *services.OnboardingService) *OnboardingTask {
+	return &OnboardingTask{
+		config:            config,
+		onboardingService: onboardingService,
+		stop:              make(chan struct{}),
+	}
+}
+
+// Start starts the onboarding task
+func (t *OnboardingTask) Start() {
+	if !t.config.OnboardingEnabled {
+		log.Printf("%s: Onboarding task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting onboarding task with %d reminders every %v",
+		utils.GetCurrentTypeName(), t.config.OnboardingReminderCount, t.config.OnboardingReminderInterval)
+	go t.run()
+}
+
+// Stop stops the onboarding task
+func (t *OnboardingTask) Stop() {
+	log.Printf("%s: Stopping onboarding task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the onboarding task
+func (t *OnboardingTask) run() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			t.onboardingService.ProcessOnboardings(now)
+		}
+	}
+}
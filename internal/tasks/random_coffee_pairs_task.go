@@ -25,10 +25,12 @@ func NewRandomCoffeePairsTask(config *config.Config, randomCoffeeService *servic
 	}
 }
 
-// Start starts the random coffee pairs task
+// Start starts the random coffee pairs task.
+// Drafts are auto-published even when scheduled generation is disabled, since admins can create them manually.
 func (t *RandomCoffeePairsTask) Start() {
 	if !t.config.RandomCoffeePairsTaskEnabled {
-		log.Printf("%s: Random coffee pairs task is disabled", utils.GetCurrentTypeName())
+		log.Printf("%s: Random coffee pairs generation is disabled, only drafts will be auto-published", utils.GetCurrentTypeName())
+		go t.run()
 		return
 	}
 	log.Printf("%s: Starting random coffee pairs task with time %02d:%02d UTC on %s",
@@ -47,8 +49,12 @@ func (t *RandomCoffeePairsTask) Stop() {
 
 // run runs the random coffee pairs task
 func (t *RandomCoffeePairsTask) run() {
-	nextRun := t.calculateNextRun()
-	log.Printf("%s: Next random coffee pairs generation scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+	enabled := t.config.RandomCoffeePairsTaskEnabled
+	var nextRun time.Time
+	if enabled {
+		nextRun = t.calculateNextRun()
+		log.Printf("%s: Next random coffee pairs generation scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+	}
 
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
@@ -58,19 +64,46 @@ func (t *RandomCoffeePairsTask) run() {
 		case <-t.stop:
 			return
 		case now := <-ticker.C:
-			if now.After(nextRun) {
+			if enabled && now.After(nextRun) {
 				log.Printf("%s: Running scheduled random coffee pairs generation", utils.GetCurrentTypeName())
 
-				go func() {
-					if err := t.randomCoffeeService.GenerateAndSendPairs(); err != nil {
-						log.Printf("%s: Error generating random coffee pairs: %v", utils.GetCurrentTypeName(), err)
-					}
-				}()
+				go t.generatePairs()
 
 				nextRun = t.calculateNextRun()
 				log.Printf("%s: Next random coffee pairs generation scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
 			}
+
+			// Publish pairs drafts, of the weekly round and of matching programs, if admins haven't done it in time
+			published, err := t.randomCoffeeService.PublishExpiredPairsDrafts()
+			if err != nil {
+				log.Printf("%s: Error auto-publishing pairs drafts: %v", utils.GetCurrentTypeName(), err)
+			}
+			if published > 0 {
+				log.Printf("%s: %d pairs drafts auto-published", utils.GetCurrentTypeName(), published)
+			}
+		}
+	}
+}
+
+// generatePairs publishes pairs right away if the draft timeout is zero,
+// otherwise it creates a draft and sends it to the admin for review
+func (t *RandomCoffeePairsTask) generatePairs() {
+	if t.config.RandomCoffeePairsDraftTimeout == 0 {
+		if err := t.randomCoffeeService.GenerateAndSendPairs(); err != nil {
+			log.Printf("%s: Error generating random coffee pairs: %v", utils.GetCurrentTypeName(), err)
 		}
+		return
+	}
+
+	draft, err := t.randomCoffeeService.CreatePairsDraft(t.config.RandomCoffeePairsDraftTimeout)
+	if err != nil {
+		log.Printf("%s: Error generating random coffee pairs draft: %v", utils.GetCurrentTypeName(), err)
+		return
+	}
+
+	// The draft is still auto-published on timeout if the admin can't be reached
+	if err := t.randomCoffeeService.SendPairsDraftToAdmin(draft.PollID); err != nil {
+		log.Printf("%s: Error sending random coffee pairs draft to admin: %v", utils.GetCurrentTypeName(), err)
 	}
 }
 
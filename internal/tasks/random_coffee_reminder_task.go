@@ -0,0 +1,101 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// RandomCoffeeReminderTask handles scheduling of random coffee reminders
+type RandomCoffeeReminderTask struct {
+	config              *config.Config
+	randomCoffeeService *services.RandomCoffeeService
+	stop                chan struct{}
+}
+
+// NewRandomCoffeeReminderTask creates a new random coffee reminder task
+func NewRandomCoffeeReminderTask(config *config.Config, randomCoffeeService *services.RandomCoffeeService) *RandomCoffeeReminderTask {
+	return &RandomCoffeeReminderTask{
+		config:              config,
+		randomCoffeeService: randomCoffeeService,
+		stop:                make(chan struct{}),
+	}
+}
+
+// Start starts the random coffee reminder task
+func (t *RandomCoffeeReminderTask) Start() {
+	if !t.config.RandomCoffeeReminderTaskEnabled {
+		log.Printf("%s: Random coffee reminder task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting random coffee reminder task with time %02d:%02d UTC on %s",
+		utils.GetCurrentTypeName(),
+		t.config.RandomCoffeeReminderTime.Hour(),
+		t.config.RandomCoffeeReminderTime.Minute(),
+		t.config.RandomCoffeeReminderDay.String())
+	go t.run()
+}
+
+// Stop stops the random coffee reminder task
+func (t *RandomCoffeeReminderTask) Stop() {
+	log.Printf("%s: Stopping random coffee reminder task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the random coffee reminder task
+func (t *RandomCoffeeReminderTask) run() {
+	nextRun := t.calculateNextRun()
+	log.Printf("%s: Next random coffee reminders scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			if now.After(nextRun) {
+				log.Printf("%s: Running scheduled random coffee reminders", utils.GetCurrentTypeName())
+
+				go func() {
+					if err := t.randomCoffeeService.SendReminders(); err != nil {
+						log.Printf("%s: Error sending random coffee reminders: %v", utils.GetCurrentTypeName(), err)
+					}
+				}()
+
+				nextRun = t.calculateNextRun()
+				log.Printf("%s: Next random coffee reminders scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+			}
+		}
+	}
+}
+
+// calculateNextRun calculates the next run time
+func (t *RandomCoffeeReminderTask) calculateNextRun() time.Time {
+	now := time.Now().UTC()
+	targetHour := t.config.RandomCoffeeReminderTime.Hour()
+	targetMinute := t.config.RandomCoffeeReminderTime.Minute()
+	targetWeekday := t.config.RandomCoffeeReminderDay
+
+	// Calculate days until target weekday
+	daysUntilTarget := (int(targetWeekday) - int(now.Weekday()) + 7) % 7
+
+	// Create target time for today
+	targetTime := time.Date(now.Year(), now.Month(), now.Day(), targetHour, targetMinute, 0, 0, time.UTC)
+
+	if daysUntilTarget == 0 && now.Before(targetTime) {
+		// Today is target day and time hasn't passed yet
+		return targetTime
+	}
+
+	// Either not target day or time has passed - schedule for next occurrence
+	if daysUntilTarget == 0 {
+		daysUntilTarget = 7 // Next week
+	}
+
+	return targetTime.AddDate(0, 0, daysUntilTarget)
+}
@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"log"
+	"time"
+
+	"evo-bot-go/internal/config"
+	"evo-bot-go/internal/services"
+	"evo-bot-go/internal/utils"
+)
+
+// ReengagementTask sends the friendly reminders to the silent members once a day
+type ReengagementTask struct {
+	config              *config.Config
+	reengagementService *services.ReengagementService
+	stop                chan struct{}
+}
+
+// NewReengagementTask creates a new re-engagement task
+func NewReengagementTask(config *config.Config, reengagementService *services.ReengagementService) *ReengagementTask {
+	return &ReengagementTask{
+		config:              config,
+		reengagementService: reengagementService,
+		stop:                make(chan struct{}),
+	}
+}
+
+// Start starts the re-engagement task
+func (t *ReengagementTask) Start() {
+	if !t.config.ReengagementEnabled {
+		log.Printf("%s: Re-engagement task is disabled", utils.GetCurrentTypeName())
+		return
+	}
+	log.Printf("%s: Starting re-engagement task with time %02d:%02d UTC after %d silent weeks",
+		utils.GetCurrentTypeName(), t.config.ReengagementTime.Hour(), t.config.ReengagementTime.Minute(), t.config.ReengagementSilentWeeks)
+	go t.run()
+}
+
+// Stop stops the re-engagement task
+func (t *ReengagementTask) Stop() {
+	log.Printf("%s: Stopping re-engagement task", utils.GetCurrentTypeName())
+	close(t.stop)
+}
+
+// run runs the re-engagement task
+func (t *ReengagementTask) run() {
+	nextRun := t.calculateNextRun()
+	log.Printf("%s: Next re-engagement run scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case now := <-ticker.C:
+			if now.After(nextRun) {
+				t.reengagementService.SendReengagementMessages(now.UTC())
+
+				nextRun = t.calculateNextRun()
+				log.Printf("%s: Next re-engagement run scheduled for: %v", utils.GetCurrentTypeName(), nextRun)
+			}
+		}
+	}
+}
+
+// calculateNextRun returns the next configured time of the day, today or tomorrow
+func (t *ReengagementTask) calculateNextRun() time.Time {
+	now := time.Now().UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), t.config.ReengagementTime.Hour(), t.config.ReengagementTime.Minute(), 0, 0, time.UTC)
+	if now.After(next) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// chartMargin is the empty space around the bars, in pixels
+const chartMargin = 16
+
+var (
+	chartBackgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	chartGridColor       = color.RGBA{R: 0xe5, G: 0xe7, B: 0xeb, A: 0xff}
+	chartAxisColor       = color.RGBA{R: 0x9c, G: 0xa3, B: 0xaf, A: 0xff}
+)
+
+// ChartSeries is a series of values drawn in one color
+type ChartSeries struct {
+	Values []int
+	Color  color.RGBA
+}
+
+// RenderStackedBarChart draws a bar per value index with the values of the series stacked in order and returns
+// the chart as PNG. Light lines mark every quarter of the highest bar. The chart has no text,
+// so the legend and the scale are up to the caption.
+func RenderStackedBarChart(series []ChartSeries, width int, height int) ([]byte, error) {
+	bars := 0
+	for _, s := range series {
+		bars = max(bars, len(s.Values))
+	}
+	if bars == 0 {
+		return nil, errors.New("no values to draw")
+	}
+	if width <= 2*chartMargin || height <= 2*chartMargin {
+		return nil, errors.New("chart is too small")
+	}
+
+	totals := make([]int, bars)
+	highest := 0
+	for i := range totals {
+		for _, s := range series {
+			if i < len(s.Values) && s.Values[i] > 0 {
+				totals[i] += s.Values[i]
+			}
+		}
+		highest = max(highest, totals[i])
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: chartBackgroundColor}, image.Point{}, draw.Src)
+
+	plot := image.Rect(chartMargin, chartMargin, width-chartMargin, height-chartMargin)
+	for quarter := 1; quarter <= 4; quarter++ {
+		y := plot.Max.Y - plot.Dy()*quarter/4
+		fillRect(img, image.Rect(plot.Min.X, y, plot.Max.X, y+1), chartGridColor)
+	}
+
+	if highest > 0 {
+		slot := float64(plot.Dx()) / float64(bars)
+		barWidth := max(1, int(slot*0.7))
+		for i := 0; i < bars; i++ {
+			left := plot.Min.X + int(slot*float64(i)+(slot-float64(barWidth))/2)
+			bottom := plot.Max.Y
+			stacked := 0
+			for _, s := range series {
+				if i >= len(s.Values) || s.Values[i] <= 0 {
+					continue
+				}
+				stacked += s.Values[i]
+				// Scaling the running total keeps the rounding from adding up across the segments
+				top := plot.Max.Y - plot.Dy()*stacked/highest
+				fillRect(img, image.Rect(left, top, left+barWidth, bottom), s.Color)
+				bottom = top
+			}
+		}
+	}
+
+	fillRect(img, image.Rect(plot.Min.X, plot.Max.Y, plot.Max.X, plot.Max.Y+1), chartAxisColor)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	draw.Draw(img, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
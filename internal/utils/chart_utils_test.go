@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderStackedBarChart(t *testing.T) {
+	blue := color.RGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff}
+	green := color.RGBA{R: 0x22, G: 0xc5, B: 0x5e, A: 0xff}
+
+	content, err := RenderStackedBarChart([]ChartSeries{
+		{Values: []int{10, 0}, Color: blue},
+		{Values: []int{10, 5}, Color: green},
+	}, 232, 132)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, 232, img.Bounds().Dx())
+	assert.Equal(t, 132, img.Bounds().Dy())
+
+	// The plot is 200x100 with two 100px slots, the first bar is stacked up to the top, the second to a quarter
+	assert.Equal(t, color.RGBAModel.Convert(blue), img.At(66, 100))
+	assert.Equal(t, color.RGBAModel.Convert(green), img.At(66, 30))
+	assert.Equal(t, color.RGBAModel.Convert(green), img.At(166, 100))
+	assert.Equal(t, color.RGBAModel.Convert(chartBackgroundColor), img.At(166, 60))
+	assert.Equal(t, color.RGBAModel.Convert(chartBackgroundColor), img.At(20, 60))
+}
+
+func TestRenderStackedBarChart_NoValues(t *testing.T) {
+	_, err := RenderStackedBarChart(nil, 200, 100)
+	assert.Error(t, err)
+
+	_, err = RenderStackedBarChart([]ChartSeries{{Values: []int{1}}}, 10, 10)
+	assert.Error(t, err)
+}
+
+func TestRenderStackedBarChart_AllZero(t *testing.T) {
+	content, err := RenderStackedBarChart([]ChartSeries{{Values: []int{0, 0, 0}}}, 100, 100)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBAModel.Convert(chartBackgroundColor), img.At(50, 45))
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimezoneLabel returns a short name of the location for display, e.g. "Kyiv" for "Europe/Kyiv"
+func TimezoneLabel(loc *time.Location) string {
+	name := loc.String()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.ReplaceAll(name, "_", " ")
+}
+
+// FormatRelativeDateTime formats t in the given location relative to now,
+// e.g. "today, 19:00 Kyiv", "tomorrow, 19:00 Kyiv", "in 3 days, 19:00 Kyiv" or "Fri, Oct 24, 19:00 Kyiv"
+func FormatRelativeDateTime(t time.Time, now time.Time, loc *time.Location) string {
+	local := t.In(loc)
+	clock := fmt.Sprintf("%s %s", local.Format("15:04"), TimezoneLabel(loc))
+
+	days := CalendarDaysBetween(now.In(loc), local)
+	switch {
+	case days == 0:
+		return "today, " + clock
+	case days == 1:
+		return "tomorrow, " + clock
+	case days == -1:
+		return "yesterday, " + clock
+	case days > 1 && days < 7:
+		return fmt.Sprintf("in %d days, %s", days, clock)
+	case local.Year() == now.In(loc).Year():
+		return fmt.Sprintf("%s, %s", local.Format("Mon, Jan 2"), clock)
+	default:
+		return fmt.Sprintf("%s, %s", local.Format("Mon, Jan 2, 2006"), clock)
+	}
+}
+
+// CalendarDaysBetween returns the number of calendar days from one date to another
+// in the location of the "to" time, ignoring the time of day
+func CalendarDaysBetween(from time.Time, to time.Time) int {
+	loc := to.Location()
+	from = from.In(loc)
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+// FormatDurationMinutes formats a duration in minutes, e.g. "45min", "2h" or "1h 30min"
+func FormatDurationMinutes(minutes int) string {
+	hours, mins := minutes/60, minutes%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dmin", mins)
+	case mins == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh %dmin", hours, mins)
+	}
+}
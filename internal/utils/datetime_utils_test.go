@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimezoneLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		expected string
+	}{
+		{
+			name:     "Region and city",
+			location: "Europe/Kyiv",
+			expected: "Kyiv",
+		},
+		{
+			name:     "City with underscore",
+			location: "America/New_York",
+			expected: "New York",
+		},
+		{
+			name:     "UTC",
+			location: "UTC",
+			expected: "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.location)
+			if err != nil {
+				t.Skipf("timezone data not available: %v", err)
+			}
+			assert.Equal(t, tt.expected, TimezoneLabel(loc))
+		})
+	}
+}
+
+func TestFormatRelativeDateTime(t *testing.T) {
+	loc := time.FixedZone("Europe/Kyiv", 3*60*60)
+	// Wednesday, 22:30 in Kyiv
+	now := time.Date(2025, time.October, 15, 19, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{
+			name:     "Later today",
+			time:     time.Date(2025, time.October, 15, 20, 0, 0, 0, time.UTC),
+			expected: "today, 23:00 Kyiv",
+		},
+		{
+			name:     "Tomorrow in local time while still today in UTC",
+			time:     time.Date(2025, time.October, 15, 22, 0, 0, 0, time.UTC),
+			expected: "tomorrow, 01:00 Kyiv",
+		},
+		{
+			name:     "In a few days",
+			time:     time.Date(2025, time.October, 18, 16, 0, 0, 0, time.UTC),
+			expected: "in 3 days, 19:00 Kyiv",
+		},
+		{
+			name:     "Yesterday",
+			time:     time.Date(2025, time.October, 14, 16, 0, 0, 0, time.UTC),
+			expected: "yesterday, 19:00 Kyiv",
+		},
+		{
+			name:     "Later this year",
+			time:     time.Date(2025, time.October, 24, 16, 0, 0, 0, time.UTC),
+			expected: "Fri, Oct 24, 19:00 Kyiv",
+		},
+		{
+			name:     "Next year",
+			time:     time.Date(2026, time.January, 9, 16, 0, 0, 0, time.UTC),
+			expected: "Fri, Jan 9, 2026, 19:00 Kyiv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatRelativeDateTime(tt.time, now, loc))
+		})
+	}
+}
+
+func TestCalendarDaysBetween(t *testing.T) {
+	loc := time.FixedZone("Test", 3*60*60)
+
+	from := time.Date(2025, time.October, 15, 23, 59, 0, 0, loc)
+	assert.Equal(t, 0, CalendarDaysBetween(from, time.Date(2025, time.October, 15, 0, 0, 0, 0, loc)))
+	assert.Equal(t, 1, CalendarDaysBetween(from, time.Date(2025, time.October, 16, 0, 1, 0, 0, loc)))
+	assert.Equal(t, -2, CalendarDaysBetween(from, time.Date(2025, time.October, 13, 12, 0, 0, 0, loc)))
+	// Across a month boundary
+	assert.Equal(t, 17, CalendarDaysBetween(from, time.Date(2025, time.November, 1, 12, 0, 0, 0, loc)))
+}
+
+func TestFormatDurationMinutes(t *testing.T) {
+	assert.Equal(t, "45min", FormatDurationMinutes(45))
+	assert.Equal(t, "2h", FormatDurationMinutes(120))
+	assert.Equal(t, "1h 30min", FormatDurationMinutes(90))
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalProductID identifies the bot as the producer of the calendars (PRODID)
+const icalProductID = "-//evo-bot-go//Events//EN"
+
+// icalMaxLineOctets is the line length limit from RFC 5545, section 3.1
+const icalMaxLineOctets = 75
+
+// ICalEvent is a single VEVENT of an iCalendar
+type ICalEvent struct {
+	UID          string // Must stay the same across updates, so calendar apps replace the event instead of duplicating it
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	LastModified time.Time
+	Sequence     int // Must grow with every update, calendar apps ignore updates that don't increase it
+	Cancelled    bool
+}
+
+// BuildICalendar renders the events as an RFC 5545 calendar with CRLF line endings and folded long lines.
+// generatedAt is the time the calendar is generated at, it's used as the DTSTAMP of every event.
+func BuildICalendar(name string, events []ICalEvent, generatedAt time.Time) string {
+	var builder strings.Builder
+	writeLine := func(line string) {
+		builder.WriteString(foldICalLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:" + icalProductID)
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	if name != "" {
+		writeLine("X-WR-CALNAME:" + escapeICalText(name))
+	}
+	writeLine("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeLine("X-PUBLISHED-TTL:PT1H")
+
+	for _, event := range events {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + event.UID)
+		writeLine("DTSTAMP:" + formatICalTime(generatedAt))
+		writeLine("LAST-MODIFIED:" + formatICalTime(event.LastModified))
+		writeLine("SEQUENCE:" + strconv.Itoa(event.Sequence))
+		writeLine("DTSTART:" + formatICalTime(event.Start))
+		writeLine("DTEND:" + formatICalTime(event.End))
+		writeLine("SUMMARY:" + escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeLine("DESCRIPTION:" + escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine("LOCATION:" + escapeICalText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine("URL:" + event.URL)
+		}
+		if event.Cancelled {
+			writeLine("STATUS:CANCELLED")
+		} else {
+			writeLine("STATUS:CONFIRMED")
+		}
+		writeLine("END:VEVENT")
+	}
+
+	writeLine("END:VCALENDAR")
+
+	return builder.String()
+}
+
+// formatICalTime formats t as a UTC DATE-TIME value, e.g. "20251024T160000Z"
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escapeICalText escapes a TEXT value: backslashes, semicolons, commas and newlines
+func escapeICalText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// foldICalLine splits a content line into lines of at most 75 octets, continued with a leading space.
+// Multi-byte characters are never split.
+func foldICalLine(line string) string {
+	if len(line) <= icalMaxLineOctets {
+		return line
+	}
+
+	var builder strings.Builder
+	limit := icalMaxLineOctets
+	lineOctets := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if lineOctets+size > limit {
+			builder.WriteString("\r\n ")
+			// The leading space counts towards the limit of the continuation line
+			limit = icalMaxLineOctets - 1
+			lineOctets = 0
+		}
+		builder.WriteRune(r)
+		lineOctets += size
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildICalendar(t *testing.T) {
+	start := time.Date(2025, 10, 24, 19, 0, 0, 0, time.FixedZone("EEST", 3*60*60))
+	modified := time.Date(2025, 10, 20, 12, 30, 0, 0, time.UTC)
+	generatedAt := time.Date(2025, 10, 22, 8, 0, 0, 0, time.UTC)
+
+	calendar := BuildICalendar("Club events", []ICalEvent{
+		{
+			UID:          "event-1@evo-bot-go",
+			Summary:      "Go meetup",
+			Description:  "Talks, pizza",
+			Location:     "Kyiv",
+			URL:          "https://example.com/join",
+			Start:        start,
+			End:          start.Add(90 * time.Minute),
+			LastModified: modified,
+			Sequence:     2,
+		},
+		{
+			UID:          "event-2@evo-bot-go",
+			Summary:      "Cancelled call",
+			Start:        start,
+			End:          start.Add(time.Hour),
+			LastModified: modified,
+			Sequence:     5,
+			Cancelled:    true,
+		},
+	}, generatedAt)
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//evo-bot-go//Events//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Club events",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H",
+		"X-PUBLISHED-TTL:PT1H",
+		"BEGIN:VEVENT",
+		"UID:event-1@evo-bot-go",
+		"DTSTAMP:20251022T080000Z",
+		"LAST-MODIFIED:20251020T123000Z",
+		"SEQUENCE:2",
+		"DTSTART:20251024T160000Z",
+		"DTEND:20251024T173000Z",
+		"SUMMARY:Go meetup",
+		`DESCRIPTION:Talks\, pizza`,
+		"LOCATION:Kyiv",
+		"URL:https://example.com/join",
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:event-2@evo-bot-go",
+		"DTSTAMP:20251022T080000Z",
+		"LAST-MODIFIED:20251020T123000Z",
+		"SEQUENCE:5",
+		"DTSTART:20251024T160000Z",
+		"DTEND:20251024T170000Z",
+		"SUMMARY:Cancelled call",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	assert.Equal(t, expected, calendar)
+}
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Plain text",
+			input:    "Go meetup",
+			expected: "Go meetup",
+		},
+		{
+			name:     "Special characters",
+			input:    `a\b;c,d`,
+			expected: `a\\b\;c\,d`,
+		},
+		{
+			name:     "Newlines",
+			input:    "line 1\nline 2\r\nline 3",
+			expected: `line 1\nline 2\nline 3`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeICalText(tt.input))
+		})
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	t.Run("Short line is not folded", func(t *testing.T) {
+		line := "SUMMARY:Go meetup"
+		assert.Equal(t, line, foldICalLine(line))
+	})
+
+	t.Run("Long line is folded at 75 octets", func(t *testing.T) {
+		line := "DESCRIPTION:" + strings.Repeat("a", 150)
+		folded := foldICalLine(line)
+
+		parts := strings.Split(folded, "\r\n")
+		assert.Len(t, parts, 3)
+		for _, part := range parts {
+			assert.LessOrEqual(t, len(part), 75)
+		}
+		for _, part := range parts[1:] {
+			assert.True(t, strings.HasPrefix(part, " "))
+		}
+		assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+	})
+
+	t.Run("Multi-byte characters are not split", func(t *testing.T) {
+		line := "SUMMARY:" + strings.Repeat("ї", 60)
+		folded := foldICalLine(line)
+
+		for _, part := range strings.Split(folded, "\r\n") {
+			assert.LessOrEqual(t, len(part), 75)
+			assert.True(t, strings.ToValidUTF8(part, "") == part)
+		}
+		assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+	})
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// karmaThanksWords are the first words of a reply that grant a karma point to the author of the message
+var karmaThanksWords = []string{"+1", "thanks", "thx", "ty", "thank you"}
+
+// IsKarmaThanks reports whether a reply starts with "+1" or a thanks, e.g. "+1", "Thanks!" or "thank you, it works"
+func IsKarmaThanks(text string) bool {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, word := range karmaThanksWords {
+		rest, found := strings.CutPrefix(text, word)
+		if !found {
+			continue
+		}
+		// The word must end there, so "+10" or "thanksgiving" don't count
+		if rest == "" {
+			return true
+		}
+		next := []rune(rest)[0]
+		if !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNewKarmaReaction reports whether one of the karma reactions is in the new reactions and not in the old ones
+func HasNewKarmaReaction(oldReactions []gotgbot.ReactionType, newReactions []gotgbot.ReactionType, karmaReactions []string) bool {
+	for _, reaction := range newReactions {
+		emoji := reaction.MergeReactionType().Emoji
+		if emoji == "" || !slices.Contains(karmaReactions, emoji) {
+			continue
+		}
+		if !slices.ContainsFunc(oldReactions, func(old gotgbot.ReactionType) bool {
+			return old.MergeReactionType().Emoji == emoji
+		}) {
+			return true
+		}
+	}
+	return false
+}
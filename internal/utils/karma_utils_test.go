@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsKarmaThanks(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected bool
+	}{
+		{name: "Plus one", text: "+1", expected: true},
+		{name: "Bare plus", text: "+", expected: false},
+		{name: "Plus one with text", text: "+1 great answer", expected: true},
+		{name: "Thanks with punctuation", text: "Thanks!", expected: true},
+		{name: "Thank you with text", text: "thank you, it works", expected: true},
+		{name: "Short thanks", text: "  thx ", expected: true},
+		{name: "Plus ten", text: "+10", expected: false},
+		{name: "Longer word", text: "thanksgiving is coming", expected: false},
+		{name: "Thanks in the middle", text: "no thanks", expected: false},
+		{name: "Empty", text: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsKarmaThanks(tt.text))
+		})
+	}
+}
+
+func TestHasNewKarmaReaction(t *testing.T) {
+	thumbsUp := gotgbot.ReactionTypeEmoji{Emoji: "\U0001f44d"}
+	fire := gotgbot.ReactionTypeEmoji{Emoji: "\U0001f525"}
+	karmaReactions := []string{"\U0001f44d"}
+
+	tests := []struct {
+		name     string
+		old      []gotgbot.ReactionType
+		new      []gotgbot.ReactionType
+		expected bool
+	}{
+		{name: "Karma reaction added", old: nil, new: []gotgbot.ReactionType{thumbsUp}, expected: true},
+		{name: "Karma reaction added to another one", old: []gotgbot.ReactionType{fire}, new: []gotgbot.ReactionType{fire, thumbsUp}, expected: true},
+		{name: "Other reaction added", old: nil, new: []gotgbot.ReactionType{fire}, expected: false},
+		{name: "Karma reaction kept", old: []gotgbot.ReactionType{thumbsUp}, new: []gotgbot.ReactionType{thumbsUp, fire}, expected: false},
+		{name: "Karma reaction removed", old: []gotgbot.ReactionType{thumbsUp}, new: nil, expected: false},
+		{name: "Custom emoji", old: nil, new: []gotgbot.ReactionType{gotgbot.ReactionTypeCustomEmoji{CustomEmojiId: "1"}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HasNewKarmaReaction(tt.old, tt.new, karmaReactions))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"evo-bot-go/internal/constants"
+)
+
+// ProfileFieldClearValue is entered to clear an optional profile field
+const ProfileFieldClearValue = "-"
+
+var (
+	gitHubUsernameRegex = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+	linkedInSlugRegex   = regexp.MustCompile(`^[A-Za-z0-9_%-]{3,100}$`)
+
+	profileLinkSubdomainRegex = regexp.MustCompile(`^(?i)(www\.|[a-z]{2}\.)?$`)
+)
+
+// NormalizeProfileFieldValue validates a value entered for a structured profile field and returns
+// the value to store: skills are deduplicated into a comma-separated list, the timezone must be an
+// IANA name and the links are stored as full profile URLs. "-" clears the field.
+func NormalizeProfileFieldValue(field constants.ProfileField, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == ProfileFieldClearValue {
+		return "", nil
+	}
+	if value == "" {
+		return "", fmt.Errorf("the value is empty, send %s to clear the field", ProfileFieldClearValue)
+	}
+	if Utf16CodeUnitCount(value) > constants.ProfileFieldLengthLimit {
+		return "", fmt.Errorf("the value is longer than %d characters", constants.ProfileFieldLengthLimit)
+	}
+
+	switch field {
+	case constants.ProfileFieldSkills:
+		return normalizeSkills(value), nil
+	case constants.ProfileFieldTimezone:
+		return normalizeTimezone(value)
+	case constants.ProfileFieldGitHub:
+		return normalizeGitHubURL(value)
+	case constants.ProfileFieldLinkedIn:
+		return normalizeLinkedInURL(value)
+	default:
+		return strings.Join(strings.Fields(value), " "), nil
+	}
+}
+
+// normalizeSkills splits the skills by commas or new lines and drops empty and repeated ones
+func normalizeSkills(value string) string {
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' || r == ';' })
+	seen := make(map[string]bool, len(parts))
+	skills := make([]string, 0, len(parts))
+	for _, part := range parts {
+		skill := strings.Join(strings.Fields(part), " ")
+		key := strings.ToLower(skill)
+		if skill == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		skills = append(skills, skill)
+	}
+	return strings.Join(skills, ", ")
+}
+
+func normalizeTimezone(value string) (string, error) {
+	if strings.EqualFold(value, "UTC") {
+		return "UTC", nil
+	}
+	// "Local" is accepted by time.LoadLocation, but means the timezone of the server
+	if strings.EqualFold(value, "Local") || !strings.Contains(value, "/") {
+		return "", fmt.Errorf("%s is not a timezone, use a name like Europe/Kyiv", value)
+	}
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a timezone, use a name like Europe/Kyiv", value)
+	}
+	return location.String(), nil
+}
+
+// normalizeGitHubURL accepts a username, @username or a link to the profile
+func normalizeGitHubURL(value string) (string, error) {
+	username := strings.TrimPrefix(trimProfileLink(value, "github.com/"), "@")
+	if !gitHubUsernameRegex.MatchString(username) {
+		return "", fmt.Errorf("%s is not a GitHub username or profile link", value)
+	}
+	return "https://github.com/" + username, nil
+}
+
+// normalizeLinkedInURL accepts the slug of the public profile or a link to it
+func normalizeLinkedInURL(value string) (string, error) {
+	slug := trimProfileLink(value, "linkedin.com/in/")
+	if !linkedInSlugRegex.MatchString(slug) {
+		return "", fmt.Errorf("%s is not a LinkedIn profile link", value)
+	}
+	return "https://www.linkedin.com/in/" + slug, nil
+}
+
+// trimProfileLink returns the first path segment after the prefix of a profile link, or the value itself
+// if it's not a link. The host may have "www." or a country subdomain, e.g. ua.linkedin.com.
+func trimProfileLink(value string, prefix string) string {
+	link := strings.TrimPrefix(strings.TrimPrefix(value, "https://"), "http://")
+	idx := strings.Index(strings.ToLower(link), prefix)
+	if idx < 0 || !profileLinkSubdomainRegex.MatchString(link[:idx]) {
+		return value
+	}
+
+	segment := link[idx+len(prefix):]
+	if end := strings.IndexAny(segment, "/?#"); end >= 0 {
+		segment = segment[:end]
+	}
+	return segment
+}
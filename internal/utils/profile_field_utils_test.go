@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"testing"
+
+	"evo-bot-go/internal/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeProfileFieldValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       constants.ProfileField
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Plain field collapses spaces",
+			field:    constants.ProfileFieldRole,
+			input:    "  Backend   developer ",
+			expected: "Backend developer",
+		},
+		{
+			name:     "Dash clears the field",
+			field:    constants.ProfileFieldCompany,
+			input:    " - ",
+			expected: "",
+		},
+		{
+			name:        "Empty value",
+			field:       constants.ProfileFieldCity,
+			input:       "   ",
+			expectError: true,
+		},
+		{
+			name:     "Skills are deduplicated",
+			field:    constants.ProfileFieldSkills,
+			input:    "Go, PostgreSQL;go,\n LLM  agents ,,",
+			expected: "Go, PostgreSQL, LLM agents",
+		},
+		{
+			name:     "Timezone name",
+			field:    constants.ProfileFieldTimezone,
+			input:    "Europe/Kyiv",
+			expected: "Europe/Kyiv",
+		},
+		{
+			name:     "UTC timezone",
+			field:    constants.ProfileFieldTimezone,
+			input:    "utc",
+			expected: "UTC",
+		},
+		{
+			name:        "Unknown timezone",
+			field:       constants.ProfileFieldTimezone,
+			input:       "Mars/Olympus",
+			expectError: true,
+		},
+		{
+			name:        "Local timezone is rejected",
+			field:       constants.ProfileFieldTimezone,
+			input:       "Local",
+			expectError: true,
+		},
+		{
+			name:     "GitHub username",
+			field:    constants.ProfileFieldGitHub,
+			input:    "@octocat",
+			expected: "https://github.com/octocat",
+		},
+		{
+			name:     "GitHub link",
+			field:    constants.ProfileFieldGitHub,
+			input:    "https://www.github.com/octocat/hello-world?tab=readme",
+			expected: "https://github.com/octocat",
+		},
+		{
+			name:        "GitHub link to another site",
+			field:       constants.ProfileFieldGitHub,
+			input:       "https://example.com/github.com/octocat",
+			expectError: true,
+		},
+		{
+			name:     "LinkedIn link with country subdomain",
+			field:    constants.ProfileFieldLinkedIn,
+			input:    "ua.linkedin.com/in/john-doe-123/",
+			expected: "https://www.linkedin.com/in/john-doe-123",
+		},
+		{
+			name:     "LinkedIn slug",
+			field:    constants.ProfileFieldLinkedIn,
+			input:    "john-doe",
+			expected: "https://www.linkedin.com/in/john-doe",
+		},
+		{
+			name:        "LinkedIn company page",
+			field:       constants.ProfileFieldLinkedIn,
+			input:       "https://www.linkedin.com/company/acme",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NormalizeProfileFieldValue(tt.field, tt.input)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNormalizeProfileFieldValue_LengthLimit(t *testing.T) {
+	long := make([]byte, constants.ProfileFieldLengthLimit+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	_, err := NormalizeProfileFieldValue(constants.ProfileFieldLookingFor, string(long))
+	assert.Error(t, err)
+
+	value, err := NormalizeProfileFieldValue(constants.ProfileFieldLookingFor, string(long[1:]))
+	assert.NoError(t, err)
+	assert.Equal(t, string(long[1:]), value)
+}
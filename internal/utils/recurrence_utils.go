@@ -0,0 +1,391 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency is the FREQ of a recurrence rule
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceFrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceFrequencyMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// recurrenceUntilLayout is the UTC DATE-TIME form of UNTIL, e.g. "20251231T235959Z"
+const recurrenceUntilLayout = "20060102T150405Z"
+
+// recurrenceUntilDateLayout is the DATE form of UNTIL, e.g. "20251231", which includes the whole day
+const recurrenceUntilDateLayout = "20060102"
+
+// recurrenceMaxPeriods guards against rules that never produce an occurrence, e.g. BYMONTHDAY=31 every 2 months
+const recurrenceMaxPeriods = 100000
+
+var recurrenceWeekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var recurrenceOrdinalNames = map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", 5: "fifth"}
+
+// RecurrenceWeekday is a BYDAY entry, e.g. "TH" or "1SA" (the first Saturday of the month)
+type RecurrenceWeekday struct {
+	Weekday time.Weekday
+	Ordinal int // 0 means every such weekday, negative values count from the end of the month
+}
+
+// RecurrenceRule is a subset of the RFC 5545 RRULE: DAILY, WEEKLY and MONTHLY frequencies
+// with INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int // Negative values count from the end of the month
+	Count      int   // 0 means unlimited
+	Until      *time.Time
+}
+
+// ParseRecurrenceRule parses a rule like "FREQ=WEEKLY;BYDAY=TH" or "RRULE:FREQ=MONTHLY;BYDAY=1SA"
+func ParseRecurrenceRule(input string) (*RecurrenceRule, error) {
+	input = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(input)), "RRULE:")
+	rule := &RecurrenceRule{Interval: 1}
+
+	for _, part := range strings.Split(input, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch frequency := RecurrenceFrequency(value); frequency {
+			case RecurrenceFrequencyDaily, RecurrenceFrequencyWeekly, RecurrenceFrequencyMonthly:
+				rule.Frequency = frequency
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q, use DAILY, WEEKLY or MONTHLY", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive number")
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				weekday, err := parseRecurrenceWeekday(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, dayStr := range strings.Split(value, ",") {
+				day, err := strconv.Atoi(dayStr)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY %q, use 1 to 31 or -1 for the last day", dayStr)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("COUNT must be a positive number")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(recurrenceUntilLayout, value)
+			if err != nil {
+				untilDate, dateErr := time.Parse(recurrenceUntilDateLayout, value)
+				if dateErr != nil {
+					return nil, fmt.Errorf("invalid UNTIL %q, use YYYYMMDD", value)
+				}
+				until = untilDate.Add(24*time.Hour - time.Second)
+			}
+			rule.Until = &until
+		case "WKST":
+			// Weeks always start on Monday
+			if value != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("COUNT and UNTIL can't be used together")
+	}
+	if len(rule.ByDay) > 0 && rule.Frequency == RecurrenceFrequencyDaily {
+		return nil, fmt.Errorf("BYDAY is only supported for WEEKLY and MONTHLY rules")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Frequency != RecurrenceFrequencyMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported for MONTHLY rules")
+	}
+	if len(rule.ByMonthDay) > 0 && len(rule.ByDay) > 0 {
+		return nil, fmt.Errorf("BYDAY and BYMONTHDAY can't be used together")
+	}
+	for _, weekday := range rule.ByDay {
+		if weekday.Ordinal != 0 && rule.Frequency != RecurrenceFrequencyMonthly {
+			return nil, fmt.Errorf("numbered BYDAY like 1SA is only supported for MONTHLY rules")
+		}
+	}
+
+	return rule, nil
+}
+
+// String formats the rule in the RRULE form, e.g. "FREQ=MONTHLY;BYDAY=1SA"
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			code := recurrenceWeekdayCodes[weekday.Weekday]
+			if weekday.Ordinal != 0 {
+				code = strconv.Itoa(weekday.Ordinal) + code
+			}
+			codes = append(codes, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(recurrenceUntilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe formats the rule for people, e.g. "every week on Thursday" or "every month on the first Saturday".
+// The first occurrence is used for the parts the rule takes from it, like the weekday of a plain weekly rule.
+func (r *RecurrenceRule) Describe(start time.Time) string {
+	var unit string
+	switch r.Frequency {
+	case RecurrenceFrequencyDaily:
+		unit = "day"
+	case RecurrenceFrequencyWeekly:
+		unit = "week"
+	default:
+		unit = "month"
+	}
+
+	var description string
+	if r.Interval > 1 {
+		description = fmt.Sprintf("every %d %ss", r.Interval, unit)
+	} else {
+		description = "every " + unit
+	}
+
+	switch {
+	case r.Frequency == RecurrenceFrequencyWeekly && len(r.ByDay) == 0:
+		description += " on " + start.Weekday().String()
+	case r.Frequency == RecurrenceFrequencyWeekly:
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, weekday.Weekday.String())
+		}
+		description += " on " + joinWithAnd(days)
+	case r.Frequency == RecurrenceFrequencyMonthly && len(r.ByDay) > 0:
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, describeRecurrenceWeekday(weekday))
+		}
+		description += " on " + joinWithAnd(days)
+	case r.Frequency == RecurrenceFrequencyMonthly && len(r.ByMonthDay) > 0:
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			switch {
+			case day == -1:
+				days = append(days, "the last day")
+			case day < 0:
+				days = append(days, fmt.Sprintf("day %d from the end", -day))
+			default:
+				days = append(days, fmt.Sprintf("day %d", day))
+			}
+		}
+		description += " on " + joinWithAnd(days)
+	case r.Frequency == RecurrenceFrequencyMonthly:
+		description += fmt.Sprintf(" on day %d", start.Day())
+	}
+
+	if r.Count > 0 {
+		description += fmt.Sprintf(", %d times", r.Count)
+	}
+	if r.Until != nil {
+		description += ", until " + r.Until.In(start.Location()).Format("Jan 2, 2006")
+	}
+	return description
+}
+
+// Occurrences returns the starts of the occurrences within (after, until], in the location of start.
+// Like DTSTART in RFC 5545, start itself is always the first occurrence, and the following ones
+// keep its wall clock time, so they don't shift with daylight saving time.
+func (r *RecurrenceRule) Occurrences(start time.Time, after time.Time, until time.Time) []time.Time {
+	var occurrences []time.Time
+	count := 0
+
+	// add counts the occurrence and collects it if it's within the range, false means the series has ended
+	add := func(occurrence time.Time) bool {
+		if (r.Count > 0 && count >= r.Count) || (r.Until != nil && occurrence.After(*r.Until)) {
+			return false
+		}
+		count++
+		if occurrence.After(after) && !occurrence.After(until) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return true
+	}
+
+	if start.After(until) || !add(start) {
+		return occurrences
+	}
+
+	for period := 0; period < recurrenceMaxPeriods; period++ {
+		candidates, periodStart := r.periodCandidates(start, period)
+		if periodStart.After(until) {
+			break
+		}
+		for _, candidate := range candidates {
+			if !candidate.After(start) {
+				continue
+			}
+			if candidate.After(until) || !add(candidate) {
+				return occurrences
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// periodCandidates returns the sorted occurrence candidates within the day, week or month with the given number
+// counted from the one of start, and the beginning of that period
+func (r *RecurrenceRule) periodCandidates(start time.Time, period int) ([]time.Time, time.Time) {
+	year, month, day := start.Date()
+	hour, minute, second := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, start.Location())
+	}
+
+	var candidates []time.Time
+	var periodStart time.Time
+	switch r.Frequency {
+	case RecurrenceFrequencyDaily:
+		periodStart = at(year, month, day+period*r.Interval)
+		candidates = append(candidates, periodStart)
+	case RecurrenceFrequencyWeekly:
+		// Weeks start on Monday
+		monday := day - (int(start.Weekday())+6)%7 + period*r.Interval*7
+		periodStart = at(year, month, monday)
+		if len(r.ByDay) == 0 {
+			candidates = append(candidates, at(year, month, day+period*r.Interval*7))
+		}
+		for _, weekday := range r.ByDay {
+			candidates = append(candidates, at(year, month, monday+(int(weekday.Weekday)+6)%7))
+		}
+	default:
+		periodStart = at(year, month+time.Month(period*r.Interval), 1)
+		periodYear, periodMonth := periodStart.Year(), periodStart.Month()
+		daysInMonth := at(periodYear, periodMonth+1, 0).Day()
+
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for _, monthDay := range r.ByMonthDay {
+				if monthDay < 0 {
+					monthDay = daysInMonth + monthDay + 1
+				}
+				if monthDay >= 1 && monthDay <= daysInMonth {
+					candidates = append(candidates, at(periodYear, periodMonth, monthDay))
+				}
+			}
+		case len(r.ByDay) > 0:
+			for _, weekday := range r.ByDay {
+				var days []int
+				firstDay := 1 + (int(weekday.Weekday)-int(periodStart.Weekday())+7)%7
+				for monthDay := firstDay; monthDay <= daysInMonth; monthDay += 7 {
+					days = append(days, monthDay)
+				}
+				switch {
+				case weekday.Ordinal == 0:
+					for _, monthDay := range days {
+						candidates = append(candidates, at(periodYear, periodMonth, monthDay))
+					}
+				case weekday.Ordinal > 0 && weekday.Ordinal <= len(days):
+					candidates = append(candidates, at(periodYear, periodMonth, days[weekday.Ordinal-1]))
+				case weekday.Ordinal < 0 && -weekday.Ordinal <= len(days):
+					candidates = append(candidates, at(periodYear, periodMonth, days[len(days)+weekday.Ordinal]))
+				}
+			}
+		case day <= daysInMonth:
+			candidates = append(candidates, at(periodYear, periodMonth, day))
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+	candidates = slices.CompactFunc(candidates, func(a, b time.Time) bool { return a.Equal(b) })
+	return candidates, periodStart
+}
+
+// parseRecurrenceWeekday parses a BYDAY entry like "TH", "1SA" or "-1FR"
+func parseRecurrenceWeekday(code string) (RecurrenceWeekday, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return RecurrenceWeekday{}, fmt.Errorf("invalid BYDAY %q", code)
+	}
+
+	weekdayIndex := slices.Index(recurrenceWeekdayCodes, code[len(code)-2:])
+	if weekdayIndex < 0 {
+		return RecurrenceWeekday{}, fmt.Errorf("invalid weekday in BYDAY %q, use MO, TU, WE, TH, FR, SA or SU", code)
+	}
+
+	weekday := RecurrenceWeekday{Weekday: time.Weekday(weekdayIndex)}
+	if ordinalStr := code[:len(code)-2]; ordinalStr != "" {
+		ordinal, err := strconv.Atoi(ordinalStr)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceWeekday{}, fmt.Errorf("invalid week number in BYDAY %q, use 1 to 5 or -1 for the last one", code)
+		}
+		weekday.Ordinal = ordinal
+	}
+	return weekday, nil
+}
+
+// describeRecurrenceWeekday formats a monthly BYDAY entry, e.g. "the first Saturday" or "the last Friday"
+func describeRecurrenceWeekday(weekday RecurrenceWeekday) string {
+	name := weekday.Weekday.String()
+	switch {
+	case weekday.Ordinal == 0:
+		return "every " + name
+	case weekday.Ordinal == -1:
+		return "the last " + name
+	case weekday.Ordinal < 0:
+		return fmt.Sprintf("the %s to last %s", recurrenceOrdinalNames[-weekday.Ordinal], name)
+	default:
+		return fmt.Sprintf("the %s %s", recurrenceOrdinalNames[weekday.Ordinal], name)
+	}
+}
+
+// joinWithAnd joins the items like "a, b and c"
+func joinWithAnd(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
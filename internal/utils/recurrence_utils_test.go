@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "Weekly on Thursday",
+			input:    "FREQ=WEEKLY;BYDAY=TH",
+			expected: "FREQ=WEEKLY;BYDAY=TH",
+		},
+		{
+			name:     "Lowercase with RRULE prefix",
+			input:    " rrule:freq=monthly;byday=1sa ",
+			expected: "FREQ=MONTHLY;BYDAY=1SA",
+		},
+		{
+			name:     "Interval and count",
+			input:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10",
+			expected: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10",
+		},
+		{
+			name:     "Until date",
+			input:    "FREQ=DAILY;UNTIL=20251231",
+			expected: "FREQ=DAILY;UNTIL=20251231T235959Z",
+		},
+		{
+			name:     "Last day of month",
+			input:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			expected: "FREQ=MONTHLY;BYMONTHDAY=-1",
+		},
+		{
+			name:        "Missing frequency",
+			input:       "BYDAY=TH",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported frequency",
+			input:       "FREQ=YEARLY",
+			expectError: true,
+		},
+		{
+			name:        "Invalid weekday",
+			input:       "FREQ=WEEKLY;BYDAY=XX",
+			expectError: true,
+		},
+		{
+			name:        "Numbered weekday in weekly rule",
+			input:       "FREQ=WEEKLY;BYDAY=1TH",
+			expectError: true,
+		},
+		{
+			name:        "Count and until together",
+			input:       "FREQ=DAILY;COUNT=3;UNTIL=20251231",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported part",
+			input:       "FREQ=DAILY;BYHOUR=10",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.input)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	mustParse := func(input string) *RecurrenceRule {
+		rule, err := ParseRecurrenceRule(input)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", input, err)
+		}
+		return rule
+	}
+	date := func(year int, month time.Month, day int, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Weekly on Thursday", func(t *testing.T) {
+		start := date(2025, 10, 2, 19) // Thursday
+		occurrences := mustParse("FREQ=WEEKLY;BYDAY=TH").Occurrences(start, start, date(2025, 10, 31, 0))
+		assert.Equal(t, []time.Time{
+			date(2025, 10, 9, 19),
+			date(2025, 10, 16, 19),
+			date(2025, 10, 23, 19),
+			date(2025, 10, 30, 19),
+		}, occurrences)
+	})
+
+	t.Run("Start is the first occurrence", func(t *testing.T) {
+		start := date(2025, 10, 2, 19)
+		occurrences := mustParse("FREQ=WEEKLY").Occurrences(start, start.Add(-time.Second), date(2025, 10, 10, 0))
+		assert.Equal(t, []time.Time{date(2025, 10, 2, 19), date(2025, 10, 9, 19)}, occurrences)
+	})
+
+	t.Run("Monthly on the first Saturday", func(t *testing.T) {
+		start := date(2025, 10, 4, 12)
+		occurrences := mustParse("FREQ=MONTHLY;BYDAY=1SA").Occurrences(start, start, date(2026, 1, 31, 0))
+		assert.Equal(t, []time.Time{
+			date(2025, 11, 1, 12),
+			date(2025, 12, 6, 12),
+			date(2026, 1, 3, 12),
+		}, occurrences)
+	})
+
+	t.Run("Monthly on the last Friday", func(t *testing.T) {
+		start := date(2025, 10, 31, 18)
+		occurrences := mustParse("FREQ=MONTHLY;BYDAY=-1FR").Occurrences(start, start, date(2025, 12, 31, 0))
+		assert.Equal(t, []time.Time{date(2025, 11, 28, 18), date(2025, 12, 26, 18)}, occurrences)
+	})
+
+	t.Run("Monthly skips months without the day", func(t *testing.T) {
+		start := date(2026, 1, 31, 10)
+		occurrences := mustParse("FREQ=MONTHLY").Occurrences(start, start, date(2026, 5, 1, 0))
+		assert.Equal(t, []time.Time{date(2026, 3, 31, 10)}, occurrences)
+	})
+
+	t.Run("Every two weeks on Monday and Thursday", func(t *testing.T) {
+		start := date(2025, 10, 6, 9) // Monday
+		occurrences := mustParse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH").Occurrences(start, start, date(2025, 10, 31, 0))
+		assert.Equal(t, []time.Time{
+			date(2025, 10, 9, 9),
+			date(2025, 10, 20, 9),
+			date(2025, 10, 23, 9),
+		}, occurrences)
+	})
+
+	t.Run("Count includes the first occurrence", func(t *testing.T) {
+		start := date(2025, 10, 1, 8)
+		occurrences := mustParse("FREQ=DAILY;COUNT=3").Occurrences(start, start, date(2025, 12, 31, 0))
+		assert.Equal(t, []time.Time{date(2025, 10, 2, 8), date(2025, 10, 3, 8)}, occurrences)
+	})
+
+	t.Run("Until", func(t *testing.T) {
+		start := date(2025, 10, 1, 8)
+		occurrences := mustParse("FREQ=DAILY;UNTIL=20251003").Occurrences(start, start, date(2025, 12, 31, 0))
+		assert.Equal(t, []time.Time{date(2025, 10, 2, 8), date(2025, 10, 3, 8)}, occurrences)
+	})
+
+	t.Run("Wall clock time is kept across daylight saving time", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Kyiv")
+		if err != nil {
+			t.Skipf("timezone data not available: %v", err)
+		}
+		start := time.Date(2025, 10, 16, 19, 0, 0, 0, loc)
+		occurrences := mustParse("FREQ=WEEKLY").Occurrences(start, start, start.Add(15*24*time.Hour))
+		if !assert.Len(t, occurrences, 2) {
+			return
+		}
+		for _, occurrence := range occurrences {
+			assert.Equal(t, 19, occurrence.Hour())
+		}
+		assert.Equal(t, 7*24*time.Hour+time.Hour, occurrences[1].Sub(occurrences[0]))
+	})
+}
+
+func TestRecurrenceRuleDescribe(t *testing.T) {
+	start := time.Date(2025, 10, 2, 19, 0, 0, 0, time.UTC) // Thursday
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Weekly",
+			input:    "FREQ=WEEKLY",
+			expected: "every week on Thursday",
+		},
+		{
+			name:     "Every two weeks on several days",
+			input:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,TH",
+			expected: "every 2 weeks on Monday, Wednesday and Thursday",
+		},
+		{
+			name:     "Monthly on the first Saturday",
+			input:    "FREQ=MONTHLY;BYDAY=1SA",
+			expected: "every month on the first Saturday",
+		},
+		{
+			name:     "Monthly on the last day",
+			input:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			expected: "every month on the last day",
+		},
+		{
+			name:     "Daily with count",
+			input:    "FREQ=DAILY;COUNT=5",
+			expected: "every day, 5 times",
+		},
+		{
+			name:     "Monthly until",
+			input:    "FREQ=MONTHLY;UNTIL=20260630",
+			expected: "every month on day 2, until Jun 30, 2026",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, rule.Describe(start))
+			}
+		})
+	}
+}